@@ -18,6 +18,9 @@ import (
 	"encoding/json"
 	bluzellechain "github.com/bluzelle/curium/types"
 	"github.com/bluzelle/curium/x/crud"
+	"github.com/bluzelle/curium/x/faucet"
+	"github.com/bluzelle/curium/x/nft"
+	"github.com/bluzelle/curium/x/tax"
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -25,6 +28,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -33,7 +37,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
-	"github.com/cosmos/modules/incubator/faucet"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -41,7 +46,6 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 	"os"
-	"time"
 )
 
 const (
@@ -65,12 +69,16 @@ var (
 		bank.AppModuleBasic{},
 		staking.AppModuleBasic{},
 		distr.AppModuleBasic{},
-		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler),
+		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler),
 		params.AppModuleBasic{},
+		crisis.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		supply.AppModuleBasic{},
+		upgrade.AppModuleBasic{},
 		crud.AppModuleBasic{},
 		faucet.AppModuleBasic{},
+		tax.AppModuleBasic{},
+		nft.AppModuleBasic{},
 	)
 
 	// account permissions
@@ -81,6 +89,10 @@ var (
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		faucet.ModuleName:         {supply.Minter}, // add permissions for faucet
 		gov.ModuleName:            {supply.Burner},
+		tax.ModuleName:            nil,
+		crud.ModuleName:           nil,
+		crud.EscrowName:           nil,
+		crud.LeaseDepositName:     nil,
 	}
 )
 
@@ -99,6 +111,20 @@ func IsCrudEnabled(nodeHome string) bool {
 	return enabled
 }
 
+// CrudMetricsNamespace returns the prometheus namespace set in the node's config.toml,
+// or false when the node does not serve prometheus metrics.
+func CrudMetricsNamespace(nodeHome string) (string, bool) {
+	config := viper.New()
+	config.SetConfigName("config")
+	config.SetConfigType("toml")
+	config.AddConfigPath(nodeHome + "/config/")
+
+	if config.ReadInConfig() != nil || !config.GetBool("instrumentation.prometheus") {
+		return "", false
+	}
+	return config.GetString("instrumentation.namespace"), true
+}
+
 func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
 	ModuleBasics.RegisterCodec(cdc)
@@ -122,18 +148,30 @@ type CRUDApp struct {
 	slashingKeeper slashing.Keeper
 	distrKeeper    distr.Keeper
 	govKeeper      gov.Keeper
+	crisisKeeper   crisis.Keeper
 	supplyKeeper   supply.Keeper
 	paramsKeeper   params.Keeper
 	crudKeeper     crud.Keeper
 	faucetKeeper   faucet.Keeper
+	taxKeeper      tax.Keeper
+	nftKeeper      nft.Keeper
+	upgradeKeeper  upgrade.Keeper
+
+	// invariants are asserted every invCheckPeriod blocks, never if 0
+	invCheckPeriod uint
 
 	// Module Manager
 	mm *module.Manager
+
+	// simulation manager
+	sm *module.SimulationManager
 }
 
 func NewCRUDApp(
 	logger log.Logger,
 	db dbm.DB,
+	skipUpgradeHeights map[int64]bool,
+	invCheckPeriod uint,
 	baseAppOptions ...func(*bam.BaseApp),
 ) *CRUDApp {
 
@@ -149,16 +187,18 @@ func NewCRUDApp(
 		bam.MainStoreKey, auth.StoreKey, staking.StoreKey,
 		supply.StoreKey, distr.StoreKey, slashing.StoreKey,
 		gov.StoreKey, params.StoreKey, crud.StoreKey,
-		faucet.StoreKey, crud.LeaseKey)
+		faucet.StoreKey, crud.LeaseKey, crud.IndexKey,
+		tax.StoreKey, upgrade.StoreKey, nft.StoreKey)
 
 	tkeys := sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
 
 	// Here you initialize your application with the store keys it requires
 	var app = &CRUDApp{
-		BaseApp: bApp,
-		cdc:     cdc,
-		keys:    keys,
-		tkeys:   tkeys,
+		BaseApp:        bApp,
+		cdc:            cdc,
+		keys:           keys,
+		tkeys:          tkeys,
+		invCheckPeriod: invCheckPeriod,
 	}
 
 	// The ParamsKeeper handles parameter storage for the application
@@ -170,6 +210,10 @@ func NewCRUDApp(
 	distrSubspace := app.paramsKeeper.Subspace(distr.DefaultParamspace)
 	slashingSubspace := app.paramsKeeper.Subspace(slashing.DefaultParamspace)
 	govSubspace := app.paramsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
+	crisisSubspace := app.paramsKeeper.Subspace(crisis.DefaultParamspace)
+	crudSubspace := app.paramsKeeper.Subspace(crud.DefaultParamspace)
+	faucetSubspace := app.paramsKeeper.Subspace(faucet.DefaultParamspace)
+	taxSubspace := app.paramsKeeper.Subspace(tax.DefaultParamspace)
 
 	// The AccountKeeper handles address -> account lookups
 	app.accountKeeper = auth.NewAccountKeeper(
@@ -220,9 +264,19 @@ func NewCRUDApp(
 		slashingSubspace,
 	)
 
+	app.crisisKeeper = crisis.NewKeeper(
+		crisisSubspace,
+		invCheckPeriod,
+		app.supplyKeeper,
+		auth.FeeCollectorName,
+	)
+
+	app.upgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
+
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper))
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
 
 	app.govKeeper = gov.NewKeeper(
 		app.cdc,
@@ -244,19 +298,35 @@ func NewCRUDApp(
 	// The CrudKeeper is the Keeper from the module
 	// It handles interactions with the namestore
 	app.crudKeeper = crud.NewKeeper(
-		app.bankKeeper,
+		app.supplyKeeper,
 		keys[crud.StoreKey],
 		keys[crud.LeaseKey],
+		keys[crud.IndexKey],
+		crudSubspace,
 		app.cdc,
 		crud.MaxKeeperSizes{MaxKeysSize: maxKeysSize, MaxKeyValuesSize: maxKeyValuesSize, MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight},
 	)
+	if namespace, ok := CrudMetricsNamespace(DefaultNodeHome); ok {
+		app.crudKeeper.SetMetrics(crud.PrometheusMetrics(namespace))
+	}
+
+	// tokens follow the entries they refer to, so the nft keeper hooks into the crud
+	// keeper before any copy of it is handed out; the nft keeper itself holds the crud
+	// keeper by reference, so the writes it makes run the hooks too
+	app.nftKeeper = nft.NewKeeper(&app.crudKeeper, keys[nft.StoreKey], app.cdc)
+	app.crudKeeper.SetHooks(app.nftKeeper.Hooks())
+	app.upgradeKeeper.SetUpgradeHandler(crud.UpgradeName, crud.NewUpgradeHandler(app.crudKeeper))
 
 	app.faucetKeeper = faucet.NewKeeper(
 		app.supplyKeeper,
-		app.stakingKeeper,
-		2000000000,
-		5*time.Minute,
 		keys[faucet.StoreKey],
+		faucetSubspace,
+		app.cdc)
+
+	app.taxKeeper = tax.NewKeeper(
+		app.supplyKeeper,
+		keys[tax.StoreKey],
+		taxSubspace,
 		app.cdc)
 
 	// check flags...
@@ -267,8 +337,12 @@ func NewCRUDApp(
 		genutil.NewAppModule(app.accountKeeper, app.stakingKeeper, app.BaseApp.DeliverTx),
 		auth.NewAppModule(app.accountKeeper),
 		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
-		crud.NewAppModule(!bluzelleCrud, app.crudKeeper, app.bankKeeper),
+		crisis.NewAppModule(&app.crisisKeeper),
+		crud.NewAppModule(!bluzelleCrud, app.crudKeeper, app.bankKeeper, app.accountKeeper),
 		faucet.NewAppModule(app.faucetKeeper), // faucet module
+		tax.NewAppModule(app.taxKeeper),
+		nft.NewAppModule(app.nftKeeper),
+		upgrade.NewAppModule(app.upgradeKeeper),
 		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
 		gov.NewAppModule(app.govKeeper, app.accountKeeper, app.supplyKeeper),
 		distr.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper),
@@ -276,8 +350,10 @@ func NewCRUDApp(
 		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
 	)
 
-	app.mm.SetOrderBeginBlockers(distr.ModuleName, slashing.ModuleName)
-	app.mm.SetOrderEndBlockers(gov.ModuleName, staking.ModuleName)
+	// upgrade must come first, it halts the chain at the height of an upgrade plan the
+	// binary has no handler for
+	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, distr.ModuleName, slashing.ModuleName)
+	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils moodule must occur after staking so that pools are
@@ -290,13 +366,34 @@ func NewCRUDApp(
 		slashing.ModuleName,
 		gov.ModuleName,
 		crud.ModuleName,
+		nft.ModuleName,
+		faucet.ModuleName,
+		tax.ModuleName,
 		supply.ModuleName,
+		crisis.ModuleName,
 		genutil.ModuleName,
 	)
 
+	app.mm.RegisterInvariants(&app.crisisKeeper)
+
 	// register all module routes and module queriers
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
+	// create the simulation manager and define the order of the modules for deterministic simulations
+	app.sm = module.NewSimulationManager(
+		auth.NewAppModule(app.accountKeeper),
+		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
+		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
+		gov.NewAppModule(app.govKeeper, app.accountKeeper, app.supplyKeeper),
+		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
+		distr.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper),
+		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
+		params.NewAppModule(), // NOTE: only used for simulation to generate randomized param change proposals
+		crud.NewAppModule(!bluzelleCrud, app.crudKeeper, app.bankKeeper, app.accountKeeper),
+	)
+
+	app.sm.RegisterStoreDecoders()
+
 	// The initChainer handles translating the genesis.json file into initial state for the network
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
@@ -304,9 +401,11 @@ func NewCRUDApp(
 
 	// The AnteHandler handles signature verification and transaction pre-processing
 	app.SetAnteHandler(
-		auth.NewAnteHandler(
+		NewAnteHandler(
 			app.accountKeeper,
 			app.supplyKeeper,
+			app.crudKeeper,
+			app.taxKeeper,
 			auth.DefaultSigVerificationGasConsumer,
 		),
 	)
@@ -347,10 +446,35 @@ func (app *CRUDApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 
 func (app *CRUDApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	r := app.mm.EndBlock(ctx, req)
-	app.crudKeeper.ProcessLeasesAtBlockHeight(ctx, app.crudKeeper.GetKVStore(ctx), app.crudKeeper.GetLeaseStore(ctx), ctx.BlockHeight())
+
+	// the module manager returns only the events of its own event manager
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	app.crudKeeper.AutoRenewLeases(ctx)
+	app.crudKeeper.PurgeExpiredLeases(ctx)
+	app.crudKeeper.EnforceRetention(ctx)
+	app.crudKeeper.RecordStoreMetrics(ctx)
+	app.crudKeeper.DistributeLeaseFees(ctx)
+	app.crudKeeper.PruneRateLimits(ctx)
+	app.crudKeeper.PruneAuditLog(ctx)
+	r.Events = append(r.Events, ctx.EventManager().ABCIEvents()...)
 	return r
 }
 
+// Codec returns the application's codec.
+func (app *CRUDApp) Codec() *codec.Codec {
+	return app.cdc
+}
+
+// CrudKeeper returns the crud keeper, for offline tools reading the stored state.
+func (app *CRUDApp) CrudKeeper() crud.Keeper {
+	return app.crudKeeper
+}
+
+// SimulationManager implements the SimulationApp interface
+func (app *CRUDApp) SimulationManager() *module.SimulationManager {
+	return app.sm
+}
+
 func (app *CRUDApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height, app.keys[bam.MainStoreKey])
 }
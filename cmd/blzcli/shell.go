@@ -0,0 +1,386 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	blzclient "github.com/bluzelle/curium/pkg/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const shellHelp = `use <UUID>               make UUID the database later commands work on
+account [name]           sign with the key name, or show the signing key
+lease [blocks]           lease new and updated entries for blocks, 0 for the network default
+create <key> <value...>  create key; the rest of the line is the value
+update <key> <value...>  update key; the rest of the line is the value
+read <key>               print the value of key
+delete <key>             delete key
+keys                     list the keys of the UUID
+help                     print this help
+exit                     leave the shell
+`
+
+var shellCommands = []string{"account", "create", "delete", "exit", "help", "keys", "lease", "read", "update", "use"}
+
+func crudCmd() *cobra.Command {
+	crudCmd := &cobra.Command{
+		Use:   "crud",
+		Short: "Crud developer tools",
+	}
+
+	crudCmd.AddCommand(flags.PostCommands(shellCmd())...)
+
+	return crudCmd
+}
+
+func shellCmd() *cobra.Command {
+	var UUID string
+	cc := cobra.Command{
+		Use:   "shell",
+		Short: "interactive shell for creating, reading, updating and deleting entries",
+		Long: "Read crud commands from the terminal and run them against --node, signing transactions with " +
+			"--from. The UUID, signing key and lease are remembered between commands and the account " +
+			"sequence is tracked locally, so consecutive transactions do not wait on account queries.\n\n" + shellHelp,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gas := flags.GasFlagVar.Gas
+			if flags.GasFlagVar.Simulate {
+				gas = 0
+			}
+
+			c, err := blzclient.New(blzclient.Config{
+				NodeURI:        viper.GetString(flags.FlagNode),
+				ChainID:        viper.GetString(flags.FlagChainID),
+				KeyringDir:     viper.GetString(flags.FlagHome),
+				KeyringBackend: viper.GetString(flags.FlagKeyringBackend),
+				From:           viper.GetString(flags.FlagFrom),
+				Gas:            gas,
+				GasAdjustment:  viper.GetFloat64(flags.FlagGasAdjustment),
+				GasPrices:      viper.GetString(flags.FlagGasPrices),
+				Fees:           viper.GetString(flags.FlagFees),
+				Memo:           viper.GetString(flags.FlagMemo),
+			})
+			if err != nil {
+				return err
+			}
+
+			s := &shell{client: c, ctx: context.Background(), out: cmd.OutOrStdout()}
+			if UUID != "" {
+				s.use(UUID)
+			}
+			return s.run(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cc.Flags().StringVar(&UUID, "uuid", "", "UUID to start the shell in")
+	return &cc
+}
+
+// shell is the state kept between the commands of a crud shell session.
+type shell struct {
+	client *blzclient.Client
+	ctx    context.Context
+	out    io.Writer
+
+	UUID  string
+	lease int64
+
+	// keys of UUID, refreshed by commands that change them, for tab completion
+	keys []string
+}
+
+func (s *shell) prompt() string {
+	if s.UUID == "" {
+		return "crud> "
+	}
+	return fmt.Sprintf("crud:%s> ", s.UUID)
+}
+
+// run reads commands until exit or end of input. On a terminal, lines are edited in
+// raw mode with tab completion; otherwise they are read as they come, so that a
+// script can be piped in.
+func (s *shell) run(in io.Reader, out io.Writer) error {
+	f, ok := in.(*os.File)
+	if !ok || !terminal.IsTerminal(int(f.Fd())) {
+		s.out = out
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			if s.exec(scanner.Text()) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	}
+
+	state, err := terminal.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return err
+	}
+	defer terminal.Restore(int(f.Fd()), state)
+
+	term := terminal.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, s.prompt())
+	term.AutoCompleteCallback = s.complete
+	s.out = term
+
+	for {
+		line, err := term.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if s.exec(line) {
+			return nil
+		}
+		term.SetPrompt(s.prompt())
+	}
+}
+
+// exec runs one command line and reports whether the shell should exit.
+func (s *shell) exec(line string) bool {
+	name, rest := splitWord(strings.TrimSpace(line))
+	if name == "" {
+		return false
+	}
+	if name == "exit" || name == "quit" {
+		return true
+	}
+
+	if err := s.dispatch(name, rest); err != nil {
+		fmt.Fprintf(s.out, "error: %s\n", err)
+	}
+	return false
+}
+
+func (s *shell) dispatch(name, rest string) error {
+	switch name {
+	case "help":
+		fmt.Fprint(s.out, shellHelp)
+		return nil
+	case "use":
+		if rest == "" {
+			return errors.New("usage: use <UUID>")
+		}
+		s.use(rest)
+		return nil
+	case "account":
+		return s.account(rest)
+	case "lease":
+		return s.setLease(rest)
+	}
+
+	if s.UUID == "" {
+		return errors.New("no UUID selected, see use")
+	}
+
+	key, value := splitWord(rest)
+	switch name {
+	case "create", "update":
+		if key == "" || value == "" {
+			return fmt.Errorf("usage: %s <key> <value>", name)
+		}
+		var err error
+		if name == "create" {
+			err = s.client.Create(s.ctx, s.UUID, key, []byte(value), s.lease)
+		} else {
+			err = s.client.Update(s.ctx, s.UUID, key, []byte(value), s.lease)
+		}
+		if err != nil {
+			return err
+		}
+		s.addKey(key)
+		return nil
+	case "read":
+		if key == "" {
+			return errors.New("usage: read <key>")
+		}
+		res, err := s.client.Read(s.ctx, s.UUID, key)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(s.out, string(res.Value))
+		return nil
+	case "delete":
+		if key == "" {
+			return errors.New("usage: delete <key>")
+		}
+		if err := s.client.Delete(s.ctx, s.UUID, key); err != nil {
+			return err
+		}
+		s.removeKey(key)
+		return nil
+	case "keys":
+		if err := s.refreshKeys(); err != nil {
+			return err
+		}
+		for _, key := range s.keys {
+			fmt.Fprintln(s.out, key)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q, see help", name)
+}
+
+func (s *shell) use(UUID string) {
+	s.UUID = UUID
+	s.keys = nil
+	if err := s.refreshKeys(); err != nil {
+		fmt.Fprintf(s.out, "error: %s\n", err)
+	}
+}
+
+func (s *shell) account(name string) error {
+	if name != "" {
+		if err := s.client.UseKey(name); err != nil {
+			return err
+		}
+	}
+
+	if s.client.Address() == nil {
+		return errors.New("no signing key selected, see account")
+	}
+	fmt.Fprintln(s.out, s.client.Address().String())
+	return nil
+}
+
+func (s *shell) setLease(arg string) error {
+	if arg != "" {
+		lease, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || lease < 0 {
+			return fmt.Errorf("invalid lease %q", arg)
+		}
+		s.lease = lease
+	}
+
+	if s.lease == 0 {
+		fmt.Fprintln(s.out, "network default")
+		return nil
+	}
+	fmt.Fprintln(s.out, s.lease)
+	return nil
+}
+
+func (s *shell) refreshKeys() error {
+	var keys []string
+	for start := ""; ; {
+		res, err := s.client.KeysFrom(s.ctx, s.UUID, start)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, res.Keys...)
+		if res.Next == "" {
+			break
+		}
+		start = res.Next
+	}
+	s.keys = keys
+	return nil
+}
+
+func (s *shell) addKey(key string) {
+	i := sort.SearchStrings(s.keys, key)
+	if i < len(s.keys) && s.keys[i] == key {
+		return
+	}
+	s.keys = append(s.keys, "")
+	copy(s.keys[i+1:], s.keys[i:])
+	s.keys[i] = key
+}
+
+func (s *shell) removeKey(key string) {
+	i := sort.SearchStrings(s.keys, key)
+	if i < len(s.keys) && s.keys[i] == key {
+		s.keys = append(s.keys[:i], s.keys[i+1:]...)
+	}
+}
+
+// complete extends the word before the cursor on tab to the longest prefix shared by
+// the commands, keys or key names that could follow. It only uses what the shell
+// already knows, since the terminal is blocked while it runs.
+func (s *shell) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	start := strings.LastIndex(line[:pos], " ") + 1
+	word := line[start:pos]
+
+	var candidates []string
+	name, _ := splitWord(line)
+	switch {
+	case start == 0:
+		candidates = shellCommands
+	case strings.Count(strings.TrimSpace(line[:start]), " ") > 0:
+		// only the first argument is completed
+		return "", 0, false
+	case name == "read" || name == "update" || name == "delete" || name == "create":
+		candidates = s.keys
+	case name == "account":
+		infos, err := s.client.ListKeys()
+		if err != nil {
+			return "", 0, false
+		}
+		for _, info := range infos {
+			candidates = append(candidates, info.GetName())
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+
+	completion := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(matches) == 1 {
+		completion += " "
+	}
+
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+// splitWord returns the first space separated word of s and the rest of s after it.
+func splitWord(s string) (string, string) {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimLeft(s[i+1:], " ")
+}
@@ -0,0 +1,321 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/p2p"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	app "github.com/bluzelle/curium"
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/bluzelle/curium/x/crud/archive"
+)
+
+const (
+	flagUUID       = "uuid"
+	flagHeight     = "height"
+	flagSigner     = "signer"
+	flagVerifyOnly = "verify-only"
+	flagOutput     = "output"
+)
+
+// CrudCmd returns the crud commands run against the node's own data, with the node stopped.
+func CrudCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crud",
+		Short: "crud module maintenance commands",
+	}
+	cmd.AddCommand(BackupCmd(ctx, cdc, defaultNodeHome), RestoreCmd(ctx, cdc, defaultNodeHome), VerifyCmd(ctx, defaultNodeHome))
+	return cmd
+}
+
+// BackupCmd returns the crud backup command, writing a signed archive of a UUID or of the
+// whole crud module.
+func BackupCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup [file]",
+		Short: "Write the keys of a UUID, or the whole crud state, to an archive signed by the node key",
+		Long: `Write the keys of a UUID (--uuid), or the whole crud state, at the last committed height
+or --height to an archive signed with the node key of this node. The node must be stopped.
+Lease heights are written relative to the archived height, as in an exported genesis.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+			if err != nil {
+				return fmt.Errorf("failed to load node key: %w", err)
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(config.GenesisFile())
+			if err != nil {
+				return err
+			}
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			blzApp := app.NewCRUDApp(ctx.Logger, db, map[int64]bool{}, uint(1))
+			if height := viper.GetInt64(flagHeight); height != 0 {
+				if err := blzApp.LoadHeight(height); err != nil {
+					return err
+				}
+			}
+			appCtx := blzApp.NewContext(true, abci.Header{ChainID: genDoc.ChainID, Height: blzApp.LastBlockHeight()})
+			keeper := blzApp.CrudKeeper()
+
+			header := archive.Header{ChainID: genDoc.ChainID, Height: blzApp.LastBlockHeight(), UUID: viper.GetString(flagUUID)}
+			if len(header.UUID) == 0 {
+				state := crud.ExportState(appCtx, keeper)
+				header.State = &state
+			}
+
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			writer, err := archive.NewWriter(file, header, nodeKey.PrivKey)
+			if err != nil {
+				return err
+			}
+
+			count := 0
+			err = crud.ExportValues(appCtx, keeper, header.UUID, func(value crud.GenesisValue) error {
+				count++
+				return writer.Write(value)
+			})
+			if err != nil {
+				return err
+			}
+			if err = writer.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "%d keys at height %d signed by node %s\n", count, header.Height, nodeKey.ID())
+			return file.Sync()
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(flagUUID, "", "back up only the keys of this UUID")
+	cmd.Flags().Int64(flagHeight, 0, "back up the state at this height instead of the last one")
+	return cmd
+}
+
+// RestoreCmd returns the crud restore command, verifying an archive and restoring it into
+// the node's genesis file, or writing its keys out for import.
+func RestoreCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [file]",
+		Short: "Verify a crud archive and restore it into genesis.json",
+		Long: `Verify that a crud archive is complete and unchanged, and signed by the node --signer
+(a node ID as shown by "blzd tendermint show-node-id") if given. Nothing is restored unless
+the whole archive verifies.
+
+The archived keys are then written to genesis.json, replacing the crud state of an archive of the
+whole module or the keys of the archived UUID. With --output the keys of a UUID archive are written
+to a file for "blzcli tx crud import" instead, and with --verify-only nothing is written.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			reader, err := archive.NewReader(file)
+			if err != nil {
+				return err
+			}
+
+			var values []crud.GenesisValue
+			for {
+				value, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return fmt.Errorf("%s: %w", args[0], err)
+				}
+				values = append(values, value)
+			}
+
+			header := reader.Header
+			signer := p2p.PubKeyToID(reader.PubKey)
+			if expected := viper.GetString(flagSigner); len(expected) > 0 && string(signer) != expected {
+				return fmt.Errorf("%s is signed by node %s, not %s", args[0], signer, expected)
+			}
+			fmt.Fprintf(os.Stderr, "%d keys of chain %s at height %d signed by node %s\n", len(values), header.ChainID, header.Height, signer)
+
+			switch {
+			case viper.GetBool(flagVerifyOnly):
+				return nil
+			case len(viper.GetString(flagOutput)) > 0:
+				return writeImportFile(cdc, header, values, viper.GetString(flagOutput))
+			default:
+				return restoreGenesis(cdc, config.GenesisFile(), header, values)
+			}
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(flagSigner, "", "ID of the node the archive must be signed by")
+	cmd.Flags().Bool(flagVerifyOnly, false, "only verify the archive")
+	cmd.Flags().String(flagOutput, "", "write the keys of a UUID archive to this file, for blzcli tx crud import")
+	return cmd
+}
+
+// VerifyCmd returns the crud verify command, checking the lease store and the indexes of
+// the node's crud state against the stored values.
+func VerifyCmd(ctx *server.Context, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check the crud leases, indexes and counters against the stored values",
+		Long: `Walk the crud state at the last committed height or --height and report every lease
+without a key or at the wrong height, key without a lease, missing or unexpected owner, lease,
+value and hash index entry, and counter that disagrees with the stored values. The node must be
+stopped. The command fails when anything is reported.
+
+Nothing is written: a repaired state committed here would be ahead of the node's blocks, and
+would differ from the state of every other node. The crud upgrade handler makes the same
+repairs, on every node at the upgrade height.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			blzApp := app.NewCRUDApp(ctx.Logger, db, map[int64]bool{}, uint(1))
+			if height := viper.GetInt64(flagHeight); height != 0 {
+				if err := blzApp.LoadHeight(height); err != nil {
+					return err
+				}
+			}
+			appCtx := blzApp.NewContext(true, abci.Header{Height: blzApp.LastBlockHeight()})
+
+			found := blzApp.CrudKeeper().Verify(appCtx, false)
+			for _, inconsistency := range found {
+				fmt.Println(inconsistency)
+			}
+
+			fmt.Fprintf(os.Stderr, "%d inconsistencies at height %d\n", len(found), blzApp.LastBlockHeight())
+			if len(found) > 0 {
+				return fmt.Errorf("the crud state at height %d is inconsistent", blzApp.LastBlockHeight())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().Int64(flagHeight, 0, "verify the state at this height instead of the last one")
+	return cmd
+}
+
+func writeImportFile(cdc *codec.Codec, header archive.Header, values []crud.GenesisValue, path string) error {
+	if len(header.UUID) == 0 {
+		return errors.New("only the archive of a UUID can be written for import")
+	}
+
+	// keys are imported with the blocks of lease they had left, those in their expiry
+	// grace period are left out
+	keyValues := make([]crud.KeyValueLease, 0, len(values))
+	for _, value := range values {
+		lease := value.Value.Height + value.Value.Lease
+		if lease < 1 {
+			fmt.Fprintf(os.Stderr, "skipping expired key %s\n", value.Key)
+			continue
+		}
+		keyValues = append(keyValues, crud.KeyValueLease{Key: value.Key, Value: value.Value.Value, Lease: lease, Owner: value.Value.Owner})
+	}
+
+	out, err := cdc.MarshalJSONIndent(keyValues, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, out, 0644)
+}
+
+func restoreGenesis(cdc *codec.Codec, genFile string, header archive.Header, values []crud.GenesisValue) error {
+	appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+	}
+
+	var crudState crud.GenesisState
+	if header.State != nil {
+		crudState = *header.State
+	} else {
+		if err := cdc.UnmarshalJSON(appState[crud.ModuleName], &crudState); err != nil {
+			return fmt.Errorf("failed to unmarshal crud genesis state: %w", err)
+		}
+
+		// the archived keys replace those of the UUID
+		kept := crudState.BlzValues[:0]
+		for _, value := range crudState.BlzValues {
+			if value.UUID != header.UUID {
+				kept = append(kept, value)
+			}
+		}
+		values = append(kept, values...)
+	}
+	crudState.BlzValues = values
+
+	if err := crud.ValidateGenesis(crudState); err != nil {
+		return err
+	}
+
+	crudStateBz, err := cdc.MarshalJSON(crudState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal crud genesis state: %w", err)
+	}
+	appState[crud.ModuleName] = crudStateBz
+
+	appStateJSON, err := cdc.MarshalJSON(appState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal application genesis state: %w", err)
+	}
+
+	genDoc.AppState = appStateJSON
+	return genutil.ExportGenesisFile(genDoc, genFile)
+}
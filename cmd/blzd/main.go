@@ -43,6 +43,10 @@ import (
 	app "github.com/bluzelle/curium"
 )
 
+const flagInvCheckPeriod = "inv-check-period"
+
+var invCheckPeriod uint
+
 func main() {
 	cobra.EnableCommandSorting = false
 
@@ -77,8 +81,11 @@ func main() {
 	rootCmd.AddCommand(AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome))
 	rootCmd.AddCommand(flags.NewCompletionCmd(rootCmd, true))
 	rootCmd.AddCommand(debug.Cmd(cdc))
+	rootCmd.AddCommand(CrudCmd(ctx, cdc, app.DefaultNodeHome))
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
+		0, "Assert registered invariants every N blocks")
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "DB", app.DefaultNodeHome)
@@ -94,8 +101,15 @@ func newApp(logger log.Logger, db dbm.DB, _ io.Writer) abci.Application {
 		panic(err)
 	}
 
+	skipUpgradeHeights := make(map[int64]bool)
+	for _, h := range viper.GetIntSlice(server.FlagUnsafeSkipUpgrades) {
+		skipUpgradeHeights[int64(h)] = true
+	}
+
 	return app.NewCRUDApp(logger,
 		db,
+		skipUpgradeHeights,
+		invCheckPeriod,
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetHaltHeight(viper.GetUint64(server.FlagHaltHeight)),
@@ -107,7 +121,7 @@ func exportAppStateAndTMValidators(
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 
 	if height != -1 {
-		blzApp := app.NewCRUDApp(logger, db)
+		blzApp := app.NewCRUDApp(logger, db, map[int64]bool{}, uint(1))
 		err := blzApp.LoadHeight(height)
 		if err != nil {
 			return nil, nil, err
@@ -115,7 +129,7 @@ func exportAppStateAndTMValidators(
 		return blzApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
 
-	blzApp := app.NewCRUDApp(logger, db)
+	blzApp := app.NewCRUDApp(logger, db, map[int64]bool{}, uint(1))
 
 	return blzApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
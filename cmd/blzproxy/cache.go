@@ -0,0 +1,144 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+type cacheEntry struct {
+	UUID string
+	path string
+	body []byte
+}
+
+// cache is an LRU of query responses by request path. Entries are grouped by UUID so
+// a change to any key of a UUID drops all of them. Every drop also stamps the UUID
+// with a new generation so that a response fetched before the change, and so
+// possibly stale, is not stored after it.
+type cache struct {
+	mtx         sync.Mutex
+	maxEntries  int
+	lru         *list.List
+	entries     map[string]*list.Element
+	uuids       map[string]map[string]*list.Element
+	generations map[string]uint64
+	flushed     uint64
+	counter     uint64
+}
+
+func newCache(maxEntries int) *cache {
+	return &cache{
+		maxEntries:  maxEntries,
+		lru:         list.New(),
+		entries:     make(map[string]*list.Element),
+		uuids:       make(map[string]map[string]*list.Element),
+		generations: make(map[string]uint64),
+	}
+}
+
+func (c *cache) get(path string) ([]byte, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	element, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).body, true
+}
+
+// generation is taken before fetching a response and handed back to put.
+func (c *cache) generation(UUID string) uint64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.generationOf(UUID)
+}
+
+func (c *cache) generationOf(UUID string) uint64 {
+	if generation, ok := c.generations[UUID]; ok && generation > c.flushed {
+		return generation
+	}
+	return c.flushed
+}
+
+func (c *cache) put(UUID string, path string, body []byte, generation uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.maxEntries <= 0 || generation != c.generationOf(UUID) {
+		return
+	}
+
+	if element, ok := c.entries[path]; ok {
+		element.Value.(*cacheEntry).body = body
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	element := c.lru.PushFront(&cacheEntry{UUID: UUID, path: path, body: body})
+	c.entries[path] = element
+	if c.uuids[UUID] == nil {
+		c.uuids[UUID] = make(map[string]*list.Element)
+	}
+	c.uuids[UUID][path] = element
+
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *cache) invalidate(UUID string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.counter++
+	c.generations[UUID] = c.counter
+	for _, element := range c.uuids[UUID] {
+		c.remove(element)
+	}
+}
+
+// flush drops everything, for when changes may have been missed.
+func (c *cache) flush() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.counter++
+	c.flushed = c.counter
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.uuids = make(map[string]map[string]*list.Element)
+	c.generations = make(map[string]uint64)
+}
+
+func (c *cache) len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *cache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.path)
+	delete(c.uuids[entry.UUID], entry.path)
+	if len(c.uuids[entry.UUID]) == 0 {
+		delete(c.uuids, entry.UUID)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	c := newCache(2)
+
+	c.put("uuid", "/crud/read/uuid/a", []byte("a"), c.generation("uuid"))
+	c.put("uuid", "/crud/read/uuid/b", []byte("b"), c.generation("uuid"))
+	body, ok := c.get("/crud/read/uuid/a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), body)
+
+	// b is the least recently used
+	c.put("other", "/crud/keys/other", []byte("keys"), c.generation("other"))
+	_, ok = c.get("/crud/read/uuid/b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.len())
+
+	c.invalidate("uuid")
+	_, ok = c.get("/crud/read/uuid/a")
+	assert.False(t, ok)
+	_, ok = c.get("/crud/keys/other")
+	assert.True(t, ok)
+
+	// a response fetched before a change is not cached after it
+	generation := c.generation("uuid")
+	c.invalidate("uuid")
+	c.put("uuid", "/crud/read/uuid/a", []byte("stale"), generation)
+	_, ok = c.get("/crud/read/uuid/a")
+	assert.False(t, ok)
+
+	generation = c.generation("uuid")
+	c.flush()
+	c.put("uuid", "/crud/read/uuid/a", []byte("stale"), generation)
+	assert.Equal(t, 0, c.len())
+
+	c.put("uuid", "/crud/read/uuid/a", []byte("a"), c.generation("uuid"))
+	assert.Equal(t, 1, c.len())
+}
+
+func TestCachedQuery(t *testing.T) {
+	UUID, ok := cachedQuery(httptest.NewRequest("GET", "/crud/read/my%2Fuuid/key", nil))
+	assert.True(t, ok)
+	assert.Equal(t, "my/uuid", UUID)
+
+	_, ok = cachedQuery(httptest.NewRequest("GET", "/crud/getlease/uuid/key", nil))
+	assert.False(t, ok)
+
+	_, ok = cachedQuery(httptest.NewRequest("POST", "/crud/read", nil))
+	assert.False(t, ok)
+
+	_, ok = cachedQuery(httptest.NewRequest("GET", "/bank/balances/addr", nil))
+	assert.False(t, ok)
+}
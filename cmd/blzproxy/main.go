@@ -0,0 +1,76 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+
+	app "github.com/bluzelle/curium"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	flagNode      = "node"
+	flagUpstream  = "upstream"
+	flagLaddr     = "laddr"
+	flagCacheSize = "cache-size"
+)
+
+// blzproxy sits in front of a blzcli rest-server, answering the crud read queries it
+// has seen before from memory until a crud_change event for their UUID arrives from
+// the node, and forwarding everything else, writes included.
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "blzproxy",
+		Short: "Read-caching proxy for the Bluzelle CRUD REST server",
+		Args:  cobra.NoArgs,
+		RunE:  run,
+	}
+
+	rootCmd.Flags().String(flagNode, "tcp://localhost:26657", "<host>:<port> of the node's tendermint RPC, for change events")
+	rootCmd.Flags().String(flagUpstream, "http://localhost:1317", "URL of the REST server requests are forwarded to")
+	rootCmd.Flags().String(flagLaddr, "localhost:1318", "address the proxy listens on")
+	rootCmd.Flags().Int(flagCacheSize, 10000, "maximum number of query responses cached")
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	nodeURI, _ := cmd.Flags().GetString(flagNode)
+	laddr, _ := cmd.Flags().GetString(flagLaddr)
+	cacheSize, _ := cmd.Flags().GetInt(flagCacheSize)
+	upstreamURL, _ := cmd.Flags().GetString(flagUpstream)
+
+	upstream, err := url.Parse(upstreamURL)
+	if err != nil {
+		return err
+	}
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "blzproxy")
+	p := newProxy(app.MakeCodec(), logger, upstream, cacheSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.watch(ctx, nodeURI)
+
+	logger.Info("starting proxy", "laddr", laddr, "upstream", upstream.String())
+	return http.ListenAndServe(laddr, p)
+}
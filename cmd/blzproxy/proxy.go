@@ -0,0 +1,270 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/cosmos/cosmos-sdk/codec"
+	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
+	"github.com/tendermint/tendermint/libs/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// the crud REST queries whose results only change when a key of their UUID changes;
+// leases count down every block and proven reads carry the height they were read at
+var cachedRoutes = map[string]bool{
+	"count":       true,
+	"find":        true,
+	"findbyhash":  true,
+	"gethash":     true,
+	"getmetadata": true,
+	"has":         true,
+	"keys":        true,
+	"keyvalues":   true,
+	"owner":       true,
+	"read":        true,
+}
+
+type contextKey struct{}
+
+// proxy serves the cached crud queries of a REST server and forwards everything else,
+// including transaction broadcasts, to it unchanged.
+type proxy struct {
+	cdc       *codec.Codec
+	logger    log.Logger
+	upstream  *url.URL
+	client    *http.Client
+	forwarder *httputil.ReverseProxy
+	cache     *cache
+	// set while the change subscription is up, the cache is bypassed otherwise
+	live int32
+}
+
+func newProxy(cdc *codec.Codec, logger log.Logger, upstream *url.URL, cacheSize int) *proxy {
+	p := &proxy{
+		cdc:       cdc,
+		logger:    logger,
+		upstream:  upstream,
+		client:    &http.Client{Timeout: time.Minute},
+		forwarder: httputil.NewSingleHostReverseProxy(upstream),
+		cache:     newCache(cacheSize),
+	}
+	p.forwarder.ModifyResponse = p.afterBroadcast
+	return p
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	UUID, ok := cachedQuery(r)
+	if !ok || atomic.LoadInt32(&p.live) == 0 {
+		p.forward(w, r)
+		return
+	}
+
+	path := r.URL.RequestURI()
+	if body, ok := p.cache.get(path); ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Cache", "HIT")
+		_, _ = w.Write(body)
+		return
+	}
+
+	generation := p.cache.generation(UUID)
+
+	res, err := p.client.Get(p.upstream.String() + path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if res.StatusCode == http.StatusOK {
+		p.cache.put(UUID, path, body, generation)
+	}
+
+	for name, values := range res.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("X-Cache", "MISS")
+	w.WriteHeader(res.StatusCode)
+	_, _ = w.Write(body)
+}
+
+// cachedQuery returns the UUID of a GET of one of the cachedRoutes.
+func cachedQuery(r *http.Request) (string, bool) {
+	if r.Method != http.MethodGet {
+		return "", false
+	}
+
+	// "", store name, route, UUID, ...
+	parts := strings.Split(r.URL.EscapedPath(), "/")
+	if len(parts) < 4 || parts[1] != crud.StoreKey || !cachedRoutes[parts[2]] {
+		return "", false
+	}
+
+	UUID, err := url.PathUnescape(parts[3])
+	if err != nil || len(UUID) == 0 {
+		return "", false
+	}
+	return UUID, true
+}
+
+// forward passes r to the REST server. The UUIDs written by a broadcast transaction
+// are noted so that their entries can be dropped before the result reaches the
+// client, which then reads its own writes even if the change events arrive later.
+func (p *proxy) forward(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/txs" {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), contextKey{}, p.broadcastUUIDs(body)))
+	}
+
+	p.forwarder.ServeHTTP(w, r)
+}
+
+func (p *proxy) broadcastUUIDs(body []byte) []string {
+	var req authrest.BroadcastReq
+	if err := p.cdc.UnmarshalJSON(body, &req); err != nil {
+		return nil
+	}
+
+	var UUIDs []string
+	for _, msg := range req.Tx.GetMsgs() {
+		if msg.Route() != crud.RouterKey {
+			continue
+		}
+
+		// every crud message names the UUID it writes, copies also the one written to
+		var fields struct {
+			UUID    string
+			NewUUID string
+		}
+		if bz, err := json.Marshal(msg); err == nil && json.Unmarshal(bz, &fields) == nil {
+			UUIDs = append(UUIDs, fields.UUID, fields.NewUUID)
+		}
+	}
+	return UUIDs
+}
+
+func (p *proxy) afterBroadcast(res *http.Response) error {
+	UUIDs, _ := res.Request.Context().Value(contextKey{}).([]string)
+	for _, UUID := range UUIDs {
+		if len(UUID) > 0 {
+			p.cache.invalidate(UUID)
+		}
+	}
+	return nil
+}
+
+// watch keeps a subscription to the node's events, dropping the cache entries of
+// every UUID with a crud_change event, and resubscribes after a failure. The cache
+// is flushed and bypassed while there is no subscription, as changes may be missed.
+func (p *proxy) watch(ctx context.Context, nodeURI string) {
+	for {
+		err := p.subscribe(ctx, nodeURI)
+
+		atomic.StoreInt32(&p.live, 0)
+		p.cache.flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+
+		p.logger.Error("change subscription lost, resubscribing", "err", err)
+	}
+}
+
+func (p *proxy) subscribe(ctx context.Context, nodeURI string) error {
+	client, err := rpchttp.New(nodeURI, "/websocket")
+	if err != nil {
+		return err
+	}
+	if err := client.Start(); err != nil {
+		return err
+	}
+	defer client.Stop()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// changes made by transactions are Tx events, expired leases are removed in
+	// EndBlock and so show up on the NewBlock event, which also shows whether any
+	// blocks went by unseen
+	txs, err := client.Subscribe(ctx, "blzproxy", "tm.event='Tx'")
+	if err != nil {
+		return err
+	}
+	blocks, err := client.Subscribe(ctx, "blzproxy", "tm.event='NewBlock'")
+	if err != nil {
+		return err
+	}
+
+	p.cache.flush()
+	atomic.StoreInt32(&p.live, 1)
+	p.logger.Info("subscribed to changes", "node", nodeURI)
+
+	var height int64
+	for {
+		var event ctypes.ResultEvent
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok = <-txs:
+		case event, ok = <-blocks:
+		}
+		if !ok {
+			return errors.New("subscription closed")
+		}
+
+		for _, UUID := range event.Events[crud.EventTypeChange+"."+crud.AttributeKeyUUID] {
+			p.cache.invalidate(UUID)
+		}
+
+		if block, isBlock := event.Data.(tmtypes.EventDataNewBlock); isBlock {
+			if height != 0 && block.Block.Height != height+1 {
+				return fmt.Errorf("missed blocks %d to %d", height+1, block.Block.Height-1)
+			}
+			height = block.Block.Height
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/gorilla/websocket"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// the errors swarmDB reported, which its clients match on
+const (
+	errRecordExists     = "RECORD_EXISTS"
+	errRecordNotFound   = "RECORD_NOT_FOUND"
+	errAccessDenied     = "ACCESS_DENIED"
+	errInvalidArgs      = "INVALID_ARGUMENTS"
+	errUnknownCommand   = "UNKNOWN_COMMAND"
+	errUnsupportedAPI   = "UNSUPPORTED_API"
+	errMalformedRequest = "MALFORMED_REQUEST"
+)
+
+// crudClient is the part of pkg/client the adapter uses.
+type crudClient interface {
+	Create(ctx context.Context, UUID, key string, value []byte, lease int64) error
+	Update(ctx context.Context, UUID, key string, value []byte, lease int64) error
+	Delete(ctx context.Context, UUID, key string) error
+	Read(ctx context.Context, UUID, key string) (crud.QueryResultRead, error)
+	TxRead(ctx context.Context, UUID, key string) (crud.QueryResultRead, error)
+	Has(ctx context.Context, UUID, key string) (crud.QueryResultHas, error)
+	Keys(ctx context.Context, UUID string) (crud.QueryResultKeys, error)
+}
+
+// request is a swarmDB database request. Expire is the lifetime of created and
+// updated keys in seconds, zero for the network default lease.
+type request struct {
+	API       string          `json:"bzn-api"`
+	Cmd       string          `json:"cmd"`
+	UUID      string          `json:"db-uuid"`
+	RequestID json.RawMessage `json:"request-id"`
+	Data      struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Expire int64  `json:"expire"`
+	} `json:"data"`
+}
+
+type response struct {
+	RequestID json.RawMessage `json:"request-id,omitempty"`
+	Data      interface{}     `json:"data,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type valueData struct {
+	Value string `json:"value"`
+}
+
+type hasData struct {
+	KeyExists bool `json:"key-exists"`
+}
+
+type keysData struct {
+	Keys []string `json:"keys"`
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// adapter accepts swarmDB WebSocket connections on any path. The requests of a
+// connection are carried out concurrently, as swarmDB did, and answered in the order
+// they complete; clients match responses to requests by request-id.
+type adapter struct {
+	client crudClient
+	logger log.Logger
+}
+
+func newAdapter(client crudClient, logger log.Logger) *adapter {
+	return &adapter{client: client, logger: logger}
+}
+
+func (a *adapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	var writeMtx sync.Mutex
+	reply := func(res response) {
+		writeMtx.Lock()
+		defer writeMtx.Unlock()
+		if err := conn.WriteJSON(res); err != nil {
+			a.logger.Debug("reply failed", "remote", r.RemoteAddr, "err", err)
+		}
+	}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req request
+		if err := json.Unmarshal(message, &req); err != nil {
+			reply(response{Error: errMalformedRequest})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reply(a.handle(ctx, req))
+		}()
+	}
+}
+
+// handle carries out req. read goes through consensus, as it did in swarmDB, while
+// quickread answers from the node's state.
+func (a *adapter) handle(ctx context.Context, req request) response {
+	res := response{RequestID: req.RequestID}
+
+	if req.API != "crud" {
+		res.Error = errUnsupportedAPI
+		return res
+	}
+	if len(req.UUID) == 0 {
+		res.Error = errInvalidArgs
+		return res
+	}
+
+	key := req.Data.Key
+	if len(key) == 0 && req.Cmd != "keys" {
+		res.Error = errInvalidArgs
+		return res
+	}
+
+	var err error
+	switch req.Cmd {
+	case "create":
+		err = a.client.Create(ctx, req.UUID, key, []byte(req.Data.Value), expireToLease(req.Data.Expire))
+	case "update":
+		err = a.client.Update(ctx, req.UUID, key, []byte(req.Data.Value), expireToLease(req.Data.Expire))
+	case "delete":
+		err = a.client.Delete(ctx, req.UUID, key)
+	case "read", "quickread":
+		var result crud.QueryResultRead
+		if req.Cmd == "read" {
+			result, err = a.client.TxRead(ctx, req.UUID, key)
+		} else {
+			result, err = a.client.Read(ctx, req.UUID, key)
+		}
+		res.Data = valueData{Value: string(result.Value)}
+	case "has":
+		var result crud.QueryResultHas
+		result, err = a.client.Has(ctx, req.UUID, key)
+		res.Data = hasData{KeyExists: result.Has}
+	case "keys":
+		var result crud.QueryResultKeys
+		result, err = a.client.Keys(ctx, req.UUID)
+		if result.Keys == nil {
+			result.Keys = []string{}
+		}
+		res.Data = keysData{Keys: result.Keys}
+	default:
+		res.Error = errUnknownCommand
+		return res
+	}
+
+	if err != nil {
+		a.logger.Debug("request failed", "cmd", req.Cmd, "uuid", req.UUID, "key", key, "err", err)
+		return response{RequestID: req.RequestID, Error: legacyError(err)}
+	}
+	return res
+}
+
+// expireToLease converts a lifetime in seconds to a lease in blocks, rounding up.
+func expireToLease(expire int64) int64 {
+	if expire <= 0 {
+		return 0
+	}
+	blockSeconds := int64(crud.BlockTimeEstimate / time.Second)
+	return (expire + blockSeconds - 1) / blockSeconds
+}
+
+// legacyError maps the errors of the crud module to the ones swarmDB returned, and
+// passes others through.
+func legacyError(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "Key already exists"):
+		return errRecordExists
+	case strings.Contains(msg, "Key does not exist"), strings.Contains(msg, "key not found"):
+		return errRecordNotFound
+	case strings.Contains(msg, "Incorrect Owner"):
+		return errAccessDenied
+	}
+	return msg
+}
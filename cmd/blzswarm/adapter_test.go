@@ -0,0 +1,166 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// memClient keeps a single UUID in memory and fails like the crud module does.
+type memClient struct {
+	values map[string][]byte
+	leases map[string]int64
+}
+
+func newMemClient() *memClient {
+	return &memClient{values: map[string][]byte{}, leases: map[string]int64{}}
+}
+
+func (m *memClient) Create(_ context.Context, _, key string, value []byte, lease int64) error {
+	if _, ok := m.values[key]; ok {
+		return errors.New("Key already exists: invalid request")
+	}
+	m.values[key], m.leases[key] = value, lease
+	return nil
+}
+
+func (m *memClient) Update(_ context.Context, _, key string, value []byte, lease int64) error {
+	if _, ok := m.values[key]; !ok {
+		return errors.New("Key does not exist: invalid request")
+	}
+	m.values[key], m.leases[key] = value, lease
+	return nil
+}
+
+func (m *memClient) Delete(_ context.Context, _, key string) error {
+	if _, ok := m.values[key]; !ok {
+		return errors.New("Key does not exist: invalid request")
+	}
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memClient) Read(_ context.Context, UUID, key string) (crud.QueryResultRead, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return crud.QueryResultRead{}, errors.New("key not found: unknown request")
+	}
+	return crud.QueryResultRead{UUID: UUID, Key: key, Value: value}, nil
+}
+
+func (m *memClient) TxRead(ctx context.Context, UUID, key string) (crud.QueryResultRead, error) {
+	return m.Read(ctx, UUID, key)
+}
+
+func (m *memClient) Has(_ context.Context, UUID, key string) (crud.QueryResultHas, error) {
+	_, ok := m.values[key]
+	return crud.QueryResultHas{UUID: UUID, Key: key, Has: ok}, nil
+}
+
+func (m *memClient) Keys(_ context.Context, UUID string) (crud.QueryResultKeys, error) {
+	var keys []string
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return crud.QueryResultKeys{UUID: UUID, Keys: keys}, nil
+}
+
+func handleJSON(t *testing.T, a *adapter, in string) string {
+	var req request
+	require.NoError(t, json.Unmarshal([]byte(in), &req))
+	out, err := json.Marshal(a.handle(context.Background(), req))
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestAdapter_handle(t *testing.T) {
+	client := newMemClient()
+	a := newAdapter(client, log.NewNopLogger())
+
+	assert.Equal(t, `{"request-id":1}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"create","db-uuid":"uuid","request-id":1,"data":{"key":"k","value":"v","expire":12}}`))
+	assert.Equal(t, []byte("v"), client.values["k"])
+	assert.Equal(t, int64(3), client.leases["k"])
+
+	assert.Equal(t, `{"request-id":2,"error":"RECORD_EXISTS"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"create","db-uuid":"uuid","request-id":2,"data":{"key":"k","value":"v"}}`))
+	assert.Equal(t, `{"request-id":3,"data":{"value":"v"}}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"read","db-uuid":"uuid","request-id":3,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":4}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"update","db-uuid":"uuid","request-id":4,"data":{"key":"k","value":"w"}}`))
+	assert.Equal(t, `{"request-id":"five","data":{"value":"w"}}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"quickread","db-uuid":"uuid","request-id":"five","data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":6,"data":{"key-exists":true}}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"has","db-uuid":"uuid","request-id":6,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":7,"data":{"keys":["k"]}}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"keys","db-uuid":"uuid","request-id":7}`))
+	assert.Equal(t, `{"request-id":8}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"delete","db-uuid":"uuid","request-id":8,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":9,"error":"RECORD_NOT_FOUND"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"quickread","db-uuid":"uuid","request-id":9,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":10,"error":"RECORD_NOT_FOUND"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"update","db-uuid":"uuid","request-id":10,"data":{"key":"k","value":"v"}}`))
+	assert.Equal(t, `{"request-id":11,"data":{"keys":[]}}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"keys","db-uuid":"uuid","request-id":11}`))
+
+	assert.Equal(t, `{"request-id":12,"error":"UNKNOWN_COMMAND"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"subscribe","db-uuid":"uuid","request-id":12,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":13,"error":"UNSUPPORTED_API"}`,
+		handleJSON(t, a, `{"bzn-api":"status","cmd":"read","db-uuid":"uuid","request-id":13,"data":{"key":"k"}}`))
+	assert.Equal(t, `{"request-id":14,"error":"INVALID_ARGUMENTS"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"read","db-uuid":"uuid","request-id":14}`))
+	assert.Equal(t, `{"request-id":15,"error":"INVALID_ARGUMENTS"}`,
+		handleJSON(t, a, `{"bzn-api":"crud","cmd":"keys","request-id":15}`))
+}
+
+func TestAdapter_ServeHTTP(t *testing.T) {
+	server := httptest.NewServer(newAdapter(newMemClient(), log.NewNopLogger()))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"bzn-api":"crud","cmd":"create","db-uuid":"uuid","request-id":1,"data":{"key":"k","value":"v"}}`)))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"request-id":1}`, string(message))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`not json`)))
+	_, message, err = conn.ReadMessage()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":"MALFORMED_REQUEST"}`, string(message))
+}
+
+func TestExpireToLease(t *testing.T) {
+	assert.Equal(t, int64(0), expireToLease(0))
+	assert.Equal(t, int64(0), expireToLease(-5))
+	assert.Equal(t, int64(1), expireToLease(1))
+	assert.Equal(t, int64(1), expireToLease(5))
+	assert.Equal(t, int64(2), expireToLease(6))
+}
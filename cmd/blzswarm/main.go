@@ -0,0 +1,92 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	app "github.com/bluzelle/curium"
+	blzclient "github.com/bluzelle/curium/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	flagNode           = "node"
+	flagChainID        = "chain-id"
+	flagHome           = "home"
+	flagKeyringBackend = "keyring-backend"
+	flagFrom           = "from"
+	flagGas            = "gas"
+	flagGasPrices      = "gas-prices"
+	flagFees           = "fees"
+	flagLaddr          = "laddr"
+)
+
+// blzswarm speaks the WebSocket protocol of the old swarmDB daemon and carries out
+// each request as a crud transaction or query against a curium node, so that swarmDB
+// clients keep working unchanged. Transactions are signed with the key named by
+// --from, which owns every key the clients create.
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "blzswarm",
+		Short: "swarmDB WebSocket API adapter for the Bluzelle CRUD module",
+		Args:  cobra.NoArgs,
+		RunE:  run,
+	}
+
+	rootCmd.Flags().String(flagNode, "tcp://localhost:26657", "<host>:<port> of the node's tendermint RPC")
+	rootCmd.Flags().String(flagChainID, "", "chain ID of the node")
+	rootCmd.Flags().String(flagHome, app.DefaultCLIHome, "directory of the keyring")
+	rootCmd.Flags().String(flagKeyringBackend, "os", "keyring backend: os, file or test")
+	rootCmd.Flags().String(flagFrom, "", "name of the key transactions are signed with")
+	rootCmd.Flags().Uint64(flagGas, 0, "gas limit of each transaction, 0 to simulate")
+	rootCmd.Flags().String(flagGasPrices, "", "gas prices to pay transaction fees with, e.g. 10.0ubnt")
+	rootCmd.Flags().String(flagFees, "", "fees to pay for each transaction, instead of --gas-prices")
+	rootCmd.Flags().String(flagLaddr, "localhost:50000", "address swarmDB clients connect to")
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	var cfg blzclient.Config
+	cfg.NodeURI, _ = cmd.Flags().GetString(flagNode)
+	cfg.ChainID, _ = cmd.Flags().GetString(flagChainID)
+	cfg.KeyringDir, _ = cmd.Flags().GetString(flagHome)
+	cfg.KeyringBackend, _ = cmd.Flags().GetString(flagKeyringBackend)
+	cfg.From, _ = cmd.Flags().GetString(flagFrom)
+	cfg.Gas, _ = cmd.Flags().GetUint64(flagGas)
+	cfg.GasPrices, _ = cmd.Flags().GetString(flagGasPrices)
+	cfg.Fees, _ = cmd.Flags().GetString(flagFees)
+	laddr, _ := cmd.Flags().GetString(flagLaddr)
+
+	c, err := blzclient.New(cfg)
+	if err != nil {
+		return err
+	}
+	if c.Address() == nil {
+		return errors.New("--from is required")
+	}
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "blzswarm")
+	a := newAdapter(c, logger)
+
+	logger.Info("starting swarmDB adapter", "laddr", laddr, "node", cfg.NodeURI, "signer", c.Address().String())
+	return http.ListenAndServe(laddr, a)
+}
@@ -4,14 +4,18 @@ go 1.14
 
 require (
 	github.com/cosmos/cosmos-sdk v0.39.1-rc1
-	github.com/cosmos/modules/incubator/faucet v0.0.0-20200315124306-c86f71ae76a0
+	github.com/go-kit/kit v0.10.0
 	github.com/golang/mock v1.4.0
+	github.com/golang/snappy v0.0.1
 	github.com/gorilla/mux v1.7.4
+	github.com/gorilla/websocket v1.4.2
 	github.com/magiconair/properties v1.8.1
+	github.com/prometheus/client_golang v1.5.1
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.6.3
 	github.com/stretchr/testify v1.6.1
 	github.com/tendermint/go-amino v0.15.1
 	github.com/tendermint/tendermint v0.33.6
 	github.com/tendermint/tm-db v0.5.1
+	golang.org/x/crypto v0.0.0-20200429183012-4b2356b1ed79
 )
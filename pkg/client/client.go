@@ -0,0 +1,238 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+// Package client is a Go API for curium's crud module. A Client holds a signing key
+// from a keyring, tracks the account number and sequence of its address and builds,
+// signs and broadcasts crud transactions, so services can use the database without
+// shelling out to blzcli.
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	app "github.com/bluzelle/curium"
+	bluzellechain "github.com/bluzelle/curium/types"
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+)
+
+// Config describes the node a Client talks to and how it pays for transactions.
+// Gas of zero simulates each transaction and scales the estimate by GasAdjustment.
+// Fees and GasPrices are mutually exclusive, as for blzcli.
+type Config struct {
+	NodeURI        string
+	ChainID        string
+	KeyringDir     string
+	KeyringBackend string
+	From           string
+	Gas            uint64
+	GasAdjustment  float64
+	GasPrices      string
+	Fees           string
+	Memo           string
+}
+
+// Client sends crud transactions signed by the key named in Config.From and runs
+// crud queries against Config.NodeURI. It is safe for concurrent use; transactions
+// are serialized so that sequence numbers are handed out in order.
+type Client struct {
+	cdc     *codec.Codec
+	cliCtx  clientcontext.CLIContext
+	keybase keys.Keybase
+	txBldr  auth.TxBuilder
+
+	mtx       sync.Mutex
+	from      keys.Info
+	accNumber uint64
+	sequence  uint64
+	synced    bool
+}
+
+var setPrefixes sync.Once
+
+// New opens the keyring and connects to the node. The signing key may be created or
+// recovered later with NewKey or RecoverKey and selected with UseKey.
+func New(cfg Config) (*Client, error) {
+	setPrefixes.Do(func() {
+		config := sdk.GetConfig()
+		if config.GetBech32AccountAddrPrefix() == bluzellechain.Bech32PrefixAccAddr {
+			return
+		}
+		config.SetBech32PrefixForAccount(bluzellechain.Bech32PrefixAccAddr, bluzellechain.Bech32PrefixAccPub)
+		config.SetBech32PrefixForValidator(bluzellechain.Bech32PrefixValAddr, bluzellechain.Bech32PrefixValPub)
+		config.SetBech32PrefixForConsensusNode(bluzellechain.Bech32PrefixConsAddr, bluzellechain.Bech32PrefixConsPub)
+	})
+
+	if cfg.ChainID == "" {
+		return nil, errors.New("chain ID is required")
+	}
+
+	backend := cfg.KeyringBackend
+	if backend == "" {
+		backend = keys.BackendOS
+	}
+
+	keybase, err := keys.NewKeyring(sdk.KeyringServiceName(), backend, cfg.KeyringDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := sdk.ParseCoins(cfg.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrices, err := sdk.ParseDecCoins(cfg.GasPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	gasAdjustment := cfg.GasAdjustment
+	if gasAdjustment == 0 {
+		gasAdjustment = flags.DefaultGasAdjustment
+	}
+
+	cdc := app.MakeCodec()
+
+	c := &Client{
+		cdc:     cdc,
+		keybase: keybase,
+		txBldr: auth.NewTxBuilder(utils.GetTxEncoder(cdc), 0, 0, cfg.Gas, gasAdjustment, cfg.Gas == 0,
+			cfg.ChainID, cfg.Memo, fees, gasPrices).WithKeybase(keybase),
+		cliCtx: clientcontext.CLIContext{}.
+			WithCodec(cdc).
+			WithChainID(cfg.ChainID).
+			WithTrustNode(true).
+			WithBroadcastMode(flags.BroadcastBlock),
+	}
+
+	if cfg.NodeURI != "" {
+		c.cliCtx = c.cliCtx.WithNodeURI(cfg.NodeURI)
+	}
+
+	if cfg.From != "" {
+		if err := c.UseKey(cfg.From); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Codec returns the application codec used to encode transactions and decode query results.
+func (c *Client) Codec() *codec.Codec {
+	return c.cdc
+}
+
+// Address returns the address transactions are signed with, or nil when no key is in use.
+func (c *Client) Address() sdk.AccAddress {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.from == nil {
+		return nil
+	}
+	return c.from.GetAddress()
+}
+
+// Send signs msgs with the current key and broadcasts them as one transaction, waiting
+// for it to be committed. The account sequence is fetched from the node on first use
+// and after any failed transaction, and a transaction rejected for a stale sequence is
+// retried once with the sequence the node reports.
+func (c *Client) Send(ctx context.Context, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return sdk.TxResponse{}, err
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.from == nil {
+		return sdk.TxResponse{}, errors.New("no signing key selected")
+	}
+
+	res, err := c.send(ctx, msgs)
+	if err == nil && res.Codespace == sdkerrors.RootCodespace && res.Code == sdkerrors.ErrUnauthorized.ABCICode() {
+		// the ante handler reports a wrong sequence as unauthorized
+		res, err = c.send(ctx, msgs)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if res.Code != 0 {
+		return res, sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog)
+	}
+
+	return res, nil
+}
+
+func (c *Client) send(ctx context.Context, msgs []sdk.Msg) (sdk.TxResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	if !c.synced {
+		accNumber, sequence, err := auth.NewAccountRetriever(c.cliCtx).GetAccountNumberSequence(c.from.GetAddress())
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		c.accNumber, c.sequence, c.synced = accNumber, sequence, true
+	}
+
+	txBldr := c.txBldr.WithAccountNumber(c.accNumber).WithSequence(c.sequence)
+
+	if txBldr.SimulateAndExecute() {
+		var err error
+		if txBldr, err = utils.EnrichWithGas(txBldr, c.cliCtx, msgs); err != nil {
+			return sdk.TxResponse{}, err
+		}
+	}
+
+	txBytes, err := txBldr.BuildAndSign(c.from.GetName(), "", msgs)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	res, err := c.cliCtx.BroadcastTx(txBytes)
+	if err != nil || res.Code != 0 {
+		// whether the sequence was used depends on where the transaction failed
+		c.synced = false
+		return res, err
+	}
+
+	c.sequence++
+	return res, nil
+}
+
+// decodeData unmarshals the JSON a crud handler returned for a single message transaction.
+func decodeData(res sdk.TxResponse, result interface{}) error {
+	data, err := hex.DecodeString(res.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) *Client {
+	c, err := New(Config{ChainID: "bluzelle", KeyringBackend: keys.BackendMemory})
+	assert.Nil(t, err)
+	return c
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{KeyringBackend: keys.BackendMemory})
+	assert.NotNil(t, err)
+
+	_, err = New(Config{ChainID: "bluzelle", KeyringBackend: keys.BackendMemory, GasPrices: "bad"})
+	assert.NotNil(t, err)
+
+	_, err = New(Config{ChainID: "bluzelle", KeyringBackend: keys.BackendMemory, From: "missing"})
+	assert.NotNil(t, err)
+}
+
+func TestClient_Keys(t *testing.T) {
+	c := newTestClient(t)
+	assert.Nil(t, c.Address())
+
+	info, mnemonic, err := c.NewKey("alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "bluzelle", sdk.GetConfig().GetBech32AccountAddrPrefix())
+
+	assert.Nil(t, c.UseKey("alice"))
+	assert.Equal(t, info.GetAddress(), c.Address())
+
+	recovered, err := c.RecoverKey("bob", mnemonic)
+	assert.Nil(t, err)
+	assert.Equal(t, info.GetAddress(), recovered.GetAddress())
+
+	list, err := c.ListKeys()
+	assert.Nil(t, err)
+	assert.Len(t, list, 2)
+
+	assert.Nil(t, c.DeleteKey("bob"))
+	assert.NotNil(t, c.UseKey("bob"))
+	assert.Equal(t, info.GetAddress(), c.Address())
+}
+
+func TestDecodeData(t *testing.T) {
+	var result crud.QueryResultCount
+	assert.Nil(t, decodeData(sdk.TxResponse{Data: hex.EncodeToString([]byte(`{"uuid":"uuid","count":"3"}`))}, &result))
+	assert.Equal(t, crud.QueryResultCount{UUID: "uuid", Count: 3}, result)
+
+	assert.NotNil(t, decodeData(sdk.TxResponse{Data: "zz"}, &result))
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewKey creates a key named name from a fresh mnemonic, which is returned so that
+// it can be backed up. The key is not used for signing until passed to UseKey.
+func (c *Client) NewKey(name string) (keys.Info, string, error) {
+	return c.keybase.CreateMnemonic(name, keys.English, "", keys.Secp256k1)
+}
+
+// RecoverKey stores the key derived from mnemonic under name.
+func (c *Client) RecoverKey(name string, mnemonic string) (keys.Info, error) {
+	return c.keybase.CreateAccount(name, mnemonic, "", "", sdk.GetConfig().GetFullFundraiserPath(), keys.Secp256k1)
+}
+
+func (c *Client) ListKeys() ([]keys.Info, error) {
+	return c.keybase.List()
+}
+
+func (c *Client) DeleteKey(name string) error {
+	return c.keybase.Delete(name, "", true)
+}
+
+// UseKey signs later transactions with the key named name. The account number and
+// sequence of its address are fetched again before the next transaction.
+func (c *Client) UseKey(name string) error {
+	info, err := c.keybase.Get(name)
+	if err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.from = info
+	c.synced = false
+	c.cliCtx = c.cliCtx.WithFromName(info.GetName()).WithFromAddress(info.GetAddress())
+	return nil
+}
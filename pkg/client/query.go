@@ -0,0 +1,224 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bluzelle/curium/x/crud"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (c *Client) Read(ctx context.Context, UUID, key string) (crud.QueryResultRead, error) {
+	var result crud.QueryResultRead
+	return result, c.query(ctx, nil, &result, "read", UUID, key)
+}
+
+func (c *Client) ReadMeta(ctx context.Context, UUID, key string) (crud.QueryResultReadMeta, error) {
+	var result crud.QueryResultReadMeta
+	return result, c.query(ctx, nil, &result, "readmeta", UUID, key)
+}
+
+func (c *Client) Has(ctx context.Context, UUID, key string) (crud.QueryResultHas, error) {
+	var result crud.QueryResultHas
+	return result, c.query(ctx, nil, &result, "has", UUID, key)
+}
+
+func (c *Client) Owner(ctx context.Context, UUID, key string) (crud.QueryResultOwner, error) {
+	var result crud.QueryResultOwner
+	return result, c.query(ctx, nil, &result, "owner", UUID, key)
+}
+
+// Keys returns the first page of the keys of UUID in byte order; KeysFrom continues
+// from the Next of the result.
+func (c *Client) Keys(ctx context.Context, UUID string) (crud.QueryResultKeys, error) {
+	return c.KeysFrom(ctx, UUID, "")
+}
+
+func (c *Client) KeysFrom(ctx context.Context, UUID, start string) (crud.QueryResultKeys, error) {
+	var result crud.QueryResultKeys
+	return result, c.query(ctx, []byte(start), &result, "keys", UUID)
+}
+
+func (c *Client) KeyValues(ctx context.Context, UUID string) (crud.QueryResultKeyValues, error) {
+	return c.KeyValuesFrom(ctx, UUID, "")
+}
+
+func (c *Client) KeyValuesFrom(ctx context.Context, UUID, start string) (crud.QueryResultKeyValues, error) {
+	var result crud.QueryResultKeyValues
+	return result, c.query(ctx, []byte(start), &result, "keyvalues", UUID)
+}
+
+func (c *Client) Count(ctx context.Context, UUID string) (crud.QueryResultCount, error) {
+	var result crud.QueryResultCount
+	return result, c.query(ctx, nil, &result, "count", UUID)
+}
+
+func (c *Client) GetLease(ctx context.Context, UUID, key string) (crud.QueryResultLease, error) {
+	var result crud.QueryResultLease
+	return result, c.query(ctx, nil, &result, "getlease", UUID, key)
+}
+
+func (c *Client) GetNShortestLeases(ctx context.Context, UUID string, N uint64) (crud.QueryResultNShortestLeaseKeys, error) {
+	var result crud.QueryResultNShortestLeaseKeys
+	return result, c.query(ctx, nil, &result, "getnshortestleases", UUID, fmt.Sprint(N))
+}
+
+// GetNShortestLeasesPage is GetNShortestLeases from start, the Next of the previous
+// page, so the leases of every owner in UUID can be paged through N at a time.
+func (c *Client) GetNShortestLeasesPage(ctx context.Context, UUID string, N uint64, start string) (crud.QueryResultNShortestLeaseKeys, error) {
+	var result crud.QueryResultNShortestLeaseKeys
+	return result, c.query(ctx, []byte(start), &result, "getnshortestleases", UUID, fmt.Sprint(N))
+}
+
+// GetLeaseAll pages through owner's leases in UUID, shortest first. start is the Next
+// of the previous page.
+func (c *Client) GetLeaseAll(ctx context.Context, UUID string, owner sdk.AccAddress, start string, limit uint64) (crud.QueryResultLeaseAll, error) {
+	var result crud.QueryResultLeaseAll
+	return result, c.query(ctx, []byte(start), &result, "getleaseall", UUID, owner.String(), fmt.Sprint(limit))
+}
+
+// Find returns the keys of an indexed UUID whose indexed field equals value.
+func (c *Client) Find(ctx context.Context, UUID, value string) (crud.QueryResultKeys, error) {
+	var result crud.QueryResultKeys
+	return result, c.query(ctx, []byte(value), &result, "find", UUID)
+}
+
+// FindByHash returns the keys of a hash indexed UUID whose value has the hex encoded
+// SHA-256 hash, so a value can be checked for before it is uploaded.
+func (c *Client) FindByHash(ctx context.Context, UUID, hash string) (crud.QueryResultKeys, error) {
+	var result crud.QueryResultKeys
+	return result, c.query(ctx, nil, &result, "findbyhash", UUID, hash)
+}
+
+func (c *Client) GetMetadata(ctx context.Context, UUID, key string) (crud.QueryResultMetadata, error) {
+	var result crud.QueryResultMetadata
+	return result, c.query(ctx, nil, &result, "getmetadata", UUID, key)
+}
+
+func (c *Client) GetHash(ctx context.Context, UUID, key string) (crud.QueryResultHash, error) {
+	var result crud.QueryResultHash
+	return result, c.query(ctx, nil, &result, "gethash", UUID, key)
+}
+
+// KeysByExpiry pages through the keys of UUID, soonest to expire first, all owners'
+// keys when owner is nil. start is the Next of the previous page.
+func (c *Client) KeysByExpiry(ctx context.Context, UUID string, owner sdk.AccAddress, start string, limit uint64) (crud.QueryResultKeysByExpiry, error) {
+	var result crud.QueryResultKeysByExpiry
+	return result, c.query(ctx, []byte(start), &result, "keysbyexpiry", UUID, owner.String(), fmt.Sprint(limit))
+}
+
+// MatchKeys pages through the keys of UUID matching a glob pattern, all owners' keys
+// when owner is nil. start is the Next of the previous page.
+func (c *Client) MatchKeys(ctx context.Context, UUID string, owner sdk.AccAddress, pattern, start string) (crud.QueryResultMatch, error) {
+	var result crud.QueryResultMatch
+	params := crud.QueryMatchParams{Pattern: pattern, Start: start}
+	return result, c.query(ctx, c.cdc.MustMarshalJSON(params), &result, "match", UUID, owner.String())
+}
+
+// AuditLog returns up to limit entries of the audit log of UUID, from sequence number
+// start (the first being 1) on.
+func (c *Client) AuditLog(ctx context.Context, UUID string, start, limit uint64) (crud.QueryResultAuditLog, error) {
+	var result crud.QueryResultAuditLog
+	return result, c.query(ctx, nil, &result, "auditlog", UUID, fmt.Sprint(start), fmt.Sprint(limit))
+}
+
+func (c *Client) MyUUIDs(ctx context.Context, owner sdk.AccAddress) (crud.QueryResultUUIDs, error) {
+	var result crud.QueryResultUUIDs
+	return result, c.query(ctx, nil, &result, "myuuids", owner.String())
+}
+
+func (c *Client) Escrow(ctx context.Context, owner sdk.AccAddress) (crud.QueryResultEscrow, error) {
+	var result crud.QueryResultEscrow
+	return result, c.query(ctx, nil, &result, "escrow", owner.String())
+}
+
+func (c *Client) AccountUsage(ctx context.Context, owner sdk.AccAddress) (crud.QueryResultAccountUsage, error) {
+	var result crud.QueryResultAccountUsage
+	return result, c.query(ctx, nil, &result, "accountusage", owner.String())
+}
+
+func (c *Client) UUIDStats(ctx context.Context, UUID string) (crud.QueryResultUUIDStats, error) {
+	var result crud.QueryResultUUIDStats
+	return result, c.query(ctx, nil, &result, "uuidstats", UUID)
+}
+
+func (c *Client) Schema(ctx context.Context, UUID string) (crud.QueryResultSchema, error) {
+	var result crud.QueryResultSchema
+	return result, c.query(ctx, nil, &result, "schema", UUID)
+}
+
+func (c *Client) CountAll(ctx context.Context, owner sdk.AccAddress) (crud.QueryResultCountAll, error) {
+	var result crud.QueryResultCountAll
+	return result, c.query(ctx, nil, &result, "countall", owner.String())
+}
+
+func (c *Client) KeysAll(ctx context.Context, owner sdk.AccAddress) (crud.QueryResultKeysAll, error) {
+	var result crud.QueryResultKeysAll
+	return result, c.query(ctx, nil, &result, "keysall", owner.String())
+}
+
+// DefaultLease returns the lease given to keys created or renewed without one.
+func (c *Client) DefaultLease(ctx context.Context) (crud.QueryResultDefaultLease, error) {
+	var result crud.QueryResultDefaultLease
+	return result, c.query(ctx, nil, &result, "defaultlease")
+}
+
+// RentHistory returns up to limit of owner's rent records, oldest first, from the epoch
+// starting at height start on.
+func (c *Client) RentHistory(ctx context.Context, owner sdk.AccAddress, start int64, limit uint64) (crud.QueryResultRentHistory, error) {
+	var result crud.QueryResultRentHistory
+	return result, c.query(ctx, nil, &result, "renthistory", owner.String(), fmt.Sprint(start), fmt.Sprint(limit))
+}
+
+// LeasePrice returns the lease price at the value bytes now stored on chain.
+func (c *Client) LeasePrice(ctx context.Context) (crud.QueryResultLeasePrice, error) {
+	var result crud.QueryResultLeasePrice
+	return result, c.query(ctx, nil, &result, "leaseprice")
+}
+
+func (c *Client) GCStatus(ctx context.Context) (crud.QueryResultGCStatus, error) {
+	var result crud.QueryResultGCStatus
+	return result, c.query(ctx, nil, &result, "gcstatus")
+}
+
+func (c *Client) EstimateLease(ctx context.Context, params crud.QueryEstimateLeaseParams) (crud.QueryResultEstimateLease, error) {
+	var result crud.QueryResultEstimateLease
+	return result, c.query(ctx, c.cdc.MustMarshalJSON(params), &result, "estimatelease")
+}
+
+// Simulate returns the gas and lease fee of msg, an unsigned crud message, with its
+// fees at gasPrices.
+func (c *Client) Simulate(ctx context.Context, msg sdk.Msg, gasPrices sdk.DecCoins) (crud.QueryResultSimulate, error) {
+	var result crud.QueryResultSimulate
+	return result, c.query(ctx, c.cdc.MustMarshalJSON(crud.QuerySimulateParams{Msg: msg, GasPrices: gasPrices}), &result, "simulate")
+}
+
+func (c *Client) query(ctx context.Context, data []byte, result interface{}, route string, args ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := strings.Join(append([]string{"custom", crud.StoreKey, route}, args...), "/")
+
+	res, _, err := c.cliCtx.QueryWithData(path, data)
+	if err != nil {
+		return err
+	}
+
+	return c.cdc.UnmarshalJSON(res, result)
+}
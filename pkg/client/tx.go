@@ -0,0 +1,272 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+
+	"github.com/bluzelle/curium/x/crud"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Create stores value under key, leased for lease blocks.
+func (c *Client) Create(ctx context.Context, UUID, key string, value []byte, lease int64) error {
+	_, err := c.Send(ctx, crud.NewMsgCreate(UUID, key, value, lease, c.Address()))
+	return err
+}
+
+// Update replaces the value of key and adds lease blocks to its lease; zero leaves
+// the lease unchanged.
+func (c *Client) Update(ctx context.Context, UUID, key string, value []byte, lease int64) error {
+	_, err := c.Send(ctx, crud.MsgUpdate{UUID: UUID, Key: key, Value: value, Lease: lease, Owner: c.Address()})
+	return err
+}
+
+// CreateTyped is Create that also records the content type and encoding of value,
+// returned with it by reads.
+func (c *Client) CreateTyped(ctx context.Context, UUID, key string, value []byte, lease int64, contentType, encoding string) error {
+	msg := crud.NewMsgCreate(UUID, key, value, lease, c.Address())
+	msg.ContentType, msg.Encoding = contentType, encoding
+	_, err := c.Send(ctx, msg)
+	return err
+}
+
+// UpdateTyped is Update that also replaces the content type and encoding of key,
+// either left as it is when empty.
+func (c *Client) UpdateTyped(ctx context.Context, UUID, key string, value []byte, lease int64, contentType, encoding string) error {
+	_, err := c.Send(ctx, crud.MsgUpdate{UUID: UUID, Key: key, Value: value, Lease: lease, Owner: c.Address(),
+		ContentType: contentType, Encoding: encoding})
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, UUID, key string) error {
+	_, err := c.Send(ctx, crud.NewMsgDelete(UUID, key, c.Address()))
+	return err
+}
+
+// UpdateIfVersion is Update that fails, changing nothing, unless key is at version.
+func (c *Client) UpdateIfVersion(ctx context.Context, UUID, key string, value []byte, lease int64, version uint64) error {
+	_, err := c.Send(ctx, crud.MsgUpdate{UUID: UUID, Key: key, Value: value, Lease: lease, Owner: c.Address(), Version: version})
+	return err
+}
+
+// DeleteIfVersion is Delete that fails, deleting nothing, unless key is at version.
+func (c *Client) DeleteIfVersion(ctx context.Context, UUID, key string, version uint64) error {
+	msg := crud.NewMsgDelete(UUID, key, c.Address())
+	msg.Version = version
+	_, err := c.Send(ctx, msg)
+	return err
+}
+
+func (c *Client) Rename(ctx context.Context, UUID, key, newKey string) (crud.QueryResultRename, error) {
+	var result crud.QueryResultRename
+	return result, c.sendAndDecode(ctx, crud.NewMsgRename(UUID, key, newKey, c.Address()), &result)
+}
+
+func (c *Client) MultiUpdate(ctx context.Context, UUID string, keyValues []crud.KeyValue) error {
+	_, err := c.Send(ctx, crud.NewMsgMultiUpdate(UUID, c.Address(), keyValues))
+	return err
+}
+
+// Atomic applies ops, which may span several UUIDs, in one transaction: either
+// all of them take effect or, if one fails, none does.
+func (c *Client) Atomic(ctx context.Context, ops []crud.AtomicOp) error {
+	_, err := c.Send(ctx, crud.NewMsgAtomic(ops, c.Address()))
+	return err
+}
+
+// DeleteAll deletes the caller's keys in UUID. While the result reports keys
+// remaining, calling it again continues where the last call stopped.
+func (c *Client) DeleteAll(ctx context.Context, UUID string) (crud.QueryResultDeleteAll, error) {
+	var result crud.QueryResultDeleteAll
+	return result, c.sendAndDecode(ctx, crud.NewMsgDeleteAll(UUID, c.Address()), &result)
+}
+
+func (c *Client) RenewLease(ctx context.Context, UUID, key string, lease int64) error {
+	_, err := c.Send(ctx, crud.MsgRenewLease{UUID: UUID, Key: key, Lease: lease, Owner: c.Address()})
+	return err
+}
+
+func (c *Client) RenewLeaseAll(ctx context.Context, UUID string, lease int64) (crud.QueryResultRenewLeaseAll, error) {
+	var result crud.QueryResultRenewLeaseAll
+	return result, c.sendAndDecode(ctx, crud.MsgRenewLeaseAll{UUID: UUID, Lease: lease, Owner: c.Address()}, &result)
+}
+
+func (c *Client) Copy(ctx context.Context, UUID, key, newUUID, newKey string, lease int64) error {
+	_, err := c.Send(ctx, crud.NewMsgCopy(UUID, key, newUUID, newKey, lease, c.Address()))
+	return err
+}
+
+func (c *Client) CopyUUID(ctx context.Context, UUID, newUUID string, lease int64) error {
+	_, err := c.Send(ctx, crud.NewMsgCopyUUID(UUID, newUUID, lease, c.Address()))
+	return err
+}
+
+// Patch applies an RFC 6902 JSON patch to the value of key.
+func (c *Client) Patch(ctx context.Context, UUID, key, patch string) error {
+	_, err := c.Send(ctx, crud.NewMsgPatch(UUID, key, patch, c.Address()))
+	return err
+}
+
+func (c *Client) SetBeneficiary(ctx context.Context, UUID, key string, beneficiary sdk.AccAddress) error {
+	_, err := c.Send(ctx, crud.NewMsgSetBeneficiary(UUID, key, beneficiary, c.Address()))
+	return err
+}
+
+func (c *Client) DepositEscrow(ctx context.Context, amount sdk.Coins) error {
+	_, err := c.Send(ctx, crud.NewMsgDepositEscrow(amount, c.Address()))
+	return err
+}
+
+func (c *Client) WithdrawEscrow(ctx context.Context, amount sdk.Coins) error {
+	_, err := c.Send(ctx, crud.NewMsgWithdrawEscrow(amount, c.Address()))
+	return err
+}
+
+func (c *Client) SetAutoRenew(ctx context.Context, UUID, key string, autoRenew bool) error {
+	_, err := c.Send(ctx, crud.NewMsgSetAutoRenew(UUID, key, autoRenew, c.Address()))
+	return err
+}
+
+func (c *Client) SetIndex(ctx context.Context, UUID, field string) error {
+	_, err := c.Send(ctx, crud.NewMsgSetIndex(UUID, field, c.Address()))
+	return err
+}
+
+func (c *Client) DeleteIndex(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgDeleteIndex(UUID, c.Address()))
+	return err
+}
+
+func (c *Client) SetHashIndex(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgSetHashIndex(UUID, c.Address()))
+	return err
+}
+
+func (c *Client) DeleteHashIndex(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgDeleteHashIndex(UUID, c.Address()))
+	return err
+}
+
+func (c *Client) SetAudit(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgSetAudit(UUID, c.Address()))
+	return err
+}
+
+func (c *Client) DeleteAudit(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgDeleteAudit(UUID, c.Address()))
+	return err
+}
+
+// SetRetention keeps at most maxKeys of the client's keys in UUID, evicting the oldest
+// in order, crud.RetentionOrderFIFO or crud.RetentionOrderLRU.
+func (c *Client) SetRetention(ctx context.Context, UUID string, maxKeys uint64, order string) error {
+	_, err := c.Send(ctx, crud.NewMsgSetRetention(UUID, maxKeys, order, c.Address()))
+	return err
+}
+
+func (c *Client) DeleteRetention(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgDeleteRetention(UUID, c.Address()))
+	return err
+}
+
+// SetSchema checks every value later written to UUID against schema, see crud.Schema.
+func (c *Client) SetSchema(ctx context.Context, UUID string, schema crud.Schema) error {
+	_, err := c.Send(ctx, crud.NewMsgSetSchema(UUID, schema.MaxValueSize, schema.ContentType, schema.JSONSchema, c.Address()))
+	return err
+}
+
+func (c *Client) DeleteSchema(ctx context.Context, UUID string) error {
+	_, err := c.Send(ctx, crud.NewMsgDeleteSchema(UUID, c.Address()))
+	return err
+}
+
+// ExpireNow ends the lease of key at once, refunding the rest of it. The key is kept,
+// read-only, for the expiry grace period before it is removed.
+func (c *Client) ExpireNow(ctx context.Context, UUID, key string) (crud.QueryResultExpireNow, error) {
+	var result crud.QueryResultExpireNow
+	return result, c.sendAndDecode(ctx, crud.NewMsgExpireNow(UUID, key, c.Address()), &result)
+}
+
+func (c *Client) Freeze(ctx context.Context, UUID, key string) error {
+	_, err := c.Send(ctx, crud.NewMsgFreeze(UUID, key, c.Address()))
+	return err
+}
+
+func (c *Client) Unfreeze(ctx context.Context, UUID, key string) error {
+	_, err := c.Send(ctx, crud.NewMsgUnfreeze(UUID, key, c.Address()))
+	return err
+}
+
+func (c *Client) StartUpload(ctx context.Context, UUID, key string, size int64, hash string, lease int64) error {
+	_, err := c.Send(ctx, crud.NewMsgStartUpload(UUID, key, size, hash, lease, c.Address()))
+	return err
+}
+
+func (c *Client) UploadChunk(ctx context.Context, UUID, key string, index uint64, data []byte) error {
+	_, err := c.Send(ctx, crud.NewMsgUploadChunk(UUID, key, index, data, c.Address()))
+	return err
+}
+
+func (c *Client) CommitUpload(ctx context.Context, UUID, key string) error {
+	_, err := c.Send(ctx, crud.NewMsgCommitUpload(UUID, key, c.Address()))
+	return err
+}
+
+// The Tx reads go through consensus, unlike the queries in query.go, and so are
+// paid for like any other transaction.
+
+func (c *Client) TxRead(ctx context.Context, UUID, key string) (crud.QueryResultRead, error) {
+	var result crud.QueryResultRead
+	return result, c.sendAndDecode(ctx, crud.NewMsgRead(UUID, key, c.Address()), &result)
+}
+
+func (c *Client) TxHas(ctx context.Context, UUID, key string) (crud.QueryResultHas, error) {
+	var result crud.QueryResultHas
+	return result, c.sendAndDecode(ctx, crud.NewMsgHas(UUID, key, c.Address()), &result)
+}
+
+func (c *Client) TxKeys(ctx context.Context, UUID string) (crud.QueryResultKeys, error) {
+	var result crud.QueryResultKeys
+	return result, c.sendAndDecode(ctx, crud.NewMsgKeys(UUID, c.Address()), &result)
+}
+
+func (c *Client) TxKeyValues(ctx context.Context, UUID string) (crud.QueryResultKeyValues, error) {
+	var result crud.QueryResultKeyValues
+	return result, c.sendAndDecode(ctx, crud.NewMsgKeyValues(UUID, c.Address()), &result)
+}
+
+func (c *Client) TxCount(ctx context.Context, UUID string) (crud.QueryResultCount, error) {
+	var result crud.QueryResultCount
+	return result, c.sendAndDecode(ctx, crud.NewMsgCount(UUID, c.Address()), &result)
+}
+
+func (c *Client) TxGetLease(ctx context.Context, UUID, key string) (crud.QueryResultLease, error) {
+	var result crud.QueryResultLease
+	return result, c.sendAndDecode(ctx, crud.MsgGetLease{UUID: UUID, Key: key, Owner: c.Address()}, &result)
+}
+
+func (c *Client) TxGetNShortestLeases(ctx context.Context, UUID string, N uint64) (crud.QueryResultNShortestLeaseKeys, error) {
+	var result crud.QueryResultNShortestLeaseKeys
+	return result, c.sendAndDecode(ctx, crud.MsgGetNShortestLeases{UUID: UUID, N: N, Owner: c.Address()}, &result)
+}
+
+func (c *Client) sendAndDecode(ctx context.Context, msg sdk.Msg, result interface{}) error {
+	res, err := c.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	return decodeData(res, result)
+}
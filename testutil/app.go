@@ -0,0 +1,162 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+// Package testutil runs the full curium app in process, so that crud flows spanning
+// several blocks, such as lease expiry and garbage collection, can be tested end to end
+// without a network.
+package testutil
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	app "github.com/bluzelle/curium"
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	ChainID   = "curium-test"
+	BlockTime = 5 * time.Second
+	txGas     = uint64(10000000)
+)
+
+// DefaultCoins is the genesis balance of each test account.
+var DefaultCoins = sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000000000000))
+
+type Account struct {
+	PrivKey crypto.PrivKey
+	Address sdk.AccAddress
+}
+
+// TestApp is a CRUDApp on an in-memory database with funded accounts. There is always
+// a block in progress: messages are delivered into it and NextBlock ends it, running
+// the EndBlockers, commits and starts the next one. Invariants are checked every block.
+type TestApp struct {
+	*app.CRUDApp
+	Accounts []Account
+
+	header abci.Header
+}
+
+// NewTestApp starts a chain whose genesis gives each of numAccounts new accounts
+// DefaultCoins. genesis may change the default genesis of any module first.
+func NewTestApp(numAccounts int, genesis ...func(app.GenesisState)) *TestApp {
+	a := &TestApp{CRUDApp: app.NewCRUDApp(log.NewNopLogger(), dbm.NewMemDB(), nil, 1)}
+	cdc := a.Codec()
+
+	accounts := make([]authexported.GenesisAccount, 0, numAccounts)
+	for i := 0; i < numAccounts; i++ {
+		privKey := secp256k1.GenPrivKey()
+		address := sdk.AccAddress(privKey.PubKey().Address())
+		a.Accounts = append(a.Accounts, Account{PrivKey: privKey, Address: address})
+		accounts = append(accounts, auth.NewBaseAccount(address, DefaultCoins, nil, 0, 0))
+	}
+
+	genesisState := app.NewDefaultGenesisState()
+	genesisState[auth.ModuleName] = cdc.MustMarshalJSON(auth.NewGenesisState(auth.DefaultParams(), accounts))
+	for _, change := range genesis {
+		change(genesisState)
+	}
+
+	a.InitChain(abci.RequestInitChain{
+		ChainId:       ChainID,
+		Validators:    []abci.ValidatorUpdate{},
+		AppStateBytes: cdc.MustMarshalJSON(genesisState),
+	})
+	a.Commit()
+
+	a.header = abci.Header{ChainID: ChainID, Height: a.LastBlockHeight() + 1, Time: time.Unix(0, 0).UTC()}
+	a.BeginBlock(abci.RequestBeginBlock{Header: a.header})
+	return a
+}
+
+// Height is the height of the block in progress.
+func (a *TestApp) Height() int64 {
+	return a.header.Height
+}
+
+// Ctx is a context on the state of the block in progress, for calling keepers directly.
+func (a *TestApp) Ctx() sdk.Context {
+	return a.NewContext(false, a.header)
+}
+
+// Deliver signs msgs with signer's key and delivers them as one transaction in the
+// block in progress. An error means the whole transaction was rolled back.
+func (a *TestApp) Deliver(signer Account, msgs ...sdk.Msg) (*sdk.Result, error) {
+	var account authexported.Account
+	params := a.Codec().MustMarshalJSON(auth.NewQueryAccountParams(signer.Address))
+	if err := a.Query(&account, params, auth.QuerierRoute, auth.QueryAccount); err != nil {
+		return nil, err
+	}
+
+	tx := helpers.GenTx(msgs, sdk.NewCoins(), txGas, ChainID,
+		[]uint64{account.GetAccountNumber()}, []uint64{account.GetSequence()}, signer.PrivKey)
+
+	_, result, err := a.CRUDApp.Deliver(tx)
+	return result, err
+}
+
+// NextBlock ends and commits the block in progress and starts the next one, returning
+// what the EndBlockers did.
+func (a *TestApp) NextBlock() abci.ResponseEndBlock {
+	res := a.EndBlock(abci.RequestEndBlock{Height: a.header.Height})
+	a.Commit()
+
+	a.header.Height++
+	a.header.Time = a.header.Time.Add(BlockTime)
+	a.BeginBlock(abci.RequestBeginBlock{Header: a.header})
+	return res
+}
+
+// AdvanceBlocks calls NextBlock n times, collecting the EndBlock events.
+func (a *TestApp) AdvanceBlocks(n int) []abci.Event {
+	var events []abci.Event
+	for i := 0; i < n; i++ {
+		events = append(events, a.NextBlock().Events...)
+	}
+	return events
+}
+
+// Query runs a querier against the state of the block in progress, which unlike the
+// ABCI query path includes what has not been committed yet, and unmarshals its JSON
+// result into result. path is the querier route followed by its path, as in the
+// "custom/..." ABCI query path.
+func (a *TestApp) Query(result interface{}, data []byte, path ...string) error {
+	querier := a.QueryRouter().Route(path[0])
+	if querier == nil {
+		return errors.New("no querier for route " + path[0])
+	}
+
+	req := abci.RequestQuery{Path: "custom/" + strings.Join(path, "/"), Data: data}
+	res, err := querier(a.Ctx(), path[1:], req)
+	if err != nil {
+		return err
+	}
+	return a.Codec().UnmarshalJSON(res, result)
+}
+
+// QueryCrud is Query of the crud querier, e.g. QueryCrud(&result, "read", UUID, key).
+func (a *TestApp) QueryCrud(result interface{}, path ...string) error {
+	return a.Query(result, nil, append([]string{crud.ModuleName}, path...)...)
+}
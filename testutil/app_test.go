@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestTestApp_LeaseExpiry(t *testing.T) {
+	a := NewTestApp(1)
+	owner := a.Accounts[0]
+
+	_, err := a.Deliver(owner, crud.NewMsgCreate("uuid", "key", []byte("value"), 3, owner.Address))
+	assert.Nil(t, err)
+
+	var has crud.QueryResultHas
+	assert.Nil(t, a.QueryCrud(&has, "has", "uuid", "key"))
+	assert.True(t, has.Has)
+
+	events := a.AdvanceBlocks(2)
+	assert.Nil(t, a.QueryCrud(&has, "has", "uuid", "key"))
+	assert.True(t, has.Has)
+	assert.False(t, hasEvent(events, crud.EventTypePurge))
+
+	events = a.AdvanceBlocks(2)
+	assert.Nil(t, a.QueryCrud(&has, "has", "uuid", "key"))
+	assert.False(t, has.Has)
+	assert.True(t, hasEvent(events, crud.EventTypePurge))
+
+	var status crud.QueryResultGCStatus
+	assert.Nil(t, a.QueryCrud(&status, "gcstatus"))
+	assert.Equal(t, uint64(1), status.LastPurge.Keys)
+}
+
+func TestTestApp_TxIsAtomic(t *testing.T) {
+	a := NewTestApp(2)
+	owner := a.Accounts[0]
+
+	// the second create fails, taking the first one with it
+	_, err := a.Deliver(owner,
+		crud.NewMsgCreate("uuid", "a", []byte("value"), 0, owner.Address),
+		crud.NewMsgCreate("uuid", "a", []byte("value"), 0, owner.Address))
+	assert.NotNil(t, err)
+
+	var count crud.QueryResultCount
+	assert.Nil(t, a.QueryCrud(&count, "count", "uuid"))
+	assert.Equal(t, uint64(0), count.Count)
+
+	// the sequence moved on regardless, and other accounts are unaffected
+	_, err = a.Deliver(owner, crud.NewMsgCreate("uuid", "a", []byte("value"), 0, owner.Address))
+	assert.Nil(t, err)
+	_, err = a.Deliver(a.Accounts[1], crud.NewMsgCreate("other", "a", []byte("value"), 0, a.Accounts[1].Address))
+	assert.Nil(t, err)
+
+	a.NextBlock()
+	assert.Nil(t, a.QueryCrud(&count, "count", "uuid"))
+	assert.Equal(t, uint64(1), count.Count)
+	assert.Equal(t, int64(2), a.Height())
+}
+
+func hasEvent(events []abci.Event, eventType string) bool {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
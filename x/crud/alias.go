@@ -15,31 +15,183 @@
 package crud
 
 import (
-	"github.com/bluzelle/curium/x/crud/internal/keeper"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
 )
 
 const (
-	ModuleName = types.ModuleName
-	RouterKey  = types.RouterKey
-	StoreKey   = types.StoreKey
-	LeaseKey   = types.LeaseKey
+	ModuleName       = types.ModuleName
+	EscrowName       = types.EscrowName
+	LeaseDepositName = types.LeaseDepositName
+	RouterKey        = types.RouterKey
+	StoreKey         = types.StoreKey
+	LeaseKey         = types.LeaseKey
+	IndexKey         = types.IndexKey
+
+	DefaultParamspace = types.DefaultParamspace
+	ConsensusVersion  = keeper.ConsensusVersion
+	BlockTimeEstimate = types.BlockTimeEstimate
+
+	EventTypeChange  = types.EventTypeChange
+	EventTypePurge   = types.EventTypePurge
+	EventTypeEvict   = types.EventTypeEvict
+	AttributeKeyUUID = types.AttributeKeyUUID
+
+	RetentionOrderFIFO = types.RetentionOrderFIFO
+	RetentionOrderLRU  = types.RetentionOrderLRU
+
+	AtomicOpCreate = types.AtomicOpCreate
+	AtomicOpUpdate = types.AtomicOpUpdate
+	AtomicOpDelete = types.AtomicOpDelete
 )
 
 var (
-	NewKeeper     = keeper.NewKeeper
-	NewQuerier    = keeper.NewQuerier
-	ModuleCdc     = types.ModuleCdc
-	RegisterCodec = types.RegisterCodec
+	NewKeeper          = keeper.NewKeeper
+	RegisterInvariants = keeper.RegisterInvariants
+	ModuleCdc          = types.ModuleCdc
+	RegisterCodec      = types.RegisterCodec
+	DefaultParams      = types.DefaultParams
+	PrometheusMetrics  = keeper.PrometheusMetrics
+	NopMetrics         = keeper.NopMetrics
+	CompileKeyPattern  = types.CompileKeyPattern
+
+	NewMsgCreate          = types.NewMsgCreate
+	NewMsgRead            = types.NewMsgRead
+	NewMsgDelete          = types.NewMsgDelete
+	NewMsgKeys            = types.NewMsgKeys
+	NewMsgHas             = types.NewMsgHas
+	NewMsgRename          = types.NewMsgRename
+	NewMsgKeyValues       = types.NewMsgKeyValues
+	NewMsgCount           = types.NewMsgCount
+	NewMsgDeleteAll       = types.NewMsgDeleteAll
+	NewMsgMultiUpdate     = types.NewMsgMultiUpdate
+	NewMsgAtomic          = types.NewMsgAtomic
+	NewMsgCopy            = types.NewMsgCopy
+	NewMsgCopyUUID        = types.NewMsgCopyUUID
+	NewMsgPatch           = types.NewMsgPatch
+	NewMsgSetBeneficiary  = types.NewMsgSetBeneficiary
+	NewMsgDepositEscrow   = types.NewMsgDepositEscrow
+	NewMsgWithdrawEscrow  = types.NewMsgWithdrawEscrow
+	NewMsgSetAutoRenew    = types.NewMsgSetAutoRenew
+	NewMsgSetIndex        = types.NewMsgSetIndex
+	NewMsgDeleteIndex     = types.NewMsgDeleteIndex
+	NewMsgSetHashIndex    = types.NewMsgSetHashIndex
+	NewMsgDeleteHashIndex = types.NewMsgDeleteHashIndex
+	NewMsgSetAudit        = types.NewMsgSetAudit
+	NewMsgDeleteAudit     = types.NewMsgDeleteAudit
+	NewMsgSetRetention    = types.NewMsgSetRetention
+	NewMsgDeleteRetention = types.NewMsgDeleteRetention
+	NewMsgExpireNow       = types.NewMsgExpireNow
+	NewMsgSetSchema       = types.NewMsgSetSchema
+	NewMsgDeleteSchema    = types.NewMsgDeleteSchema
+	NewMsgFreeze          = types.NewMsgFreeze
+	NewMsgUnfreeze        = types.NewMsgUnfreeze
+	NewMsgStartUpload     = types.NewMsgStartUpload
+	NewMsgUploadChunk     = types.NewMsgUploadChunk
+	NewMsgCommitUpload    = types.NewMsgCommitUpload
 )
 
 type (
-	Keeper          = keeper.Keeper
-	MaxKeeperSizes  = keeper.MaxKeeperSizes
-	MsgCreate       = types.MsgCreate
-	MsgRead         = types.MsgRead
-	MsgUpdate       = types.MsgUpdate
-	MsgDelete       = types.MsgDelete
-	QueryResultRead = types.QueryResultRead
-	QueryResultHas  = types.QueryResultHas
+	Keeper           = keeper.Keeper
+	IKeeper          = keeper.IKeeper
+	AccountKeeper    = types.AccountKeeper
+	SupplyKeeper     = types.SupplyKeeper
+	CrudHooks        = types.CrudHooks
+	GenesisState     = types.GenesisState
+	MaxKeeperSizes   = keeper.MaxKeeperSizes
+	MigrationHandler = keeper.MigrationHandler
+	Inconsistency    = keeper.Inconsistency
+	Metrics          = keeper.Metrics
+	Params           = types.Params
+	UtilizationBand  = types.UtilizationBand
+	LeaseDeposit     = types.LeaseDeposit
+
+	MsgCreate                     = types.MsgCreate
+	MsgRead                       = types.MsgRead
+	MsgUpdate                     = types.MsgUpdate
+	MsgDelete                     = types.MsgDelete
+	MsgKeys                       = types.MsgKeys
+	MsgHas                        = types.MsgHas
+	MsgRename                     = types.MsgRename
+	MsgKeyValues                  = types.MsgKeyValues
+	MsgCount                      = types.MsgCount
+	MsgDeleteAll                  = types.MsgDeleteAll
+	MsgMultiUpdate                = types.MsgMultiUpdate
+	MsgAtomic                     = types.MsgAtomic
+	MsgGetLease                   = types.MsgGetLease
+	MsgGetNShortestLeases         = types.MsgGetNShortestLeases
+	MsgRenewLease                 = types.MsgRenewLease
+	MsgRenewLeaseAll              = types.MsgRenewLeaseAll
+	MsgCopy                       = types.MsgCopy
+	MsgCopyUUID                   = types.MsgCopyUUID
+	MsgPatch                      = types.MsgPatch
+	MsgSetBeneficiary             = types.MsgSetBeneficiary
+	MsgDepositEscrow              = types.MsgDepositEscrow
+	MsgWithdrawEscrow             = types.MsgWithdrawEscrow
+	MsgSetAutoRenew               = types.MsgSetAutoRenew
+	MsgSetIndex                   = types.MsgSetIndex
+	MsgDeleteIndex                = types.MsgDeleteIndex
+	MsgSetHashIndex               = types.MsgSetHashIndex
+	MsgDeleteHashIndex            = types.MsgDeleteHashIndex
+	MsgSetAudit                   = types.MsgSetAudit
+	MsgDeleteAudit                = types.MsgDeleteAudit
+	MsgSetRetention               = types.MsgSetRetention
+	MsgDeleteRetention            = types.MsgDeleteRetention
+	MsgExpireNow                  = types.MsgExpireNow
+	MsgSetSchema                  = types.MsgSetSchema
+	MsgDeleteSchema               = types.MsgDeleteSchema
+	MsgFreeze                     = types.MsgFreeze
+	MsgUnfreeze                   = types.MsgUnfreeze
+	MsgStartUpload                = types.MsgStartUpload
+	MsgUploadChunk                = types.MsgUploadChunk
+	MsgCommitUpload               = types.MsgCommitUpload
+	QueryResultRead               = types.QueryResultRead
+	QueryResultReadMeta           = types.QueryResultReadMeta
+	QueryResultHas                = types.QueryResultHas
+	QueryResultOwner              = types.QueryResultOwner
+	QueryResultKeys               = types.QueryResultKeys
+	QueryResultKeyValues          = types.QueryResultKeyValues
+	QueryResultKeyValuesPage      = types.QueryResultKeyValuesPage
+	QueryResultCount              = types.QueryResultCount
+	QueryResultDeleteAll          = types.QueryResultDeleteAll
+	QueryResultRenewLeaseAll      = types.QueryResultRenewLeaseAll
+	QueryResultLeaseAll           = types.QueryResultLeaseAll
+	QueryResultEstimateLease      = types.QueryResultEstimateLease
+	QueryResultSimulate           = types.QueryResultSimulate
+	QueryResultRename             = types.QueryResultRename
+	QueryResultExpireNow          = types.QueryResultExpireNow
+	QueryResultMultiUpdate        = types.QueryResultMultiUpdate
+	MultiUpdateResult             = types.MultiUpdateResult
+	QueryResultGCStatus           = types.QueryResultGCStatus
+	QueryResultDefaultLease       = types.QueryResultDefaultLease
+	QueryResultLeasePrice         = types.QueryResultLeasePrice
+	QueryResultRentHistory        = types.QueryResultRentHistory
+	RentRecord                    = types.RentRecord
+	QueryResultEscrow             = types.QueryResultEscrow
+	QueryResultLease              = types.QueryResultLease
+	QueryResultNShortestLeaseKeys = types.QueryResultNShortestLeaseKeys
+	QueryResultMetadata           = types.QueryResultMetadata
+	QueryResultHash               = types.QueryResultHash
+	QueryResultUUIDs              = types.QueryResultUUIDs
+	QueryResultAccountUsage       = types.QueryResultAccountUsage
+	QueryResultCountAll           = types.QueryResultCountAll
+	QueryResultKeysAll            = types.QueryResultKeysAll
+	UUIDKey                       = types.UUIDKey
+	QueryResultKeysByExpiry       = types.QueryResultKeysByExpiry
+	QueryResultMatch              = types.QueryResultMatch
+	QueryMatchParams              = types.QueryMatchParams
+	KeyPattern                    = types.KeyPattern
+	QueryResultUUIDStats          = types.QueryResultUUIDStats
+	QueryResultAuditLog           = types.QueryResultAuditLog
+	AuditEntry                    = types.AuditEntry
+	RetentionPolicy               = types.RetentionPolicy
+	Schema                        = types.Schema
+	QueryResultSchema             = types.QueryResultSchema
+	QueryEstimateLeaseParams      = types.QueryEstimateLeaseParams
+	QuerySimulateParams           = types.QuerySimulateParams
+	KeyValue                      = types.KeyValue
+	AtomicOp                      = types.AtomicOp
+	KeyValueLease                 = types.KeyValueLease
+	GenesisValue                  = types.GenesisValue
+	BLZValue                      = types.BLZValue
 )
@@ -0,0 +1,86 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package crud
+
+import (
+	"github.com/bluzelle/curium/x/crud/keeper"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MinGasDecorator charges the flat gas of the MinMsgGas param for every crud message in
+// a transaction, so that writes cost a minimum fee whatever they store and lease.
+type MinGasDecorator struct {
+	keeper keeper.IKeeper
+}
+
+func NewMinGasDecorator(keeper keeper.IKeeper) MinGasDecorator {
+	return MinGasDecorator{keeper: keeper}
+}
+
+func (d MinGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	params := d.keeper.GetParams(ctx)
+	if len(params.MinMsgGas) != 0 {
+		for _, msg := range tx.GetMsgs() {
+			if msg.Route() == RouterKey {
+				ctx.GasMeter().ConsumeGas(params.MinGas(msg.Type()), "crud "+msg.Type())
+			}
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+// crud messages that only read, which the rate limit does not count
+var readMsgTypes = map[string]bool{
+	"read":               true,
+	"has":                true,
+	"keys":               true,
+	"keyvalues":          true,
+	"count":              true,
+	"getlease":           true,
+	"getnshortestleases": true,
+}
+
+// RateLimitDecorator rejects transactions that would take a signer past the
+// MaxWritesPerWindow param, counting every crud message other than the reads.
+type RateLimitDecorator struct {
+	keeper keeper.IKeeper
+}
+
+func NewRateLimitDecorator(keeper keeper.IKeeper) RateLimitDecorator {
+	return RateLimitDecorator{keeper: keeper}
+}
+
+func (d RateLimitDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// signers in order of appearance, for the counters to be written deterministically
+	var signers []sdk.AccAddress
+	writes := make(map[string]uint64)
+	for _, msg := range tx.GetMsgs() {
+		if msg.Route() != RouterKey || readMsgTypes[msg.Type()] {
+			continue
+		}
+		signer := msg.GetSigners()[0]
+		if writes[signer.String()] == 0 {
+			signers = append(signers, signer)
+		}
+		writes[signer.String()]++
+	}
+
+	for _, signer := range signers {
+		if err := d.keeper.CountWrites(ctx, signer, writes[signer.String()]); err != nil {
+			return ctx, err
+		}
+	}
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package crud
+
+import (
+	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMinGasDecorator(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	params := types.DefaultParams()
+	params.MinMsgGas = []types.MsgGas{{MsgType: "create", Gas: 1000}, {MsgType: "delete", Gas: 300}}
+	mockKeeper.EXPECT().GetParams(ctx).Return(params)
+
+	tx := auth.StdTx{Msgs: []sdk.Msg{
+		types.NewMsgCreate("uuid", "key0", []byte("value"), 0, owner),
+		types.NewMsgCreate("uuid", "key1", []byte("value"), 0, owner),
+		types.MsgDelete{UUID: "uuid", Key: "key2", Owner: owner},
+		types.MsgRead{UUID: "uuid", Key: "key0", Owner: owner},
+		bank.NewMsgSend(owner, owner, nil),
+	}}
+
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		return ctx, nil
+	}
+	_, err := NewMinGasDecorator(mockKeeper).AnteHandle(ctx, tx, false, next)
+	assert.Nil(t, err)
+	assert.Equal(t, sdk.Gas(2300), ctx.GasMeter().GasConsumed())
+}
+
+func TestRateLimitDecorator(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	other := sdk.AccAddress("bluzelle1t0ywtmrdu34")
+	ctx := sdk.Context{}
+
+	tx := auth.StdTx{Msgs: []sdk.Msg{
+		types.NewMsgCreate("uuid", "key0", []byte("value"), 0, owner),
+		types.MsgRead{UUID: "uuid", Key: "key0", Owner: owner},
+		types.NewMsgCreate("uuid", "key1", []byte("value"), 0, other),
+		types.MsgDelete{UUID: "uuid", Key: "key2", Owner: owner},
+		bank.NewMsgSend(owner, owner, nil),
+	}}
+
+	called := false
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		called = true
+		return ctx, nil
+	}
+
+	gomock.InOrder(
+		mockKeeper.EXPECT().CountWrites(ctx, owner, uint64(2)).Return(nil),
+		mockKeeper.EXPECT().CountWrites(ctx, other, uint64(1)).Return(nil),
+	)
+	_, err := NewRateLimitDecorator(mockKeeper).AnteHandle(ctx, tx, false, next)
+	assert.Nil(t, err)
+	assert.True(t, called)
+
+	// over the limit
+	called = false
+	mockKeeper.EXPECT().CountWrites(ctx, owner, uint64(2)).Return(sdkerrors.ErrInvalidRequest)
+	_, err = NewRateLimitDecorator(mockKeeper).AnteHandle(ctx, tx, false, next)
+	assert.NotNil(t, err)
+	assert.False(t, called)
+
+	// reads alone are not counted
+	_, err = NewRateLimitDecorator(mockKeeper).AnteHandle(ctx, auth.StdTx{Msgs: []sdk.Msg{types.MsgRead{UUID: "uuid", Key: "key0", Owner: owner}}}, false, next)
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,235 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+// Package archive reads and writes crud backup archives: a header and the keys of a UUID,
+// or of the whole module, as length prefixed frames. Every frame extends a sha256 hash
+// chain whose end is signed by the node key of the node that wrote the archive, so that
+// a reader can tell the archive is complete and unchanged since it was written.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoamino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"io"
+)
+
+// Magic starts every archive, naming the format and its version
+const Magic = "BLZCRUD1"
+
+// MaxFrameSize bounds the frames a Reader accepts, so that a corrupt length cannot make it
+// allocate without limit
+const MaxFrameSize = 64 << 20
+
+// the first byte of every frame after the header says what it holds
+const (
+	frameValue   byte = 1
+	frameTrailer byte = 2
+)
+
+var cdc = codec.New()
+
+// Header describes an archive. UUID is empty for an archive of the whole module, whose
+// State then holds everything but the keys. PubKey is the amino encoded key the archive
+// is signed with.
+type Header struct {
+	ChainID string
+	Height  int64
+	UUID    string
+	State   *crud.GenesisState
+	PubKey  []byte
+}
+
+// trailer ends an archive with the number of values, the end of the hash chain and its
+// signature
+type trailer struct {
+	Count     uint64
+	Hash      []byte
+	Signature []byte
+}
+
+// Writer writes an archive, see NewWriter.
+type Writer struct {
+	w     io.Writer
+	key   crypto.PrivKey
+	hash  []byte
+	count uint64
+}
+
+// NewWriter writes the header of an archive to be signed with key to w. The values are
+// then added with Write, and the archive is finished by Close.
+func NewWriter(w io.Writer, header Header, key crypto.PrivKey) (*Writer, error) {
+	header.PubKey = key.PubKey().Bytes()
+	payload, err := cdc.MarshalBinaryBare(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(w, Magic); err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{w: w, key: key, hash: chain([]byte(Magic), payload)}
+	return writer, writeFrame(w, payload)
+}
+
+// Write adds a value to the archive.
+func (w *Writer) Write(value crud.GenesisValue) error {
+	bz, err := cdc.MarshalBinaryBare(value)
+	if err != nil {
+		return err
+	}
+
+	payload := append([]byte{frameValue}, bz...)
+	w.hash = chain(w.hash, payload)
+	w.count++
+	return writeFrame(w.w, payload)
+}
+
+// Close signs the archive, writing its trailer. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	signature, err := w.key.Sign(w.hash)
+	if err != nil {
+		return err
+	}
+
+	bz, err := cdc.MarshalBinaryBare(trailer{Count: w.count, Hash: w.hash, Signature: signature})
+	if err != nil {
+		return err
+	}
+	return writeFrame(w.w, append([]byte{frameTrailer}, bz...))
+}
+
+// Reader reads an archive, see NewReader.
+type Reader struct {
+	r      *bufio.Reader
+	Header Header
+	PubKey crypto.PubKey
+	hash   []byte
+	count  uint64
+	done   bool
+}
+
+// NewReader reads the header of the archive in r. Its values are then read with Next,
+// which verifies the archive once it reaches the end.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(reader.r, magic); err != nil || string(magic) != Magic {
+		return nil, errors.New("not a crud archive")
+	}
+
+	payload, err := reader.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	if err := cdc.UnmarshalBinaryBare(payload, &reader.Header); err != nil {
+		return nil, fmt.Errorf("could not decode archive header: %w", err)
+	}
+
+	reader.PubKey, err = cryptoamino.PubKeyFromBytes(reader.Header.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode archive key: %w", err)
+	}
+
+	reader.hash = chain([]byte(Magic), payload)
+	return reader, nil
+}
+
+// Next returns the next value of the archive, or io.EOF once every value has been read
+// and the archive is found to be complete and signed by PubKey. Any other error means the
+// archive cannot be trusted, including the values already read.
+func (r *Reader) Next() (crud.GenesisValue, error) {
+	var value crud.GenesisValue
+	if r.done {
+		return value, io.EOF
+	}
+
+	payload, err := r.readFrame()
+	if err == io.EOF {
+		return value, errors.New("archive is truncated")
+	}
+	if err != nil {
+		return value, err
+	}
+
+	switch payload[0] {
+	case frameValue:
+		if err := cdc.UnmarshalBinaryBare(payload[1:], &value); err != nil {
+			return value, fmt.Errorf("could not decode archive value %d: %w", r.count+1, err)
+		}
+		r.hash = chain(r.hash, payload)
+		r.count++
+		return value, nil
+
+	case frameTrailer:
+		var t trailer
+		if err := cdc.UnmarshalBinaryBare(payload[1:], &t); err != nil {
+			return value, fmt.Errorf("could not decode archive trailer: %w", err)
+		}
+		if t.Count != r.count || !bytes.Equal(t.Hash, r.hash) {
+			return value, errors.New("archive does not match its hash chain")
+		}
+		if !r.PubKey.VerifyBytes(r.hash, t.Signature) {
+			return value, errors.New("archive signature is invalid")
+		}
+		r.done = true
+		return value, io.EOF
+
+	default:
+		return value, fmt.Errorf("unknown archive frame %d", payload[0])
+	}
+}
+
+// Count returns the number of values read so far.
+func (r *Reader) Count() uint64 {
+	return r.count
+}
+
+func (r *Reader) readFrame() ([]byte, error) {
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 || length > MaxFrameSize {
+		return nil, fmt.Errorf("invalid archive frame length %d", length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, errors.New("archive is truncated")
+	}
+	return payload, nil
+}
+
+func writeFrame(w io.Writer, payload []byte) error {
+	bz := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(payload))
+	bz = append(bz[:binary.PutUvarint(bz, uint64(len(payload)))], payload...)
+	_, err := w.Write(bz)
+	return err
+}
+
+// chain extends the hash chain ending in hash with payload
+func chain(hash []byte, payload []byte) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, hash...), payload...))
+	return sum[:]
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"bytes"
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"io"
+	"testing"
+)
+
+func writeTestArchive(t *testing.T, key ed25519.PrivKeyEd25519) []byte {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, Header{ChainID: "bluzelle", Height: 10, UUID: "uuid"}, key)
+	assert.Nil(t, err)
+	for _, k := range []string{"key0", "key1"} {
+		assert.Nil(t, writer.Write(crud.GenesisValue{UUID: "uuid", Key: k, Value: crud.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner}}))
+	}
+	assert.Nil(t, writer.Close())
+	return buf.Bytes()
+}
+
+func readAll(bz []byte) (*Reader, []crud.GenesisValue, error) {
+	reader, err := NewReader(bytes.NewReader(bz))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values []crud.GenesisValue
+	for {
+		value, err := reader.Next()
+		if err == io.EOF {
+			return reader, values, nil
+		}
+		if err != nil {
+			return reader, values, err
+		}
+		values = append(values, value)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	reader, values, err := readAll(writeTestArchive(t, key))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "bluzelle", reader.Header.ChainID)
+	assert.Equal(t, int64(10), reader.Header.Height)
+	assert.Equal(t, "uuid", reader.Header.UUID)
+	assert.Nil(t, reader.Header.State)
+	assert.Equal(t, key.PubKey(), reader.PubKey)
+	assert.Equal(t, uint64(2), reader.Count())
+	assert.Equal(t, []string{"key0", "key1"}, []string{values[0].Key, values[1].Key})
+	assert.Equal(t, []byte("value"), values[1].Value.Value)
+}
+
+func TestArchive_State(t *testing.T) {
+	var buf bytes.Buffer
+	state := crud.DefaultGenesisState()
+	writer, err := NewWriter(&buf, Header{Height: 10, State: &state}, ed25519.GenPrivKey())
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	reader, values, err := readAll(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+	assert.Equal(t, state.Params, reader.Header.State.Params)
+}
+
+func TestArchive_Tampered(t *testing.T) {
+	bz := writeTestArchive(t, ed25519.GenPrivKey())
+
+	// a changed value breaks the hash chain
+	changed := bytes.Replace(bz, []byte("key1"), []byte("key2"), 1)
+	_, _, err := readAll(changed)
+	assert.EqualError(t, err, "archive does not match its hash chain")
+
+	// a missing trailer leaves the archive unverified
+	_, values, err := readAll(bz[:len(bz)-10])
+	assert.NotNil(t, err)
+	assert.Len(t, values, 2)
+
+	// the trailer must be signed by the key in the header
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, Header{UUID: "uuid"}, ed25519.GenPrivKey())
+	assert.Nil(t, err)
+	writer.key = ed25519.GenPrivKey()
+	assert.Nil(t, writer.Close())
+	_, _, err = readAll(buf.Bytes())
+	assert.EqualError(t, err, "archive signature is invalid")
+
+	_, _, err = readAll([]byte("not an archive"))
+	assert.EqualError(t, err, "not a crud archive")
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+)
+
+func GetCmdQExport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var owner, file string
+	cc := cobra.Command{
+		Use:   "export [UUID]",
+		Short: "write every entry of UUID, with its remaining lease and owner, as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+
+			keyValues := make([]types.KeyValueLease, 0)
+			for next, more := "", true; more; {
+				res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvaluespage/%s/%s", queryRoute, UUID, owner), []byte(next))
+				if err != nil {
+					return err
+				}
+
+				// read the remaining pages at the same height so the snapshot is consistent
+				cliCtx = cliCtx.WithHeight(height)
+
+				var page types.QueryResultKeyValuesPage
+				cdc.MustUnmarshalJSON(res, &page)
+				keyValues = append(keyValues, page.KeyValues...)
+				next, more = page.Next, len(page.Next) > 0
+			}
+
+			out, err := cdc.MarshalJSONIndent(keyValues, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if len(file) == 0 {
+				_, err = fmt.Fprintln(os.Stdout, string(out))
+				return err
+			}
+			return ioutil.WriteFile(file, out, 0644)
+		},
+	}
+	cc.Flags().StringVar(&owner, "owner", "", "only export the entries of this owner")
+	cc.Flags().StringVar(&file, "file", "", "file to write the snapshot to (default stdout)")
+	return &cc
+}
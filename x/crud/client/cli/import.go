@@ -0,0 +1,214 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var batchSize int
+
+func GetCmdImport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "import [UUID] [file]",
+		Short: "create or update the entries listed in a JSON or CSV file",
+		Long: `Create or update the entries listed in a JSON or CSV file, batching them into transactions.
+
+A JSON file holds an array of {"key": ..., "value": ..., "lease": ...} objects with base64
+values, as written by "query crud export", a CSV file key,value[,lease] rows, with base64
+values only if --base64 is given. The lease, in blocks, is optional: new keys get the default lease
+and existing keys keep theirs when it is left out. With --dry-run nothing is broadcast,
+the gas of every batch is estimated instead.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if batchSize <= 0 {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "batch size must be positive")
+			}
+
+			keyValues, err := readImportFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			UUID := args[0]
+			owner := cliCtx.GetFromAddress()
+
+			if !cliCtx.GenerateOnly {
+				txBldr, err = utils.PrepareTxBuilder(txBldr, cliCtx)
+				if err != nil {
+					return err
+				}
+			}
+
+			totalGas := uint64(0)
+			for start := 0; start < len(keyValues); start += batchSize {
+				end := start + batchSize
+				if end > len(keyValues) {
+					end = len(keyValues)
+				}
+
+				msgs, err := importMsgs(cliCtx, queryRoute, UUID, owner, keyValues[start:end])
+				if err != nil {
+					return err
+				}
+
+				if cliCtx.Simulate {
+					simBldr, err := utils.EnrichWithGas(txBldr, cliCtx, msgs)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(os.Stderr, "keys %d-%d: estimated gas = %d\n", start, end-1, simBldr.Gas())
+					totalGas += simBldr.Gas()
+					continue
+				}
+
+				if err = utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, msgs); err != nil {
+					return err
+				}
+				txBldr = txBldr.WithSequence(txBldr.Sequence() + 1)
+			}
+
+			if cliCtx.Simulate {
+				fmt.Fprintf(os.Stderr, "%d keys in %d transactions: estimated gas = %d\n",
+					len(keyValues), (len(keyValues)+batchSize-1)/batchSize, totalGas)
+			}
+			return nil
+		},
+	}
+	cc.PersistentFlags().IntVar(&batchSize, "batch-size", 100, "number of keys sent in each transaction")
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the CSV values are base64 encoded binary")
+	return &cc
+}
+
+func readImportFile(path string) ([]types.KeyValue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readImportCSV(file)
+	}
+
+	var keyValues []types.KeyValue
+	if err := json.NewDecoder(file).Decode(&keyValues); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("could not read %s: %s", path, err))
+	}
+	return keyValues, nil
+}
+
+func readImportCSV(r io.Reader) ([]types.KeyValue, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var keyValues []types.KeyValue
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return keyValues, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("line %d: expected key,value[,lease]", line))
+		}
+
+		value, err := parseValue(record[1])
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("line %d: %s", line, err))
+		}
+
+		keyValue := types.KeyValue{Key: record[0], Value: value}
+		if len(record) == 3 && len(record[2]) > 0 {
+			if keyValue.Lease, err = strconv.ParseInt(record[2], 10, 64); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("line %d: %s", line, err))
+			}
+		}
+		keyValues = append(keyValues, keyValue)
+	}
+}
+
+// importMsgs turns a batch of entries into a MsgCreate for every new key and a single
+// MsgMultiUpdate for the existing ones. The lease of an existing key is given as the
+// delta that brings its remaining lease to the requested one.
+func importMsgs(cliCtx context.CLIContext, queryRoute string, UUID string, owner sdk.AccAddress, keyValues []types.KeyValue) ([]sdk.Msg, error) {
+	var msgs []sdk.Msg
+	update := types.NewMsgMultiUpdate(UUID, owner, nil)
+
+	for _, keyValue := range keyValues {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/owner/%s/%s", queryRoute, UUID, keyValue.Key), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var keyOwner types.QueryResultOwner
+		cliCtx.Codec.MustUnmarshalJSON(res, &keyOwner)
+
+		if !keyOwner.Has {
+			msgs = append(msgs, types.NewMsgCreate(UUID, keyValue.Key, keyValue.Value, keyValue.Lease, owner))
+			continue
+		}
+
+		if !keyOwner.Owner.Equals(owner) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("key %s is owned by %s", keyValue.Key, keyOwner.Owner))
+		}
+
+		if keyValue.Lease != 0 {
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getlease/%s/%s", queryRoute, UUID, keyValue.Key), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var lease types.QueryResultLease
+			cliCtx.Codec.MustUnmarshalJSON(res, &lease)
+			keyValue.Lease -= lease.Lease
+		}
+		update.KeyValues = append(update.KeyValues, keyValue)
+	}
+
+	if len(update.KeyValues) > 0 {
+		msgs = append(msgs, update)
+	}
+
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
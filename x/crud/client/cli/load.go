@@ -0,0 +1,324 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+var loadConcurrency int
+var loadRetries int
+var loadWait time.Duration
+var loadFailedFile string
+
+func GetCmdLoad(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "load [UUID] [file]",
+		Short: "create the entries listed in a JSON or CSV file, many transactions at a time",
+		Long: `Create the entries listed in a JSON or CSV file, in the formats read by import, for
+initial migrations of large datasets.
+
+The entries are split into transactions of --batch-size creates, signed by --concurrency
+workers and broadcast in sequence order without waiting for each to be committed. The
+account sequence is tracked locally and fetched again when the node rejects it, and
+transactions are retried up to --retries times when the mempool is full. Once every
+transaction is broadcast, load waits up to --wait for them to be committed and reports the
+entries that were not created; --failed writes those to a file that can be loaded again.
+Unlike import, existing keys are not updated: a transaction with an existing key fails.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithBroadcastMode(flags.BroadcastSync)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if batchSize <= 0 || loadConcurrency <= 0 {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "batch size and concurrency must be positive")
+			}
+			if cliCtx.GenerateOnly || cliCtx.Simulate {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "load broadcasts transactions, use import to generate or estimate them")
+			}
+
+			keyValues, err := readImportFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			txBldr, err = utils.PrepareTxBuilder(txBldr, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			l := &loader{
+				cliCtx:  cliCtx,
+				txBldr:  txBldr,
+				UUID:    args[0],
+				owner:   cliCtx.GetFromAddress(),
+				retries: loadRetries,
+				out:     os.Stderr,
+			}
+
+			batches := l.load(keyValues, batchSize, loadConcurrency)
+			l.confirm(batches, time.Now().Add(loadWait))
+
+			failed := l.report(batches, len(keyValues))
+			if len(loadFailedFile) > 0 && len(failed) > 0 {
+				out, err := json.MarshalIndent(failed, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(loadFailedFile, out, 0644); err != nil {
+					return err
+				}
+			}
+
+			if len(failed) > 0 {
+				return fmt.Errorf("%d of %d keys were not created", len(failed), len(keyValues))
+			}
+			return nil
+		},
+	}
+	cc.PersistentFlags().IntVar(&batchSize, "batch-size", 100, "number of keys created by each transaction")
+	cc.PersistentFlags().IntVar(&loadConcurrency, "concurrency", 4, "number of transactions built and signed at once")
+	cc.PersistentFlags().IntVar(&loadRetries, "retries", 5, "number of times a rejected transaction is broadcast again")
+	cc.PersistentFlags().DurationVar(&loadWait, "wait", time.Minute, "how long to wait for the transactions to be committed")
+	cc.PersistentFlags().StringVar(&loadFailedFile, "failed", "", "file the entries that were not created are written to, as JSON")
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the CSV values are base64 encoded binary")
+	return &cc
+}
+
+// loadBatch is the transaction creating one batch of the loaded entries.
+type loadBatch struct {
+	index     int
+	keyValues []types.KeyValue
+	msgs      []sdk.Msg
+	gas       uint64
+	sequence  uint64
+	txBytes   []byte
+	hash      string
+	err       error
+}
+
+// loader creates entries with transactions signed by the from account, whose account
+// number and next sequence are set in txBldr.
+type loader struct {
+	cliCtx  context.CLIContext
+	txBldr  auth.TxBuilder
+	UUID    string
+	owner   sdk.AccAddress
+	retries int
+	out     io.Writer
+}
+
+// load splits keyValues into batches and broadcasts a transaction for each. Batches
+// are signed concurrently with the sequence they are expected to get, and broadcast
+// in order; a batch is signed again when an earlier one failed and left its
+// sequence unused.
+func (l *loader) load(keyValues []types.KeyValue, batchSize, concurrency int) []*loadBatch {
+	var batches []*loadBatch
+	for start := 0; start < len(keyValues); start += batchSize {
+		end := start + batchSize
+		if end > len(keyValues) {
+			end = len(keyValues)
+		}
+		batches = append(batches, &loadBatch{index: len(batches), keyValues: keyValues[start:end]})
+	}
+
+	base := l.txBldr.Sequence()
+
+	work := make(chan *loadBatch)
+	signed := make(chan *loadBatch, concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				l.sign(batch, base+uint64(batch.index))
+				signed <- batch
+			}
+		}()
+	}
+
+	go func() {
+		for _, batch := range batches {
+			work <- batch
+		}
+		close(work)
+		wg.Wait()
+		close(signed)
+	}()
+
+	progress := time.NewTicker(5 * time.Second)
+	defer progress.Stop()
+
+	pending := make(map[int]*loadBatch)
+	next, sequence, sent := 0, base, 0
+	for batch := range signed {
+		pending[batch.index] = batch
+		for batch, ok := pending[next]; ok; batch, ok = pending[next] {
+			delete(pending, next)
+			next++
+
+			sequence = l.broadcast(batch, sequence)
+			if batch.err == nil {
+				sent += len(batch.keyValues)
+			}
+
+			select {
+			case <-progress.C:
+				fmt.Fprintf(l.out, "%d of %d keys broadcast\n", sent, len(keyValues))
+			default:
+			}
+		}
+	}
+	fmt.Fprintf(l.out, "%d of %d keys broadcast\n", sent, len(keyValues))
+
+	return batches
+}
+
+// sign builds the transaction of batch with sequence, estimating its gas first if
+// the gas is to be simulated.
+func (l *loader) sign(batch *loadBatch, sequence uint64) {
+	if batch.msgs == nil {
+		for _, keyValue := range batch.keyValues {
+			msg := types.NewMsgCreate(l.UUID, keyValue.Key, keyValue.Value, keyValue.Lease, l.owner)
+			if batch.err = msg.ValidateBasic(); batch.err != nil {
+				return
+			}
+			batch.msgs = append(batch.msgs, msg)
+		}
+
+		batch.gas = l.txBldr.Gas()
+		if l.txBldr.SimulateAndExecute() {
+			txBldr, err := utils.EnrichWithGas(l.txBldr, l.cliCtx, batch.msgs)
+			if err != nil {
+				batch.err = err
+				return
+			}
+			batch.gas = txBldr.Gas()
+		}
+	}
+
+	batch.sequence = sequence
+	batch.txBytes, batch.err = l.txBldr.WithGas(batch.gas).WithSequence(sequence).BuildAndSign(l.cliCtx.GetFromName(), "", batch.msgs)
+}
+
+// broadcast sends the transaction of batch, given the next unused sequence of the
+// account, and returns the one after it.
+func (l *loader) broadcast(batch *loadBatch, sequence uint64) uint64 {
+	if batch.err != nil {
+		return sequence
+	}
+
+	for attempt := 0; ; attempt++ {
+		if batch.sequence != sequence {
+			l.sign(batch, sequence)
+			if batch.err != nil {
+				return sequence
+			}
+		}
+
+		res, err := l.cliCtx.BroadcastTx(batch.txBytes)
+		if err == nil {
+			switch {
+			case res.Code == 0, isABCIError(res, sdkerrors.ErrTxInMempoolCache):
+				batch.hash = res.TxHash
+				return sequence + 1
+			case isABCIError(res, sdkerrors.ErrUnauthorized):
+				// the ante handler reports a wrong sequence as unauthorized; the node
+				// is ahead when another client signed with the account, and behind
+				// when earlier transactions are still in the mempool
+				_, nodeSequence, seqErr := auth.NewAccountRetriever(l.cliCtx).GetAccountNumberSequence(l.owner)
+				if seqErr == nil {
+					sequence = nodeSequence
+				}
+			case !isABCIError(res, sdkerrors.ErrMempoolIsFull):
+				batch.err = sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog)
+				return sequence
+			}
+			err = sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog)
+		}
+
+		if attempt >= l.retries {
+			batch.err = err
+			return sequence
+		}
+		time.Sleep(time.Second << uint(attempt))
+	}
+}
+
+// confirm waits until deadline for the broadcast transactions to be committed and
+// records the ones that failed in the block or were not committed in time.
+func (l *loader) confirm(batches []*loadBatch, deadline time.Time) {
+	for _, batch := range batches {
+		if batch.err != nil {
+			continue
+		}
+
+		for {
+			res, err := utils.QueryTx(l.cliCtx, batch.hash)
+			if err == nil {
+				if res.Code != 0 {
+					batch.err = sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				batch.err = errors.New("not committed in time")
+				break
+			}
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+// report prints a line for every failed batch and a summary, and returns the
+// entries that were not created.
+func (l *loader) report(batches []*loadBatch, total int) []types.KeyValue {
+	var failed []types.KeyValue
+	for _, batch := range batches {
+		if batch.err == nil {
+			continue
+		}
+		first, last := batch.keyValues[0].Key, batch.keyValues[len(batch.keyValues)-1].Key
+		fmt.Fprintf(l.out, "transaction %d, keys %s to %s: %s\n", batch.index, first, last, batch.err)
+		failed = append(failed, batch.keyValues...)
+	}
+
+	fmt.Fprintf(l.out, "%d of %d keys created in %d transactions\n", total-len(failed), total, len(batches))
+	return failed
+}
+
+func isABCIError(res sdk.TxResponse, err *sdkerrors.Error) bool {
+	return res.Codespace == err.Codespace() && res.Code == err.ABCICode()
+}
@@ -16,12 +16,14 @@ package cli
 
 import (
 	"fmt"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
+	"io/ioutil"
 	"strconv"
 )
 
@@ -37,18 +39,42 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	crudQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdQRead(storeKey, cdc),
 		GetCmdQHas(storeKey, cdc),
+		GetCmdQOwner(storeKey, cdc),
 		GetCmdQKeys(storeKey, cdc),
 		GetCmdQKeyValues(storeKey, cdc),
 		GetCmdQCount(storeKey, cdc),
 		GetCmdQGetLease(storeKey, cdc),
 		GetCmdQGetNShortestLeases(storeKey, cdc),
+		GetCmdQGetLeaseAll(storeKey, cdc),
+		GetCmdQKeysByExpiry(storeKey, cdc),
+		GetCmdQKeysMatching(storeKey, cdc),
+		GetCmdQFind(storeKey, cdc),
+		GetCmdQFindByHash(storeKey, cdc),
+		GetCmdQGetMetadata(storeKey, cdc),
+		GetCmdQGetHash(storeKey, cdc),
+		GetCmdQMyUUIDs(storeKey, cdc),
+		GetCmdQEscrow(storeKey, cdc),
+		GetCmdQAccountUsage(storeKey, cdc),
+		GetCmdQCountAll(storeKey, cdc),
+		GetCmdQUUIDStats(storeKey, cdc),
+		GetCmdQSchema(storeKey, cdc),
+		GetCmdQAuditLog(storeKey, cdc),
+		GetCmdQKeysAll(storeKey, cdc),
+		GetCmdQGCStatus(storeKey, cdc),
+		GetCmdQDefaultLease(storeKey, cdc),
+		GetCmdQLeasePrice(storeKey, cdc),
+		GetCmdQRentHistory(storeKey, cdc),
+		GetCmdQExport(storeKey, cdc),
+		GetCmdQEstimateLease(storeKey, cdc),
+		GetCmdQSimulate(storeKey, cdc),
 	)...)
 
 	return crudQueryCmd
 }
 
 func GetCmdQRead(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	var verbose bool
+	cc := cobra.Command{
 		Use:   "read [UUID] [key]",
 		Short: "read UUID key",
 		Args:  cobra.ExactArgs(2),
@@ -56,17 +82,30 @@ func GetCmdQRead(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			UUID := args[0]
 			key := args[1]
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/read/%s/%s", queryRoute, UUID, key), nil)
+
+			route := "read"
+			if verbose {
+				route = "readmeta"
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, route, UUID, key), nil)
 
 			if err != nil {
 				fmt.Printf("could not read key - %s : %s\n", UUID, key)
 				return nil
 			}
+
+			if verbose {
+				var out types.QueryResultReadMeta
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
 			var out types.QueryResultRead
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
+	cc.Flags().BoolVar(&verbose, "verbose", false, "also show the owner, blocks of lease left and the created and modified heights")
+	return &cc
 }
 
 func GetCmdQHas(queryRoute string, cdc *codec.Codec) *cobra.Command {
@@ -87,15 +126,38 @@ func GetCmdQHas(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
-func GetCmdQKeys(queryRoute string, cdc *codec.Codec) *cobra.Command {
+func GetCmdQOwner(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
+		Use:   "owner [UUID] [key]",
+		Short: "owner UUID key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			key := args[1]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/owner/%s/%s", queryRoute, UUID, key), nil)
+
+			if err != nil {
+				fmt.Printf("could not read key - %s : %s\n", UUID, key)
+				return nil
+			}
+			var out types.QueryResultOwner
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQKeys(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start string
+	cc := cobra.Command{
 		Use:   "keys [UUID]",
-		Short: "keys UUID",
+		Short: "keys UUID, in byte order, a page at a time",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			UUID := args[0]
-			res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keys/%s", queryRoute, UUID), nil)
+			res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keys/%s", queryRoute, UUID), []byte(start))
 
 			var out types.QueryResultKeys
 			cdc.MustUnmarshalJSON(res, &out)
@@ -108,17 +170,20 @@ func GetCmdQKeys(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			return cliCtx.PrintOutput(out)
 		},
 	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	return &cc
 }
 
 func GetCmdQKeyValues(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	var start string
+	cc := cobra.Command{
 		Use:   "keyvalues [UUID]",
-		Short: "keyvalues UUID",
+		Short: "keyvalues UUID, in byte order of the keys, a page at a time",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			UUID := args[0]
-			res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvalues/%s", queryRoute, UUID), nil)
+			res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvalues/%s", queryRoute, UUID), []byte(start))
 
 			var out types.QueryResultKeyValues
 			cdc.MustUnmarshalJSON(res, &out)
@@ -131,6 +196,8 @@ func GetCmdQKeyValues(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			return cliCtx.PrintOutput(out)
 		},
 	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	return &cc
 }
 
 func GetCmdQCount(queryRoute string, cdc *codec.Codec) *cobra.Command {
@@ -173,8 +240,178 @@ func GetCmdQGetLease(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdQGetLeaseAll(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start string
+	var limit uint64
+	cc := cobra.Command{
+		Use:   "getleaseall [UUID] [owner]",
+		Short: "getleaseall UUID owner",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getleaseall/%s/%s/%d", queryRoute, UUID, args[1], limit), []byte(start))
+
+			if err != nil {
+				fmt.Printf("could not read leases - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultLeaseAll
+			cdc.MustUnmarshalJSON(res, &out)
+
+			// ensure we don't lose the fact that the leases list is empty...
+			if out.Leases == nil {
+				out.Leases = make([]types.KeyLeaseExpiry, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	cc.PersistentFlags().Uint64Var(&limit, "limit", 100, "maximum number of leases to return")
+	return &cc
+}
+
+func GetCmdQKeysByExpiry(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start string
+	var limit uint64
+	cc := cobra.Command{
+		Use:   "keys-by-expiry [UUID] [owner]",
+		Short: "keys-by-expiry UUID [owner], the keys soonest to expire first, a page at a time",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID, owner := args[0], ""
+			if len(args) > 1 {
+				owner = args[1]
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keysbyexpiry/%s/%s/%d", queryRoute, UUID, owner, limit), []byte(start))
+			if err != nil {
+				fmt.Printf("could not read leases - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultKeysByExpiry
+			cdc.MustUnmarshalJSON(res, &out)
+
+			// ensure we don't lose the fact that the leases list is empty...
+			if out.KeyLeases == nil {
+				out.KeyLeases = make([]types.KeyLease, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	cc.PersistentFlags().Uint64Var(&limit, "limit", 100, "maximum number of keys to return")
+	return &cc
+}
+
+func GetCmdQKeysMatching(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start string
+	cc := cobra.Command{
+		Use:   "keys-matching [UUID] [pattern] [owner]",
+		Short: "keys-matching UUID pattern [owner], the keys matching a glob pattern such as sensor:*:temp, a page at a time",
+		Long: `List the keys of a UUID matching a glob pattern, a page at a time: * matches any run of characters,
+? any one character, [...] one of a set of characters such as [a-z] or, with a leading !, any other, and \
+takes the next character literally. Quote the pattern to keep the shell from expanding it.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID, owner := args[0], ""
+			if len(args) > 2 {
+				owner = args[2]
+			}
+
+			data := cdc.MustMarshalJSON(types.QueryMatchParams{Pattern: args[1], Start: start})
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/match/%s/%s", queryRoute, UUID, owner), data)
+			if err != nil {
+				fmt.Printf("could not match keys - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultMatch
+			cdc.MustUnmarshalJSON(res, &out)
+
+			// ensure we don't lose the fact that the keys list is empty...
+			if out.Keys == nil {
+				out.Keys = make([]string, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	return &cc
+}
+
+func GetCmdQAuditLog(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start, limit uint64
+	cc := cobra.Command{
+		Use:   "audit-log [UUID]",
+		Short: "audit-log UUID, the recorded changes to its keys oldest first, a page at a time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auditlog/%s/%d/%d", queryRoute, UUID, start, limit), nil)
+			if err != nil {
+				fmt.Printf("could not read audit log - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultAuditLog
+			cdc.MustUnmarshalJSON(res, &out)
+
+			if out.Entries == nil {
+				out.Entries = make([]types.AuditEntry, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.PersistentFlags().Uint64Var(&start, "start", 1, "sequence number of the first entry, next of the previous page")
+	cc.PersistentFlags().Uint64Var(&limit, "limit", 100, "maximum number of entries to return")
+	return &cc
+}
+
+func GetCmdQRentHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var start int64
+	var limit uint64
+	cc := cobra.Command{
+		Use:   "rent-history [address]",
+		Short: "rent-history address, the lease fees an account paid and had refunded, epoch by epoch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/renthistory/%s/%d/%d", queryRoute, args[0], start, limit), nil)
+			if err != nil {
+				fmt.Printf("could not read rent history - %s : %s\n", args[0], err)
+				return nil
+			}
+
+			var out types.QueryResultRentHistory
+			cdc.MustUnmarshalJSON(res, &out)
+
+			if out.Records == nil {
+				out.Records = make([]types.RentRecord, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.PersistentFlags().Int64Var(&start, "start", 0, "height of the first epoch, next of the previous page; epochs imported from an exported chain start at negative heights")
+	cc.PersistentFlags().Uint64Var(&limit, "limit", 100, "maximum number of epochs to return")
+	return &cc
+}
+
 func GetCmdQGetNShortestLeases(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	var start string
+	cc := cobra.Command{
 		Use:   "getnshortestleases [UUID] [N]",
 		Short: "getnshortestleases UUID N",
 		Args:  cobra.ExactArgs(2),
@@ -188,7 +425,7 @@ func GetCmdQGetNShortestLeases(queryRoute string, cdc *codec.Codec) *cobra.Comma
 				return nil
 			}
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getnshortestleases/%s/%d", queryRoute, UUID, N), nil)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getnshortestleases/%s/%d", queryRoute, UUID, N), []byte(start))
 
 			var out types.QueryResultNShortestLeaseKeys
 			cdc.MustUnmarshalJSON(res, &out)
@@ -201,4 +438,432 @@ func GetCmdQGetNShortestLeases(queryRoute string, cdc *codec.Codec) *cobra.Comma
 			return cliCtx.PrintOutput(out)
 		},
 	}
+	cc.PersistentFlags().StringVar(&start, "start", "", "next of the previous page")
+	return &cc
+}
+
+func GetCmdQFind(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "find [UUID] [value]",
+		Short: "find UUID value",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/find/%s", queryRoute, UUID), []byte(args[1]))
+
+			if err != nil {
+				fmt.Printf("could not search UUID - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultKeys
+			cdc.MustUnmarshalJSON(res, &out)
+
+			if out.Keys == nil {
+				out.Keys = make([]string, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQFindByHash(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "find-by-hash [UUID] [hash]",
+		Short: "find the keys of UUID whose value has the hex encoded SHA-256 hash",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/findbyhash/%s/%s", queryRoute, UUID, args[1]), nil)
+
+			if err != nil {
+				fmt.Printf("could not search UUID - %s : %s\n", UUID, err)
+				return nil
+			}
+
+			var out types.QueryResultKeys
+			cdc.MustUnmarshalJSON(res, &out)
+
+			if out.Keys == nil {
+				out.Keys = make([]string, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQGetMetadata(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "getmetadata [UUID] [key]",
+		Short: "getmetadata UUID key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			key := args[1]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getmetadata/%s/%s", queryRoute, UUID, key), nil)
+
+			if err != nil {
+				fmt.Printf("could not read key - %s : %s\n", UUID, key)
+				return nil
+			}
+			var out types.QueryResultMetadata
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQGetHash(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "gethash [UUID] [key]",
+		Short: "gethash UUID key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			UUID := args[0]
+			key := args[1]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gethash/%s/%s", queryRoute, UUID, key), nil)
+
+			if err != nil {
+				fmt.Printf("could not read key - %s : %s\n", UUID, key)
+				return nil
+			}
+			var out types.QueryResultHash
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQMyUUIDs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "my-uuids [owner]",
+		Short: "my-uuids owner (default the --from account)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner := cliCtx.GetFromAddress().String()
+			if len(args) > 0 {
+				owner = args[0]
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/myuuids/%s", queryRoute, owner), nil)
+			if err != nil {
+				fmt.Printf("could not read UUIDs - %s : %s\n", owner, err)
+				return nil
+			}
+
+			var out types.QueryResultUUIDs
+			cdc.MustUnmarshalJSON(res, &out)
+
+			// ensure we don't lose the fact that the UUIDs list is empty...
+			if out.UUIDs == nil {
+				out.UUIDs = make([]types.UUIDCount, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().String(flags.FlagFrom, "", "Name or address of the account to list UUIDs of")
+	cc.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return &cc
+}
+
+func GetCmdQEscrow(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "escrow [owner]",
+		Short: "escrow owner, the balance left to pay for auto-renewed leases",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/escrow/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				fmt.Printf("could not read escrow - %s : %s\n", args[0], err)
+				return nil
+			}
+
+			var out types.QueryResultEscrow
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQAccountUsage(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "account-usage [owner]",
+		Short: "account-usage owner (default the --from account), the keys and bytes stored and what renewing them would cost",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner := cliCtx.GetFromAddress().String()
+			if len(args) > 0 {
+				owner = args[0]
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/accountusage/%s", queryRoute, owner), nil)
+			if err != nil {
+				fmt.Printf("could not read usage - %s : %s\n", owner, err)
+				return nil
+			}
+
+			var out types.QueryResultAccountUsage
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().String(flags.FlagFrom, "", "Name or address of the account to report on")
+	cc.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return &cc
+}
+
+func GetCmdQUUIDStats(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "uuid-stats [UUID]",
+		Short: "uuid-stats UUID, the keys, value bytes and owners of UUID and when its leases expire",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/uuidstats/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				fmt.Printf("could not read stats - %s : %s\n", args[0], err)
+				return nil
+			}
+
+			var out types.QueryResultUUIDStats
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQSchema(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [UUID]",
+		Short: "schema UUID, the constraints the values written to UUID are checked against",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/schema/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				fmt.Printf("could not read schema - %s : %s\n", args[0], err)
+				return nil
+			}
+
+			var out types.QueryResultSchema
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQCountAll(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "count-all [owner]",
+		Short: "count-all owner (default the --from account), the keys owner has in all UUIDs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner := cliCtx.GetFromAddress().String()
+			if len(args) > 0 {
+				owner = args[0]
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/countall/%s", queryRoute, owner), nil)
+			if err != nil {
+				fmt.Printf("could not count keys - %s : %s\n", owner, err)
+				return nil
+			}
+
+			var out types.QueryResultCountAll
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().String(flags.FlagFrom, "", "Name or address of the account to count the keys of")
+	cc.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return &cc
+}
+
+func GetCmdQKeysAll(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "keys-all [owner]",
+		Short: "keys-all owner (default the --from account), the keys owner has in all UUIDs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner := cliCtx.GetFromAddress().String()
+			if len(args) > 0 {
+				owner = args[0]
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keysall/%s", queryRoute, owner), nil)
+			if err != nil {
+				fmt.Printf("could not read keys - %s : %s\n", owner, err)
+				return nil
+			}
+
+			var out types.QueryResultKeysAll
+			cdc.MustUnmarshalJSON(res, &out)
+
+			// ensure we don't lose the fact that the keys list is empty...
+			if out.Keys == nil {
+				out.Keys = make([]types.UUIDKey, 0)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().String(flags.FlagFrom, "", "Name or address of the account to list the keys of")
+	cc.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return &cc
+}
+
+func GetCmdQGCStatus(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "gc-status",
+		Short: "gc-status, how far the purge of expired keys has got and how many are waiting",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gcstatus", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not read gc status - %s\n", err)
+				return nil
+			}
+
+			var out types.QueryResultGCStatus
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQDefaultLease(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "default-lease",
+		Short: "default-lease, the lease in blocks of keys created or renewed without one",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/defaultlease", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not read default lease - %s\n", err)
+				return nil
+			}
+
+			var out types.QueryResultDefaultLease
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQLeasePrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lease-price",
+		Short: "lease-price, the price of a byte-block of lease at the bytes now stored on chain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/leaseprice", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not read lease price - %s\n", err)
+				return nil
+			}
+
+			var out types.QueryResultLeasePrice
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQEstimateLease(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var params types.QueryEstimateLeaseParams
+	var gasPrices string
+	cc := cobra.Command{
+		Use:   "estimate-lease",
+		Short: "estimate the gas and fees of creating (or --operation renew, renewing) a key",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var err error
+			if params.GasPrices, err = sdk.ParseDecCoins(gasPrices); err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/estimatelease", queryRoute), cdc.MustMarshalJSON(params))
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResultEstimateLease
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().StringVar(&params.Operation, "operation", types.EstimateCreate, "operation to estimate (create|renew)")
+	cc.Flags().StringVar(&params.UUID, "uuid", "", "UUID of the key")
+	cc.Flags().StringVar(&params.Key, "key", "", "key to create or renew")
+	cc.Flags().Uint64Var(&params.Size, "size", 0, "size of the value in bytes (create only)")
+	cc.Flags().Int64Var(&params.Lease, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	cc.Flags().StringVar(&gasPrices, "gas-prices", "", "gas prices to compute the fees at (e.g. 10.0ubnt)")
+	return &cc
+}
+
+func GetCmdQSimulate(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var gasPrices string
+	cc := cobra.Command{
+		Use:   "simulate [file]",
+		Short: "the gas and fees of the crud message in file, as run by the current state",
+		Long: `Run the crud message in file, in the JSON of a transaction's messages, on a copy of the
+current state and report the gas it consumes, including the minimum and base gas of its
+type, and the lease fee it would charge. The message is not signed and its signer need not
+hold the lease fee. Signature verification and transaction size gas are not included.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var params types.QuerySimulateParams
+			if err = cdc.UnmarshalJSON(bz, &params.Msg); err != nil {
+				return err
+			}
+			if params.GasPrices, err = sdk.ParseDecCoins(gasPrices); err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/simulate", queryRoute), cdc.MustMarshalJSON(params))
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResultSimulate
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cc.Flags().StringVar(&gasPrices, "gas-prices", "", "gas prices to compute the fees at (e.g. 10.0ubnt)")
+	return &cc
 }
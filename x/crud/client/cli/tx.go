@@ -16,7 +16,10 @@ package cli
 
 import (
 	"bufio"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -26,12 +29,18 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/spf13/cobra"
+	"io/ioutil"
 	"strconv"
 )
 
 var leaseValue int64
+var indexField string
+var base64Value bool
+var versionValue uint64
+var contentTypeValue string
+var encodingValue string
 
-func GetTxCmd(_ string, cdc *codec.Codec) *cobra.Command {
+func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	crudTxCmd := &cobra.Command{
 		Use:                        types.ModuleName,
 		Short:                      "Crud transaction subcommands",
@@ -40,21 +49,47 @@ func GetTxCmd(_ string, cdc *codec.Codec) *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 	crudTxCmd.AddCommand(flags.PostCommands(
+		GetCmdAtomic(cdc),
+		GetCmdCommitUpload(cdc),
+		GetCmdCopy(cdc),
+		GetCmdCopyUUID(cdc),
 		GetCmdCount(cdc),
 		GetCmdCreate(cdc),
 		GetCmdDelete(cdc),
 		GetCmdDeleteAll(cdc),
+		GetCmdDeleteAudit(cdc),
+		GetCmdDeleteHashIndex(cdc),
+		GetCmdDeleteIndex(cdc),
+		GetCmdDeleteRetention(cdc),
+		GetCmdDeleteSchema(cdc),
+		GetCmdDepositEscrow(cdc),
+		GetCmdWithdrawEscrow(cdc),
+		GetCmdExpireNow(cdc),
+		GetCmdFreeze(cdc),
 		GetCmdGetLease(cdc),
 		GetCmdGetNShortestLeases(cdc),
 		GetCmdHas(cdc),
+		GetCmdImport(storeKey, cdc),
 		GetCmdKeyValues(cdc),
 		GetCmdKeys(cdc),
+		GetCmdLoad(cdc),
 		GetCmdMultiUpdate(cdc),
+		GetCmdPatch(cdc),
 		GetCmdRead(cdc),
 		GetCmdRename(cdc),
 		GetCmdRenewLease(cdc),
 		GetCmdRenewLeaseAll(cdc),
+		GetCmdSetAudit(cdc),
+		GetCmdSetAutoRenew(cdc),
+		GetCmdSetBeneficiary(cdc),
+		GetCmdSetHashIndex(cdc),
+		GetCmdSetIndex(cdc),
+		GetCmdSetRetention(cdc),
+		GetCmdSetSchema(cdc),
+		GetCmdStartUpload(cdc),
+		GetCmdUnfreeze(cdc),
 		GetCmdUpdate(cdc),
+		GetCmdUploadChunk(cdc),
 	)...)
 
 	return crudTxCmd
@@ -70,16 +105,25 @@ func GetCmdCreate(cdc *codec.Codec) *cobra.Command {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			msg := types.NewMsgCreate(args[0], args[1], args[2], leaseValue, cliCtx.GetFromAddress())
+			value, err := parseValue(args[2])
+			if err != nil {
+				return err
+			}
 
-			err := msg.ValidateBasic()
+			msg := types.NewMsgCreate(args[0], args[1], value, leaseValue, cliCtx.GetFromAddress())
+			msg.ContentType, msg.Encoding = contentTypeValue, encodingValue
+
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
-	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default 172800 (10 days))")
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the value is base64 encoded binary")
+	cc.PersistentFlags().StringVar(&contentTypeValue, "content-type", "", "content type of the value, e.g. application/json")
+	cc.PersistentFlags().StringVar(&encodingValue, "encoding", "", "encoding of the value, e.g. gzip")
 	return &cc
 }
 
@@ -113,9 +157,15 @@ func GetCmdUpdate(cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
-			msg := types.MsgUpdate{UUID: args[0], Key: args[1], Value: args[2], Lease: leaseValue, Owner: cliCtx.GetFromAddress()}
+			value, err := parseValue(args[2])
+			if err != nil {
+				return err
+			}
 
-			err := msg.ValidateBasic()
+			msg := types.MsgUpdate{UUID: args[0], Key: args[1], Value: value, Lease: leaseValue, Owner: cliCtx.GetFromAddress(), Version: versionValue,
+				ContentType: contentTypeValue, Encoding: encodingValue}
+
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
@@ -125,11 +175,15 @@ func GetCmdUpdate(cdc *codec.Codec) *cobra.Command {
 	}
 
 	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default 0 (no change))")
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the value is base64 encoded binary")
+	cc.PersistentFlags().Uint64Var(&versionValue, "version", 0, "fail unless the key is at this version (default 0 (any version))")
+	cc.PersistentFlags().StringVar(&contentTypeValue, "content-type", "", "content type of the value (default no change)")
+	cc.PersistentFlags().StringVar(&encodingValue, "encoding", "", "encoding of the value (default no change)")
 	return &cc
 }
 
 func GetCmdDelete(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cc := cobra.Command{
 		Use:   "delete [UUID] [key]",
 		Short: "delete an existing entry in the database",
 		Args:  cobra.ExactArgs(2),
@@ -138,6 +192,35 @@ func GetCmdDelete(cdc *codec.Codec) *cobra.Command {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			msg := types.NewMsgDelete(args[0], args[1], cliCtx.GetFromAddress())
+			msg.Version = versionValue
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().Uint64Var(&versionValue, "version", 0, "fail unless the key is at this version (default 0 (any version))")
+	return &cc
+}
+
+func GetCmdExpireNow(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "expirenow [UUID] [key]",
+		Short: "end the lease of an entry now, refunding the rest of it",
+		Long: `End the lease of an entry at this block, refunding the lease fee of the blocks left. The entry is
+then treated as any expired one: it can still be read, and brought back with renewlease, for the
+expiry_grace_blocks parameter before it is removed, and is handed over to its beneficiary if it has
+one. Auto-renewal of the entry is turned off.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgExpireNow(args[0], args[1], cliCtx.GetFromAddress())
 
 			err := msg.ValidateBasic()
 			if err != nil {
@@ -213,7 +296,8 @@ func GetCmdHas(cdc *codec.Codec) *cobra.Command {
 }
 
 func GetCmdRename(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	var overwrite bool
+	cc := cobra.Command{
 		Use:   "rename [UUID] [key] [new key]",
 		Short: "rename an existing entry in the database",
 		Args:  cobra.ExactArgs(3),
@@ -222,6 +306,7 @@ func GetCmdRename(cdc *codec.Codec) *cobra.Command {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			msg := types.NewMsgRename(args[0], args[1], args[2], cliCtx.GetFromAddress())
+			msg.Overwrite = overwrite
 
 			err := msg.ValidateBasic()
 			if err != nil {
@@ -231,6 +316,8 @@ func GetCmdRename(cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cc.Flags().BoolVar(&overwrite, "overwrite", false, "replace the new key if it already exists")
+	return &cc
 }
 
 func GetCmdCount(cdc *codec.Codec) *cobra.Command {
@@ -276,7 +363,8 @@ func GetCmdDeleteAll(cdc *codec.Codec) *cobra.Command {
 }
 
 func GetCmdMultiUpdate(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	var partial bool
+	cc := cobra.Command{
 		Use:   "multiupdate [UUID] [key] [value] <key> <value> ...",
 		Short: "update existing entries in the database",
 		Args:  cobra.MinimumNArgs(3),
@@ -290,9 +378,14 @@ func GetCmdMultiUpdate(cdc *codec.Codec) *cobra.Command {
 
 			if (argsLen % 2) == 0 {
 				msg := types.NewMsgMultiUpdate(args[0], cliCtx.GetFromAddress(), nil)
+				msg.Partial = partial
 
 				for i := 1; i < argsLen; i += 2 {
-					msg.KeyValues = append(msg.KeyValues, types.KeyValue{Key: args[i], Value: args[i+1]})
+					value, err := parseValue(args[i+1])
+					if err != nil {
+						return err
+					}
+					msg.KeyValues = append(msg.KeyValues, types.KeyValue{Key: args[i], Value: value})
 				}
 
 				err := msg.ValidateBasic()
@@ -306,6 +399,47 @@ func GetCmdMultiUpdate(cdc *codec.Codec) *cobra.Command {
 			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "incorrect number of k/v arguments")
 		},
 	}
+
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the values are base64 encoded binary")
+	cc.PersistentFlags().BoolVar(&partial, "partial", false, "update the keys that can be, rather than none when one cannot")
+	return &cc
+}
+
+func GetCmdAtomic(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "atomic [file]",
+		Short: "apply the creates, updates and deletes listed in a JSON file all at once",
+		Long: `Apply the creates, updates and deletes listed in a JSON file all at once, in one transaction.
+
+The file holds an array of {"op": "create"|"update"|"delete", "uuid": ..., "key": ..., "value": ...,
+"lease": ..., "version": ...} objects with base64 values; the operations may span any number of UUIDs.
+They are applied in order and if any of them fails none of them is.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var ops []types.AtomicOp
+			if err := json.Unmarshal(data, &ops); err != nil {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("could not read %s: %s", args[0], err))
+			}
+
+			msg := types.NewMsgAtomic(ops, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
 }
 
 func GetCmdGetLease(cdc *codec.Codec) *cobra.Command {
@@ -383,7 +517,7 @@ func GetCmdRenewLease(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
-	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default 172800 (10 days))")
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
 	return &cc
 }
 
@@ -412,6 +546,579 @@ func GetCmdRenewLeaseAll(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
-	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default 172800 (10 days))")
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	return &cc
+}
+
+func GetCmdCopy(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "copy [UUID] [key] [new UUID] [new key]",
+		Short: "copy an existing entry to a new key owned by the sender",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgCopy(args[0], args[1], args[2], args[3], leaseValue, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	return &cc
+}
+
+func GetCmdCopyUUID(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "copyuuid [UUID] [new UUID]",
+		Short: "copy all entries of a UUID into a new UUID owned by the sender",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgCopyUUID(args[0], args[1], leaseValue, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	return &cc
+}
+
+func GetCmdPatch(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "patch [UUID] [key] [JSON merge patch]",
+		Short: "apply a JSON merge patch (RFC 7386) to an existing JSON entry in the database",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgPatch(args[0], args[1], args[2], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetBeneficiary(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setbeneficiary [UUID] [key] [beneficiary]",
+		Short: "hand a key over to beneficiary when its lease runs out, without a beneficiary the key's beneficiary is removed",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			var beneficiary sdk.AccAddress
+			if len(args) > 2 {
+				addr, err := sdk.AccAddressFromBech32(args[2])
+				if err != nil {
+					return err
+				}
+				beneficiary = addr
+			}
+			msg := types.NewMsgSetBeneficiary(args[0], args[1], beneficiary, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdDepositEscrow(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "depositescrow [amount]",
+		Short: "deposit amount (e.g. 1000ubnt) to pay for the auto-renewed leases of your keys",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseCoins(args[0])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgDepositEscrow(amount, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdWithdrawEscrow(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdrawescrow [amount]",
+		Short: "take amount (e.g. 1000ubnt) back out of your escrow, up to what it holds",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseCoins(args[0])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgWithdrawEscrow(amount, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetAutoRenew(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setautorenew [UUID] [key] [true|false]",
+		Short: "renew the lease of a key from your escrow when it runs out, or with false let it expire",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			autoRenew, err := strconv.ParseBool(args[2])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgSetAutoRenew(args[0], args[1], autoRenew, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetIndex(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "setindex [UUID]",
+		Short: "index the values of a UUID so its keys can be found by value",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgSetIndex(args[0], indexField, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().StringVar(&indexField, "field", "", "JSON field to index, nested fields separated by '.' (default whole value)")
 	return &cc
 }
+
+func GetCmdDeleteIndex(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deleteindex [UUID]",
+		Short: "remove the value index of a UUID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgDeleteIndex(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetHashIndex(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sethashindex [UUID]",
+		Short: "index the value hashes of a UUID so its keys can be found by hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgSetHashIndex(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdDeleteHashIndex(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deletehashindex [UUID]",
+		Short: "remove the hash index of a UUID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgDeleteHashIndex(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetAudit(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setaudit [UUID]",
+		Short: "start recording every change to the keys of a UUID in its audit log",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgSetAudit(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdDeleteAudit(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deleteaudit [UUID]",
+		Short: "stop recording changes to a UUID, keeping its audit log until it is pruned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgDeleteAudit(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetRetention(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setretention [UUID] [max keys] [fifo|lru]",
+		Short: "keep at most max keys of yours in a UUID, evicting the oldest by creation (fifo) or last change (lru)",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			maxKeys, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetRetention(args[0], maxKeys, args[2], cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdDeleteRetention(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deleteretention [UUID]",
+		Short: "remove the retention policy of a UUID, no longer evicting its keys",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgDeleteRetention(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdSetSchema(cdc *codec.Codec) *cobra.Command {
+	var maxValueSize uint64
+	var jsonSchemaFile string
+	cc := cobra.Command{
+		Use:   "setschema [UUID]",
+		Short: "reject the values written to a UUID that are too large, have another content type or do not match a JSON Schema",
+		Long: `Set the schema the values later written to a UUID are checked against. Creates, updates and other
+writes of a value that is larger than --max-value-size, is written with a content type other than
+--content-type or is not a JSON document matching the JSON Schema in the --json-schema file fail.
+
+The JSON Schema may use the type, enum, const, properties, required, additionalProperties, items,
+minItems, maxItems, minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum and
+exclusiveMaximum keywords. Only the account that set the schema can change or delete it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			var jsonSchema []byte
+			if len(jsonSchemaFile) != 0 {
+				var err error
+				if jsonSchema, err = ioutil.ReadFile(jsonSchemaFile); err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSetSchema(args[0], maxValueSize, contentTypeValue, string(jsonSchema), cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cc.Flags().Uint64Var(&maxValueSize, "max-value-size", 0, "largest value in bytes (default 0 (any size))")
+	cc.Flags().StringVar(&contentTypeValue, "content-type", "", "content type the values must be written with (default any)")
+	cc.Flags().StringVar(&jsonSchemaFile, "json-schema", "", "file with the JSON Schema the values must match (default none)")
+	return &cc
+}
+
+func GetCmdDeleteSchema(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deleteschema [UUID]",
+		Short: "remove the schema of a UUID, no longer checking the values written to it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgDeleteSchema(args[0], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdFreeze(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "freeze [UUID] [key]",
+		Short: "lock a key, or without one all of your keys in a UUID, against update, rename and delete",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			msg := types.NewMsgFreeze(args[0], key, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdUnfreeze(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unfreeze [UUID] [key]",
+		Short: "lift a freeze set with the same UUID and key",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			msg := types.NewMsgUnfreeze(args[0], key, cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdStartUpload(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "startupload [UUID] [key] [size] [SHA-256 hex]",
+		Short: "start a chunked upload of a value too large for a single transaction",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			size, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgStartUpload(args[0], args[1], size, args[3], leaseValue, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().Int64Var(&leaseValue, "lease", 0, "lease in blocks (default: the network default lease, see default-lease)")
+	return &cc
+}
+
+func GetCmdUploadChunk(cdc *codec.Codec) *cobra.Command {
+	cc := cobra.Command{
+		Use:   "uploadchunk [UUID] [key] [index] [data]",
+		Short: "append the next chunk to a pending upload, chunks are numbered from 0",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			index, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			data, err := parseValue(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUploadChunk(args[0], args[1], index, data, cliCtx.GetFromAddress())
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cc.PersistentFlags().BoolVar(&base64Value, "base64", false, "the chunk is base64 encoded binary")
+	return &cc
+}
+
+func GetCmdCommitUpload(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commitupload [UUID] [key]",
+		Short: "verify a completed upload and store it as a new key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgCommitUpload(args[0], args[1], cliCtx.GetFromAddress())
+
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// parseValue returns the bytes of a value given on the command line, decoding it when
+// --base64 is set so that binary values need not be valid text.
+func parseValue(arg string) ([]byte, error) {
+	if !base64Value {
+		return []byte(arg), nil
+	}
+
+	value, err := base64.StdEncoding.DecodeString(arg)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("invalid base64 value: %s", err))
+	}
+	return value, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package rest
+
+import (
+	gocontext "context"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/websocket"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"net/http"
+	"strings"
+)
+
+// ChangeNotification is sent to change feed subscribers for every crud_change event
+// that matches their subscription.
+type ChangeNotification struct {
+	UUID   string `json:"uuid"`
+	Key    string `json:"key"`
+	Action string `json:"action"`
+	Hash   string `json:"hash"`
+	Height int64  `json:"height,string"`
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// BlzSubscribeHandler upgrades the request to a websocket that receives a JSON
+// ChangeNotification for every change to the keys of the uuid parameter starting with
+// the (optional) prefix parameter, including keys removed when their lease runs out.
+func BlzSubscribeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		UUID := r.URL.Query().Get("uuid")
+		prefix := r.URL.Query().Get("prefix")
+		if len(UUID) == 0 || strings.ContainsAny(UUID, `'\`) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "invalid uuid")
+			return
+		}
+
+		client, err := rpchttp.New(cliCtx.NodeURI, "/websocket")
+		if err == nil {
+			err = client.Start()
+		}
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		defer client.Stop()
+
+		ctx, cancel := gocontext.WithCancel(r.Context())
+		defer cancel()
+
+		// changes made by transactions are Tx events, expired leases are removed in
+		// EndBlock and so show up on the NewBlock event
+		subscriber := "crud-feed-" + r.RemoteAddr
+		filter := fmt.Sprintf("%s.%s='%s'", types.EventTypeChange, types.AttributeKeyUUID, UUID)
+		txs, err := client.Subscribe(ctx, subscriber, fmt.Sprintf("tm.event='Tx' AND %s", filter))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		blocks, err := client.Subscribe(ctx, subscriber, fmt.Sprintf("tm.event='NewBlock' AND %s", filter))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// nothing is expected from the client, reading only notices it going away
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					cancel()
+					return
+				}
+			}
+		}()
+
+		for {
+			var event ctypes.ResultEvent
+			select {
+			case <-ctx.Done():
+				return
+			case event = <-txs:
+			case event = <-blocks:
+			}
+
+			for _, change := range changesFromEvent(event, UUID, prefix) {
+				if err := conn.WriteJSON(change); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// changesFromEvent picks the crud_change events of UUID and prefix out of event. The
+// attributes of all the crud_change events are listed together, in emission order.
+func changesFromEvent(event ctypes.ResultEvent, UUID string, prefix string) []ChangeNotification {
+	attribute := func(name string) []string {
+		return event.Events[types.EventTypeChange+"."+name]
+	}
+	uuids, keys, actions, hashes := attribute(types.AttributeKeyUUID), attribute(types.AttributeKeyKey),
+		attribute(types.AttributeKeyAction), attribute(types.AttributeKeyHash)
+
+	var height int64
+	switch data := event.Data.(type) {
+	case tmtypes.EventDataTx:
+		height = data.Height
+	case tmtypes.EventDataNewBlock:
+		height = data.Block.Height
+	}
+
+	var changes []ChangeNotification
+	for i := range uuids {
+		if i >= len(keys) || i >= len(actions) || i >= len(hashes) {
+			break
+		}
+		if uuids[i] == UUID && strings.HasPrefix(keys[i], prefix) {
+			changes = append(changes, ChangeNotification{UUID: uuids[i], Key: keys[i], Action: actions[i], Hash: hashes[i], Height: height})
+		}
+	}
+	return changes
+}
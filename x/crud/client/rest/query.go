@@ -16,12 +16,14 @@ package rest
 
 import (
 	"fmt"
-	"github.com/bluzelle/curium/x/crud/internal/keeper"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
 	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/gorilla/mux"
 	"net/http"
+	"strconv"
 )
 
 func BlzQReadHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
@@ -37,6 +39,51 @@ func BlzQReadHandler(cliCtx context.CLIContext, storeName string) http.HandlerFu
 	}
 }
 
+// BlzQRawHandler serves the bytes of a value as they are, with its content type and
+// encoding as the Content-Type and Content-Encoding headers, for gateways that pass
+// stored data on to browsers and other HTTP clients.
+func BlzQRawHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/read/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		var value types.QueryResultRead
+		if err := cliCtx.Codec.UnmarshalJSON(res, &value); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		contentType := value.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+		if value.Encoding != "" {
+			w.Header().Set("Content-Encoding", value.Encoding)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(value.Value)))
+		_, _ = w.Write(value.Value)
+	}
+}
+
+func BlzQReadMetaHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/readmeta/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func BlzQProvenReadHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -53,17 +100,36 @@ func BlzQProvenReadHandler(cliCtx context.CLIContext, storeName string) http.Han
 		}
 
 		value := types.BLZValue{}.Unmarshal(res)
-		resp := types.QueryResultRead{UUID: vars["UUID"], Key: vars["key"], Value: value.Value}
+		resp := types.QueryResultRead{UUID: vars["UUID"], Key: vars["key"], Value: value.Value, Version: value.Version,
+			ContentType: value.ContentType, Encoding: value.Encoding}
 
 		rest.PostProcessResponse(w, cliCtx, resp)
 	}
 }
 
+func BlzQOwnerHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/owner/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func BlzQHasHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 
-		res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/has/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/has/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
 
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
@@ -73,7 +139,11 @@ func BlzQKeysHandler(cliCtx context.CLIContext, storeName string) http.HandlerFu
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 
-		res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keys/%s", storeName, vars["UUID"]), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keys/%s", storeName, vars["UUID"]), []byte(r.URL.Query().Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
 
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
@@ -83,7 +153,11 @@ func BlzQKeyValuesHandler(cliCtx context.CLIContext, storeName string) http.Hand
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 
-		res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvalues/%s", storeName, vars["UUID"]), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvalues/%s", storeName, vars["UUID"]), []byte(r.URL.Query().Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
 
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
@@ -93,7 +167,11 @@ func BlzQCountHandler(cliCtx context.CLIContext, storeName string) http.HandlerF
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 
-		res, _, _ := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/count/%s", storeName, vars["UUID"]), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/count/%s", storeName, vars["UUID"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
 
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
@@ -112,11 +190,27 @@ func BlzQGetLeaseHandler(cliCtx context.CLIContext, storeName string) http.Handl
 	}
 }
 
+// the start, the next of the previous page, is passed in the optional "start" query
+// parameter
 func BlzQGetNShortestLeasesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getnshortestleases/%s/%s", storeName, vars["UUID"], vars["N"]), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getnshortestleases/%s/%s", storeName, vars["UUID"], vars["N"]), []byte(r.URL.Query().Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the value to find is passed in the "value" query parameter
+func BlzQFindHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/find/%s", storeName, vars["UUID"]), []byte(r.URL.Query().Get("value")))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -124,3 +218,385 @@ func BlzQGetNShortestLeasesHandler(cliCtx context.CLIContext, storeName string)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func BlzQFindByHashHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/findbyhash/%s/%s", storeName, vars["UUID"], vars["hash"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQGetMetadataHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getmetadata/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQGetHashHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gethash/%s/%s", storeName, vars["UUID"], vars["key"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQKeyValuesPageHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keyvaluespage/%s/%s", storeName, vars["UUID"], query.Get("owner")), []byte(query.Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the start and limit are passed in the URL query
+func BlzQGetLeaseAllHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		limit := uint64(100)
+		if err := parseUintParam(r, "limit", &limit); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getleaseall/%s/%s/%d", storeName, vars["UUID"], vars["owner"], limit), []byte(r.URL.Query().Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the owner, start and limit are passed in the URL query, every owner's keys being
+// listed without an owner
+func BlzQKeysByExpiryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+
+		limit := uint64(100)
+		if err := parseUintParam(r, "limit", &limit); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keysbyexpiry/%s/%s/%d", storeName, vars["UUID"], query.Get("owner"), limit), []byte(query.Get("start")))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the pattern, owner and start are passed in the URL query, every owner's keys being
+// matched without an owner
+func BlzQMatchHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := r.URL.Query()
+
+		params := types.QueryMatchParams{Pattern: query.Get("pattern"), Start: query.Get("start")}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/match/%s/%s", storeName, vars["UUID"], query.Get("owner")), cliCtx.Codec.MustMarshalJSON(params))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the audit log is paged with the start and limit query parameters, start being the
+// sequence number of the first entry wanted
+func BlzQAuditLogHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		start, limit := uint64(1), uint64(100)
+		if err := parseUintParam(r, "start", &start); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := parseUintParam(r, "limit", &limit); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auditlog/%s/%d/%d", storeName, vars["UUID"], start, limit), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the rent history is paged with the start and limit query parameters, start being the
+// height of the first epoch wanted, negative for the epochs imported from an exported chain
+func BlzQRentHistoryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		start, limit := int64(0), uint64(100)
+		if err := parseIntParam(r, "start", &start); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := parseUintParam(r, "limit", &limit); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/renthistory/%s/%d/%d", storeName, vars["owner"], start, limit), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQMyUUIDsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/myuuids/%s", storeName, vars["owner"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQEscrowHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/escrow/%s", storeName, vars["owner"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQAccountUsageHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/accountusage/%s", storeName, vars["owner"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQUUIDStatsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/uuidstats/%s", storeName, vars["UUID"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQSchemaHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/schema/%s", storeName, vars["UUID"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQCountAllHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/countall/%s", storeName, vars["owner"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQKeysAllHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/keysall/%s", storeName, vars["owner"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQGCStatusHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/gcstatus", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQDefaultLeaseHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/defaultlease", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func BlzQLeasePriceHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/leaseprice", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// the parameters are passed in the URL query: operation, uuid, key, size, lease and gas_prices
+func BlzQEstimateLeaseHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		params := types.QueryEstimateLeaseParams{Operation: query.Get("operation"), UUID: query.Get("uuid"), Key: query.Get("key")}
+		if len(params.Operation) == 0 {
+			params.Operation = types.EstimateCreate
+		}
+
+		var lease uint64
+		if err := parseUintParam(r, "size", &params.Size); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := parseUintParam(r, "lease", &lease); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params.Lease = int64(lease)
+
+		gasPrices, err := sdk.ParseDecCoins(query.Get("gas_prices"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params.GasPrices = gasPrices
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/estimatelease", storeName), cliCtx.Codec.MustMarshalJSON(params))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+type simulateReq struct {
+	Msg       sdk.Msg
+	GasPrices string
+}
+
+// the message is posted rather than passed in the URL, in the amino JSON of a
+// transaction's messages
+func BlzQSimulateHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req simulateReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		gasPrices, err := sdk.ParseDecCoins(req.GasPrices)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.QuerySimulateParams{Msg: req.Msg, GasPrices: gasPrices}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/simulate", storeName), cliCtx.Codec.MustMarshalJSON(params))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// parseUintParam leaves value unchanged if the URL has no such parameter
+func parseUintParam(r *http.Request, name string, value *uint64) error {
+	param := r.URL.Query().Get(name)
+	if len(param) == 0 {
+		return nil
+	}
+
+	parsed, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", name, err)
+	}
+	*value = parsed
+	return nil
+}
+
+func parseIntParam(r *http.Request, name string, value *int64) error {
+	param := r.URL.Query().Get(name)
+	if len(param) == 0 {
+		return nil
+	}
+
+	parsed, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", name, err)
+	}
+	*value = parsed
+	return nil
+}
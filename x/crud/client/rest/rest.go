@@ -22,27 +22,77 @@ import (
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
+	r.HandleFunc(fmt.Sprintf("/%s/accountusage/{owner}", storeName), BlzQAccountUsageHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/atomic", storeName), BlzAtomicHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auditlog/{UUID}", storeName), BlzQAuditLogHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/commitupload", storeName), BlzCommitUploadHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/copy", storeName), BlzCopyHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/copyuuid", storeName), BlzCopyUUIDHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/count", storeName), BlzCountHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/count/{UUID}", storeName), BlzQCountHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/countall/{owner}", storeName), BlzQCountAllHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/create", storeName), BlzCreateHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/defaultlease", storeName), BlzQDefaultLeaseHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/delete", storeName), BlzDeleteHandler(cliCtx)).Methods("DELETE")
 	r.HandleFunc(fmt.Sprintf("/%s/deleteall", storeName), BlzDeleteAllHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deleteaudit", storeName), BlzDeleteAuditHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deletehashindex", storeName), BlzDeleteHashIndexHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deleteindex", storeName), BlzDeleteIndexHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deleteretention", storeName), BlzDeleteRetentionHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deleteschema", storeName), BlzDeleteSchemaHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/estimatelease", storeName), BlzQEstimateLeaseHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/expirenow", storeName), BlzExpireNowHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/find/{UUID}", storeName), BlzQFindHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/findbyhash/{UUID}/{hash}", storeName), BlzQFindByHashHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/freeze", storeName), BlzFreezeHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/gcstatus", storeName), BlzQGCStatusHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/gethash/{UUID}/{key}", storeName), BlzQGetHashHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/getlease", storeName), BlzGetLeaseHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/getlease/{UUID}/{key}", storeName), BlzQGetLeaseHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/depositescrow", storeName), BlzDepositEscrowHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/withdrawescrow", storeName), BlzWithdrawEscrowHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/escrow/{owner}", storeName), BlzQEscrowHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/getleaseall/{UUID}/{owner}", storeName), BlzQGetLeaseAllHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/getmetadata/{UUID}/{key}", storeName), BlzQGetMetadataHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/getnshortestleases", storeName), BlzGetNShortestLeasesHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/getnshortestleases/{UUID}/{N}", storeName), BlzQGetNShortestLeasesHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/has", storeName), BlzHasHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/has/{UUID}/{key}", storeName), BlzQHasHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/keys", storeName), BlzKeysHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/keys/{UUID}", storeName), BlzQKeysHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/keysall/{owner}", storeName), BlzQKeysAllHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/keysbyexpiry/{UUID}", storeName), BlzQKeysByExpiryHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/keyvalues", storeName), BlzKeyValuesHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/keyvalues/{UUID}", storeName), BlzQKeyValuesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/keyvaluespage/{UUID}", storeName), BlzQKeyValuesPageHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/leaseprice", storeName), BlzQLeasePriceHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/match/{UUID}", storeName), BlzQMatchHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/multiupdate", storeName), BlzMultiUpdateHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/myuuids/{owner}", storeName), BlzQMyUUIDsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/owner/{UUID}/{key}", storeName), BlzQOwnerHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/patch", storeName), BlzPatchHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/pread/{UUID}/{key}", storeName), BlzQProvenReadHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/raw/{UUID}/{key}", storeName), BlzQRawHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/read", storeName), BlzReadHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/read/{UUID}/{key}", storeName), BlzQReadHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/readmeta/{UUID}/{key}", storeName), BlzQReadMetaHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/rename", storeName), BlzRenameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/renthistory/{owner}", storeName), BlzQRentHistoryHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/schema/{UUID}", storeName), BlzQSchemaHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/setaudit", storeName), BlzSetAuditHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/setautorenew", storeName), BlzSetAutoRenewHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/setbeneficiary", storeName), BlzSetBeneficiaryHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/sethashindex", storeName), BlzSetHashIndexHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/setindex", storeName), BlzSetIndexHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/setretention", storeName), BlzSetRetentionHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/setschema", storeName), BlzSetSchemaHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/simulate", storeName), BlzQSimulateHandler(cliCtx, storeName)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/startupload", storeName), BlzStartUploadHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/subscribe", storeName), BlzSubscribeHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/unfreeze", storeName), BlzUnfreezeHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/update", storeName), BlzUpdateHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/uploadchunk", storeName), BlzUploadChunkHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/uuidstats/{UUID}", storeName), BlzQUUIDStatsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/renewlease", storeName), BlzRenewLease(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/renewleaseall", storeName), BlzRenewLeaseAll(cliCtx)).Methods("POST")
 }
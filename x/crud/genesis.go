@@ -16,24 +16,119 @@ package crud
 
 import (
 	"fmt"
-	"github.com/bluzelle/curium/x/crud/internal/keeper"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-type GenesisState struct {
-	BlzValues []types.BLZValue
-}
-
-func NewGenesisState(_ []types.BLZValue) GenesisState {
-	return GenesisState{BlzValues: nil}
+func NewGenesisState(values []types.GenesisValue) GenesisState {
+	return GenesisState{BlzValues: values, Params: types.DefaultParams()}
 }
 
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
 	for _, record := range data.BlzValues {
-		if record.Owner == nil {
-			return fmt.Errorf("invalid BlzValue: Value: %s. Error: Missing Owner", record.Value)
+		if record.Value.Owner == nil {
+			return fmt.Errorf("invalid BlzValue: Value: %s. Error: Missing Owner", record.Value.Value)
+		}
+		if len(record.UUID) == 0 || len(record.Key) == 0 {
+			return fmt.Errorf("invalid BlzValue: Value: %s. Error: Missing UUID or Key", record.Value.Value)
+		}
+		if record.Value.Lease <= 0 || record.Value.Height > 0 {
+			return fmt.Errorf("invalid BlzValue: Value: %s. Error: Invalid Lease", record.Value.Value)
+		}
+
+		metaKey := keeper.MakeMetaKey(record.UUID, record.Key)
+		if seen[metaKey] {
+			return fmt.Errorf("invalid BlzValue: UUID: %s, Key: %s. Error: Duplicate Key", record.UUID, record.Key)
+		}
+		seen[metaKey] = true
+	}
+
+	for _, index := range data.Indexes {
+		if len(index.UUID) == 0 || index.Config.Owner.Empty() {
+			return fmt.Errorf("invalid Index: UUID: %s. Error: Missing UUID or Owner", index.UUID)
+		}
+	}
+
+	for _, freeze := range data.Frozen {
+		if len(freeze.UUID) == 0 || freeze.Owner.Empty() {
+			return fmt.Errorf("invalid Freeze: UUID: %s, Key: %s. Error: Missing UUID or Owner", freeze.UUID, freeze.Key)
+		}
+	}
+
+	for _, record := range data.Beneficiaries {
+		if !seen[keeper.MakeMetaKey(record.UUID, record.Key)] || record.Beneficiary.Address.Empty() {
+			return fmt.Errorf("invalid Beneficiary: UUID: %s, Key: %s. Error: Missing Key or Address", record.UUID, record.Key)
+		}
+	}
+
+	for _, escrow := range data.Escrows {
+		if escrow.Owner.Empty() || !escrow.Balance.IsValid() {
+			return fmt.Errorf("invalid Escrow: Owner: %s, Balance: %s. Error: Missing Owner or Invalid Balance", escrow.Owner, escrow.Balance)
+		}
+	}
+
+	for _, record := range data.AutoRenew {
+		if !seen[keeper.MakeMetaKey(record.UUID, record.Key)] {
+			return fmt.Errorf("invalid AutoRenew: UUID: %s, Key: %s. Error: Missing Key", record.UUID, record.Key)
+		}
+	}
+
+	for _, record := range data.LeaseDeposits {
+		if !seen[keeper.MakeMetaKey(record.UUID, record.Key)] || !record.Deposit.Amount.IsValid() || record.Deposit.To < record.Deposit.From {
+			return fmt.Errorf("invalid LeaseDeposit: UUID: %s, Key: %s. Error: Missing Key or Invalid Deposit", record.UUID, record.Key)
+		}
+	}
+
+	for _, audit := range data.Audits {
+		if len(audit.UUID) == 0 || audit.Config.Owner.Empty() {
+			return fmt.Errorf("invalid Audit: UUID: %s. Error: Missing UUID or Owner", audit.UUID)
+		}
+	}
+
+	for _, entry := range data.AuditLog {
+		if len(entry.UUID) == 0 || entry.Seq == 0 {
+			return fmt.Errorf("invalid AuditEntry: UUID: %s, Seq: %d. Error: Missing UUID or Seq", entry.UUID, entry.Seq)
+		}
+	}
+
+	for _, index := range data.HashIndexes {
+		if len(index.UUID) == 0 || index.Config.Owner.Empty() {
+			return fmt.Errorf("invalid HashIndex: UUID: %s. Error: Missing UUID or Owner", index.UUID)
+		}
+	}
+
+	for _, record := range data.RentHistory {
+		if record.Owner.Empty() || !record.Record.Paid.IsValid() || !record.Record.Refunded.IsValid() {
+			return fmt.Errorf("invalid RentRecord: Owner: %s, StartHeight: %d. Error: Missing Owner or Invalid Coins", record.Owner, record.Record.StartHeight)
+		}
+	}
+
+	for _, retention := range data.Retention {
+		policy := retention.Policy
+		if len(retention.UUID) == 0 || policy.Owner.Empty() || policy.MaxKeys == 0 || !types.IsValidRetentionOrder(policy.Order) {
+			return fmt.Errorf("invalid Retention: UUID: %s. Error: Missing UUID or Owner, or Invalid Policy", retention.UUID)
+		}
+	}
+
+	for _, record := range data.Uploads {
+		if len(record.UUID) == 0 || len(record.Key) == 0 || record.Upload.Owner.Empty() || record.Upload.Chunks != uint64(len(record.Chunks)) {
+			return fmt.Errorf("invalid Upload: UUID: %s, Key: %s. Error: Missing UUID, Key or Owner, or Invalid Chunks", record.UUID, record.Key)
+		}
+	}
+
+	for _, schema := range data.Schemas {
+		if len(schema.UUID) == 0 || schema.Schema.Owner.Empty() {
+			return fmt.Errorf("invalid Schema: UUID: %s. Error: Missing UUID or Owner", schema.UUID)
+		}
+		if err := schema.Schema.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid Schema: UUID: %s. Error: %s", schema.UUID, err)
 		}
 	}
 	return nil
@@ -42,24 +137,158 @@ func ValidateGenesis(data GenesisState) error {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		BlzValues: nil,
+		Params:    types.DefaultParams(),
 	}
 }
 
+// InitGenesis restores the exported keys with their lease heights counting from the
+// current height, so each key keeps the number of blocks it had left at export and a
+// key exported in its expiry grace period is still expired. Lease deposits, rent epochs
+// and pending uploads are moved along with them.
 func InitGenesis(ctx sdk.Context, keeper keeper.IKeeper, data GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
+	keeper.SetStoreVersion(ctx, ConsensusVersion)
+
+	store := keeper.GetKVStore(ctx)
+	leaseStore := keeper.GetLeaseStore(ctx)
+	purgeFrom := ctx.BlockHeight() + 1
 	for _, record := range data.BlzValues {
-		keeper.SetValue(ctx, keeper.GetKVStore(ctx), "UUID-Genesis", "Key-Genesis", record)
+		value := record.Value
+		value.Height += ctx.BlockHeight()
+		keeper.SetValue(ctx, store, record.UUID, record.Key, value)
+		keeper.SetLease(ctx, leaseStore, record.UUID, record.Key, value.Height, value.Lease)
+		if expiry := value.Height + value.Lease; expiry < purgeFrom {
+			purgeFrom = expiry
+		}
+	}
+
+	// the leases of expired keys ran out at or before this height, which the purge
+	// would not otherwise go back to
+	if purgeFrom <= ctx.BlockHeight() {
+		keeper.ImportPurgedHeight(ctx, purgeFrom-1)
+	}
+
+	for _, index := range data.Indexes {
+		keeper.SetIndexConfig(ctx, store, index.UUID, index.Config)
+	}
+
+	for _, index := range data.HashIndexes {
+		keeper.SetHashIndexConfig(ctx, store, index.UUID, index.Config)
+	}
+
+	for _, freeze := range data.Frozen {
+		keeper.Freeze(ctx, freeze.Owner, freeze.UUID, freeze.Key)
+	}
+
+	for _, record := range data.Beneficiaries {
+		keeper.ImportBeneficiary(ctx, record.UUID, record.Key, record.Beneficiary)
+	}
+
+	for _, escrow := range data.Escrows {
+		keeper.ImportEscrow(ctx, escrow.Owner, escrow.Balance)
+	}
+
+	for _, record := range data.AutoRenew {
+		keeper.SetAutoRenew(ctx, record.UUID, record.Key, true)
+	}
+
+	for _, record := range data.LeaseDeposits {
+		deposit := record.Deposit
+		deposit.From += ctx.BlockHeight()
+		deposit.To += ctx.BlockHeight()
+		keeper.ImportLeaseDeposit(ctx, record.UUID, record.Key, deposit)
+	}
+
+	// imported after the values, which are not changes to record
+	for _, audit := range data.Audits {
+		keeper.SetAuditConfig(ctx, audit.UUID, audit.Config)
+	}
+
+	for _, entry := range data.AuditLog {
+		keeper.ImportAuditEntry(ctx, entry)
+	}
+
+	for _, retention := range data.Retention {
+		keeper.SetRetentionPolicy(ctx, store, retention.UUID, retention.Policy)
+	}
+
+	for _, record := range data.RentHistory {
+		rent := record.Record
+		rent.StartHeight += ctx.BlockHeight()
+		keeper.ImportRentRecord(ctx, record.Owner, rent)
+	}
+
+	for _, record := range data.Uploads {
+		record.Upload.Height += ctx.BlockHeight()
+		keeper.ImportUpload(ctx, record)
+	}
+
+	for _, schema := range data.Schemas {
+		keeper.SetSchema(ctx, schema.UUID, schema.Schema)
 	}
 	return []abci.ValidatorUpdate{}
 }
 
-// TODO - fix key value issues
 func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) GenesisState {
-	var records []types.BLZValue
-	iterator := k.GetValuesIterator(ctx, k.GetKVStore(ctx))
+	var records []types.GenesisValue
+	_ = ExportValues(ctx, k, "", func(record types.GenesisValue) error {
+		records = append(records, record)
+		return nil
+	})
+
+	state := ExportState(ctx, k)
+	state.BlzValues = records
+	return state
+}
+
+// ExportValues passes the keys of UUID, or of every UUID when it is empty, to write one
+// at a time as ExportGenesis exports them, stopping at the first error write returns.
+func ExportValues(ctx sdk.Context, k keeper.IKeeper, UUID string, write func(types.GenesisValue) error) error {
+	store := k.GetKVStore(ctx)
+	var iterator sdk.Iterator
+	if len(UUID) == 0 {
+		iterator = k.GetValuesIterator(ctx, store)
+	} else {
+		iterator = sdk.KVStorePrefixIterator(store, []byte(keeper.MakeMetaKey(UUID, "")))
+	}
+	defer iterator.Close()
+
 	for ; iterator.Valid(); iterator.Next() {
-		key := string(iterator.Key())
-		value := k.GetValue(ctx, k.GetKVStore(ctx), "UUID-Genesis", key)
-		records = append(records, value)
+		UUID, key := keeper.SplitMetaKey(string(iterator.Key()))
+		value := k.GetValue(ctx, store, UUID, key)
+
+		// the lease is exported counting from the export height, so a key in its expiry
+		// grace period is exported with its lease already run out
+		if value.Lease == 0 {
+			value.Lease = k.GetDefaultLeaseBlocks(ctx)
+		}
+		value.Height -= ctx.BlockHeight()
+
+		if err := write(types.GenesisValue{UUID: UUID, Key: key, Value: value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportState is ExportGenesis without the keys, see ExportValues.
+func ExportState(ctx sdk.Context, k keeper.IKeeper) GenesisState {
+	deposits := k.GetLeaseDeposits(ctx)
+	for i := range deposits {
+		deposits[i].Deposit.From -= ctx.BlockHeight()
+		deposits[i].Deposit.To -= ctx.BlockHeight()
+	}
+	rents := k.GetRentHistories(ctx)
+	for i := range rents {
+		rents[i].Record.StartHeight -= ctx.BlockHeight()
+	}
+	uploads := k.GetUploads(ctx)
+	for i := range uploads {
+		uploads[i].Upload.Height -= ctx.BlockHeight()
 	}
-	return GenesisState{records}
+	return GenesisState{Indexes: k.GetIndexConfigs(ctx), Frozen: k.GetFrozen(ctx),
+		Beneficiaries: k.GetBeneficiaries(ctx), Escrows: k.GetEscrows(ctx), AutoRenew: k.GetAutoRenewals(ctx),
+		LeaseDeposits: deposits, Audits: k.GetAuditConfigs(ctx), AuditLog: k.GetAuditLogs(ctx),
+		Retention: k.GetRetentionPolicies(ctx), HashIndexes: k.GetHashIndexConfigs(ctx), RentHistory: rents,
+		Schemas: k.GetSchemas(ctx), Uploads: uploads, Params: k.GetParams(ctx)}
 }
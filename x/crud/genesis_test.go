@@ -15,48 +15,399 @@
 package crud
 
 import (
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"errors"
+	"github.com/bluzelle/curium/x/crud/keeper"
 	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 	"testing"
 )
 
 func TestNewGenesisState(t *testing.T) {
 	assert.Empty(t, NewGenesisState(nil).BlzValues)
+	assert.Equal(t, types.DefaultParams(), NewGenesisState(nil).Params)
 }
 
 func TestValidateGenesis(t *testing.T) {
-	var blzValues []types.BLZValue
+	owner := []byte("notnilowner")
 
-	genesisState := NewGenesisState(blzValues)
+	assert.Nil(t, ValidateGenesis(NewGenesisState(nil)))
 
+	genesisState := NewGenesisState([]types.GenesisValue{
+		{UUID: "uuid", Key: "key0", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Owner: owner}},
+		{UUID: "uuid", Key: "key1", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Height: -20, Owner: owner}},
+	})
+	genesisState.Indexes = []types.GenesisIndex{{UUID: "uuid", Config: types.IndexConfig{Owner: owner}}}
 	assert.Nil(t, ValidateGenesis(genesisState))
 
-	blzValues = append(blzValues, types.BLZValue{Value: "test", Owner: []byte("notnilowner")})
+	invalid := []types.GenesisValue{
+		{UUID: "uuid", Key: "key", Value: types.BLZValue{Value: []byte("test"), Lease: 10}},
+		{UUID: "", Key: "key", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Owner: owner}},
+		{UUID: "uuid", Key: "", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Owner: owner}},
+		{UUID: "uuid", Key: "key", Value: types.BLZValue{Value: []byte("test"), Owner: owner}},
+		{UUID: "uuid", Key: "key", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Height: 1, Owner: owner}},
+		{UUID: "uuid", Key: "key0", Value: types.BLZValue{Value: []byte("test"), Lease: 10, Owner: owner}},
+	}
+	for i := range invalid {
+		assert.NotNil(t, ValidateGenesis(NewGenesisState(append(genesisState.BlzValues[:2:2], invalid[i]))))
+	}
 
+	genesisState.Indexes = []types.GenesisIndex{{UUID: "uuid"}}
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Indexes = nil
+	genesisState.Frozen = []types.GenesisFreeze{{UUID: "uuid", Owner: owner}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Frozen = []types.GenesisFreeze{{UUID: "uuid", Key: "key0"}}
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Frozen = nil
+	genesisState.Beneficiaries = []types.GenesisBeneficiary{{UUID: "uuid", Key: "key0", Beneficiary: types.Beneficiary{Address: owner}}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	// the key must be in the genesis
+	genesisState.Beneficiaries[0].Key = "key2"
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Beneficiaries = nil
+	genesisState.Escrows = []types.GenesisEscrow{{Owner: owner, Balance: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10))}}
+	genesisState.AutoRenew = []types.GenesisAutoRenew{{UUID: "uuid", Key: "key1"}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.AutoRenew[0].Key = "key2"
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.AutoRenew = nil
+	genesisState.Escrows[0].Owner = nil
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Escrows = nil
+	genesisState.LeaseDeposits = []types.GenesisLeaseDeposit{{UUID: "uuid", Key: "key0", Deposit: types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), From: -10, To: 90}}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.LeaseDeposits[0].Deposit.To = -20
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.LeaseDeposits[0].Deposit.To = 90
+	genesisState.LeaseDeposits[0].Key = "key2"
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.LeaseDeposits = nil
+	genesisState.Audits = []types.GenesisAudit{{UUID: "uuid", Config: types.AuditConfig{Owner: owner}}}
+	genesisState.AuditLog = []types.AuditEntry{{UUID: "uuid", Seq: 1, Op: types.AuditOpCreate, Key: "key0"}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.AuditLog[0].Seq = 0
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.AuditLog = nil
+	genesisState.Audits[0].Config.Owner = nil
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Audits = nil
+	genesisState.Retention = []types.GenesisRetention{{UUID: "uuid", Policy: types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderFIFO}}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Retention[0].Policy.Order = "newest"
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Retention[0].Policy.Order = types.RetentionOrderLRU
+	genesisState.Retention[0].Policy.MaxKeys = 0
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Retention = nil
+	genesisState.HashIndexes = []types.GenesisHashIndex{{UUID: "uuid", Config: types.HashIndexConfig{Owner: owner}}}
+	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.HashIndexes[0].UUID = ""
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.HashIndexes = nil
+	genesisState.RentHistory = []types.GenesisRentRecord{{Owner: owner, Record: types.RentRecord{StartHeight: 100, Charges: 1, Paid: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1))}}}
 	assert.Nil(t, ValidateGenesis(genesisState))
 
-	blzValues = append(blzValues, types.BLZValue{Value: "test"})
+	genesisState.RentHistory[0].Record.Refunded = sdk.Coins{{Denom: "ubnt", Amount: sdk.NewInt(-1)}}
+	assert.NotNil(t, ValidateGenesis(genesisState))
 
+	genesisState.RentHistory[0].Record.Refunded = nil
+	genesisState.RentHistory[0].Owner = nil
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.RentHistory = nil
+	genesisState.Schemas = []types.GenesisSchema{{UUID: "uuid", Schema: types.Schema{Owner: owner, JSONSchema: `{"type":"object"}`}}}
 	assert.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Schemas[0].Schema.JSONSchema = `{"type":"text"}`
+	assert.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Schemas[0].Schema.JSONSchema = `{"type":"object"}`
+	genesisState.Schemas[0].Schema.Owner = nil
+	assert.NotNil(t, ValidateGenesis(genesisState))
 }
 
 func TestInitGenesis(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
 	data := DefaultGenesisState()
-	ctx := sdk.Context{}
+	ctx := sdk.Context{}.WithBlockHeight(5)
 	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
-	data.BlzValues = append(data.BlzValues, types.BLZValue{Value: "test", Owner: owner})
+	data.BlzValues = append(data.BlzValues, types.GenesisValue{UUID: "uuid", Key: "key", Value: types.BLZValue{Value: []byte("test"), Lease: 100, Height: -20, Owner: owner}})
+	data.BlzValues = append(data.BlzValues, types.GenesisValue{UUID: "uuid", Key: "expired", Value: types.BLZValue{Value: []byte("test"), Lease: 100, Height: -110, Owner: owner}})
+	data.Indexes = append(data.Indexes, types.GenesisIndex{UUID: "uuid", Config: types.IndexConfig{Owner: owner}})
+	data.Frozen = append(data.Frozen, types.GenesisFreeze{UUID: "uuid", Key: "key", Owner: owner})
+	data.Beneficiaries = append(data.Beneficiaries, types.GenesisBeneficiary{UUID: "uuid", Key: "key", Beneficiary: types.Beneficiary{Address: owner}})
+	data.Escrows = append(data.Escrows, types.GenesisEscrow{Owner: owner, Balance: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10))})
+	data.AutoRenew = append(data.AutoRenew, types.GenesisAutoRenew{UUID: "uuid", Key: "key"})
+	data.LeaseDeposits = append(data.LeaseDeposits, types.GenesisLeaseDeposit{UUID: "uuid", Key: "key", Deposit: types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), From: -10, To: 100}})
+	data.Audits = append(data.Audits, types.GenesisAudit{UUID: "uuid", Config: types.AuditConfig{Owner: owner}})
+	data.AuditLog = append(data.AuditLog, types.AuditEntry{UUID: "uuid", Seq: 1, Op: types.AuditOpCreate, Key: "key", Actor: owner, Height: 900})
+	data.Retention = append(data.Retention, types.GenesisRetention{UUID: "uuid", Policy: types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderLRU}})
+	data.HashIndexes = append(data.HashIndexes, types.GenesisHashIndex{UUID: "uuid", Config: types.HashIndexConfig{Owner: owner}})
+	data.RentHistory = append(data.RentHistory, types.GenesisRentRecord{Owner: owner, Record: types.RentRecord{StartHeight: 800, Charges: 1, ByteBlocks: 100}})
+	data.Schemas = append(data.Schemas, types.GenesisSchema{UUID: "uuid", Schema: types.Schema{Owner: owner, MaxValueSize: 10}})
+	data.Uploads = append(data.Uploads, types.GenesisUpload{UUID: "uuid", Key: "up", Upload: types.Upload{Owner: owner, Size: 10, Height: -10, Chunks: 1, Received: 5}, Chunks: [][]byte{[]byte("chunk")}})
 
 	mockKeeper.EXPECT().
-		SetValue(ctx, nil, "UUID-Genesis", "Key-Genesis",
-			types.BLZValue{Value: "test", Owner: owner})
+		SetParams(ctx, types.DefaultParams())
+
+	mockKeeper.EXPECT().
+		SetStoreVersion(ctx, ConsensusVersion)
 
 	mockKeeper.EXPECT().
 		GetKVStore(ctx).Return(nil)
 
+	mockKeeper.EXPECT().
+		GetLeaseStore(ctx).Return(nil)
+
+	// lease heights count from the current height
+	mockKeeper.EXPECT().
+		SetValue(ctx, nil, "uuid", "key",
+			types.BLZValue{Value: []byte("test"), Lease: 100, Height: -15, Owner: owner})
+
+	mockKeeper.EXPECT().
+		SetLease(ctx, nil, "uuid", "key", int64(-15), int64(100))
+
+	// a key in its grace period stays expired, and is purged from where its lease ran out
+	mockKeeper.EXPECT().
+		SetValue(ctx, nil, "uuid", "expired",
+			types.BLZValue{Value: []byte("test"), Lease: 100, Height: -105, Owner: owner})
+
+	mockKeeper.EXPECT().
+		SetLease(ctx, nil, "uuid", "expired", int64(-105), int64(100))
+
+	mockKeeper.EXPECT().
+		ImportPurgedHeight(ctx, int64(-6))
+
+	mockKeeper.EXPECT().
+		SetIndexConfig(ctx, nil, "uuid", types.IndexConfig{Owner: owner})
+
+	mockKeeper.EXPECT().
+		SetHashIndexConfig(ctx, nil, "uuid", types.HashIndexConfig{Owner: owner})
+
+	mockKeeper.EXPECT().
+		Freeze(ctx, sdk.AccAddress(owner), "uuid", "key")
+
+	mockKeeper.EXPECT().
+		ImportBeneficiary(ctx, "uuid", "key", types.Beneficiary{Address: owner})
+
+	mockKeeper.EXPECT().
+		ImportEscrow(ctx, sdk.AccAddress(owner), sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)))
+
+	mockKeeper.EXPECT().
+		SetAutoRenew(ctx, "uuid", "key", true)
+
+	// as is the deposit
+	mockKeeper.EXPECT().
+		ImportLeaseDeposit(ctx, "uuid", "key", types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), From: -5, To: 105})
+
+	mockKeeper.EXPECT().
+		SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+
+	// entries keep the height they were recorded at
+	mockKeeper.EXPECT().
+		ImportAuditEntry(ctx, types.AuditEntry{UUID: "uuid", Seq: 1, Op: types.AuditOpCreate, Key: "key", Actor: owner, Height: 900})
+
+	mockKeeper.EXPECT().
+		SetRetentionPolicy(ctx, nil, "uuid", types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderLRU})
+
+	// rent records and uploads count from the current height like the leases
+	mockKeeper.EXPECT().
+		ImportRentRecord(ctx, sdk.AccAddress(owner), types.RentRecord{StartHeight: 805, Charges: 1, ByteBlocks: 100})
+
+	mockKeeper.EXPECT().
+		ImportUpload(ctx, types.GenesisUpload{UUID: "uuid", Key: "up", Upload: types.Upload{Owner: owner, Size: 10, Height: -5, Chunks: 1, Received: 5}, Chunks: [][]byte{[]byte("chunk")}})
+
+	mockKeeper.EXPECT().
+		SetSchema(ctx, "uuid", types.Schema{Owner: owner, MaxValueSize: 10})
+
 	InitGenesis(ctx, mockKeeper, data)
 }
+
+func TestExportGenesis(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	ctx := sdk.Context{}.WithBlockHeight(50)
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	store := dbadapter.Store{DB: dbm.NewMemDB()}
+	store.Set([]byte(keeper.MakeMetaKey("uuid", "key0")), []byte{})
+	store.Set([]byte(keeper.MakeMetaKey("uuid", "key1")), []byte{})
+	store.Set([]byte(keeper.MakeMetaKey("uuid", "key2")), []byte{})
+
+	mockKeeper.EXPECT().GetKVStore(ctx).Return(store)
+	mockKeeper.EXPECT().GetValuesIterator(ctx, store).Return(store.Iterator(nil, nil))
+	mockKeeper.EXPECT().GetValue(ctx, store, "uuid", "key0").Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+	mockKeeper.EXPECT().GetValue(ctx, store, "uuid", "key1").Return(types.BLZValue{Value: []byte("value1"), Lease: 30, Height: 10, Owner: owner})
+	mockKeeper.EXPECT().GetValue(ctx, store, "uuid", "key2").Return(types.BLZValue{Value: []byte("value2"), Height: 10, Owner: owner})
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(ctx).Return(int64(1000))
+	mockKeeper.EXPECT().GetIndexConfigs(ctx).Return(nil)
+	mockKeeper.EXPECT().GetFrozen(ctx).Return([]types.GenesisFreeze{{UUID: "uuid", Owner: owner}})
+	mockKeeper.EXPECT().GetBeneficiaries(ctx).Return([]types.GenesisBeneficiary{{UUID: "uuid", Key: "key0", Beneficiary: types.Beneficiary{Address: owner}}})
+	mockKeeper.EXPECT().GetEscrows(ctx).Return([]types.GenesisEscrow{{Owner: owner, Balance: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10))}})
+	mockKeeper.EXPECT().GetAutoRenewals(ctx).Return([]types.GenesisAutoRenew{{UUID: "uuid", Key: "key1"}})
+	mockKeeper.EXPECT().GetLeaseDeposits(ctx).Return([]types.GenesisLeaseDeposit{{UUID: "uuid", Key: "key0", Deposit: types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), From: 10, To: 110}}})
+	mockKeeper.EXPECT().GetAuditConfigs(ctx).Return([]types.GenesisAudit{{UUID: "uuid", Config: types.AuditConfig{Owner: owner}}})
+	mockKeeper.EXPECT().GetAuditLogs(ctx).Return([]types.AuditEntry{{UUID: "uuid", Seq: 1, Op: types.AuditOpCreate, Key: "key0", Actor: owner, Height: 10}})
+	mockKeeper.EXPECT().GetRetentionPolicies(ctx).Return([]types.GenesisRetention{{UUID: "uuid", Policy: types.RetentionPolicy{Owner: owner, MaxKeys: 1, Order: types.RetentionOrderFIFO}}})
+	mockKeeper.EXPECT().GetHashIndexConfigs(ctx).Return([]types.GenesisHashIndex{{UUID: "uuid", Config: types.HashIndexConfig{Owner: owner}}})
+	mockKeeper.EXPECT().GetRentHistories(ctx).Return([]types.GenesisRentRecord{{Owner: owner, Record: types.RentRecord{StartHeight: 0, Charges: 1, ByteBlocks: 100, Paid: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1))}}})
+	mockKeeper.EXPECT().GetSchemas(ctx).Return([]types.GenesisSchema{{UUID: "uuid", Schema: types.Schema{Owner: owner, MaxValueSize: 10}}})
+	mockKeeper.EXPECT().GetUploads(ctx).Return([]types.GenesisUpload{{UUID: "uuid", Key: "up", Upload: types.Upload{Owner: owner, Size: 10, Height: 40, Chunks: 1, Received: 5}, Chunks: [][]byte{[]byte("chunk")}}})
+	mockKeeper.EXPECT().GetParams(ctx).Return(types.DefaultParams())
+
+	genesisState := ExportGenesis(ctx, mockKeeper)
+
+	// heights are exported relative to the export height, so key1 has run out, and the
+	// default lease is written out
+	assert.Equal(t, []types.GenesisValue{
+		{UUID: "uuid", Key: "key0", Value: types.BLZValue{Value: []byte("value0"), Lease: 100, Height: -40, Owner: owner}},
+		{UUID: "uuid", Key: "key1", Value: types.BLZValue{Value: []byte("value1"), Lease: 30, Height: -40, Owner: owner}},
+		{UUID: "uuid", Key: "key2", Value: types.BLZValue{Value: []byte("value2"), Lease: 1000, Height: -40, Owner: owner}},
+	}, genesisState.BlzValues)
+	assert.Equal(t, []types.GenesisFreeze{{UUID: "uuid", Owner: owner}}, genesisState.Frozen)
+	assert.Equal(t, []types.GenesisBeneficiary{{UUID: "uuid", Key: "key0", Beneficiary: types.Beneficiary{Address: owner}}}, genesisState.Beneficiaries)
+	assert.Equal(t, []types.GenesisEscrow{{Owner: owner, Balance: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10))}}, genesisState.Escrows)
+	assert.Equal(t, []types.GenesisAutoRenew{{UUID: "uuid", Key: "key1"}}, genesisState.AutoRenew)
+	assert.Equal(t, []types.GenesisLeaseDeposit{{UUID: "uuid", Key: "key0", Deposit: types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), From: -40, To: 60}}}, genesisState.LeaseDeposits)
+	assert.Equal(t, []types.GenesisAudit{{UUID: "uuid", Config: types.AuditConfig{Owner: owner}}}, genesisState.Audits)
+	assert.Equal(t, []types.AuditEntry{{UUID: "uuid", Seq: 1, Op: types.AuditOpCreate, Key: "key0", Actor: owner, Height: 10}}, genesisState.AuditLog)
+	assert.Equal(t, []types.GenesisRetention{{UUID: "uuid", Policy: types.RetentionPolicy{Owner: owner, MaxKeys: 1, Order: types.RetentionOrderFIFO}}}, genesisState.Retention)
+	assert.Equal(t, []types.GenesisHashIndex{{UUID: "uuid", Config: types.HashIndexConfig{Owner: owner}}}, genesisState.HashIndexes)
+	assert.Equal(t, []types.GenesisRentRecord{{Owner: owner, Record: types.RentRecord{StartHeight: -50, Charges: 1, ByteBlocks: 100, Paid: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1))}}}, genesisState.RentHistory)
+	assert.Equal(t, []types.GenesisSchema{{UUID: "uuid", Schema: types.Schema{Owner: owner, MaxValueSize: 10}}}, genesisState.Schemas)
+	assert.Equal(t, []types.GenesisUpload{{UUID: "uuid", Key: "up", Upload: types.Upload{Owner: owner, Size: 10, Height: -10, Chunks: 1, Received: 5}, Chunks: [][]byte{[]byte("chunk")}}}, genesisState.Uploads)
+	assert.Equal(t, types.DefaultParams(), genesisState.Params)
+	assert.Nil(t, ValidateGenesis(genesisState))
+}
+
+func TestExportValues(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	ctx := sdk.Context{}.WithBlockHeight(50)
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	store := dbadapter.Store{DB: dbm.NewMemDB()}
+	store.Set([]byte(keeper.MakeMetaKey("uuid", "key0")), []byte{})
+	store.Set([]byte(keeper.MakeMetaKey("uuid2", "key1")), []byte{})
+
+	// only the keys of the UUID are read
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(store)
+	mockKeeper.EXPECT().GetValue(ctx, store, "uuid", "key0").Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+
+	var values []types.GenesisValue
+	err := ExportValues(ctx, mockKeeper, "uuid", func(value types.GenesisValue) error {
+		values = append(values, value)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []types.GenesisValue{{UUID: "uuid", Key: "key0", Value: types.BLZValue{Value: []byte("value0"), Lease: 100, Height: -40, Owner: owner}}}, values)
+
+	// the first error stops the export
+	mockKeeper.EXPECT().GetValue(ctx, store, "uuid", "key0").Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+	err = ExportValues(ctx, mockKeeper, "uuid", func(types.GenesisValue) error {
+		return errors.New("disk full")
+	})
+	assert.EqualError(t, err, "disk full")
+}
+
+// newGenesisTestKeeper returns a crud keeper over fresh stores, and a context at height
+func newGenesisTestKeeper(height int64) (sdk.Context, keeper.Keeper) {
+	storeKey, leaseKey, indexKey := sdk.NewKVStoreKey(StoreKey), sdk.NewKVStoreKey(LeaseKey), sdk.NewKVStoreKey(IndexKey)
+	paramsKey, paramsTKey := sdk.NewKVStoreKey(params.StoreKey), sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	for _, key := range []sdk.StoreKey{storeKey, leaseKey, indexKey, paramsKey} {
+		cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	cms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, db)
+	if err := cms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	cdc := codec.New()
+	subspace := params.NewKeeper(cdc, paramsKey, paramsTKey).Subspace(DefaultParamspace)
+	k := keeper.NewKeeper(nil, storeKey, leaseKey, indexKey, subspace, cdc, keeper.MaxKeeperSizes{MaxDefaultLeaseBlocks: 1000})
+	return sdk.NewContext(cms, abci.Header{Height: height}, false, log.NewNopLogger()), k
+}
+
+func TestGenesis_roundTrip(t *testing.T) {
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	ctx, k := newGenesisTestKeeper(50)
+	params := types.DefaultParams()
+	params.ExpiryGraceBlocks = 20
+	k.SetParams(ctx, params)
+	k.SetValue(ctx, k.GetKVStore(ctx), "uuid", "key", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+	k.SetValue(ctx, k.GetKVStore(ctx), "uuid", "default", types.BLZValue{Value: []byte("value"), Height: 10, Owner: owner})
+
+	// expired at 40, and read-only until it is purged at 60
+	k.SetValue(ctx, k.GetKVStore(ctx), "uuid", "grace", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 30, Owner: owner})
+	k.SetLease(ctx, k.GetLeaseStore(ctx), "uuid", "grace", 10, 30)
+	assert.True(t, k.IsExpired(ctx, k.GetKVStore(ctx), "uuid", "grace"))
+	k.StartUpload(ctx, "uuid", "up", types.Upload{Owner: owner, Size: 10, Lease: 100, Height: 40})
+	k.AddUploadChunk(ctx, "uuid", "up", []byte("chunk"))
+	k.ImportRentRecord(ctx, owner, types.RentRecord{StartHeight: 0, Charges: 1, ByteBlocks: 100})
+
+	state := ExportGenesis(ctx, k)
+	assert.Nil(t, ValidateGenesis(state))
+
+	ctx, k = newGenesisTestKeeper(5)
+	InitGenesis(ctx, k, state)
+
+	// the upload is still in flight, with the blocks it had left before it times out
+	upload := k.GetUpload(ctx, "uuid", "up")
+	assert.Equal(t, types.Upload{Owner: owner, Size: 10, Lease: 100, Height: -5, Chunks: 1, Received: 5}, upload)
+	assert.Equal(t, []byte("chunk"), k.AssembleUpload(ctx, "uuid", "up"))
+
+	// the rent epoch started 50 blocks before the export, and so before the import
+	history := k.GetRentHistory(ctx, owner, -100, 10)
+	assert.Equal(t, []types.RentRecord{{StartHeight: -45, Charges: 1, ByteBlocks: 100}}, history.Records)
+
+	store := k.GetKVStore(ctx)
+	assert.Equal(t, []byte("value"), k.GetValue(ctx, store, "uuid", "key").Value)
+	assert.False(t, k.IsExpired(ctx.WithBlockHeight(65), store, "uuid", "key"))
+	assert.True(t, k.IsExpired(ctx.WithBlockHeight(66), store, "uuid", "key"))
+
+	// a key with the default lease keeps it rather than running out in the first block
+	assert.False(t, k.IsExpired(ctx.WithBlockHeight(100), store, "uuid", "default"))
+
+	// the key in its grace period is still expired, and is purged 10 blocks on, as it
+	// would have been on the exported chain
+	assert.True(t, k.IsExpired(ctx.WithBlockHeight(6), store, "uuid", "grace"))
+	k.PurgeExpiredLeases(ctx.WithBlockHeight(14))
+	assert.True(t, k.IsKeyPresent(ctx, store, "uuid", "grace"))
+	k.PurgeExpiredLeases(ctx.WithBlockHeight(15))
+	assert.False(t, k.IsKeyPresent(ctx, store, "uuid", "grace"))
+	assert.True(t, k.IsKeyPresent(ctx, store, "uuid", "key"))
+}
@@ -15,16 +15,24 @@
 package crud
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"github.com/bluzelle/curium/x/crud/internal/keeper"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strconv"
 )
 
 func NewHandler(keeper keeper.IKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		// charged before the message is looked at, so rejected messages pay it too
+		if gas := keeper.GetParams(ctx).BaseGas(msg.Type()); gas != 0 {
+			ctx.GasMeter().ConsumeGas(gas, "crud base "+msg.Type())
+		}
+
 		switch msg := msg.(type) {
 		case types.MsgCreate:
 			return handleMsgCreate(ctx, keeper, msg)
@@ -34,6 +42,8 @@ func NewHandler(keeper keeper.IKeeper) sdk.Handler {
 			return handleMsgUpdate(ctx, keeper, msg)
 		case types.MsgDelete:
 			return handleMsgDelete(ctx, keeper, msg)
+		case types.MsgExpireNow:
+			return handleMsgExpireNow(ctx, keeper, msg)
 		case types.MsgKeys:
 			return handleMsgKeys(ctx, keeper, msg)
 		case types.MsgHas:
@@ -48,6 +58,8 @@ func NewHandler(keeper keeper.IKeeper) sdk.Handler {
 			return handleMsgDeleteAll(ctx, keeper, msg)
 		case types.MsgMultiUpdate:
 			return handleMsgMultiUpdate(ctx, keeper, msg)
+		case types.MsgAtomic:
+			return handleMsgAtomic(ctx, keeper, msg)
 		case types.MsgGetLease:
 			return handleMsgGetLease(ctx, keeper, msg)
 		case types.MsgGetNShortestLeases:
@@ -56,6 +68,50 @@ func NewHandler(keeper keeper.IKeeper) sdk.Handler {
 			return handleMsgRenewLease(ctx, keeper, msg)
 		case types.MsgRenewLeaseAll:
 			return handleMsgRenewLeaseAll(ctx, keeper, msg)
+		case types.MsgCopy:
+			return handleMsgCopy(ctx, keeper, msg)
+		case types.MsgCopyUUID:
+			return handleMsgCopyUUID(ctx, keeper, msg)
+		case types.MsgPatch:
+			return handleMsgPatch(ctx, keeper, msg)
+		case types.MsgSetBeneficiary:
+			return handleMsgSetBeneficiary(ctx, keeper, msg)
+		case types.MsgDepositEscrow:
+			return handleMsgDepositEscrow(ctx, keeper, msg)
+		case types.MsgWithdrawEscrow:
+			return handleMsgWithdrawEscrow(ctx, keeper, msg)
+		case types.MsgSetAutoRenew:
+			return handleMsgSetAutoRenew(ctx, keeper, msg)
+		case types.MsgSetIndex:
+			return handleMsgSetIndex(ctx, keeper, msg)
+		case types.MsgDeleteIndex:
+			return handleMsgDeleteIndex(ctx, keeper, msg)
+		case types.MsgSetAudit:
+			return handleMsgSetAudit(ctx, keeper, msg)
+		case types.MsgDeleteAudit:
+			return handleMsgDeleteAudit(ctx, keeper, msg)
+		case types.MsgSetHashIndex:
+			return handleMsgSetHashIndex(ctx, keeper, msg)
+		case types.MsgDeleteHashIndex:
+			return handleMsgDeleteHashIndex(ctx, keeper, msg)
+		case types.MsgSetRetention:
+			return handleMsgSetRetention(ctx, keeper, msg)
+		case types.MsgDeleteRetention:
+			return handleMsgDeleteRetention(ctx, keeper, msg)
+		case types.MsgSetSchema:
+			return handleMsgSetSchema(ctx, keeper, msg)
+		case types.MsgDeleteSchema:
+			return handleMsgDeleteSchema(ctx, keeper, msg)
+		case types.MsgFreeze:
+			return handleMsgFreeze(ctx, keeper, msg)
+		case types.MsgUnfreeze:
+			return handleMsgUnfreeze(ctx, keeper, msg)
+		case types.MsgStartUpload:
+			return handleMsgStartUpload(ctx, keeper, msg)
+		case types.MsgUploadChunk:
+			return handleMsgUploadChunk(ctx, keeper, msg)
+		case types.MsgCommitUpload:
+			return handleMsgCommitUpload(ctx, keeper, msg)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized crud msg type: %v", msg.Type()))
 		}
@@ -73,20 +129,53 @@ func handleMsgCreate(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgCreate
 
 	// default lease...
 	if msg.Lease == 0 {
-		msg.Lease = keeper.GetDefaultLeaseBlocks()
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
 	}
 
-	keeper.SetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, types.BLZValue{
-		Value:  msg.Value,
-		Owner:  msg.Owner,
-		Lease:  msg.Lease,
-		Height: ctx.BlockHeight(),
+	if err := setNewValue(ctx, keeper, msg.UUID, msg.Key, msg.Value, msg.Lease, msg.Owner, msg.ContentType, msg.Encoding); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+// setNewValue writes a new key and charges owner for its lease.
+func setNewValue(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string, value []byte, lease int64, owner sdk.AccAddress, contentType string, encoding string) error {
+	if err := checkSchema(ctx, keeper, UUID, key, value, contentType); err != nil {
+		return err
+	}
+
+	keeper.SetValue(ctx, keeper.GetKVStore(ctx), UUID, key, types.BLZValue{
+		Value:       value,
+		Owner:       owner,
+		Lease:       lease,
+		Height:      ctx.BlockHeight(),
+		ContentType: contentType,
+		Encoding:    encoding,
 	})
 
 	leaseCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
-	keeper.SetLease(keeper.GetLeaseStore(leaseCtx), msg.UUID, msg.Key, ctx.BlockHeight(), msg.Lease)
+	keeper.SetLease(leaseCtx, keeper.GetLeaseStore(leaseCtx), UUID, key, ctx.BlockHeight(), lease)
 
-	return &sdk.Result{}, nil
+	return keeper.ChargeLease(ctx, owner, UUID, key, leaseUsage(ctx, UUID, key, value, ctx.BlockHeight()+lease))
+}
+
+// checkSchema rejects a value written to key that does not conform to the schema of
+// UUID.
+func checkSchema(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string, value []byte, contentType string) error {
+	if err := keeper.GetSchema(ctx, UUID).Validate(value, contentType); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Value of %s does not match schema: %s", key, err))
+	}
+	return nil
+}
+
+// leaseUsage returns the byte-blocks held by a key with value from the current block
+// until its lease ends at expiry.
+func leaseUsage(ctx sdk.Context, UUID string, key string, value []byte, expiry int64) int64 {
+	if remaining := expiry - ctx.BlockHeight(); remaining > 0 {
+		return int64(len(UUID)+len(key)+len(value)) * remaining
+	}
+	return 0
 }
 
 func handleMsgRead(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgRead) (*sdk.Result, error) {
@@ -100,7 +189,8 @@ func handleMsgRead(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgRead) (*
 	}
 
 	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
-	jsonData, err := json.Marshal(types.QueryResultRead{UUID: msg.UUID, Key: msg.Key, Value: blzValue.Value})
+	jsonData, err := json.Marshal(types.QueryResultRead{UUID: msg.UUID, Key: msg.Key, Value: blzValue.Value, Version: blzValue.Version,
+		ContentType: blzValue.ContentType, Encoding: blzValue.Encoding})
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
 	}
@@ -122,28 +212,73 @@ func handleMsgUpdate(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgUpdate
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
 	}
 
-	oldBlzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if keeper.IsFrozen(ctx, owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+
+	if keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	if msg.Version != 0 {
+		if version := keeper.GetVersion(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key); version != msg.Version {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Version mismatch: key is at version %d, expected %d", version, msg.Version))
+		}
+	}
+
+	ok, err := updateValue(ctx, keeper, msg.UUID, msg.Key, msg.Value, msg.Lease, msg.Owner, msg.ContentType, msg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid lease")
+	}
+	return &sdk.Result{}, nil
+}
+
+// updateValue replaces the value of an existing key, adding lease (a delta, 0 meaning
+// no change) to its lease, and charges owner for any byte-blocks added. It returns
+// false, writing nothing, if the new lease would not outlast the current block. An
+// empty contentType or encoding keeps the one the key has.
+func updateValue(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string, value []byte, lease int64, owner sdk.AccAddress, contentType string, encoding string) (bool, error) {
+	oldBlzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), UUID, key)
+	oldUsage := leaseUsage(ctx, UUID, key, oldBlzValue.Value, oldBlzValue.Height+oldBlzValue.Lease)
+	newLease := oldBlzValue.Lease
+
+	if contentType == "" {
+		contentType = oldBlzValue.ContentType
+	}
+	if encoding == "" {
+		encoding = oldBlzValue.Encoding
+	}
+
+	if err := checkSchema(ctx, keeper, UUID, key, value, contentType); err != nil {
+		return false, err
+	}
 
-	if msg.Lease != 0 { // 0 means no change to lease
-		newLease := oldBlzValue.Lease + msg.Lease
+	if lease != 0 {
+		newLease = oldBlzValue.Lease + lease
 		if newLease <= 0 {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid lease")
+			return false, nil
 		}
 
 		if (oldBlzValue.Height + newLease) <= ctx.BlockHeight() {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid lease")
+			return false, nil
 		}
 
-		keeper.SetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, types.BLZValue{Value: msg.Value, Lease: newLease, Height: oldBlzValue.Height, Owner: msg.Owner})
+		keeper.SetValue(ctx, keeper.GetKVStore(ctx), UUID, key, types.BLZValue{Value: value, Lease: newLease, Height: oldBlzValue.Height, Owner: owner,
+			ContentType: contentType, Encoding: encoding})
 
 		leaseCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
-		keeper.DeleteLease(keeper.GetLeaseStore(leaseCtx), msg.UUID, msg.Key, oldBlzValue.Height, oldBlzValue.Lease)
-		keeper.SetLease(keeper.GetLeaseStore(leaseCtx), msg.UUID, msg.Key, oldBlzValue.Height, newLease)
+		keeper.DeleteLease(keeper.GetLeaseStore(leaseCtx), UUID, key, oldBlzValue.Height, oldBlzValue.Lease)
+		keeper.SetLease(leaseCtx, keeper.GetLeaseStore(leaseCtx), UUID, key, oldBlzValue.Height, newLease)
 	} else {
-		keeper.SetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, types.BLZValue{Value: msg.Value, Lease: oldBlzValue.Lease,
-			Owner: msg.Owner, Height: oldBlzValue.Height})
+		keeper.SetValue(ctx, keeper.GetKVStore(ctx), UUID, key, types.BLZValue{Value: value, Lease: oldBlzValue.Lease,
+			Owner: owner, Height: oldBlzValue.Height, ContentType: contentType, Encoding: encoding})
 	}
-	return &sdk.Result{}, nil
+
+	newUsage := leaseUsage(ctx, UUID, key, value, oldBlzValue.Height+newLease)
+	return true, keeper.ChargeLease(ctx, owner, UUID, key, newUsage-oldUsage)
 }
 
 func handleMsgDelete(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDelete) (*sdk.Result, error) {
@@ -160,12 +295,71 @@ func handleMsgDelete(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDelete
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
 	}
 
+	if keeper.IsFrozen(ctx, owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+
+	if keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	if msg.Version != 0 {
+		if version := keeper.GetVersion(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key); version != msg.Version {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Version mismatch: key is at version %d, expected %d", version, msg.Version))
+		}
+	}
+
 	newCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 	keeper.DeleteValue(ctx, keeper.GetKVStore(ctx), keeper.GetLeaseStore(newCtx), msg.UUID, msg.Key)
 
 	return &sdk.Result{}, nil
 }
 
+// handleMsgExpireNow ends the lease of a key at once, refunding the rest of its lease
+// deposit. Unlike a delete the key is kept for the expiry grace period, in which it can
+// be read and brought back with a renewal, before it is removed at the end of block.
+func handleMsgExpireNow(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgExpireNow) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	if !msg.Owner.Equals(owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	if keeper.IsFrozen(ctx, owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+
+	if keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	leaseCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	expiry, refund := keeper.ExpireNow(ctx, keeper.GetKVStore(ctx), keeper.GetLeaseStore(leaseCtx), msg.UUID, msg.Key)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExpireNow,
+		sdk.NewAttribute(types.AttributeKeyUUID, msg.UUID),
+		sdk.NewAttribute(types.AttributeKeyKey, msg.Key),
+		sdk.NewAttribute(types.AttributeKeyOwner, owner.String()),
+		sdk.NewAttribute(types.AttributeKeyExpiry, strconv.FormatInt(expiry, 10)),
+		sdk.NewAttribute(types.AttributeKeyRefund, refund.String()),
+	))
+
+	jsonData, err := json.Marshal(types.QueryResultExpireNow{UUID: msg.UUID, Key: msg.Key, Expiry: expiry, Refund: refund})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	return &sdk.Result{Data: jsonData, Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgKeys(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgKeys) (*sdk.Result, error) {
 	if len(msg.UUID) == 0 || msg.Owner.Empty() {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
@@ -206,11 +400,39 @@ func handleMsgRename(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgRename
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
 	}
 
-	if !keeper.RenameKey(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, msg.NewKey) {
+	if keeper.IsFrozen(ctx, owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+
+	if keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	if newKeyOwner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.NewKey); !newKeyOwner.Empty() {
+		if !msg.Overwrite {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "New key already exists")
+		}
+
+		if !msg.Owner.Equals(newKeyOwner) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner of new key")
+		}
+
+		if keeper.IsFrozen(ctx, newKeyOwner, msg.UUID, msg.NewKey) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "New key is frozen")
+		}
+	}
+
+	expiry, ok := keeper.RenameKey(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, msg.NewKey, msg.Overwrite)
+	if !ok {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Rename failed")
 	}
 
-	return &sdk.Result{}, nil
+	jsonData, err := json.Marshal(types.QueryResultRename{UUID: msg.UUID, Key: msg.Key, NewKey: msg.NewKey, Expiry: expiry})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	return &sdk.Result{Data: jsonData}, nil
 }
 
 func handleMsgKeyValues(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgKeyValues) (*sdk.Result, error) {
@@ -244,34 +466,144 @@ func handleMsgDeleteAll(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDel
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
 	}
 
-	keeper.DeleteAll(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Owner)
+	if keeper.HasFrozen(ctx, msg.Owner, msg.UUID) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID has frozen keys")
+	}
+
+	result := keeper.DeleteAll(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Owner)
+	ctx.GasMeter().ConsumeGas(result.Count*ctx.KVGasConfig().DeleteCost, "crud deleteall")
 
-	return &sdk.Result{}, nil
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	return &sdk.Result{Data: jsonData}, nil
 }
 
+// handleMsgMultiUpdate updates the key values of msg, up to the MaxBatchSize param of
+// them, each charged the batch gas of its size. All of them are checked before any is
+// updated, and one that cannot be updated fails the message, unless msg is partial:
+// then each key value is updated on its own and those that cannot be are skipped. The
+// result reports which were applied.
 func handleMsgMultiUpdate(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgMultiUpdate) (*sdk.Result, error) {
 	if len(msg.UUID) == 0 || len(msg.KeyValues) == 0 || msg.Owner.Empty() {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
 	}
 
-	// we're past basic validation, now scan owners & if the keys exist...
-	for i := range msg.KeyValues[:] {
-		owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.KeyValues[i].Key)
+	params := keeper.GetParams(ctx)
+	if params.MaxBatchSize != 0 && uint64(len(msg.KeyValues)) > params.MaxBatchSize {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Too many key values, %d of at most %d", len(msg.KeyValues), params.MaxBatchSize))
+	}
 
-		if owner.Empty() {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Key does not exist [%d]", i))
+	for i := range msg.KeyValues {
+		if gas := params.BatchGas(msg.KeyValues[i].Key, msg.KeyValues[i].Value); gas != 0 {
+			ctx.GasMeter().ConsumeGas(gas, "crud batch item")
 		}
+	}
+
+	results := make([]types.MultiUpdateResult, len(msg.KeyValues))
+	for i := range msg.KeyValues {
+		results[i].Key = msg.KeyValues[i].Key
+	}
 
-		if !owner.Equals(msg.Owner) {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Incorrect Owner [%d]", i))
+	if msg.Partial {
+		for i := range msg.KeyValues {
+			if err := multiUpdateKeyValue(ctx, keeper, msg, i); err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			results[i].Applied = true
 		}
+	} else {
+		// we're past basic validation, now scan owners & if the keys exist...
+		for i := range msg.KeyValues {
+			if reason := checkMultiUpdateKey(ctx, keeper, msg, i); reason != "" {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("%s [%d]", reason, i))
+			}
+		}
+
+		// update the values...
+		for i := range msg.KeyValues {
+			ok, err := updateValue(ctx, keeper, msg.UUID, msg.KeyValues[i].Key, msg.KeyValues[i].Value, msg.KeyValues[i].Lease, msg.Owner, "", "")
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Invalid lease [%d]", i))
+			}
+			results[i].Applied = true
+		}
+	}
+
+	jsonData, err := json.Marshal(types.QueryResultMultiUpdate{UUID: msg.UUID, Results: results})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	return &sdk.Result{Data: jsonData}, nil
+}
+
+// checkMultiUpdateKey returns why key value i of msg cannot be updated, if it cannot.
+func checkMultiUpdateKey(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgMultiUpdate, i int) string {
+	owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.KeyValues[i].Key)
+	switch {
+	case owner.Empty():
+		return "Key does not exist"
+	case !owner.Equals(msg.Owner):
+		return "Incorrect Owner"
+	case keeper.IsFrozen(ctx, owner, msg.UUID, msg.KeyValues[i].Key):
+		return "Key is frozen"
+	case keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.KeyValues[i].Key):
+		return "Key has expired"
 	}
+	return ""
+}
 
-	// update the values...
-	for i := range msg.KeyValues[:] {
-		keeper.SetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.KeyValues[i].Key, types.BLZValue{Value: msg.KeyValues[i].Value, Owner: msg.Owner})
+// multiUpdateKeyValue checks and updates key value i of msg on a branch of the store,
+// written only if the update succeeds.
+func multiUpdateKeyValue(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgMultiUpdate, i int) error {
+	if reason := checkMultiUpdateKey(ctx, keeper, msg, i); reason != "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, reason)
 	}
 
+	cacheCtx, write := ctx.CacheContext()
+	ok, err := updateValue(cacheCtx, keeper, msg.UUID, msg.KeyValues[i].Key, msg.KeyValues[i].Value, msg.KeyValues[i].Lease, msg.Owner, "", "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid lease")
+	}
+
+	write()
+	return nil
+}
+
+// handleMsgAtomic applies the operations of msg in order, each handled (and charged) like
+// the message it stands for, on a cached store that is written only once all of them
+// have succeeded.
+func handleMsgAtomic(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgAtomic) (*sdk.Result, error) {
+	if len(msg.Ops) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+	handler := NewHandler(keeper)
+	for i := range msg.Ops {
+		op := msg.Ops[i].Msg(msg.Owner)
+		if op == nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Unknown operation %s [%d]", msg.Ops[i].Op, i))
+		}
+
+		if _, err := handler(cacheCtx, op); err != nil {
+			return nil, sdkerrors.Wrap(err, fmt.Sprintf("[%d]", i))
+		}
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
 	return &sdk.Result{}, nil
 }
 
@@ -329,10 +661,12 @@ func handleMsgRenewLease(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgRe
 	}
 
 	if msg.Lease == 0 {
-		msg.Lease = keeper.GetDefaultLeaseBlocks()
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
 	}
 
-	updateLease(ctx, keeper, msg.UUID, msg.Key, msg.Lease)
+	if _, err := updateLease(ctx, keeper, msg.UUID, msg.Key, msg.Lease, msg.Owner); err != nil {
+		return nil, err
+	}
 
 	return &sdk.Result{}, nil
 }
@@ -348,18 +682,43 @@ func handleMsgRenewLeaseAll(ctx sdk.Context, keeper keeper.IKeeper, msg types.Ms
 	}
 
 	if msg.Lease == 0 {
-		msg.Lease = keeper.GetDefaultLeaseBlocks()
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
 	}
 
+	result := types.QueryResultRenewLeaseAll{UUID: msg.UUID, Keys: make([]types.KeyExpiry, 0, len(value.Keys))}
+	gasStart := ctx.GasMeter().GasConsumed()
+
 	for i := range value.Keys[:] {
-		updateLease(ctx, keeper, msg.UUID, value.Keys[i], msg.Lease)
+		expiry, err := updateLease(ctx, keeper, msg.UUID, value.Keys[i], msg.Lease, msg.Owner)
+		if err != nil {
+			return nil, err
+		}
+		result.Keys = append(result.Keys, types.KeyExpiry{Key: value.Keys[i], Expiry: expiry})
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeRenewLease,
+			sdk.NewAttribute(types.AttributeKeyUUID, msg.UUID),
+			sdk.NewAttribute(types.AttributeKeyKey, value.Keys[i]),
+			sdk.NewAttribute(types.AttributeKeyExpiry, strconv.FormatInt(expiry, 10)),
+		))
 	}
 
-	return &sdk.Result{}, nil
+	result.Count = uint64(len(result.Keys))
+	result.GasUsed = ctx.GasMeter().GasConsumed() - gasStart
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	return &sdk.Result{Data: jsonData, Events: ctx.EventManager().Events()}, nil
 }
 
-func updateLease(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string, lease int64) {
+// updateLease restarts the lease of key at the current block, charging owner for any
+// byte-blocks added, and returns the height it now expires at.
+func updateLease(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string, lease int64, owner sdk.AccAddress) (int64, error) {
 	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), UUID, key)
+	oldUsage := leaseUsage(ctx, UUID, key, blzValue.Value, blzValue.Height+blzValue.Lease)
 
 	leaseCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 	keeper.DeleteLease(keeper.GetLeaseStore(leaseCtx), UUID, key, blzValue.Height, blzValue.Lease)
@@ -367,5 +726,504 @@ func updateLease(ctx sdk.Context, keeper keeper.IKeeper, UUID string, key string
 	blzValue.Height = ctx.BlockHeight()
 	blzValue.Lease = lease
 	keeper.SetValue(ctx, keeper.GetKVStore(ctx), UUID, key, blzValue)
-	keeper.SetLease(keeper.GetLeaseStore(leaseCtx), UUID, key, blzValue.Height, blzValue.Lease)
+	keeper.SetLease(leaseCtx, keeper.GetLeaseStore(leaseCtx), UUID, key, blzValue.Height, blzValue.Lease)
+
+	expiry := blzValue.Height + blzValue.Lease
+	return expiry, keeper.ChargeLease(ctx, owner, UUID, key, leaseUsage(ctx, UUID, key, blzValue.Value, expiry)-oldUsage)
+}
+
+func handleMsgCopy(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgCopy) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || len(msg.NewUUID) == 0 || len(msg.NewKey) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if blzValue.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	if !keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.NewUUID, msg.NewKey).Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists")
+	}
+
+	if msg.Lease == 0 {
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
+	}
+
+	// the copy is charged exactly as if it were a fresh create by the sender...
+	if err := setNewValue(ctx, keeper, msg.NewUUID, msg.NewKey, blzValue.Value, msg.Lease, msg.Owner, blzValue.ContentType, blzValue.Encoding); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCopyUUID(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgCopyUUID) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.NewUUID) == 0 || msg.UUID == msg.NewUUID || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	if msg.Lease == 0 {
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
+	}
+
+	leaseCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	keyValues, ok := keeper.CopyAll(ctx, keeper.GetKVStore(ctx), keeper.GetLeaseStore(leaseCtx), msg.UUID, msg.NewUUID, msg.Owner, msg.Lease)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Copy failed")
+	}
+
+	// the copies are checked against the schema of the new UUID, if it has one
+	checkCopies := !keeper.GetSchema(ctx, msg.NewUUID).Owner.Empty()
+	for _, keyValue := range keyValues {
+		if checkCopies {
+			contentType := keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.NewUUID, keyValue.Key).ContentType
+			if err := checkSchema(ctx, keeper, msg.NewUUID, keyValue.Key, keyValue.Value, contentType); err != nil {
+				return nil, err
+			}
+		}
+
+		usage := leaseUsage(ctx, msg.NewUUID, keyValue.Key, keyValue.Value, ctx.BlockHeight()+msg.Lease)
+		if err := keeper.ChargeLease(ctx, msg.Owner, msg.NewUUID, keyValue.Key, usage); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgPatch(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgPatch) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || len(msg.Patch) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if blzValue.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	if !msg.Owner.Equals(blzValue.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	if keeper.IsFrozen(ctx, msg.Owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+
+	if keeper.IsExpired(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	newValue, err := types.ApplyMergePatch(blzValue.Value, msg.Patch)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	if len(newValue) > types.MaxValueSize {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large")
+	}
+
+	if err := checkSchema(ctx, keeper, msg.UUID, msg.Key, newValue, blzValue.ContentType); err != nil {
+		return nil, err
+	}
+
+	// the patch is paid for as if it were written, plus any growth of the stored value...
+	patchGas := uint64(len(msg.Patch))
+	if len(newValue) > len(blzValue.Value) {
+		patchGas += uint64(len(newValue) - len(blzValue.Value))
+	}
+	ctx.GasMeter().ConsumeGas(patchGas*ctx.KVGasConfig().WriteCostPerByte, "crud patch")
+
+	expiry := blzValue.Height + blzValue.Lease
+	growth := leaseUsage(ctx, msg.UUID, msg.Key, newValue, expiry) - leaseUsage(ctx, msg.UUID, msg.Key, blzValue.Value, expiry)
+
+	blzValue.Value = newValue
+	keeper.SetValue(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key, blzValue)
+
+	if err := keeper.ChargeLease(ctx, msg.Owner, msg.UUID, msg.Key, growth); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+// handleMsgSetBeneficiary names who takes over a key when its lease runs out, the owner
+// paying now for the lease the beneficiary will get.
+func handleMsgSetBeneficiary(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetBeneficiary) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	if !msg.Owner.Equals(owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	if err := keeper.SetBeneficiary(ctx, msg.UUID, msg.Key, msg.Owner, msg.Beneficiary); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDepositEscrow(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDepositEscrow) (*sdk.Result, error) {
+	if msg.Owner.Empty() || !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	if err := keeper.DepositEscrow(ctx, msg.Owner, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+// handleMsgWithdrawEscrow pays coins out of the owner's escrow back to them, up to what
+// the escrow holds.
+func handleMsgWithdrawEscrow(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgWithdrawEscrow) (*sdk.Result, error) {
+	if msg.Owner.Empty() || !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	if err := keeper.WithdrawEscrow(ctx, msg.Owner, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{}, nil
+}
+
+// handleMsgSetAutoRenew marks a key to have its lease renewed from the owner's escrow,
+// nothing being charged until the lease runs out.
+func handleMsgSetAutoRenew(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetAutoRenew) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	if !msg.Owner.Equals(owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetAutoRenew(ctx, msg.UUID, msg.Key, msg.AutoRenew)
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetIndex(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetIndex) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	// the first account to index a UUID owns its index configuration
+	owner := keeper.GetIndexConfig(ctx, msg.UUID).Owner
+	if !owner.Empty() && !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetIndexConfig(ctx, keeper.GetKVStore(ctx), msg.UUID, types.IndexConfig{Field: msg.Field, Owner: msg.Owner})
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeleteIndex(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDeleteIndex) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetIndexConfig(ctx, msg.UUID).Owner
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Index does not exist")
+	}
+
+	if !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.DeleteIndexConfig(ctx, msg.UUID)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetAudit(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetAudit) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	// as with indexes the first account to audit a UUID owns its audit configuration
+	owner := keeper.GetAuditConfig(ctx, msg.UUID).Owner
+	if !owner.Empty() && !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetAuditConfig(ctx, msg.UUID, types.AuditConfig{Owner: msg.Owner})
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeleteAudit(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDeleteAudit) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetAuditConfig(ctx, msg.UUID).Owner
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Audit not enabled")
+	}
+
+	if !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.DeleteAuditConfig(ctx, msg.UUID)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetHashIndex(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetHashIndex) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetHashIndexConfig(ctx, msg.UUID).Owner
+	if !owner.Empty() && !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetHashIndexConfig(ctx, keeper.GetKVStore(ctx), msg.UUID, types.HashIndexConfig{Owner: msg.Owner})
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeleteHashIndex(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDeleteHashIndex) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetHashIndexConfig(ctx, msg.UUID).Owner
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Hash index does not exist")
+	}
+
+	if !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.DeleteHashIndexConfig(ctx, msg.UUID)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetRetention(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetRetention) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() || msg.MaxKeys == 0 || !types.IsValidRetentionOrder(msg.Order) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetRetentionPolicy(ctx, msg.UUID).Owner
+	if !owner.Empty() && !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetRetentionPolicy(ctx, keeper.GetKVStore(ctx), msg.UUID, types.RetentionPolicy{Owner: msg.Owner, MaxKeys: msg.MaxKeys, Order: msg.Order})
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeleteRetention(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDeleteRetention) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetRetentionPolicy(ctx, msg.UUID).Owner
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Retention policy not set")
+	}
+
+	if !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.DeleteRetentionPolicy(ctx, msg.UUID)
+
+	return &sdk.Result{}, nil
+}
+
+// handleMsgSetSchema sets the schema the values later written to a UUID must conform
+// to. As with retention policies the first account to set one owns it.
+func handleMsgSetSchema(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgSetSchema) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	schema := msg.Schema()
+	if err := schema.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	owner := keeper.GetSchema(ctx, msg.UUID).Owner
+	if !owner.Empty() && !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.SetSchema(ctx, msg.UUID, schema)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeleteSchema(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgDeleteSchema) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	owner := keeper.GetSchema(ctx, msg.UUID).Owner
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Schema not set")
+	}
+
+	if !owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	keeper.DeleteSchema(ctx, msg.UUID)
+
+	return &sdk.Result{}, nil
+}
+
+// handleMsgFreeze freezes one key, which must belong to the sender, or with no key all of
+// the sender's keys in the UUID, including those created later.
+func handleMsgFreeze(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgFreeze) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	if len(msg.Key) != 0 {
+		owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key)
+		if owner.Empty() {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+		}
+
+		if !msg.Owner.Equals(owner) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+		}
+	}
+
+	keeper.Freeze(ctx, msg.Owner, msg.UUID, msg.Key)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgUnfreeze(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgUnfreeze) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	if !keeper.Unfreeze(ctx, msg.Owner, msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Not frozen")
+	}
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgStartUpload(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgStartUpload) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() || msg.Size <= 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	hash, err := hex.DecodeString(msg.Hash)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid hash")
+	}
+
+	if keeper.IsKeyPresent(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists")
+	}
+
+	upload := keeper.GetUpload(ctx, msg.UUID, msg.Key)
+	if !upload.Owner.Empty() && !upload.Owner.Equals(msg.Owner) && !upload.IsExpired(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload already in progress")
+	}
+
+	// default lease...
+	if msg.Lease == 0 {
+		msg.Lease = keeper.GetDefaultLeaseBlocks(ctx)
+	}
+
+	keeper.StartUpload(ctx, msg.UUID, msg.Key, types.Upload{
+		Owner:  msg.Owner,
+		Size:   msg.Size,
+		Hash:   hash,
+		Lease:  msg.Lease,
+		Height: ctx.BlockHeight(),
+	})
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgUploadChunk(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgUploadChunk) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() || len(msg.Data) == 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	upload := keeper.GetUpload(ctx, msg.UUID, msg.Key)
+	if upload.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload does not exist")
+	}
+
+	if !upload.Owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	// chunks must arrive in order, a resent chunk is rejected rather than appended twice
+	if msg.Index != upload.Chunks {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Unexpected chunk index, expected %d", upload.Chunks))
+	}
+
+	if upload.Received+int64(len(msg.Data)) > upload.Size {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload larger than declared size")
+	}
+
+	keeper.AddUploadChunk(ctx, msg.UUID, msg.Key, msg.Data)
+
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCommitUpload(ctx sdk.Context, keeper keeper.IKeeper, msg types.MsgCommitUpload) (*sdk.Result, error) {
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 || msg.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message")
+	}
+
+	upload := keeper.GetUpload(ctx, msg.UUID, msg.Key)
+	if upload.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload does not exist")
+	}
+
+	if !upload.Owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner")
+	}
+
+	if upload.Received != upload.Size {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload incomplete")
+	}
+
+	if keeper.IsKeyPresent(ctx, keeper.GetKVStore(ctx), msg.UUID, msg.Key) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists")
+	}
+
+	value := keeper.AssembleUpload(ctx, msg.UUID, msg.Key)
+	if !bytes.Equal(types.ValueHash(value), upload.Hash) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Hash mismatch")
+	}
+
+	if err := setNewValue(ctx, keeper, msg.UUID, msg.Key, value, upload.Lease, msg.Owner, "", ""); err != nil {
+		return nil, err
+	}
+	keeper.DeleteUpload(ctx, msg.UUID, msg.Key)
+
+	return &sdk.Result{}, nil
 }
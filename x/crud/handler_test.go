@@ -15,14 +15,21 @@
 package crud
 
 import (
+	"encoding/hex"
 	"encoding/json"
-	"github.com/bluzelle/curium/x/crud/internal/types"
 	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -30,7 +37,67 @@ const DefaultLeaseBlockHeight = int64(10 * 86400 / 5) // (10 days of blocks * se
 
 func initTest(t *testing.T) (*gomock.Controller, *mocks.MockIKeeper, sdk.Context, []byte) {
 	mockCtrl := gomock.NewController(t)
-	return mockCtrl, mocks.NewMockIKeeper(mockCtrl), sdk.Context{}, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	// no base gas, the handlers are tested for the gas they consume themselves
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(types.Params{})
+	// and no schemas, see Test_handleMsgSetSchema for the values checked against one
+	mockKeeper.EXPECT().GetSchema(gomock.Any(), gomock.Any()).AnyTimes().Return(types.Schema{})
+	return mockCtrl, mockKeeper, sdk.Context{}, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+}
+
+func TestNewHandler_BaseGas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+
+	params := types.DefaultParams()
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().DoAndReturn(func(sdk.Context) types.Params { return params })
+
+	// the empty messages are rejected before the keeper is used, leaving only the base gas
+	golden := []struct {
+		msg sdk.Msg
+		gas uint64
+	}{
+		{types.MsgCreate{}, 2000},
+		{types.MsgRead{}, 1000},
+		{types.MsgUpdate{}, 2000},
+		{types.MsgDelete{}, 1000},
+		{types.MsgKeys{}, 2000},
+		{types.MsgHas{}, 1000},
+		{types.MsgRename{}, 2000},
+		{types.MsgKeyValues{}, 2000},
+		{types.MsgCount{}, 1000},
+		{types.MsgDeleteAll{}, 5000},
+		{types.MsgMultiUpdate{}, 2000},
+		{types.MsgGetLease{}, 1000},
+		{types.MsgGetNShortestLeases{}, 2000},
+		{types.MsgRenewLease{}, 1000},
+		{types.MsgRenewLeaseAll{}, 2000},
+		{types.MsgCopy{}, 2000},
+		{types.MsgCopyUUID{}, 5000},
+		{types.MsgPatch{}, 2000},
+		{types.MsgSetBeneficiary{}, 0},
+		{types.MsgFreeze{}, 0},
+	}
+
+	for _, g := range golden {
+		ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := NewHandler(mockKeeper)(ctx, g.msg)
+		assert.NotNil(t, err)
+		assert.Equal(t, g.gas, ctx.GasMeter().GasConsumed(), g.msg.Type())
+	}
+
+	// the table is governed by the params
+	params.BaseMsgGas = []types.MsgGas{{MsgType: "read", Gas: 250}}
+	for _, g := range []struct {
+		msg sdk.Msg
+		gas uint64
+	}{{types.MsgRead{}, 250}, {types.MsgCreate{}, 0}} {
+		ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := NewHandler(mockKeeper)(ctx, g.msg)
+		assert.NotNil(t, err)
+		assert.Equal(t, g.gas, ctx.GasMeter().GasConsumed(), g.msg.Type())
+	}
 }
 
 type BadMsg struct {
@@ -46,14 +113,14 @@ func Test_handleMsgCreate(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
-	mockKeeper.EXPECT().GetDefaultLeaseBlocks().AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
 
 	// Simple Unit
 	{
 		createMsg := types.MsgCreate{
 			UUID:  "uuid",
 			Key:   "key",
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 0,
 			Owner: owner,
 		}
@@ -74,11 +141,33 @@ func Test_handleMsgCreate(t *testing.T) {
 		// test valid create message
 		mockKeeper.EXPECT().GetValue(ctx, nil, createMsg.UUID, createMsg.Key)
 		mockKeeper.EXPECT().SetValue(ctx, nil, createMsg.UUID, createMsg.Key, types.BLZValue{Value: createMsg.Value, Owner: createMsg.Owner, Lease: DefaultLeaseBlockHeight})
-		mockKeeper.EXPECT().SetLease(nil, createMsg.UUID, createMsg.Key, int64(0), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, createMsg.UUID, createMsg.Key, int64(0), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().ChargeLease(ctx, createMsg.Owner, createMsg.UUID, createMsg.Key, int64(len("uuid")+len("key")+len("value"))*DefaultLeaseBlockHeight)
 
 		_, err = NewHandler(mockKeeper)(ctx, createMsg)
 		assert.Nil(t, err)
 
+		// content type and encoding are stored with the value
+		typedMsg := createMsg
+		typedMsg.ContentType, typedMsg.Encoding = "text/plain", "gzip"
+		mockKeeper.EXPECT().GetValue(ctx, nil, createMsg.UUID, createMsg.Key)
+		mockKeeper.EXPECT().SetValue(ctx, nil, createMsg.UUID, createMsg.Key, types.BLZValue{Value: createMsg.Value, Owner: createMsg.Owner,
+			Lease: DefaultLeaseBlockHeight, ContentType: "text/plain", Encoding: "gzip"})
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, createMsg.UUID, createMsg.Key, int64(0), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().ChargeLease(ctx, createMsg.Owner, createMsg.UUID, createMsg.Key, gomock.Any())
+
+		_, err = NewHandler(mockKeeper)(ctx, typedMsg)
+		assert.Nil(t, err)
+
+		// test owner unable to pay for the lease
+		mockKeeper.EXPECT().GetValue(ctx, nil, createMsg.UUID, createMsg.Key)
+		mockKeeper.EXPECT().SetValue(ctx, nil, createMsg.UUID, createMsg.Key, gomock.Any())
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, createMsg.UUID, createMsg.Key, int64(0), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().ChargeLease(ctx, createMsg.Owner, createMsg.UUID, createMsg.Key, gomock.Any()).Return(sdkerrors.ErrInsufficientFunds)
+
+		_, err = NewHandler(mockKeeper)(ctx, createMsg)
+		assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
 		// test bad message
 		_, err = NewHandler(mockKeeper)(ctx, BadMsg{})
 		assert.NotNil(t, err)
@@ -128,7 +217,7 @@ func Test_handleMsgRead(t *testing.T) {
 			Owner: owner,
 		}
 		mockKeeper.EXPECT().GetOwner(ctx, nil, readMsg.UUID, readMsg.Key).Return(owner)
-		mockKeeper.EXPECT().GetValue(ctx, nil, readMsg.UUID, readMsg.Key).Return(types.BLZValue{Value: "utest", Owner: owner})
+		mockKeeper.EXPECT().GetValue(ctx, nil, readMsg.UUID, readMsg.Key).Return(types.BLZValue{Value: []byte("utest"), Owner: owner})
 
 		result, err := handleMsgRead(ctx, mockKeeper, readMsg)
 
@@ -138,7 +227,7 @@ func Test_handleMsgRead(t *testing.T) {
 		jsonResult := types.BLZValue{}
 		json.Unmarshal(result.Data, &jsonResult)
 
-		assert.Equal(t, "utest", jsonResult.Value)
+		assert.Equal(t, []byte("utest"), jsonResult.Value)
 	}
 
 	// Test for empty message parameters
@@ -158,7 +247,12 @@ func Test_handleMsgUpdate(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
-	mockKeeper.EXPECT().GetDefaultLeaseBlocks().AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
 
 	// zero new lease must fail
 	{
@@ -166,7 +260,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 			UUID:  "uuid",
 			Key:   "key",
 			Lease: -100,
-			Value: "value",
+			Value: []byte("value"),
 			Owner: owner,
 		}
 		assert.Equal(t, updateMsg.Type(), "update")
@@ -176,7 +270,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 
 		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 100,
 			Owner: owner,
 		})
@@ -200,7 +294,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 			UUID:  "uuid",
 			Key:   "key",
 			Lease: -100,
-			Value: "value",
+			Value: []byte("value"),
 			Owner: owner,
 		}
 		assert.Equal(t, updateMsg.Type(), "update")
@@ -210,7 +304,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 
 		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 0,
 			Owner: owner,
 		})
@@ -234,7 +328,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 			UUID:  "uuid",
 			Key:   "key",
 			Lease: -16,
-			Value: "value",
+			Value: []byte("value"),
 			Owner: owner,
 		}
 		assert.Equal(t, updateMsg.Type(), "update")
@@ -243,7 +337,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 		mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
 		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, updateMsg.UUID, updateMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  20,
 			Owner:  owner,
 			Height: 110,
@@ -260,7 +354,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 			UUID:  "uuid",
 			Key:   "key",
 			Lease: 0,
-			Value: "value",
+			Value: []byte("value"),
 			Owner: owner,
 		}
 		assert.Equal(t, updateMsg.Type(), "update")
@@ -270,12 +364,12 @@ func Test_handleMsgUpdate(t *testing.T) {
 
 		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 0,
 			Owner: owner,
 		})
 		mockKeeper.EXPECT().SetValue(ctx, nil, updateMsg.UUID, updateMsg.Key, types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 0,
 			Owner: owner,
 		})
@@ -298,7 +392,7 @@ func Test_handleMsgUpdate(t *testing.T) {
 			UUID:  "uuid",
 			Key:   "key",
 			Lease: 2000,
-			Value: "value",
+			Value: []byte("value"),
 			Owner: owner,
 		}
 		assert.Equal(t, updateMsg.Type(), "update")
@@ -310,18 +404,18 @@ func Test_handleMsgUpdate(t *testing.T) {
 
 		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 4000,
 			Owner: owner,
 		})
 		mockKeeper.EXPECT().SetValue(ctx, nil, updateMsg.UUID, updateMsg.Key, types.BLZValue{
-			Value: "value",
+			Value: []byte("value"),
 			Lease: 6000,
 			Owner: owner,
 		})
 
 		mockKeeper.EXPECT().DeleteLease(nil, updateMsg.UUID, updateMsg.Key, int64(0), int64(4000))
-		mockKeeper.EXPECT().SetLease(nil, updateMsg.UUID, updateMsg.Key, int64(0), int64(6000))
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, updateMsg.UUID, updateMsg.Key, int64(0), int64(6000))
 
 		_, err := NewHandler(mockKeeper)(ctx, updateMsg)
 		assert.Nil(t, err)
@@ -336,6 +430,26 @@ func Test_handleMsgUpdate(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 
+	// the update only goes ahead when the key is at the expected version
+	{
+		updateMsg := types.MsgUpdate{UUID: "uuid", Key: "key", Value: []byte("new value"), Owner: owner, Version: 3}
+
+		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
+		mockKeeper.EXPECT().GetVersion(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(uint64(4))
+
+		_, err := handleMsgUpdate(ctx, mockKeeper, updateMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Version mismatch: key is at version 4, expected 3").Error(), err.Error())
+
+		mockKeeper.EXPECT().GetOwner(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(owner)
+		mockKeeper.EXPECT().GetVersion(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(uint64(3))
+		mockKeeper.EXPECT().GetValue(ctx, nil, updateMsg.UUID, updateMsg.Key).Return(types.BLZValue{Value: []byte("value"), Owner: owner, Version: 3})
+		mockKeeper.EXPECT().SetValue(ctx, nil, updateMsg.UUID, updateMsg.Key, types.BLZValue{Value: []byte("new value"), Owner: owner})
+
+		_, err = handleMsgUpdate(ctx, mockKeeper, updateMsg)
+		assert.Nil(t, err)
+	}
+
 	// Test for empty message parameters
 	{
 		_, err := handleMsgUpdate(ctx, mockKeeper, types.MsgUpdate{})
@@ -349,6 +463,32 @@ func Test_handleMsgUpdate(t *testing.T) {
 	}
 }
 
+func Test_handleMsgUpdate_ContentType(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").AnyTimes().Return(owner)
+
+	current := types.BLZValue{Value: []byte("{}"), Lease: 100, Owner: owner, ContentType: "application/json", Encoding: "gzip"}
+
+	// without a content type or encoding the key keeps its own
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").Return(current)
+	mockKeeper.EXPECT().SetValue(ctx, nil, "uuid", "key", types.BLZValue{Value: []byte("[]"), Lease: 100, Owner: owner,
+		ContentType: "application/json", Encoding: "gzip"})
+	_, err := NewHandler(mockKeeper)(ctx, types.MsgUpdate{UUID: "uuid", Key: "key", Value: []byte("[]"), Owner: owner})
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").Return(current)
+	mockKeeper.EXPECT().SetValue(ctx, nil, "uuid", "key", types.BLZValue{Value: []byte("text"), Lease: 100, Owner: owner,
+		ContentType: "text/plain", Encoding: "gzip"})
+	_, err = NewHandler(mockKeeper)(ctx, types.MsgUpdate{UUID: "uuid", Key: "key", Value: []byte("text"), Owner: owner, ContentType: "text/plain"})
+	assert.Nil(t, err)
+}
+
 func Test_handleMsgDelete(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
@@ -376,6 +516,38 @@ func Test_handleMsgDelete(t *testing.T) {
 		assert.NotNil(t, err)
 
 		mockKeeper.EXPECT().GetOwner(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(owner)
+		mockKeeper.EXPECT().IsFrozen(ctx, gomock.Any(), deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().IsExpired(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().DeleteValue(ctx, nil, nil, deleteMsg.UUID, deleteMsg.Key)
+		_, err = handleMsgDelete(ctx, mockKeeper, deleteMsg)
+		assert.Nil(t, err)
+
+		// expired keys are read-only until their lease is renewed or they are purged
+		mockKeeper.EXPECT().GetOwner(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(owner)
+		mockKeeper.EXPECT().IsFrozen(ctx, gomock.Any(), deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().IsExpired(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(true)
+		_, err = handleMsgDelete(ctx, mockKeeper, deleteMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired").Error(), err.Error())
+
+		// frozen keys are not deleted
+		mockKeeper.EXPECT().GetOwner(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(owner)
+		mockKeeper.EXPECT().IsFrozen(ctx, gomock.Any(), deleteMsg.UUID, deleteMsg.Key).Return(true)
+		_, err = handleMsgDelete(ctx, mockKeeper, deleteMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen").Error(), err.Error())
+
+		// a stale version leaves the key in place
+		deleteMsg.Version = 1
+		mockKeeper.EXPECT().GetOwner(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(owner)
+		mockKeeper.EXPECT().IsFrozen(ctx, gomock.Any(), deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().IsExpired(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().GetVersion(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(uint64(2))
+		_, err = handleMsgDelete(ctx, mockKeeper, deleteMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Version mismatch: key is at version 2, expected 1").Error(), err.Error())
+
+		mockKeeper.EXPECT().GetOwner(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(owner)
+		mockKeeper.EXPECT().IsFrozen(ctx, gomock.Any(), deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().IsExpired(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(false)
+		mockKeeper.EXPECT().GetVersion(ctx, nil, deleteMsg.UUID, deleteMsg.Key).Return(uint64(1))
 		mockKeeper.EXPECT().DeleteValue(ctx, nil, nil, deleteMsg.UUID, deleteMsg.Key)
 		_, err = handleMsgDelete(ctx, mockKeeper, deleteMsg)
 		assert.Nil(t, err)
@@ -394,6 +566,58 @@ func Test_handleMsgDelete(t *testing.T) {
 	}
 }
 
+func Test_handleMsgExpireNow(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgExpireNow("uuid", "key", owner)
+	assert.Equal(t, "expirenow", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, msg.UUID, msg.Key)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, msg.UUID, msg.Key).Return([]byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"))
+	_, err = handleMsgExpireNow(ctx, mockKeeper, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, msg.UUID, msg.Key).Return(owner)
+	mockKeeper.EXPECT().IsFrozen(ctx, owner, msg.UUID, msg.Key).Return(true)
+	_, err = handleMsgExpireNow(ctx, mockKeeper, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, msg.UUID, msg.Key).Return(owner)
+	mockKeeper.EXPECT().IsFrozen(ctx, owner, msg.UUID, msg.Key).Return(false)
+	mockKeeper.EXPECT().IsExpired(ctx, nil, msg.UUID, msg.Key).Return(true)
+	_, err = handleMsgExpireNow(ctx, mockKeeper, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired").Error(), err.Error())
+
+	refund := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 40))
+	mockKeeper.EXPECT().GetOwner(ctx, nil, msg.UUID, msg.Key).Return(owner)
+	mockKeeper.EXPECT().IsFrozen(ctx, owner, msg.UUID, msg.Key).Return(false)
+	mockKeeper.EXPECT().IsExpired(ctx, nil, msg.UUID, msg.Key).Return(false)
+	mockKeeper.EXPECT().ExpireNow(ctx, nil, nil, msg.UUID, msg.Key).Return(int64(100), refund)
+	result, err := handleMsgExpireNow(ctx, mockKeeper, msg)
+	assert.Nil(t, err)
+
+	var jsonResult types.QueryResultExpireNow
+	assert.Nil(t, json.Unmarshal(result.Data, &jsonResult))
+	assert.Equal(t, types.QueryResultExpireNow{UUID: "uuid", Key: "key", Expiry: 100, Refund: refund}, jsonResult)
+	assert.Contains(t, result.Events, sdk.NewEvent(types.EventTypeExpireNow,
+		sdk.NewAttribute(types.AttributeKeyUUID, "uuid"),
+		sdk.NewAttribute(types.AttributeKeyKey, "key"),
+		sdk.NewAttribute(types.AttributeKeyOwner, owner.String()),
+		sdk.NewAttribute(types.AttributeKeyExpiry, "100"),
+		sdk.NewAttribute(types.AttributeKeyRefund, "40ubnt"),
+	))
+
+	_, err = handleMsgExpireNow(ctx, mockKeeper, types.MsgExpireNow{UUID: "uuid", Key: "key"})
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message").Error(), err.Error())
+}
+
 func Test_handleMsgKeys(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
@@ -488,6 +712,9 @@ func Test_handleMsgRename(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+
 	// Simple Rename test
 	{
 		renameMsg := types.MsgRename{
@@ -501,11 +728,33 @@ func Test_handleMsgRename(t *testing.T) {
 		// always return nil for a store...
 		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
 		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(owner)
-		mockKeeper.EXPECT().RenameKey(ctx, gomock.Any(), renameMsg.UUID, renameMsg.Key, renameMsg.NewKey).Return(true)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.NewKey)
+		mockKeeper.EXPECT().RenameKey(ctx, gomock.Any(), renameMsg.UUID, renameMsg.Key, renameMsg.NewKey, false).Return(int64(110), true)
+
+		result, err := NewHandler(mockKeeper)(ctx, renameMsg)
+		assert.Nil(t, err)
+		assert.Equal(t, "{\"uuid\":\"uuid\",\"key\":\"key\",\"new_key\":\"newkey\",\"expiry\":\"110\"}", string(result.Data))
+
+		// an existing new key needs overwrite
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(owner)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.NewKey).Return(owner)
+		_, err = handleMsgRename(ctx, mockKeeper, renameMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "New key already exists").Error(), err.Error())
 
-		_, err := NewHandler(mockKeeper)(ctx, renameMsg)
+		renameMsg.Overwrite = true
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(owner)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.NewKey).Return(owner)
+		mockKeeper.EXPECT().RenameKey(ctx, gomock.Any(), renameMsg.UUID, renameMsg.Key, renameMsg.NewKey, true).Return(int64(110), true)
+		_, err = handleMsgRename(ctx, mockKeeper, renameMsg)
 		assert.Nil(t, err)
 
+		// and may only replace a key of the same owner
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(owner)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.NewKey).Return(sdk.AccAddress("other"))
+		_, err = handleMsgRename(ctx, mockKeeper, renameMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner of new key").Error(), err.Error())
+		renameMsg.Overwrite = false
+
 		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(owner)
 		renameMsg.Owner = []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
 		_, err = handleMsgRename(ctx, mockKeeper, renameMsg)
@@ -518,7 +767,8 @@ func Test_handleMsgRename(t *testing.T) {
 		// Rename failed
 		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
 		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.Key).Return(renameMsg.Owner)
-		mockKeeper.EXPECT().RenameKey(ctx, gomock.Any(), renameMsg.UUID, renameMsg.Key, renameMsg.NewKey).Return(false)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, renameMsg.UUID, renameMsg.NewKey)
+		mockKeeper.EXPECT().RenameKey(ctx, gomock.Any(), renameMsg.UUID, renameMsg.Key, renameMsg.NewKey, false).Return(int64(0), false)
 
 		_, err = NewHandler(mockKeeper)(ctx, renameMsg)
 		assert.NotNil(t, err)
@@ -554,8 +804,8 @@ func Test_handleMsgKeyValues(t *testing.T) {
 		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
 
 		var keyValues []types.KeyValue
-		keyValues = append(keyValues, types.KeyValue{Key: "key0", Value: "value0"})
-		keyValues = append(keyValues, types.KeyValue{Key: "key1", Value: "value1"})
+		keyValues = append(keyValues, types.KeyValue{Key: "key0", Value: []byte("value0")})
+		keyValues = append(keyValues, types.KeyValue{Key: "key1", Value: []byte("value1")})
 
 		acceptedKeyValues := types.QueryResultKeyValues{UUID: "uuid", KeyValues: keyValues}
 
@@ -620,18 +870,51 @@ func Test_handleMsgDeleteAll(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
+	mockGasMeter := mocks.NewMockGasMeter(mockCtrl)
+	ctx = ctx.WithGasMeter(mockGasMeter).WithKVGasConfig(storetypes.KVGasConfig())
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
 	// Simple delete test key does not exist
 	{
 		deleteAllMsg := types.MsgDeleteAll{UUID: "uuid", Owner: owner}
 
-		// always return nil for a store...
-		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
-		mockKeeper.EXPECT().DeleteAll(ctx, nil, deleteAllMsg.UUID, gomock.Any())
+		mockKeeper.EXPECT().HasFrozen(ctx, gomock.Any(), deleteAllMsg.UUID).Return(false)
+		mockKeeper.EXPECT().DeleteAll(ctx, nil, deleteAllMsg.UUID, gomock.Any()).Return(types.QueryResultDeleteAll{UUID: "uuid"})
+		mockGasMeter.EXPECT().ConsumeGas(uint64(0), "crud deleteall")
 
 		_, err := NewHandler(mockKeeper)(ctx, deleteAllMsg)
 		assert.Nil(t, err)
 	}
 
+	// gas is charged per deleted key and the remaining count is returned
+	{
+		deleteAllMsg := types.MsgDeleteAll{UUID: "uuid", Owner: owner}
+		result := types.QueryResultDeleteAll{UUID: "uuid", Count: 3, Remaining: 2, Next: "key3"}
+
+		mockKeeper.EXPECT().HasFrozen(ctx, gomock.Any(), deleteAllMsg.UUID).Return(false)
+		mockKeeper.EXPECT().DeleteAll(ctx, nil, deleteAllMsg.UUID, owner).Return(result)
+		mockGasMeter.EXPECT().ConsumeGas(uint64(3*1000), "crud deleteall")
+
+		res, err := NewHandler(mockKeeper)(ctx, deleteAllMsg)
+		assert.Nil(t, err)
+
+		var actual types.QueryResultDeleteAll
+		assert.Nil(t, json.Unmarshal(res.Data, &actual))
+		assert.Equal(t, result, actual)
+	}
+
+	// nothing is deleted while any of the owner's keys are frozen
+	{
+		deleteAllMsg := types.MsgDeleteAll{UUID: "uuid", Owner: owner}
+
+		mockKeeper.EXPECT().HasFrozen(ctx, sdk.AccAddress(owner), deleteAllMsg.UUID).Return(true)
+
+		_, err := NewHandler(mockKeeper)(ctx, deleteAllMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID has frozen keys").Error(), err.Error())
+	}
+
 	// Test for empty message parameters
 	{
 		_, err := handleMsgDeleteAll(ctx, mockKeeper, types.MsgDeleteAll{})
@@ -646,11 +929,16 @@ func Test_handleMsgMultiUpdate(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
 	// Update multiple key/values
 	{
 		multiUpdateMsg := types.MsgMultiUpdate{UUID: "uuid", Owner: owner}
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: "value1"})
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: "value1"})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: []byte("value1")})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: []byte("value1")})
 
 		assert.Equal(t, "multiupdate", multiUpdateMsg.Type())
 
@@ -659,20 +947,64 @@ func Test_handleMsgMultiUpdate(t *testing.T) {
 		mockKeeper.EXPECT().GetOwner(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(owner)
 		mockKeeper.EXPECT().GetOwner(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key).Return(owner)
 
+		mockKeeper.EXPECT().GetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().GetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key).Return(types.BLZValue{Value: []byte("value0"), Lease: 200, Height: 20, Owner: owner})
+
+		mockKeeper.EXPECT().SetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key,
+			types.BLZValue{Value: multiUpdateMsg.KeyValues[0].Value, Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().SetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key,
+			types.BLZValue{Value: multiUpdateMsg.KeyValues[1].Value, Lease: 200, Height: 20, Owner: owner})
+
+		_, err := NewHandler(mockKeeper)(ctx, multiUpdateMsg)
+		assert.Nil(t, err)
+	}
+
+	// Update multiple key/values, extending the lease of one and shortening the other
+	{
+		multiUpdateMsg := types.MsgMultiUpdate{UUID: "uuid", Owner: owner}
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: []byte("value1"), Lease: 50})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: []byte("value1"), Lease: -100})
+
+		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+		mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(owner)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key).Return(owner)
+
+		mockKeeper.EXPECT().GetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().GetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key).Return(types.BLZValue{Value: []byte("value0"), Lease: 200, Height: 20, Owner: owner})
+
 		mockKeeper.EXPECT().SetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key,
-			types.BLZValue{Value: multiUpdateMsg.KeyValues[0].Value, Owner: owner})
+			types.BLZValue{Value: multiUpdateMsg.KeyValues[0].Value, Lease: 150, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().DeleteLease(nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key, int64(10), int64(100))
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key, int64(10), int64(150))
+
 		mockKeeper.EXPECT().SetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key,
-			types.BLZValue{Value: multiUpdateMsg.KeyValues[1].Value, Owner: owner})
+			types.BLZValue{Value: multiUpdateMsg.KeyValues[1].Value, Lease: 100, Height: 20, Owner: owner})
+		mockKeeper.EXPECT().DeleteLease(nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key, int64(20), int64(200))
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[1].Key, int64(20), int64(100))
 
 		_, err := NewHandler(mockKeeper)(ctx, multiUpdateMsg)
 		assert.Nil(t, err)
 	}
 
+	// a lease delta that would expire the key fails the whole message
+	{
+		multiUpdateMsg := types.MsgMultiUpdate{UUID: "uuid", Owner: owner}
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: []byte("value1"), Lease: -100})
+
+		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+		mockKeeper.EXPECT().GetOwner(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(owner)
+		mockKeeper.EXPECT().GetValue(ctx, nil, multiUpdateMsg.UUID, multiUpdateMsg.KeyValues[0].Key).Return(types.BLZValue{Value: []byte("value0"), Lease: 100, Height: 10, Owner: owner})
+
+		_, err := NewHandler(mockKeeper)(ctx, multiUpdateMsg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid lease [0]").Error(), err.Error())
+	}
+
 	// Attempt to update key/values, but one does not exist
 	{
 		multiUpdateMsg := types.MsgMultiUpdate{UUID: "uuid", Owner: owner}
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: "value1"})
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: "value1"})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: []byte("value1")})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: []byte("value1")})
 
 		// always return nil for a store...
 		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
@@ -687,8 +1019,8 @@ func Test_handleMsgMultiUpdate(t *testing.T) {
 	// Attempt to update key/values, but one has a different owner
 	{
 		multiUpdateMsg := types.MsgMultiUpdate{UUID: "uuid", Owner: owner}
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: "value1"})
-		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: "value1"})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key0", Value: []byte("value1")})
+		multiUpdateMsg.KeyValues = append(multiUpdateMsg.KeyValues, types.KeyValue{Key: "key1", Value: []byte("value1")})
 
 		// always return nil for a store...
 		mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
@@ -708,11 +1040,169 @@ func Test_handleMsgMultiUpdate(t *testing.T) {
 		_, err = handleMsgMultiUpdate(ctx, mockKeeper, types.MsgMultiUpdate{UUID: "uuid"})
 		assert.NotNil(t, err)
 
-		_, err = handleMsgMultiUpdate(ctx, mockKeeper, types.MsgMultiUpdate{UUID: "uuid", KeyValues: []types.KeyValue{{Key: "key0", Value: "value1"}}})
+		_, err = handleMsgMultiUpdate(ctx, mockKeeper, types.MsgMultiUpdate{UUID: "uuid", KeyValues: []types.KeyValue{{Key: "key0", Value: []byte("value1")}}})
 		assert.NotNil(t, err)
 	}
 }
 
+func Test_handleMsgMultiUpdate_Batch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	assert.Nil(t, cms.LoadLatestVersion())
+	newCtx := func() sdk.Context {
+		return sdk.NewContext(cms, abci.Header{Height: 100}, false, log.NewNopLogger())
+	}
+
+	params := types.Params{MaxBatchSize: 2, BatchItemGas: 100, BatchByteGas: 10}
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(params)
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetSchema(gomock.Any(), gomock.Any()).AnyTimes().Return(types.Schema{})
+
+	keyValues := []types.KeyValue{{Key: "key0", Value: []byte("value0")}, {Key: "key1", Value: []byte("value1")}}
+
+	// batches over MaxBatchSize are rejected
+	{
+		msg := types.NewMsgMultiUpdate("uuid", owner, append(keyValues, types.KeyValue{Key: "key2", Value: []byte("value2")}))
+		_, err := NewHandler(mockKeeper)(newCtx(), msg)
+		assert.EqualError(t, err, "Too many key values, 3 of at most 2: invalid request")
+	}
+
+	// every key value is charged its batch gas and reported as applied
+	{
+		ctx := newCtx()
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key0").Return(owner)
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key1").Return(owner)
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key0").Return(types.BLZValue{Value: []byte("old"), Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key1").Return(types.BLZValue{Value: []byte("old"), Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key0", gomock.Any())
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key1", gomock.Any())
+
+		res, err := NewHandler(mockKeeper)(ctx, types.NewMsgMultiUpdate("uuid", owner, keyValues))
+		assert.Nil(t, err)
+		// (100 + 10 bytes * 10) per key value
+		assert.Equal(t, uint64(400), ctx.GasMeter().GasConsumed())
+
+		var result types.QueryResultMultiUpdate
+		assert.Nil(t, json.Unmarshal(res.Data, &result))
+		assert.Equal(t, types.QueryResultMultiUpdate{UUID: "uuid", Results: []types.MultiUpdateResult{
+			{Key: "key0", Applied: true}, {Key: "key1", Applied: true}}}, result)
+	}
+
+	// a partial update skips the key values that cannot be updated
+	{
+		msg := types.NewMsgMultiUpdate("uuid", owner, keyValues)
+		msg.Partial = true
+
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key0")
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key1").Return(owner)
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key1").Return(types.BLZValue{Value: []byte("old"), Lease: 100, Height: 10, Owner: owner})
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key1", gomock.Any())
+
+		res, err := NewHandler(mockKeeper)(newCtx(), msg)
+		assert.Nil(t, err)
+
+		var result types.QueryResultMultiUpdate
+		assert.Nil(t, json.Unmarshal(res.Data, &result))
+		assert.Equal(t, types.QueryResultMultiUpdate{UUID: "uuid", Results: []types.MultiUpdateResult{
+			{Key: "key0", Error: "Key does not exist: invalid request"}, {Key: "key1", Applied: true}}}, result)
+	}
+}
+
+func Test_handleMsgAtomic(t *testing.T) {
+	mockCtrl, mockKeeper, _, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	// the mocked keeper keeps its values in a real store, so that the writes of the ops can
+	// be seen to reach it, or not, through the cache context
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	assert.Nil(t, cms.LoadLatestVersion())
+	ctx := sdk.NewContext(cms, abci.Header{Height: 10}, false, log.NewNopLogger())
+
+	has := func(UUID, key string) bool { return ctx.KVStore(storeKey).Has([]byte(UUID + key)) }
+	ctx.KVStore(storeKey).Set([]byte("uuid0old"), []byte("value"))
+
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().DoAndReturn(func(ctx sdk.Context) sdk.KVStore { return ctx.KVStore(storeKey) })
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().GetOwner(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ sdk.Context, store sdk.KVStore, UUID string, key string) sdk.AccAddress {
+			if store.Has([]byte(UUID + key)) {
+				return owner
+			}
+			return nil
+		})
+	mockKeeper.EXPECT().GetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ sdk.Context, store sdk.KVStore, UUID string, key string) types.BLZValue {
+			if store.Has([]byte(UUID + key)) {
+				return types.BLZValue{Value: store.Get([]byte(UUID + key)), Owner: owner}
+			}
+			return types.BLZValue{}
+		})
+	mockKeeper.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Do(
+		func(_ sdk.Context, store sdk.KVStore, UUID string, key string, value types.BLZValue) {
+			store.Set([]byte(UUID+key), value.Value)
+		})
+	mockKeeper.EXPECT().DeleteValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Do(
+		func(_ sdk.Context, store sdk.KVStore, _ sdk.KVStore, UUID string, key string) {
+			store.Delete([]byte(UUID + key))
+		})
+	mockKeeper.EXPECT().SetLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+
+	assert.Equal(t, "atomic", types.MsgAtomic{}.Type())
+
+	// ops across two UUIDs are all applied
+	{
+		msg := types.NewMsgAtomic([]types.AtomicOp{
+			{Op: types.AtomicOpCreate, UUID: "uuid0", Key: "key", Value: []byte("value")},
+			{Op: types.AtomicOpCreate, UUID: "uuid1", Key: "key", Value: []byte("value")},
+			{Op: types.AtomicOpDelete, UUID: "uuid0", Key: "old"},
+		}, owner)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Nil(t, err)
+		assert.True(t, has("uuid0", "key"))
+		assert.True(t, has("uuid1", "key"))
+		assert.False(t, has("uuid0", "old"))
+	}
+
+	// a failing op leaves the store as it was, the ops before it included
+	{
+		msg := types.NewMsgAtomic([]types.AtomicOp{
+			{Op: types.AtomicOpCreate, UUID: "uuid2", Key: "key", Value: []byte("value")},
+			{Op: types.AtomicOpDelete, UUID: "uuid0", Key: "key"},
+			{Op: types.AtomicOpCreate, UUID: "uuid1", Key: "key", Value: []byte("value")},
+		}, owner)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists"), "[2]").Error(), err.Error())
+		assert.False(t, has("uuid2", "key"))
+		assert.True(t, has("uuid0", "key"))
+	}
+
+	// unknown operations are rejected
+	{
+		msg := types.NewMsgAtomic([]types.AtomicOp{{Op: "rename", UUID: "uuid0", Key: "key"}}, owner)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Unknown operation rename [0]").Error(), err.Error())
+	}
+
+	_, err := NewHandler(mockKeeper)(ctx, types.MsgAtomic{Owner: owner})
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid message").Error(), err.Error())
+}
+
 func Test_handleMsgGetLease(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
@@ -745,7 +1235,7 @@ func Test_handleMsgGetLease(t *testing.T) {
 		// always return nil for a store...
 		mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
 		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, msgGetLease.UUID, msgGetLease.Key).Return(types.BLZValue{
-			Value:  "test",
+			Value:  []byte("test"),
 			Lease:  10,
 			Height: 1000,
 			Owner:  msgGetLease.Owner,
@@ -821,7 +1311,9 @@ func Test_handleMsgRenewLease(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
-	mockKeeper.EXPECT().GetDefaultLeaseBlocks().AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
 
 	// renew the lease to the default and update height
 	{
@@ -838,7 +1330,7 @@ func Test_handleMsgRenewLease(t *testing.T) {
 		mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes()
 		mockKeeper.EXPECT().GetOwner(ctx, nil, renewMsg.UUID, renewMsg.Key).Return(owner)
 		mockKeeper.EXPECT().GetValue(ctx, nil, renewMsg.UUID, renewMsg.Key).Return(types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  100,
 			Owner:  owner,
 			Height: 1000,
@@ -846,13 +1338,13 @@ func Test_handleMsgRenewLease(t *testing.T) {
 
 		mockKeeper.EXPECT().DeleteLease(nil, renewMsg.UUID, renewMsg.Key, int64(1000), int64(100))
 		mockKeeper.EXPECT().SetValue(ctx, nil, renewMsg.UUID, renewMsg.Key, types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  DefaultLeaseBlockHeight,
 			Owner:  owner,
 			Height: 1100,
 		})
 
-		mockKeeper.EXPECT().SetLease(gomock.Any(), renewMsg.UUID, renewMsg.Key, int64(1100), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().SetLease(gomock.Any(), gomock.Any(), renewMsg.UUID, renewMsg.Key, int64(1100), DefaultLeaseBlockHeight)
 		_, err := NewHandler(mockKeeper)(ctx, renewMsg)
 		assert.Nil(t, err)
 
@@ -884,6 +1376,8 @@ func Test_handleMsgRenewLeaseAll(t *testing.T) {
 	mockCtrl, mockKeeper, ctx, owner := initTest(t)
 	defer mockCtrl.Finish()
 
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
 	msg := types.MsgRenewLeaseAll{UUID: "uuid", Lease: 0, Owner: owner}
 
 	ctx = ctx.WithBlockHeight(int64(500))
@@ -891,7 +1385,7 @@ func Test_handleMsgRenewLeaseAll(t *testing.T) {
 	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
 	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
 	mockKeeper.EXPECT().GetKeys(ctx, nil, msg.UUID, msg.Owner)
-	mockKeeper.EXPECT().GetDefaultLeaseBlocks().AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
 
 	_, err := NewHandler(mockKeeper)(ctx, msg)
 	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID does not exist").Error(), err.Error())
@@ -903,16 +1397,16 @@ func Test_handleMsgRenewLeaseAll(t *testing.T) {
 			Keys: []string{"one", "two"},
 		})
 
-		ctx = ctx.WithBlockHeight(8000)
+		ctx = ctx.WithBlockHeight(8000).WithGasMeter(sdk.NewInfiniteGasMeter()).WithEventManager(sdk.NewEventManager())
 		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, msg.UUID, "one").Return(types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  1700,
 			Height: 7000,
 			Owner:  msg.Owner,
 		})
 
 		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, msg.UUID, "two").Return(types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  600,
 			Height: 7500,
 			Owner:  msg.Owner,
@@ -922,24 +1416,34 @@ func Test_handleMsgRenewLeaseAll(t *testing.T) {
 		mockKeeper.EXPECT().DeleteLease(nil, msg.UUID, "two", int64(7500), int64(600))
 
 		mockKeeper.EXPECT().SetValue(ctx, nil, msg.UUID, "one", types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  DefaultLeaseBlockHeight,
 			Height: 8000,
 			Owner:  msg.Owner,
 		})
 
 		mockKeeper.EXPECT().SetValue(ctx, nil, msg.UUID, "two", types.BLZValue{
-			Value:  "value",
+			Value:  []byte("value"),
 			Lease:  DefaultLeaseBlockHeight,
 			Height: 8000,
 			Owner:  msg.Owner,
 		})
 
-		mockKeeper.EXPECT().SetLease(nil, msg.UUID, "one", int64(8000), DefaultLeaseBlockHeight)
-		mockKeeper.EXPECT().SetLease(nil, msg.UUID, "two", int64(8000), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, msg.UUID, "one", int64(8000), DefaultLeaseBlockHeight)
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, msg.UUID, "two", int64(8000), DefaultLeaseBlockHeight)
 
-		_, err = handleMsgRenewLeaseAll(ctx, mockKeeper, msg)
+		result, err := handleMsgRenewLeaseAll(ctx, mockKeeper, msg)
 		assert.Nil(t, err)
+
+		expiry := 8000 + DefaultLeaseBlockHeight
+		var renewed types.QueryResultRenewLeaseAll
+		assert.Nil(t, json.Unmarshal(result.Data, &renewed))
+		assert.Equal(t, types.QueryResultRenewLeaseAll{UUID: msg.UUID, Count: 2, Keys: []types.KeyExpiry{{Key: "one", Expiry: expiry}, {Key: "two", Expiry: expiry}}}, renewed)
+
+		assert.Len(t, result.Events, 2)
+		assert.Equal(t, types.EventTypeRenewLease, result.Events[1].Type)
+		assert.Equal(t, []byte("two"), result.Events[1].Attributes[1].Value)
+		assert.Equal(t, []byte(strconv.FormatInt(expiry, 10)), result.Events[1].Attributes[2].Value)
 	}
 
 	// Test for empty message parameters
@@ -954,3 +1458,749 @@ func Test_handleMsgRenewLeaseAll(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func Test_handleMsgCopy(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	msg := types.NewMsgCopy("uuid", "key", "newuuid", "newkey", 0, owner)
+	assert.Equal(t, "copy", msg.Type())
+
+	ctx = ctx.WithBlockHeight(100)
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
+
+	// source key does not exist
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+	}
+
+	// destination key already exists
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key).Return(types.BLZValue{Value: []byte("value"), Owner: owner})
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.NewUUID, msg.NewKey).Return(types.BLZValue{Value: []byte("other"), Owner: owner})
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists").Error(), err.Error())
+	}
+
+	// copy of another owner's key is owned by the sender with a fresh lease
+	{
+		other := []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key).Return(types.BLZValue{Value: []byte("value"), Lease: 10, Height: 5, Owner: other})
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.NewUUID, msg.NewKey)
+		mockKeeper.EXPECT().SetValue(ctx, nil, msg.NewUUID, msg.NewKey, types.BLZValue{
+			Value:  []byte("value"),
+			Lease:  DefaultLeaseBlockHeight,
+			Height: 100,
+			Owner:  owner,
+		})
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, msg.NewUUID, msg.NewKey, int64(100), DefaultLeaseBlockHeight)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Nil(t, err)
+	}
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgCopy(ctx, mockKeeper, types.MsgCopy{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgCopy(ctx, mockKeeper, types.MsgCopy{UUID: "uuid", Key: "key", Owner: owner})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgCopyUUID(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgCopyUUID("uuid", "newuuid", 500, owner)
+	assert.Equal(t, "copyuuid", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+
+	keyValues := []types.KeyValue{{Key: "a", Value: []byte("value")}, {Key: "bb", Value: []byte("v")}}
+	mockKeeper.EXPECT().CopyAll(ctx, nil, nil, msg.UUID, msg.NewUUID, msg.Owner, int64(500)).Return(keyValues, true)
+	mockKeeper.EXPECT().ChargeLease(ctx, msg.Owner, msg.NewUUID, "a", int64((7+1+5)*500))
+	mockKeeper.EXPECT().ChargeLease(ctx, msg.Owner, msg.NewUUID, "bb", int64((7+2+1)*500))
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().CopyAll(ctx, nil, nil, msg.UUID, msg.NewUUID, msg.Owner, int64(500)).Return(nil, false)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Copy failed").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgCopyUUID(ctx, mockKeeper, types.MsgCopyUUID{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgCopyUUID(ctx, mockKeeper, types.MsgCopyUUID{UUID: "uuid", NewUUID: "uuid", Owner: owner})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgPatch(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	mockGasMeter := mocks.NewMockGasMeter(mockCtrl)
+	ctx = ctx.WithGasMeter(mockGasMeter).WithKVGasConfig(storetypes.KVGasConfig())
+
+	msg := types.NewMsgPatch("uuid", "key", `{"b":"c"}`, owner)
+	assert.Equal(t, "patch", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	// key does not exist
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key)
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+	}
+
+	// wrong owner
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key).Return(types.BLZValue{Value: []byte(`{"a":"b"}`), Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+	}
+
+	// stored value is not JSON
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key).Return(types.BLZValue{Value: []byte("plain text"), Owner: owner})
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "value is not valid JSON").Error(), err.Error())
+	}
+
+	// patch applied, lease untouched, gas charged for the patch plus the growth
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, msg.UUID, msg.Key).Return(types.BLZValue{Value: []byte(`{"a":"b"}`), Lease: 100, Height: 10, Owner: owner})
+		mockGasMeter.EXPECT().ConsumeGas(uint64((9+8)*30), "crud patch")
+		mockKeeper.EXPECT().SetValue(ctx, nil, msg.UUID, msg.Key, types.BLZValue{Value: []byte(`{"a":"b","b":"c"}`), Lease: 100, Height: 10, Owner: owner})
+
+		_, err := NewHandler(mockKeeper)(ctx, msg)
+		assert.Nil(t, err)
+	}
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgPatch(ctx, mockKeeper, types.MsgPatch{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgPatch(ctx, mockKeeper, types.MsgPatch{UUID: "uuid", Key: "key", Owner: owner})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgSetBeneficiary(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	heir := sdk.AccAddress("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
+	msg := types.NewMsgSetBeneficiary("uuid", "key", heir, owner)
+	assert.Equal(t, "setbeneficiary", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(msg.Owner)
+	mockKeeper.EXPECT().SetBeneficiary(ctx, "uuid", "key", msg.Owner, heir)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// the owner cannot pay the escrow
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(msg.Owner)
+	mockKeeper.EXPECT().SetBeneficiary(ctx, "uuid", "key", msg.Owner, heir).Return(sdkerrors.ErrInsufficientFunds)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key")
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(heir)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgSetBeneficiary(ctx, mockKeeper, types.MsgSetBeneficiary{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgSetBeneficiary(ctx, mockKeeper, types.MsgSetBeneficiary{UUID: "uuid", Owner: owner})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgDepositEscrow(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))
+	msg := types.NewMsgDepositEscrow(amount, owner)
+	assert.Equal(t, "depositescrow", msg.Type())
+
+	mockKeeper.EXPECT().DepositEscrow(ctx, msg.Owner, amount)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().DepositEscrow(ctx, msg.Owner, amount).Return(sdkerrors.ErrInsufficientFunds)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
+	_, err = handleMsgDepositEscrow(ctx, mockKeeper, types.MsgDepositEscrow{Owner: owner})
+	assert.NotNil(t, err)
+}
+
+func Test_handleMsgWithdrawEscrow(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))
+	msg := types.NewMsgWithdrawEscrow(amount, owner)
+	assert.Equal(t, "withdrawescrow", msg.Type())
+
+	mockKeeper.EXPECT().WithdrawEscrow(ctx, msg.Owner, amount)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().WithdrawEscrow(ctx, msg.Owner, amount).Return(sdkerrors.ErrInsufficientFunds)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
+	_, err = handleMsgWithdrawEscrow(ctx, mockKeeper, types.MsgWithdrawEscrow{Owner: owner})
+	assert.NotNil(t, err)
+}
+
+func Test_handleMsgSetAutoRenew(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgSetAutoRenew("uuid", "key", true, owner)
+	assert.Equal(t, "setautorenew", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(msg.Owner)
+	mockKeeper.EXPECT().SetAutoRenew(ctx, "uuid", "key", true)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key")
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(sdk.AccAddress("other"))
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	_, err = handleMsgSetAutoRenew(ctx, mockKeeper, types.MsgSetAutoRenew{UUID: "uuid", Owner: owner})
+	assert.NotNil(t, err)
+}
+
+func Test_handleMsgSetIndex(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgSetIndex("uuid", "colour", owner)
+	assert.Equal(t, "setindex", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	// not yet indexed
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{})
+	mockKeeper.EXPECT().SetIndexConfig(ctx, nil, "uuid", types.IndexConfig{Field: "colour", Owner: owner})
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// reconfigured by its owner
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{Owner: owner})
+	mockKeeper.EXPECT().SetIndexConfig(ctx, nil, "uuid", types.IndexConfig{Field: "colour", Owner: owner})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// indexed by someone else
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgSetIndex(ctx, mockKeeper, types.MsgSetIndex{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgDeleteIndex(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgDeleteIndex("uuid", owner)
+	assert.Equal(t, "deleteindex", msg.Type())
+
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{Owner: owner})
+	mockKeeper.EXPECT().DeleteIndexConfig(ctx, "uuid")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Index does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgDeleteIndex(ctx, mockKeeper, types.MsgDeleteIndex{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgSetHashIndex(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgSetHashIndex("uuid", owner)
+	assert.Equal(t, "sethashindex", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").Return(types.HashIndexConfig{})
+	mockKeeper.EXPECT().SetHashIndexConfig(ctx, nil, "uuid", types.HashIndexConfig{Owner: owner})
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// indexed by someone else
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").Return(types.HashIndexConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgSetHashIndex(ctx, mockKeeper, types.MsgSetHashIndex{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgDeleteHashIndex(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgDeleteHashIndex("uuid", owner)
+	assert.Equal(t, "deletehashindex", msg.Type())
+
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").Return(types.HashIndexConfig{Owner: owner})
+	mockKeeper.EXPECT().DeleteHashIndexConfig(ctx, "uuid")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").Return(types.HashIndexConfig{})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Hash index does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").Return(types.HashIndexConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgDeleteHashIndex(ctx, mockKeeper, types.MsgDeleteHashIndex{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgSetAudit(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgSetAudit("uuid", owner)
+	assert.Equal(t, "setaudit", msg.Type())
+
+	mockKeeper.EXPECT().GetAuditConfig(ctx, "uuid").Return(types.AuditConfig{})
+	mockKeeper.EXPECT().SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// audited by someone else
+	mockKeeper.EXPECT().GetAuditConfig(ctx, "uuid").Return(types.AuditConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgSetAudit(ctx, mockKeeper, types.MsgSetAudit{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgDeleteAudit(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgDeleteAudit("uuid", owner)
+	assert.Equal(t, "deleteaudit", msg.Type())
+
+	mockKeeper.EXPECT().GetAuditConfig(ctx, "uuid").Return(types.AuditConfig{Owner: owner})
+	mockKeeper.EXPECT().DeleteAuditConfig(ctx, "uuid")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetAuditConfig(ctx, "uuid").Return(types.AuditConfig{})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Audit not enabled").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetAuditConfig(ctx, "uuid").Return(types.AuditConfig{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgDeleteAudit(ctx, mockKeeper, types.MsgDeleteAudit{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgSetRetention(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgSetRetention("uuid", 10, types.RetentionOrderFIFO, owner)
+	assert.Equal(t, "setretention", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{})
+	mockKeeper.EXPECT().SetRetentionPolicy(ctx, nil, "uuid", types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderFIFO})
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// the owner may change the policy
+	msg = types.NewMsgSetRetention("uuid", 5, types.RetentionOrderLRU, owner)
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderFIFO})
+	mockKeeper.EXPECT().SetRetentionPolicy(ctx, nil, "uuid", types.RetentionPolicy{Owner: owner, MaxKeys: 5, Order: types.RetentionOrderLRU})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// set by someone else
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"), MaxKeys: 10, Order: types.RetentionOrderFIFO})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgSetRetention(ctx, mockKeeper, types.MsgSetRetention{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgSetRetention(ctx, mockKeeper, types.NewMsgSetRetention("uuid", 10, "newest", owner))
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgDeleteRetention(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgDeleteRetention("uuid", owner)
+	assert.Equal(t, "deleteretention", msg.Type())
+
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{Owner: owner, MaxKeys: 10, Order: types.RetentionOrderFIFO})
+	mockKeeper.EXPECT().DeleteRetentionPolicy(ctx, "uuid")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Retention policy not set").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetRetentionPolicy(ctx, "uuid").Return(types.RetentionPolicy{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgDeleteRetention(ctx, mockKeeper, types.MsgDeleteRetention{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgSetSchema(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(types.Params{})
+	ctx := sdk.Context{}
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	msg := types.NewMsgSetSchema("uuid", 64, "", `{"type":"object","required":["name"]}`, owner)
+	assert.Equal(t, "setschema", msg.Type())
+
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(types.Schema{})
+	mockKeeper.EXPECT().SetSchema(ctx, "uuid", msg.Schema())
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// set by someone else
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(types.Schema{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"), MaxValueSize: 10})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters and bad schemas
+	{
+		_, err := handleMsgSetSchema(ctx, mockKeeper, types.MsgSetSchema{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgSetSchema(ctx, mockKeeper, types.NewMsgSetSchema("uuid", 0, "", `{"type":"text"}`, owner))
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, `JSON schema /type: unknown type "text"`).Error(), err.Error())
+	}
+
+	// the values written to the UUID are checked against its schema
+	mockKeeper.EXPECT().GetSchema(gomock.Any(), "uuid").AnyTimes().Return(msg.Schema())
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
+	mockKeeper.EXPECT().IsFrozen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().IsExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(false)
+	mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key").AnyTimes().Return(owner)
+	{
+		mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "new")
+		_, err := NewHandler(mockKeeper)(ctx, types.NewMsgCreate("uuid", "new", []byte(`{"id":1}`), 0, owner))
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, `Value of new does not match schema: value: missing property "name"`).Error(), err.Error())
+
+		mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").Return(types.BLZValue{Value: []byte(`{"name":"a"}`), Owner: owner, Lease: 100})
+		_, err = NewHandler(mockKeeper)(ctx, types.MsgUpdate{UUID: "uuid", Key: "key", Value: []byte(`["a"]`), Owner: owner})
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value of key does not match schema: value: expected object, got array").Error(), err.Error())
+
+		mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").Return(types.BLZValue{Value: []byte(`{"name":"a"}`), Owner: owner, Lease: 100})
+		_, err = NewHandler(mockKeeper)(ctx, types.NewMsgMultiUpdate("uuid", owner, []types.KeyValue{{Key: "key", Value: make([]byte, 65)}}))
+		assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value of key does not match schema: value is 65 bytes, more than 64").Error(), err.Error())
+	}
+}
+
+func Test_handleMsgDeleteSchema(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(types.Params{})
+	ctx := sdk.Context{}
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	msg := types.NewMsgDeleteSchema("uuid", owner)
+	assert.Equal(t, "deleteschema", msg.Type())
+
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(types.Schema{Owner: owner, MaxValueSize: 10})
+	mockKeeper.EXPECT().DeleteSchema(ctx, "uuid")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(types.Schema{})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Schema not set").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(types.Schema{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgDeleteSchema(ctx, mockKeeper, types.MsgDeleteSchema{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgFreeze(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgFreeze("uuid", "key", owner)
+	assert.Equal(t, "freeze", msg.Type())
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(msg.Owner)
+	mockKeeper.EXPECT().Freeze(ctx, msg.Owner, "uuid", "key")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key")
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return([]byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"))
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	// freezing the whole UUID needs no key
+	mockKeeper.EXPECT().Freeze(ctx, msg.Owner, "uuid", "")
+	_, err = NewHandler(mockKeeper)(ctx, types.NewMsgFreeze("uuid", "", owner))
+	assert.Nil(t, err)
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgFreeze(ctx, mockKeeper, types.MsgFreeze{})
+		assert.NotNil(t, err)
+
+		_, err = handleMsgFreeze(ctx, mockKeeper, types.MsgFreeze{UUID: "uuid"})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgUnfreeze(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgUnfreeze("uuid", "key", owner)
+	assert.Equal(t, "unfreeze", msg.Type())
+
+	mockKeeper.EXPECT().Unfreeze(ctx, msg.Owner, "uuid", "key").Return(true)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().Unfreeze(ctx, msg.Owner, "uuid", "key").Return(false)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Not frozen").Error(), err.Error())
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgUnfreeze(ctx, mockKeeper, types.MsgUnfreeze{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgStartUpload(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	hash := "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619"
+	msg := types.NewMsgStartUpload("uuid", "key", 5, hash, 0, owner)
+	assert.Equal(t, "startupload", msg.Type())
+
+	ctx = ctx.WithBlockHeight(100)
+	other := []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(DefaultLeaseBlockHeight)
+
+	// key already exists
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(true)
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already exists").Error(), err.Error())
+
+	// another account's upload is in progress
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: other, Height: 90})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload already in progress").Error(), err.Error())
+
+	// an abandoned upload can be taken over
+	expectedHash, _ := hex.DecodeString(hash)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: other, Height: 100 - types.UploadTimeoutBlocks})
+	mockKeeper.EXPECT().StartUpload(ctx, "uuid", "key", types.Upload{Owner: owner, Size: 5, Hash: expectedHash, Lease: DefaultLeaseBlockHeight, Height: 100})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgStartUpload(ctx, mockKeeper, types.MsgStartUpload{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgUploadChunk(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	msg := types.NewMsgUploadChunk("uuid", "key", 1, []byte("lue"), owner)
+	assert.Equal(t, "uploadchunk", msg.Type())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload does not exist").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"), Size: 5})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Incorrect Owner").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: owner, Size: 5, Chunks: 2, Received: 4})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Unexpected chunk index, expected 2").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: owner, Size: 4, Chunks: 1, Received: 2})
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload larger than declared size").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(types.Upload{Owner: owner, Size: 5, Chunks: 1, Received: 2})
+	mockKeeper.EXPECT().AddUploadChunk(ctx, "uuid", "key", []byte("lue"))
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgUploadChunk(ctx, mockKeeper, types.MsgUploadChunk{})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_handleMsgCommitUpload(t *testing.T) {
+	mockCtrl, mockKeeper, ctx, owner := initTest(t)
+	defer mockCtrl.Finish()
+
+	mockKeeper.EXPECT().ChargeLease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	msg := types.NewMsgCommitUpload("uuid", "key", owner)
+	assert.Equal(t, "commitupload", msg.Type())
+
+	ctx = ctx.WithBlockHeight(100)
+	upload := types.Upload{Owner: owner, Size: 5, Hash: types.ValueHash([]byte("value")), Lease: 1000, Height: 90, Chunks: 2, Received: 5}
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key")
+	_, err := NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload does not exist").Error(), err.Error())
+
+	incomplete := upload
+	incomplete.Received = 2
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(incomplete)
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Upload incomplete").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(upload)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+	mockKeeper.EXPECT().AssembleUpload(ctx, "uuid", "key").Return([]byte("va1ue"))
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Hash mismatch").Error(), err.Error())
+
+	mockKeeper.EXPECT().GetUpload(ctx, "uuid", "key").Return(upload)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+	mockKeeper.EXPECT().AssembleUpload(ctx, "uuid", "key").Return([]byte("value"))
+	mockKeeper.EXPECT().SetValue(ctx, nil, "uuid", "key", types.BLZValue{Value: []byte("value"), Lease: 1000, Height: 100, Owner: owner})
+	mockKeeper.EXPECT().SetLease(gomock.Any(), nil, "uuid", "key", int64(100), int64(1000))
+	mockKeeper.EXPECT().DeleteUpload(ctx, "uuid", "key")
+	_, err = NewHandler(mockKeeper)(ctx, msg)
+	assert.Nil(t, err)
+
+	// Test for empty message parameters
+	{
+		_, err := handleMsgCommitUpload(ctx, mockKeeper, types.MsgCommitUpload{})
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func makeAuditConfigKey(UUID string) []byte {
+	return append(append([]byte{}, types.AuditConfigPrefix...), []byte(UUID)...)
+}
+
+// the last sequence number used is kept per UUID, and outlives its audit configuration
+// so that entries are never numbered twice
+func makeAuditSeqKey(UUID string) []byte {
+	return append(append([]byte{}, types.AuditSeqPrefix...), []byte(UUID)...)
+}
+
+// audit log entries are laid out as prefix | len(UUID) | UUID | sequence number
+func makeAuditLogPrefix(UUID string) []byte {
+	return append(append([]byte{}, types.AuditLogPrefix...), lengthPrefixed(UUID)...)
+}
+
+func makeAuditLogKey(UUID string, seq uint64) []byte {
+	return append(makeAuditLogPrefix(UUID), sdk.Uint64ToBigEndian(seq)...)
+}
+
+// entries are queued for pruning by the height they were recorded at, as prefix | height |
+// len(UUID) | UUID | sequence number, the part after the height being their log key
+func makeAuditPruneKey(height int64, logKey []byte) []byte {
+	prefix := append(append([]byte{}, types.AuditPrunePrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(prefix, logKey[len(types.AuditLogPrefix):]...)
+}
+
+func (k Keeper) GetAuditConfig(ctx sdk.Context, UUID string) types.AuditConfig {
+	bz := k.GetIndexStore(ctx).Get(makeAuditConfigKey(UUID))
+	if bz == nil {
+		return types.AuditConfig{}
+	}
+
+	var config types.AuditConfig
+	k.cdc.MustUnmarshalBinaryBare(bz, &config)
+	return config
+}
+
+// SetAuditConfig starts recording the changes to the keys of UUID in its audit log.
+func (k Keeper) SetAuditConfig(ctx sdk.Context, UUID string, config types.AuditConfig) {
+	k.GetIndexStore(ctx).Set(makeAuditConfigKey(UUID), k.cdc.MustMarshalBinaryBare(config))
+}
+
+// DeleteAuditConfig stops recording changes to UUID, leaving its log to be pruned.
+func (k Keeper) DeleteAuditConfig(ctx sdk.Context, UUID string) {
+	k.GetIndexStore(ctx).Delete(makeAuditConfigKey(UUID))
+}
+
+// GetAuditConfigs returns the audit configuration of every audited UUID.
+func (k Keeper) GetAuditConfigs(ctx sdk.Context) []types.GenesisAudit {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.AuditConfigPrefix)
+	defer iterator.Close()
+
+	var audits []types.GenesisAudit
+	for ; iterator.Valid(); iterator.Next() {
+		var config types.AuditConfig
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &config)
+		audits = append(audits, types.GenesisAudit{UUID: string(iterator.Key()[len(types.AuditConfigPrefix):]), Config: config})
+	}
+	return audits
+}
+
+// recordAudit appends an entry for a change to key to the audit log of UUID, if it has one.
+func (k Keeper) recordAudit(ctx sdk.Context, UUID string, key string, op string, actor sdk.AccAddress, hash []byte) {
+	indexStore := k.GetIndexStore(ctx)
+	if !indexStore.Has(makeAuditConfigKey(UUID)) {
+		return
+	}
+
+	seq := uint64(1)
+	if bz := indexStore.Get(makeAuditSeqKey(UUID)); bz != nil {
+		seq = sdk.BigEndianToUint64(bz) + 1
+	}
+
+	k.setAuditEntry(ctx, types.AuditEntry{UUID: UUID, Seq: seq, Op: op, Key: key, Actor: actor, Height: ctx.BlockHeight(), ValueHash: hash})
+}
+
+// setAuditEntry stores entry, queued for pruning from the current height.
+func (k Keeper) setAuditEntry(ctx sdk.Context, entry types.AuditEntry) {
+	indexStore := k.GetIndexStore(ctx)
+	logKey := makeAuditLogKey(entry.UUID, entry.Seq)
+	indexStore.Set(logKey, k.cdc.MustMarshalBinaryBare(entry))
+	indexStore.Set(makeAuditPruneKey(ctx.BlockHeight(), logKey), []byte{})
+
+	seqKey := makeAuditSeqKey(entry.UUID)
+	if bz := indexStore.Get(seqKey); bz == nil || sdk.BigEndianToUint64(bz) < entry.Seq {
+		indexStore.Set(seqKey, sdk.Uint64ToBigEndian(entry.Seq))
+	}
+}
+
+// ImportAuditEntry restores an exported audit log entry, to be kept for the retention
+// period from now.
+func (k Keeper) ImportAuditEntry(ctx sdk.Context, entry types.AuditEntry) {
+	k.setAuditEntry(ctx, entry)
+}
+
+// GetAuditLog returns up to limit entries of the audit log of UUID from sequence number
+// start on, also limited to MaxKeysSize of keys like GetKeys.
+func (k Keeper) GetAuditLog(ctx sdk.Context, UUID string, start uint64, limit uint64) types.QueryResultAuditLog {
+	prefix := makeAuditLogPrefix(UUID)
+	iterator := k.GetIndexStore(ctx).Iterator(makeAuditLogKey(UUID, start), sdk.PrefixEndBytes(prefix))
+	defer iterator.Close()
+
+	page := types.QueryResultAuditLog{UUID: UUID, Entries: make([]types.AuditEntry, 0)}
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.AuditEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		keysSize = uint64(len(entry.Key)) + keysSize
+
+		// always return at least one entry so that paging makes progress
+		if uint64(len(page.Entries)) >= limit || (keysSize >= k.mks.MaxKeysSize && len(page.Entries) > 0) {
+			page.Next = entry.Seq
+			break
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	return page
+}
+
+// GetAuditLogs returns the entries of every audit log, for export.
+func (k Keeper) GetAuditLogs(ctx sdk.Context) []types.AuditEntry {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.AuditLogPrefix)
+	defer iterator.Close()
+
+	var entries []types.AuditEntry
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.AuditEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// PruneAuditLog deletes the audit log entries recorded AuditRetentionBlocks or more
+// blocks ago. Nothing is pruned while the parameter is 0.
+func (k Keeper) PruneAuditLog(ctx sdk.Context) {
+	retention := k.GetParams(ctx).AuditRetentionBlocks
+	if retention == 0 || uint64(ctx.BlockHeight()) <= retention {
+		return
+	}
+
+	store := k.GetIndexStore(ctx)
+	end := append(append([]byte{}, types.AuditPrunePrefix...), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())-retention+1)...)
+	iterator := store.Iterator(types.AuditPrunePrefix, end)
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		logKey := append(append([]byte{}, types.AuditLogPrefix...), key[len(types.AuditPrunePrefix)+8:]...)
+		store.Delete(logKey)
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_AuditLog(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	// nothing is recorded until the UUID is audited
+	keeper.SetValue(ctx.WithBlockHeight(5), testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Owner: owner})
+	assert.Empty(t, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries)
+
+	keeper.SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+	assert.Equal(t, []types.GenesisAudit{{UUID: "uuid", Config: types.AuditConfig{Owner: owner}}}, keeper.GetAuditConfigs(ctx))
+
+	ctx = ctx.WithBlockHeight(10)
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value1"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value2"), Owner: owner, Height: 10, Lease: 10})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key1", 10, 10)
+	keeper.SetValue(ctx, testStore, "other", "key", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.DeleteValue(ctx.WithBlockHeight(11), testStore, leaseStore, "uuid", "key0")
+	keeper.ProcessLeasesAtBlockHeight(ctx.WithBlockHeight(20), testStore, leaseStore, 20)
+
+	assert.Equal(t, []types.AuditEntry{
+		{UUID: "uuid", Seq: 1, Op: types.AuditOpUpdate, Key: "key0", Actor: owner, Height: 10, ValueHash: types.ValueHash([]byte("value1"))},
+		{UUID: "uuid", Seq: 2, Op: types.AuditOpCreate, Key: "key1", Actor: owner, Height: 10, ValueHash: types.ValueHash([]byte("value2"))},
+		{UUID: "uuid", Seq: 3, Op: types.AuditOpDelete, Key: "key0", Actor: owner, Height: 11},
+		{UUID: "uuid", Seq: 4, Op: types.AuditOpExpire, Key: "key1", Height: 20},
+	}, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries)
+	assert.Len(t, keeper.GetAuditLogs(ctx), 4)
+
+	// paging
+	page := keeper.GetAuditLog(ctx, "uuid", 2, 2)
+	assert.Equal(t, []uint64{2, 3}, []uint64{page.Entries[0].Seq, page.Entries[1].Seq})
+	assert.Equal(t, uint64(4), page.Next)
+	page = keeper.GetAuditLog(ctx, "uuid", page.Next, 2)
+	assert.Len(t, page.Entries, 1)
+	assert.Equal(t, uint64(0), page.Next)
+
+	// once auditing stops the log is kept, and numbering carries on if it is enabled again
+	keeper.DeleteAuditConfig(ctx, "uuid")
+	assert.Empty(t, keeper.GetAuditConfigs(ctx))
+	keeper.SetValue(ctx.WithBlockHeight(21), testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	assert.Len(t, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries, 4)
+
+	keeper.SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(22), testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	assert.Equal(t, uint64(5), keeper.GetAuditLog(ctx, "uuid", 5, 10).Entries[0].Seq)
+}
+
+func TestKeeper_PruneAuditLog(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	keeper.SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+
+	keeper.SetValue(ctx.WithBlockHeight(10), testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(20), testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	// kept forever by default
+	keeper.PruneAuditLog(ctx.WithBlockHeight(1000))
+	assert.Len(t, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries, 2)
+
+	params := types.DefaultParams()
+	params.AuditRetentionBlocks = 100
+	keeper.SetParams(ctx, params)
+
+	keeper.PruneAuditLog(ctx.WithBlockHeight(109))
+	assert.Len(t, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries, 2)
+
+	keeper.PruneAuditLog(ctx.WithBlockHeight(110))
+	entries := keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "key1", entries[0].Key)
+
+	// imported entries are kept for the retention period from the import
+	keeper.ImportAuditEntry(ctx.WithBlockHeight(200), types.AuditEntry{UUID: "uuid", Seq: 7, Op: types.AuditOpCreate, Key: "key", Height: 5})
+	keeper.PruneAuditLog(ctx.WithBlockHeight(299))
+	assert.Len(t, keeper.GetAuditLog(ctx, "uuid", 7, 10).Entries, 1)
+	keeper.PruneAuditLog(ctx.WithBlockHeight(300))
+	assert.Empty(t, keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries)
+
+	// the sequence carries on after imported entries
+	keeper.SetValue(ctx.WithBlockHeight(300), testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	assert.Equal(t, uint64(8), keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries[0].Seq)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// beneficiaries are kept as prefix | len(UUID) | UUID | key
+func makeBeneficiaryKey(UUID string, key string) []byte {
+	return append(append([]byte{}, types.BeneficiaryPrefix...), []byte(MakeMetaKey(UUID, key))...)
+}
+
+func (k Keeper) GetBeneficiary(ctx sdk.Context, UUID string, key string) types.Beneficiary {
+	bz := k.GetIndexStore(ctx).Get(makeBeneficiaryKey(UUID, key))
+	if bz == nil {
+		return types.Beneficiary{}
+	}
+
+	var beneficiary types.Beneficiary
+	k.cdc.MustUnmarshalBinaryBare(bz, &beneficiary)
+	return beneficiary
+}
+
+// SetBeneficiary names beneficiary to take over key from owner when its lease runs out.
+// The fee for a default lease of the key at its current size is taken from owner into
+// escrow, after refunding the deposit for any earlier beneficiary. An empty beneficiary
+// only removes the earlier one.
+func (k Keeper) SetBeneficiary(ctx sdk.Context, UUID string, key string, owner sdk.AccAddress, beneficiary sdk.AccAddress) error {
+	k.removeBeneficiary(ctx, UUID, key, owner)
+	if beneficiary.Empty() {
+		return nil
+	}
+
+	value := k.GetValue(ctx, k.GetKVStore(ctx), UUID, key)
+	escrow := k.LeaseFee(ctx, int64(len(UUID)+len(key)+len(value.Value))*k.GetDefaultLeaseBlocks(ctx))
+	if !escrow.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, owner, types.EscrowName, escrow); err != nil {
+			return err
+		}
+	}
+
+	k.ImportBeneficiary(ctx, UUID, key, types.Beneficiary{Address: beneficiary, Escrow: escrow})
+	return nil
+}
+
+// ImportBeneficiary stores a beneficiary whose escrow is already held, as it is when
+// importing genesis.
+func (k Keeper) ImportBeneficiary(ctx sdk.Context, UUID string, key string, beneficiary types.Beneficiary) {
+	k.GetIndexStore(ctx).Set(makeBeneficiaryKey(UUID, key), k.cdc.MustMarshalBinaryBare(beneficiary))
+}
+
+// GetBeneficiaries returns the beneficiary of every key that has one.
+func (k Keeper) GetBeneficiaries(ctx sdk.Context) []types.GenesisBeneficiary {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.BeneficiaryPrefix)
+	defer iterator.Close()
+
+	var beneficiaries []types.GenesisBeneficiary
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(types.BeneficiaryPrefix):]))
+		var beneficiary types.Beneficiary
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &beneficiary)
+		beneficiaries = append(beneficiaries, types.GenesisBeneficiary{UUID: UUID, Key: key, Beneficiary: beneficiary})
+	}
+	return beneficiaries
+}
+
+// removeBeneficiary drops the beneficiary of key, refunding its escrow to refundTo
+func (k Keeper) removeBeneficiary(ctx sdk.Context, UUID string, key string, refundTo sdk.AccAddress) {
+	beneficiary := k.GetBeneficiary(ctx, UUID, key)
+	if beneficiary.Address.Empty() {
+		return
+	}
+
+	k.GetIndexStore(ctx).Delete(makeBeneficiaryKey(UUID, key))
+	if !beneficiary.Escrow.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.EscrowName, refundTo, beneficiary.Escrow); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// handOver gives a key whose lease ran out to its beneficiary with a fresh default
+// lease, paid for by moving the escrow to the key's lease deposit.
+func (k Keeper) handOver(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string, value types.BLZValue, beneficiary types.Beneficiary) {
+	k.GetIndexStore(ctx).Delete(makeBeneficiaryKey(UUID, key))
+	if !beneficiary.Escrow.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.EscrowName, types.LeaseDepositName, beneficiary.Escrow); err != nil {
+			panic(err)
+		}
+	}
+
+	value.Owner = beneficiary.Address
+	value.Height = ctx.BlockHeight()
+	value.Lease = k.GetDefaultLeaseBlocks(ctx)
+	k.SetValue(ctx, store, UUID, key, value)
+	k.SetLease(ctx, leaseStore, UUID, key, value.Height, value.Lease)
+	k.depositLease(ctx, UUID, key, beneficiary.Escrow)
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_Beneficiary(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	heir := sdk.AccAddress("bluzelle1nnpyp9wr6la")
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: 100})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	keeper.SetParams(ctx, params)
+
+	for _, key := range []string{"key0", "key1"} {
+		keeper.SetValue(ctx, testStore, "uuid", key, types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 0, 10)
+	}
+
+	// the default lease of (uuid + key + value) * 100 blocks at 0.01ubnt goes into escrow
+	escrow := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 13))
+	assert.Nil(t, keeper.SetBeneficiary(ctx, "uuid", "key0", owner, heir))
+	assert.Equal(t, types.Beneficiary{Address: heir, Escrow: escrow}, keeper.GetBeneficiary(ctx, "uuid", "key0"))
+	assert.Equal(t, escrow, supplyKeeper[types.EscrowName])
+	assert.Equal(t, heir, keeper.GetMetadata(ctx, testStore, "uuid", "key0").Beneficiary)
+
+	// naming another beneficiary refunds the first deposit
+	assert.Nil(t, keeper.SetBeneficiary(ctx, "uuid", "key1", owner, owner))
+	assert.Nil(t, keeper.SetBeneficiary(ctx, "uuid", "key1", owner, heir))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 74)), supplyKeeper[string(owner)])
+	assert.Len(t, keeper.GetBeneficiaries(ctx), 2)
+
+	// deleting the key refunds the deposit
+	keeper.DeleteValue(ctx, testStore, leaseStore, "uuid", "key1")
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 87)), supplyKeeper[string(owner)])
+	assert.Equal(t, types.Beneficiary{}, keeper.GetBeneficiary(ctx, "uuid", "key1"))
+
+	// the beneficiary takes the key over with a fresh lease paid from the escrow
+	ctx = ctx.WithBlockHeight(10)
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, leaseStore, 10)
+
+	value := keeper.GetValue(ctx, testStore, "uuid", "key0")
+	assert.Equal(t, heir, value.Owner)
+	assert.Equal(t, []byte("value"), value.Value)
+	assert.Equal(t, int64(10), value.Height)
+	assert.Equal(t, int64(100), value.Lease)
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key0"))))
+	assert.Equal(t, []string{"key0"}, keeper.GetKeys(ctx, testStore, "uuid", heir).Keys)
+	assert.Empty(t, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+
+	assert.True(t, supplyKeeper[types.EscrowName].IsZero())
+	assert.Equal(t, types.LeaseDeposit{Amount: escrow, From: 10, To: 110}, keeper.GetLeaseDeposit(ctx, "uuid", "key0"))
+	assert.Equal(t, escrow, supplyKeeper[types.LeaseDepositName])
+	assert.Empty(t, keeper.GetBeneficiaries(ctx))
+
+	// without a beneficiary the key goes at the end of the new lease, its deposit earned
+	keeper.ProcessLeasesAtBlockHeight(ctx.WithBlockHeight(110), testStore, leaseStore, 110)
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key0"))
+	assert.Equal(t, escrow, supplyKeeper[types.ModuleName])
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strconv"
+)
+
+// escrow balances are kept as prefix | owner, held in the escrow module account
+// alongside the beneficiary deposits
+func makeEscrowKey(owner sdk.AccAddress) []byte {
+	return append(append([]byte{}, types.EscrowPrefix...), owner...)
+}
+
+// auto-renewed keys are marked as prefix | len(UUID) | UUID | key
+func makeAutoRenewKey(UUID string, key string) []byte {
+	return append(append([]byte{}, types.AutoRenewPrefix...), []byte(MakeMetaKey(UUID, key))...)
+}
+
+func (k Keeper) GetEscrow(ctx sdk.Context, owner sdk.AccAddress) sdk.Coins {
+	bz := k.GetIndexStore(ctx).Get(makeEscrowKey(owner))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var balance sdk.Coins
+	k.cdc.MustUnmarshalBinaryBare(bz, &balance)
+	return balance
+}
+
+// ImportEscrow sets the escrow balance of owner, whose coins are already held, as they
+// are when importing genesis.
+func (k Keeper) ImportEscrow(ctx sdk.Context, owner sdk.AccAddress, balance sdk.Coins) {
+	if balance.IsZero() {
+		k.GetIndexStore(ctx).Delete(makeEscrowKey(owner))
+		return
+	}
+	k.GetIndexStore(ctx).Set(makeEscrowKey(owner), k.cdc.MustMarshalBinaryBare(balance))
+}
+
+// DepositEscrow moves amount from owner's account to the escrow their auto-renewed
+// leases are paid from.
+func (k Keeper) DepositEscrow(ctx sdk.Context, owner sdk.AccAddress, amount sdk.Coins) error {
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, owner, types.EscrowName, amount); err != nil {
+		return err
+	}
+
+	k.ImportEscrow(ctx, owner, k.GetEscrow(ctx, owner).Add(amount...))
+	return nil
+}
+
+// WithdrawEscrow pays amount out of owner's escrow back to their account. It fails,
+// paying nothing, if the escrow holds less than amount.
+func (k Keeper) WithdrawEscrow(ctx sdk.Context, owner sdk.AccAddress, amount sdk.Coins) error {
+	balance := k.GetEscrow(ctx, owner)
+	if !balance.IsAllGTE(amount) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "escrow holds %s", balance)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.EscrowName, owner, amount); err != nil {
+		return err
+	}
+	k.ImportEscrow(ctx, owner, balance.Sub(amount))
+	return nil
+}
+
+// GetEscrows returns the balance of every owner with one.
+func (k Keeper) GetEscrows(ctx sdk.Context) []types.GenesisEscrow {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.EscrowPrefix)
+	defer iterator.Close()
+
+	var escrows []types.GenesisEscrow
+	for ; iterator.Valid(); iterator.Next() {
+		var balance sdk.Coins
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &balance)
+		escrows = append(escrows, types.GenesisEscrow{Owner: iterator.Key()[len(types.EscrowPrefix):], Balance: balance})
+	}
+	return escrows
+}
+
+// SetAutoRenew marks key to have its lease renewed from its owner's escrow when it runs
+// out, or with autoRenew false to expire as usual.
+func (k Keeper) SetAutoRenew(ctx sdk.Context, UUID string, key string, autoRenew bool) {
+	if autoRenew {
+		k.GetIndexStore(ctx).Set(makeAutoRenewKey(UUID, key), []byte{})
+	} else {
+		k.GetIndexStore(ctx).Delete(makeAutoRenewKey(UUID, key))
+	}
+}
+
+func (k Keeper) IsAutoRenew(ctx sdk.Context, UUID string, key string) bool {
+	return k.GetIndexStore(ctx).Has(makeAutoRenewKey(UUID, key))
+}
+
+// GetAutoRenewals returns every key marked for auto-renewal.
+func (k Keeper) GetAutoRenewals(ctx sdk.Context) []types.GenesisAutoRenew {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.AutoRenewPrefix)
+	defer iterator.Close()
+
+	var renewals []types.GenesisAutoRenew
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(types.AutoRenewPrefix):]))
+		renewals = append(renewals, types.GenesisAutoRenew{UUID: UUID, Key: key})
+	}
+	return renewals
+}
+
+// AutoRenewLeases renews the auto-renew keys whose lease runs out in this block for
+// the same number of blocks again, moving the lease fee from the owner's escrow to the
+// key's lease deposit. Keys whose owner cannot pay are left to expire.
+func (k Keeper) AutoRenewLeases(ctx sdk.Context) {
+	store, leaseStore := k.GetKVStore(ctx), k.GetLeaseStore(ctx)
+
+	prefix := strconv.FormatInt(ctx.BlockHeight(), 10) + "\x00"
+	iterator := sdk.KVStorePrefixIterator(leaseStore, []byte(prefix))
+	var renewals []string
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(prefix):]))
+		if k.IsAutoRenew(ctx, UUID, key) {
+			renewals = append(renewals, string(iterator.Key()[len(prefix):]))
+		}
+	}
+	iterator.Close()
+
+	// renewals do not change the bytes stored, so they are all priced alike
+	params, stored := k.GetParams(ctx), k.GetStoredBytes(ctx)
+	for _, metaKey := range renewals {
+		UUID, key := SplitMetaKey(metaKey)
+		value := k.GetValue(ctx, store, UUID, key)
+		if value.Owner.Empty() || value.Lease <= 0 {
+			continue
+		}
+
+		usage := int64(len(UUID)+len(key)+len(value.Value)) * value.Lease
+		cost := params.LeaseFeeAt(usage, stored)
+		balance := k.GetEscrow(ctx, value.Owner)
+		if !balance.IsAllGTE(cost) {
+			continue
+		}
+		if !cost.IsZero() {
+			if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.EscrowName, types.LeaseDepositName, cost); err != nil {
+				panic(err)
+			}
+			k.ImportEscrow(ctx, value.Owner, balance.Sub(cost))
+			k.recordRent(ctx, value.Owner, usage, cost, nil)
+		}
+
+		k.DeleteLease(leaseStore, UUID, key, value.Height, value.Lease)
+		value.Height = leaseExpiry(&value)
+		k.SetValue(ctx, store, UUID, key, value)
+		k.SetLease(ctx, leaseStore, UUID, key, value.Height, value.Lease)
+		k.depositLease(ctx, UUID, key, cost)
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeAutoRenew,
+			sdk.NewAttribute(types.AttributeKeyUUID, UUID),
+			sdk.NewAttribute(types.AttributeKeyKey, key),
+			sdk.NewAttribute(types.AttributeKeyOwner, value.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiry, strconv.FormatInt(leaseExpiry(&value), 10)),
+			sdk.NewAttribute(types.AttributeKeyCost, cost.String()),
+		))
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_AutoRenewLeases(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: 100})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	keeper.SetParams(ctx, params)
+
+	for _, key := range []string{"key0", "key1"} {
+		keeper.SetValue(ctx, testStore, "uuid", key, types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 0, 10)
+	}
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 3))
+	assert.Nil(t, keeper.DepositEscrow(ctx, owner, deposit))
+	assert.Equal(t, deposit, keeper.GetEscrow(ctx, owner))
+	assert.Equal(t, deposit, supplyKeeper[types.EscrowName])
+	assert.NotNil(t, keeper.DepositEscrow(ctx, owner, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))))
+
+	keeper.SetAutoRenew(ctx, "uuid", "key0", true)
+	assert.True(t, keeper.IsAutoRenew(ctx, "uuid", "key0"))
+	assert.Equal(t, []types.GenesisAutoRenew{{UUID: "uuid", Key: "key0"}}, keeper.GetAutoRenewals(ctx))
+
+	// (uuid + key + value) * 10 blocks at 0.01ubnt, rounded up
+	ctx = ctx.WithBlockHeight(10).WithEventManager(sdk.NewEventManager())
+	keeper.AutoRenewLeases(ctx)
+
+	value := keeper.GetValue(ctx, testStore, "uuid", "key0")
+	assert.Equal(t, int64(10), value.Height)
+	assert.Equal(t, int64(10), value.Lease)
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(20, "uuid", "key0"))))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(10, "uuid", "key0"))))
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(10, "uuid", "key1"))))
+
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1)), keeper.GetEscrow(ctx, owner))
+	assert.Equal(t, types.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 2)), From: 10, To: 20}, keeper.GetLeaseDeposit(ctx, "uuid", "key0"))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 2)), supplyKeeper[types.LeaseDepositName])
+	events := ctx.EventManager().Events()
+	assert.Equal(t, types.EventTypeAutoRenew, events[len(events)-1].Type)
+
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, leaseStore, 10)
+	assert.True(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key0"))
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key1"))
+
+	// once the escrow cannot pay the key expires as usual
+	ctx = ctx.WithBlockHeight(20)
+	keeper.AutoRenewLeases(ctx)
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(20, "uuid", "key0"))))
+	assert.Equal(t, []types.GenesisEscrow{{Owner: owner, Balance: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1))}}, keeper.GetEscrows(ctx))
+
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, leaseStore, 20)
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key0"))
+	assert.Empty(t, keeper.GetAutoRenewals(ctx))
+	assert.True(t, supplyKeeper[types.LeaseDepositName].IsZero())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 2)), supplyKeeper[types.ModuleName])
+}
+
+func TestKeeper_WithdrawEscrow(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	ubnt := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ubnt", amount)) }
+
+	assert.NotNil(t, keeper.WithdrawEscrow(ctx, owner, ubnt(1)))
+
+	assert.Nil(t, keeper.DepositEscrow(ctx, owner, ubnt(30)))
+	assert.Nil(t, keeper.WithdrawEscrow(ctx, owner, ubnt(20)))
+	assert.Equal(t, ubnt(10), keeper.GetEscrow(ctx, owner))
+	assert.Equal(t, ubnt(10), supplyKeeper[types.EscrowName])
+	assert.Equal(t, ubnt(90), supplyKeeper[string(owner)])
+
+	// no more than the escrow holds is paid out, even with the module account holding
+	// more for other owners
+	supplyKeeper[types.EscrowName] = ubnt(50)
+	err := keeper.WithdrawEscrow(ctx, owner, ubnt(11))
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	assert.Equal(t, ubnt(10), keeper.GetEscrow(ctx, owner))
+	assert.Equal(t, ubnt(90), supplyKeeper[string(owner)])
+
+	assert.Nil(t, keeper.WithdrawEscrow(ctx, owner, ubnt(10)))
+	assert.Empty(t, keeper.GetEscrows(ctx))
+	assert.Equal(t, ubnt(100), supplyKeeper[string(owner)])
+}
+
+func TestKeeper_SetAutoRenew(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+	keeper.SetAutoRenew(ctx, "uuid", "key0", true)
+
+	// the mark goes with a renamed key
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key0", "key1", false)
+	assert.True(t, ok)
+	assert.False(t, keeper.IsAutoRenew(ctx, "uuid", "key0"))
+	assert.True(t, keeper.IsAutoRenew(ctx, "uuid", "key1"))
+
+	// but not to a new owner, whose escrow would pay
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Lease: 10, Owner: []byte("other")})
+	assert.False(t, keeper.IsAutoRenew(ctx, "uuid", "key1"))
+
+	keeper.SetAutoRenew(ctx, "uuid", "key1", true)
+	keeper.SetAutoRenew(ctx, "uuid", "key1", false)
+	assert.False(t, keeper.IsAutoRenew(ctx, "uuid", "key1"))
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// freeze entries are laid out as prefix | len(owner) | owner | len(UUID) | UUID | key, the
+// entry with an empty key freezing all of the owner's keys in the UUID
+func makeFreezePrefix(owner sdk.AccAddress, UUID string) []byte {
+	prefix := append(append([]byte{}, types.FreezePrefix...), byte(len(owner)))
+	return append(append(prefix, owner...), lengthPrefixed(UUID)...)
+}
+
+func makeFreezeKey(owner sdk.AccAddress, UUID string, key string) []byte {
+	return append(makeFreezePrefix(owner, UUID), []byte(key)...)
+}
+
+// Freeze locks key, or with an empty key every key owner has in UUID, against writes
+// until it is unfrozen.
+func (k Keeper) Freeze(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) {
+	k.GetIndexStore(ctx).Set(makeFreezeKey(owner, UUID, key), []byte{})
+}
+
+// Unfreeze lifts a freeze set with the same key, returning false if there was none.
+// Unfreezing a UUID leaves the keys frozen one by one frozen.
+func (k Keeper) Unfreeze(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) bool {
+	indexStore := k.GetIndexStore(ctx)
+	if !indexStore.Has(makeFreezeKey(owner, UUID, key)) {
+		return false
+	}
+	indexStore.Delete(makeFreezeKey(owner, UUID, key))
+	return true
+}
+
+// IsFrozen reports whether key (owned by owner) is frozen by itself or with its UUID,
+// or with an empty key whether the UUID is.
+func (k Keeper) IsFrozen(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) bool {
+	indexStore := k.GetIndexStore(ctx)
+	return indexStore.Has(makeFreezeKey(owner, UUID, "")) || indexStore.Has(makeFreezeKey(owner, UUID, key))
+}
+
+// HasFrozen reports whether the UUID or any of owner's keys in it are frozen.
+func (k Keeper) HasFrozen(ctx sdk.Context, owner sdk.AccAddress, UUID string) bool {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), makeFreezePrefix(owner, UUID))
+	defer iterator.Close()
+	return iterator.Valid()
+}
+
+// GetFrozen returns every freeze in the store.
+func (k Keeper) GetFrozen(ctx sdk.Context) []types.GenesisFreeze {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.FreezePrefix)
+	defer iterator.Close()
+
+	var frozen []types.GenesisFreeze
+	for ; iterator.Valid(); iterator.Next() {
+		bz := iterator.Key()[len(types.FreezePrefix):]
+		owner := sdk.AccAddress(bz[1 : 1+int(bz[0])])
+		UUID, key := SplitMetaKey(string(bz[1+int(bz[0]):]))
+		frozen = append(frozen, types.GenesisFreeze{UUID: UUID, Key: key, Owner: owner})
+	}
+	return frozen
+}
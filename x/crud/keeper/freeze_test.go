@@ -0,0 +1,56 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_Freeze(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	other := sdk.AccAddress("bluzelle1nnpyp9wr6la")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner})
+	assert.False(t, keeper.HasFrozen(ctx, owner, "uuid"))
+
+	keeper.Freeze(ctx, owner, "uuid", "key0")
+	assert.True(t, keeper.IsFrozen(ctx, owner, "uuid", "key0"))
+	assert.False(t, keeper.IsFrozen(ctx, owner, "uuid", "key1"))
+	assert.True(t, keeper.HasFrozen(ctx, owner, "uuid"))
+	assert.False(t, keeper.HasFrozen(ctx, other, "uuid"))
+	assert.True(t, keeper.GetMetadata(ctx, testStore, "uuid", "key0").Frozen)
+
+	// a frozen UUID covers every key of the owner, the key freeze outlasts it
+	keeper.Freeze(ctx, owner, "uuid", "")
+	assert.True(t, keeper.IsFrozen(ctx, owner, "uuid", "key1"))
+	assert.False(t, keeper.IsFrozen(ctx, other, "uuid", "key1"))
+	assert.Equal(t, []types.GenesisFreeze{{UUID: "uuid", Key: "", Owner: owner}, {UUID: "uuid", Key: "key0", Owner: owner}},
+		keeper.GetFrozen(ctx))
+
+	assert.True(t, keeper.Unfreeze(ctx, owner, "uuid", ""))
+	assert.False(t, keeper.Unfreeze(ctx, owner, "uuid", ""))
+	assert.False(t, keeper.IsFrozen(ctx, owner, "uuid", "key1"))
+	assert.True(t, keeper.IsFrozen(ctx, owner, "uuid", "key0"))
+
+	// the freeze goes with the key when its lease runs out
+	keeper.DeleteValue(ctx, testStore, nil, "uuid", "key0")
+	assert.False(t, keeper.IsFrozen(ctx, owner, "uuid", "key0"))
+	assert.Empty(t, keeper.GetFrozen(ctx))
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func makeHashIndexConfigKey(UUID string) []byte {
+	return append(append([]byte{}, types.HashIndexPrefix...), []byte(UUID)...)
+}
+
+// hash index entries are laid out as prefix | len(UUID) | UUID | value hash | key
+func makeHashEntryPrefix(UUID string, hash []byte) []byte {
+	prefix := append(append([]byte{}, types.HashEntryPrefix...), lengthPrefixed(UUID)...)
+	return append(prefix, hash...)
+}
+
+func makeHashEntryKey(UUID string, hash []byte, key string) []byte {
+	return append(makeHashEntryPrefix(UUID, hash), []byte(key)...)
+}
+
+// storedHash is the hash kept with value, computed for values stored before it was
+func storedHash(value *types.BLZValue) []byte {
+	if len(value.Hash) > 0 {
+		return value.Hash
+	}
+	return types.ValueHash(value.Value)
+}
+
+func (k Keeper) GetHashIndexConfig(ctx sdk.Context, UUID string) types.HashIndexConfig {
+	bz := k.GetIndexStore(ctx).Get(makeHashIndexConfigKey(UUID))
+	if bz == nil {
+		return types.HashIndexConfig{}
+	}
+
+	var config types.HashIndexConfig
+	k.cdc.MustUnmarshalBinaryBare(bz, &config)
+	return config
+}
+
+// SetHashIndexConfig enables the hash index of UUID, indexing the keys already stored
+// under it the first time.
+func (k Keeper) SetHashIndexConfig(ctx sdk.Context, store sdk.KVStore, UUID string, config types.HashIndexConfig) {
+	indexStore := k.GetIndexStore(ctx)
+	indexed := !k.GetHashIndexConfig(ctx, UUID).Owner.Empty()
+	indexStore.Set(makeHashIndexConfigKey(UUID), k.cdc.MustMarshalBinaryBare(config))
+	if indexed {
+		return
+	}
+
+	prefix := lengthPrefixed(UUID)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		value := k.unmarshalValueMeta(iterator.Value())
+		if len(value.Hash) == 0 {
+			value = k.unmarshalValue(iterator.Value())
+		}
+		indexStore.Set(makeHashEntryKey(UUID, storedHash(&value), string(iterator.Key())[len(prefix):]), []byte{})
+	}
+}
+
+// DeleteHashIndexConfig disables the hash index of UUID and drops its entries.
+func (k Keeper) DeleteHashIndexConfig(ctx sdk.Context, UUID string) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearPrefix(indexStore, append(append([]byte{}, types.HashEntryPrefix...), lengthPrefixed(UUID)...))
+	indexStore.Delete(makeHashIndexConfigKey(UUID))
+}
+
+// GetHashIndexConfigs returns the hash index configuration of every UUID that has one.
+func (k Keeper) GetHashIndexConfigs(ctx sdk.Context) []types.GenesisHashIndex {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.HashIndexPrefix)
+	defer iterator.Close()
+
+	var indexes []types.GenesisHashIndex
+	for ; iterator.Valid(); iterator.Next() {
+		var config types.HashIndexConfig
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &config)
+		indexes = append(indexes, types.GenesisHashIndex{UUID: string(iterator.Key()[len(types.HashIndexPrefix):]), Config: config})
+	}
+	return indexes
+}
+
+// FindKeysByHash returns the keys of UUID whose value has the SHA-256 hash, limited to
+// MaxKeysSize like GetKeys. Nothing is found unless the UUID has a hash index.
+func (k Keeper) FindKeysByHash(ctx sdk.Context, UUID string, hash []byte) types.QueryResultKeys {
+	prefix := makeHashEntryPrefix(UUID, hash)
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+	keys := types.QueryResultKeys{UUID: UUID, Keys: make([]string, 0)}
+
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key()[len(prefix):])
+		keysSize = uint64(len(key)) + keysSize
+		if keysSize >= k.mks.MaxKeysSize {
+			break
+		}
+		keys.Keys = append(keys.Keys, key)
+	}
+	return keys
+}
+
+func (k Keeper) updateHashIndex(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	if k.GetHashIndexConfig(ctx, UUID).Owner.Empty() {
+		return
+	}
+
+	indexStore := k.GetIndexStore(ctx)
+	if oldValue != nil {
+		indexStore.Delete(makeHashEntryKey(UUID, storedHash(oldValue), key))
+	}
+	if value != nil {
+		indexStore.Set(makeHashEntryKey(UUID, storedHash(value), key), []byte{})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_SetHashIndexConfig(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	assert.True(t, keeper.GetHashIndexConfig(ctx, "uuid").Owner.Empty())
+
+	// existing keys are indexed when the index is enabled
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("blue"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "otheruuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+
+	keeper.SetHashIndexConfig(ctx, testStore, "uuid", types.HashIndexConfig{Owner: owner})
+
+	assert.Equal(t, types.HashIndexConfig{Owner: owner}, keeper.GetHashIndexConfig(ctx, "uuid"))
+	assert.Equal(t, []types.GenesisHashIndex{{UUID: "uuid", Config: types.HashIndexConfig{Owner: owner}}}, keeper.GetHashIndexConfigs(ctx))
+	assert.Equal(t, []string{"key0", "key2"}, keeper.FindKeysByHash(ctx, "uuid", types.ValueHash([]byte("red"))).Keys)
+	assert.Equal(t, []string{"key1"}, keeper.FindKeysByHash(ctx, "uuid", types.ValueHash([]byte("blue"))).Keys)
+	assert.Empty(t, keeper.FindKeysByHash(ctx, "otheruuid", types.ValueHash([]byte("red"))).Keys)
+
+	keeper.DeleteHashIndexConfig(ctx, "uuid")
+
+	assert.True(t, keeper.GetHashIndexConfig(ctx, "uuid").Owner.Empty())
+	assert.Empty(t, keeper.FindKeysByHash(ctx, "uuid", types.ValueHash([]byte("red"))).Keys)
+	assert.Empty(t, keeper.GetHashIndexConfigs(ctx))
+}
+
+func TestKeeper_HashIndexMaintenance(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	keeper.SetHashIndexConfig(ctx, testStore, "uuid", types.HashIndexConfig{Owner: owner})
+	red, blue := types.ValueHash([]byte("red")), types.ValueHash([]byte("blue"))
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("red"), Owner: owner})
+	assert.Equal(t, []string{"key0", "key1"}, keeper.FindKeysByHash(ctx, "uuid", red).Keys)
+
+	// update
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("blue"), Owner: owner})
+	assert.Equal(t, []string{"key1"}, keeper.FindKeysByHash(ctx, "uuid", red).Keys)
+	assert.Equal(t, []string{"key0"}, keeper.FindKeysByHash(ctx, "uuid", blue).Keys)
+
+	// rename
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key1", "key2", false)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"key2"}, keeper.FindKeysByHash(ctx, "uuid", red).Keys)
+
+	// delete
+	keeper.SetLease(ctx, testStore, "uuid", "key0", 0, 0)
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key0")
+	assert.Empty(t, keeper.FindKeysByHash(ctx, "uuid", blue).Keys)
+
+	_, broken := IndexesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// recordingHooks notes every change it is told of
+type recordingHooks struct {
+	changes []string
+}
+
+func (h *recordingHooks) AfterValueSet(_ sdk.Context, UUID string, key string, oldValue *types.BLZValue, value types.BLZValue) {
+	action := "update"
+	if oldValue == nil {
+		action = "create"
+	}
+	h.changes = append(h.changes, action+" "+UUID+"/"+key+" "+string(value.Value)+" "+value.Owner.String())
+}
+
+func (h *recordingHooks) AfterValueRemoved(_ sdk.Context, UUID string, key string, value types.BLZValue) {
+	h.changes = append(h.changes, "remove "+UUID+"/"+key+" "+string(value.Value))
+}
+
+func TestKeeper_Hooks(t *testing.T) {
+	ctx, testStore, ownerBytes, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	hooks := &recordingHooks{}
+	keeper.SetHooks(hooks)
+	owner, newOwner := sdk.AccAddress(ownerBytes), sdk.AccAddress("newowner")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("a"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("b"), Owner: newOwner})
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key0", "key1", false)
+	assert.True(t, ok)
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key1")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("c"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid", "key2", 0, 10)
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 10)
+
+	assert.Equal(t, []string{
+		"create uuid/key0 a " + owner.String(),
+		"update uuid/key0 b " + newOwner.String(),
+		"create uuid/key1 b " + newOwner.String(),
+		"remove uuid/key0 b",
+		"remove uuid/key1 b",
+		"create uuid/key2 c " + owner.String(),
+		"remove uuid/key2 c",
+	}, hooks.changes)
+
+	assert.Panics(t, func() { keeper.SetHooks(hooks) })
+}
@@ -0,0 +1,503 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func makeIndexConfigKey(UUID string) []byte {
+	return append(append([]byte{}, types.IndexConfigPrefix...), []byte(UUID)...)
+}
+
+// value index entries are laid out as prefix | len(UUID) | UUID | sha256(indexed value) | key
+func makeValueIndexPrefix(UUID string, hash []byte) []byte {
+	prefix := append(append([]byte{}, types.ValueIndexPrefix...), lengthPrefixed(UUID)...)
+	return append(prefix, hash...)
+}
+
+func makeValueIndexKey(UUID string, hash []byte, key string) []byte {
+	return append(makeValueIndexPrefix(UUID, hash), []byte(key)...)
+}
+
+// owner index entries are laid out as prefix | len(owner) | owner | len(UUID) | UUID | key
+func makeOwnerIndexPrefix(owner sdk.AccAddress, UUID string) []byte {
+	return append(makeOwnerPrefix(owner), lengthPrefixed(UUID)...)
+}
+
+// makeOwnerPrefix is the start of every owner index entry of owner, in all UUIDs
+func makeOwnerPrefix(owner sdk.AccAddress) []byte {
+	prefix := append(append([]byte{}, types.OwnerIndexPrefix...), byte(len(owner)))
+	return append(prefix, owner...)
+}
+
+func makeOwnerIndexKey(owner sdk.AccAddress, UUID string, key string) []byte {
+	return append(makeOwnerIndexPrefix(owner, UUID), []byte(key)...)
+}
+
+// lease index entries are laid out as prefix | len(owner) | owner | len(UUID) | UUID |
+// expiry height | key, and like the counters are also kept with an empty owner
+func makeLeaseIndexPrefix(owner sdk.AccAddress, UUID string) []byte {
+	prefix := append(append([]byte{}, types.LeaseIndexPrefix...), byte(len(owner)))
+	return append(append(prefix, owner...), lengthPrefixed(UUID)...)
+}
+
+func makeLeaseIndexKey(owner sdk.AccAddress, UUID string, expiry int64, key string) []byte {
+	return append(append(makeLeaseIndexPrefix(owner, UUID), sdk.Uint64ToBigEndian(uint64(expiry))...), []byte(key)...)
+}
+
+func leaseExpiry(value *types.BLZValue) int64 {
+	return value.Height + value.Lease
+}
+
+// counters are kept per UUID and owner as prefix | len(owner) | owner | UUID, the
+// counter with an empty owner holding the total for the UUID
+func makeCountKey(owner sdk.AccAddress, UUID string) []byte {
+	prefix := append(append([]byte{}, types.CountPrefix...), byte(len(owner)))
+	return append(append(prefix, owner...), []byte(UUID)...)
+}
+
+func (k Keeper) getCounter(indexStore sdk.KVStore, owner sdk.AccAddress, UUID string) uint64 {
+	bz := indexStore.Get(makeCountKey(owner, UUID))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// addToCounter also keeps the number of distinct owners in UUID, which changes when an
+// owner's counter leaves or reaches zero
+func (k Keeper) addToCounter(indexStore sdk.KVStore, owner sdk.AccAddress, UUID string, delta int64) {
+	old := k.getCounter(indexStore, owner, UUID)
+	count := uint64(int64(old) + delta)
+	if len(owner) > 0 && (old == 0) != (count == 0) {
+		if count == 0 {
+			k.addToUUIDStat(indexStore, types.UUIDOwnersPrefix, UUID, -1)
+		} else {
+			k.addToUUIDStat(indexStore, types.UUIDOwnersPrefix, UUID, 1)
+		}
+	}
+
+	if count == 0 {
+		indexStore.Delete(makeCountKey(owner, UUID))
+		return
+	}
+
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, count)
+	indexStore.Set(makeCountKey(owner, UUID), bz)
+}
+
+// the value bytes and distinct owners of a UUID are kept as prefix | UUID, next to its
+// key counter
+func makeUUIDStatKey(prefix []byte, UUID string) []byte {
+	return append(append([]byte{}, prefix...), []byte(UUID)...)
+}
+
+func (k Keeper) getUUIDStat(indexStore sdk.KVStore, prefix []byte, UUID string) uint64 {
+	bz := indexStore.Get(makeUUIDStatKey(prefix, UUID))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) addToUUIDStat(indexStore sdk.KVStore, prefix []byte, UUID string, delta int64) {
+	stat := uint64(int64(k.getUUIDStat(indexStore, prefix, UUID)) + delta)
+	if stat == 0 {
+		indexStore.Delete(makeUUIDStatKey(prefix, UUID))
+		return
+	}
+	indexStore.Set(makeUUIDStatKey(prefix, UUID), sdk.Uint64ToBigEndian(stat))
+}
+
+// GetStoredBytes returns the value bytes stored on chain, over every UUID.
+func (k Keeper) GetStoredBytes(ctx sdk.Context) uint64 {
+	return k.getStoredBytes(k.GetIndexStore(ctx))
+}
+
+func (k Keeper) getStoredBytes(indexStore sdk.KVStore) uint64 {
+	bz := indexStore.Get(types.StoredBytesKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) addToStoredBytes(indexStore sdk.KVStore, delta int64) {
+	stored := uint64(int64(k.getStoredBytes(indexStore)) + delta)
+	if stored == 0 {
+		indexStore.Delete(types.StoredBytesKey)
+		return
+	}
+	indexStore.Set(types.StoredBytesKey, sdk.Uint64ToBigEndian(stored))
+}
+
+// GetUUIDStats reads the keys, value bytes and owners of UUID from its counters, and
+// the earliest and latest lease expiry from the ends of its lease index.
+func (k Keeper) GetUUIDStats(ctx sdk.Context, UUID string) types.QueryResultUUIDStats {
+	indexStore := k.GetIndexStore(ctx)
+	stats := types.QueryResultUUIDStats{
+		UUID:   UUID,
+		Keys:   k.getCounter(indexStore, nil, UUID),
+		Bytes:  k.getUUIDStat(indexStore, types.UUIDBytesPrefix, UUID),
+		Owners: k.getUUIDStat(indexStore, types.UUIDOwnersPrefix, UUID),
+	}
+
+	prefix := makeLeaseIndexPrefix(nil, UUID)
+	first := sdk.KVStorePrefixIterator(indexStore, prefix)
+	if first.Valid() {
+		stats.EarliestExpiry = int64(binary.BigEndian.Uint64(first.Key()[len(prefix):]))
+	}
+	first.Close()
+
+	last := sdk.KVStoreReversePrefixIterator(indexStore, prefix)
+	if last.Valid() {
+		stats.LatestExpiry = int64(binary.BigEndian.Uint64(last.Key()[len(prefix):]))
+	}
+	last.Close()
+
+	return stats
+}
+
+// GetUUIDs lists the UUIDs owner has keys in with the number of keys in each, read
+// from the per owner counters. Like GetKeys the result is limited to MaxKeysSize.
+func (k Keeper) GetUUIDs(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultUUIDs {
+	prefix := makeCountKey(owner, "")
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+
+	uuids := types.QueryResultUUIDs{Owner: owner, UUIDs: make([]types.UUIDCount, 0)}
+	uuidsSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		UUID := string(iterator.Key()[len(prefix):])
+		uuidsSize = uint64(len(UUID)) + uuidsSize
+		if uuidsSize >= k.mks.MaxKeysSize {
+			break
+		}
+		uuids.UUIDs = append(uuids.UUIDs, types.UUIDCount{UUID: UUID, Count: binary.BigEndian.Uint64(iterator.Value())})
+	}
+	return uuids
+}
+
+// GetCountAll adds up owner's per UUID counters, without listing the UUIDs.
+func (k Keeper) GetCountAll(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultCountAll {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), makeCountKey(owner, ""))
+	defer iterator.Close()
+
+	count := types.QueryResultCountAll{Owner: owner}
+	for ; iterator.Valid(); iterator.Next() {
+		count.Count += binary.BigEndian.Uint64(iterator.Value())
+		count.UUIDs++
+	}
+	return count
+}
+
+// GetKeysAll lists owner's keys in every UUID from the owner index, ordered by UUID and
+// then key. Like GetKeys the result is limited to MaxKeysSize, counting UUIDs and keys.
+func (k Keeper) GetKeysAll(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultKeysAll {
+	prefix := makeOwnerPrefix(owner)
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+
+	keys := types.QueryResultKeysAll{Owner: owner, Keys: make([]types.UUIDKey, 0)}
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		if ctx.GasMeter().IsPastLimit() {
+			return types.QueryResultKeysAll{Owner: owner, Keys: make([]types.UUIDKey, 0)}
+		}
+
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(prefix):]))
+		keysSize = uint64(len(UUID)+len(key)) + keysSize
+		if keysSize >= k.mks.MaxKeysSize {
+			break
+		}
+		keys.Keys = append(keys.Keys, types.UUIDKey{UUID: UUID, Key: key})
+	}
+	return keys
+}
+
+// GetAccountUsage adds up the keys, bytes and renewal cost of everything owner stores,
+// per UUID and in total, walking owner's keys in the owner index.
+func (k Keeper) GetAccountUsage(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultAccountUsage {
+	prefix := makeOwnerPrefix(owner)
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+
+	store := k.GetKVStore(ctx)
+	params, stored := k.GetParams(ctx), k.GetStoredBytes(ctx)
+	usage := types.QueryResultAccountUsage{Owner: owner, RenewalCost: sdk.NewCoins(), UUIDs: make([]types.UUIDUsage, 0)}
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(prefix):]))
+		value := k.GetValue(ctx, store, UUID, key)
+		size := uint64(len(UUID) + len(key) + len(value.Value))
+		cost := params.LeaseFeeAt(int64(size)*value.Lease, stored)
+
+		// the index is ordered by UUID, so each UUID's keys are together
+		if n := len(usage.UUIDs); n == 0 || usage.UUIDs[n-1].UUID != UUID {
+			usage.UUIDs = append(usage.UUIDs, types.UUIDUsage{UUID: UUID, RenewalCost: sdk.NewCoins()})
+		}
+		uuidUsage := &usage.UUIDs[len(usage.UUIDs)-1]
+		uuidUsage.Keys++
+		uuidUsage.Bytes += size
+		uuidUsage.RenewalCost = uuidUsage.RenewalCost.Add(cost...)
+
+		usage.Keys++
+		usage.Bytes += size
+		usage.RenewalCost = usage.RenewalCost.Add(cost...)
+	}
+	return usage
+}
+
+func (k Keeper) GetIndexConfig(ctx sdk.Context, UUID string) types.IndexConfig {
+	bz := k.GetIndexStore(ctx).Get(makeIndexConfigKey(UUID))
+	if bz == nil {
+		return types.IndexConfig{}
+	}
+
+	var config types.IndexConfig
+	k.cdc.MustUnmarshalBinaryBare(bz, &config)
+	return config
+}
+
+// SetIndexConfig enables (or reconfigures) the value index of UUID and indexes the
+// keys already stored under it.
+func (k Keeper) SetIndexConfig(ctx sdk.Context, store sdk.KVStore, UUID string, config types.IndexConfig) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearValueIndex(indexStore, UUID)
+	indexStore.Set(makeIndexConfigKey(UUID), k.cdc.MustMarshalBinaryBare(config))
+
+	prefix := lengthPrefixed(UUID)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		value := k.unmarshalValue(iterator.Value())
+		if indexed, ok := config.IndexedValue(value.Value); ok {
+			indexStore.Set(makeValueIndexKey(UUID, types.IndexHash(indexed), string(iterator.Key())[len(prefix):]), []byte{})
+		}
+	}
+}
+
+// GetIndexConfigs returns the value index configuration of every indexed UUID.
+func (k Keeper) GetIndexConfigs(ctx sdk.Context) []types.GenesisIndex {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.IndexConfigPrefix)
+	defer iterator.Close()
+
+	var indexes []types.GenesisIndex
+	for ; iterator.Valid(); iterator.Next() {
+		var config types.IndexConfig
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &config)
+		indexes = append(indexes, types.GenesisIndex{UUID: string(iterator.Key()[len(types.IndexConfigPrefix):]), Config: config})
+	}
+	return indexes
+}
+
+func (k Keeper) DeleteIndexConfig(ctx sdk.Context, UUID string) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearValueIndex(indexStore, UUID)
+	indexStore.Delete(makeIndexConfigKey(UUID))
+}
+
+// FindKeys returns the keys of UUID whose indexed value matches value, limited to
+// MaxKeysSize like GetKeys. Nothing is found unless the UUID has an index.
+func (k Keeper) FindKeys(ctx sdk.Context, UUID string, value string) types.QueryResultKeys {
+	prefix := makeValueIndexPrefix(UUID, types.IndexHash(value))
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+	keys := types.QueryResultKeys{UUID: UUID, Keys: make([]string, 0)}
+
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key()[len(prefix):])
+		keysSize = uint64(len(key)) + keysSize
+		if keysSize >= k.mks.MaxKeysSize {
+			break
+		}
+		keys.Keys = append(keys.Keys, key)
+	}
+	return keys
+}
+
+// BuildOwnerIndex rebuilds the owner index and the key counters from the values in
+// store. Stores written before they existed need this once before owner-scoped reads
+// and counts are correct.
+func (k Keeper) BuildOwnerIndex(ctx sdk.Context, store sdk.KVStore) {
+	k.buildOwnerIndex(ctx, store, SplitMetaKey)
+}
+
+// buildOwnerIndex is BuildOwnerIndex for a store whose meta keys split into UUID and key
+// with split, as older store versions lay them out differently.
+func (k Keeper) buildOwnerIndex(ctx sdk.Context, store sdk.KVStore, split func(string) (string, string)) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearPrefix(indexStore, types.OwnerIndexPrefix)
+	k.clearPrefix(indexStore, types.CountPrefix)
+	k.clearPrefix(indexStore, types.LeaseIndexPrefix)
+	k.clearPrefix(indexStore, types.UUIDBytesPrefix)
+	k.clearPrefix(indexStore, types.UUIDOwnersPrefix)
+	indexStore.Delete(types.StoredBytesKey)
+
+	iterator := k.GetValuesIterator(ctx, store)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := split(string(iterator.Key()))
+		value := k.unmarshalValue(iterator.Value())
+		indexStore.Set(makeOwnerIndexKey(value.Owner, UUID, key), []byte{})
+		k.addToCounter(indexStore, nil, UUID, 1)
+		k.addToCounter(indexStore, value.Owner, UUID, 1)
+		k.addToUUIDStat(indexStore, types.UUIDBytesPrefix, UUID, value.Size)
+		k.addToStoredBytes(indexStore, value.Size)
+		indexStore.Set(makeLeaseIndexKey(nil, UUID, leaseExpiry(&value), key), []byte{})
+		indexStore.Set(makeLeaseIndexKey(value.Owner, UUID, leaseExpiry(&value), key), []byte{})
+	}
+}
+
+func (k Keeper) clearValueIndex(indexStore sdk.KVStore, UUID string) {
+	k.clearPrefix(indexStore, append(append([]byte{}, types.ValueIndexPrefix...), lengthPrefixed(UUID)...))
+}
+
+func (k Keeper) clearPrefix(indexStore sdk.KVStore, prefix []byte) {
+	iterator := sdk.KVStorePrefixIterator(indexStore, prefix)
+
+	var indexKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		indexKeys = append(indexKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for i := range indexKeys {
+		indexStore.Delete(indexKeys[i])
+	}
+}
+
+// updateIndexes must be called before key is written (value set) or deleted (value
+// nil), with oldValue holding what is currently stored under key, if anything.
+func (k Keeper) updateIndexes(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	k.emitChange(ctx, UUID, key, oldValue, value)
+	k.callHooks(ctx, UUID, key, oldValue, value)
+
+	indexStore := k.GetIndexStore(ctx)
+	if oldValue != nil && (value == nil || !oldValue.Owner.Equals(value.Owner)) {
+		indexStore.Delete(makeOwnerIndexKey(oldValue.Owner, UUID, key))
+		indexStore.Delete(makeFreezeKey(oldValue.Owner, UUID, key))
+		indexStore.Delete(makeAutoRenewKey(UUID, key))
+		k.refundLeaseDeposit(ctx, UUID, key, oldValue.Owner)
+		k.addToCounter(indexStore, oldValue.Owner, UUID, -1)
+	}
+	if value != nil && (oldValue == nil || !oldValue.Owner.Equals(value.Owner)) {
+		indexStore.Set(makeOwnerIndexKey(value.Owner, UUID, key), []byte{})
+		k.addToCounter(indexStore, value.Owner, UUID, 1)
+	}
+
+	if oldValue == nil && value != nil {
+		k.addToCounter(indexStore, nil, UUID, 1)
+	} else if oldValue != nil && value == nil {
+		k.addToCounter(indexStore, nil, UUID, -1)
+		k.removeBeneficiary(ctx, UUID, key, oldValue.Owner)
+	}
+
+	var size int64
+	if oldValue != nil {
+		size -= oldValue.Size
+	}
+	if value != nil {
+		size += value.Size
+	}
+	if size != 0 {
+		k.addToUUIDStat(indexStore, types.UUIDBytesPrefix, UUID, size)
+		k.addToStoredBytes(indexStore, size)
+	}
+
+	k.updateLeaseIndex(ctx, UUID, key, oldValue, value)
+	k.updateValueIndex(ctx, UUID, key, oldValue, value)
+	k.updateHashIndex(ctx, UUID, key, oldValue, value)
+	k.updateRetentionIndex(ctx, UUID, key, oldValue, value)
+}
+
+// updateLeaseIndex moves key in the lease index, both overall and for its owner, and
+// in the backlog of expired keys, when its expiry or owner changes.
+func (k Keeper) updateLeaseIndex(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	leaseChanged := oldValue == nil || value == nil || !oldValue.Owner.Equals(value.Owner) || leaseExpiry(oldValue) != leaseExpiry(value)
+	if !leaseChanged {
+		return
+	}
+
+	indexStore := k.GetIndexStore(ctx)
+	if oldValue != nil {
+		indexStore.Delete(makeLeaseIndexKey(nil, UUID, leaseExpiry(oldValue), key))
+		indexStore.Delete(makeLeaseIndexKey(oldValue.Owner, UUID, leaseExpiry(oldValue), key))
+	}
+	if value != nil {
+		indexStore.Set(makeLeaseIndexKey(nil, UUID, leaseExpiry(value), key), []byte{})
+		indexStore.Set(makeLeaseIndexKey(value.Owner, UUID, leaseExpiry(value), key), []byte{})
+	}
+	k.updateBacklog(ctx, oldValue, value)
+}
+
+func (k Keeper) callHooks(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	if k.hooks == nil {
+		return
+	}
+	if value == nil {
+		k.hooks.AfterValueRemoved(ctx, UUID, key, *oldValue)
+	} else {
+		k.hooks.AfterValueSet(ctx, UUID, key, oldValue, *value)
+	}
+}
+
+// emitChange emits the crud_change event that change feed subscribers follow
+func (k Keeper) emitChange(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	action, hash := types.ActionUpdate, ""
+	if oldValue == nil {
+		action = types.ActionCreate
+	}
+	if value == nil {
+		action = types.ActionDelete
+	} else {
+		hash = hex.EncodeToString(value.Hash)
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeChange,
+		sdk.NewAttribute(types.AttributeKeyUUID, UUID),
+		sdk.NewAttribute(types.AttributeKeyKey, key),
+		sdk.NewAttribute(types.AttributeKeyAction, action),
+		sdk.NewAttribute(types.AttributeKeyHash, hash),
+	))
+}
+
+func (k Keeper) updateValueIndex(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	config := k.GetIndexConfig(ctx, UUID)
+	if config.Owner.Empty() {
+		return
+	}
+
+	indexStore := k.GetIndexStore(ctx)
+	if oldValue != nil {
+		if indexed, ok := config.IndexedValue(oldValue.Value); ok {
+			indexStore.Delete(makeValueIndexKey(UUID, types.IndexHash(indexed), key))
+		}
+	}
+
+	if value != nil {
+		if indexed, ok := config.IndexedValue(value.Value); ok {
+			indexStore.Set(makeValueIndexKey(UUID, types.IndexHash(indexed), key), []byte{})
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_SetIndexConfig(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	assert.True(t, keeper.GetIndexConfig(ctx, "uuid").Owner.Empty())
+
+	// existing keys are indexed when the index is enabled
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("blue"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "otheruuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+
+	keeper.SetIndexConfig(ctx, testStore, "uuid", types.IndexConfig{Owner: owner})
+
+	assert.Equal(t, types.IndexConfig{Owner: owner}, keeper.GetIndexConfig(ctx, "uuid"))
+	assert.Equal(t, []types.GenesisIndex{{UUID: "uuid", Config: types.IndexConfig{Owner: owner}}}, keeper.GetIndexConfigs(ctx))
+	assert.Equal(t, []string{"key0", "key2"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+	assert.Equal(t, []string{"key1"}, keeper.FindKeys(ctx, "uuid", "blue").Keys)
+	assert.Empty(t, keeper.FindKeys(ctx, "otheruuid", "red").Keys)
+
+	// reconfiguring drops the entries of the previous configuration
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte(`{"colour":"red"}`), Owner: owner})
+	keeper.SetIndexConfig(ctx, testStore, "uuid", types.IndexConfig{Field: "colour", Owner: owner})
+
+	assert.Equal(t, []string{"key3"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+	assert.Empty(t, keeper.FindKeys(ctx, "uuid", "blue").Keys)
+
+	keeper.DeleteIndexConfig(ctx, "uuid")
+
+	assert.True(t, keeper.GetIndexConfig(ctx, "uuid").Owner.Empty())
+	assert.Empty(t, keeper.FindKeys(ctx, "uuid", "red").Keys)
+}
+
+func TestKeeper_ValueIndexMaintenance(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	keeper.SetIndexConfig(ctx, testStore, "uuid", types.IndexConfig{Owner: owner})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("red"), Owner: owner})
+	assert.Equal(t, []string{"key0", "key1"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+
+	// update
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("blue"), Owner: owner})
+	assert.Equal(t, []string{"key1"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+	assert.Equal(t, []string{"key0"}, keeper.FindKeys(ctx, "uuid", "blue").Keys)
+
+	// rename
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key1", "key2", false)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"key2"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+
+	// delete
+	keeper.SetLease(ctx, testStore, "uuid", "key0", 0, 0)
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key0")
+	assert.Empty(t, keeper.FindKeys(ctx, "uuid", "blue").Keys)
+
+	// lease expiry
+	keeper.SetLease(ctx, testStore, "uuid", "key2", 0, 10)
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 10)
+	assert.Empty(t, keeper.FindKeys(ctx, "uuid", "red").Keys)
+
+	// delete all
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.DeleteAll(ctx, testStore, "uuid", owner)
+	assert.Empty(t, keeper.FindKeys(ctx, "uuid", "red").Keys)
+}
+
+func TestKeeper_FindKeys_MaxSize(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 9})
+
+	keeper.SetIndexConfig(ctx, testStore, "uuid", types.IndexConfig{Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("red"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("red"), Owner: owner})
+
+	assert.Equal(t, []string{"key0", "key1"}, keeper.FindKeys(ctx, "uuid", "red").Keys)
+}
+
+func TestKeeper_OwnerIndexMaintenance(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024, MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+	assert.Equal(t, []string{"key0", "key1"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"key2"}, keeper.GetKeys(ctx, testStore, "uuid", otherOwner).Keys)
+	assert.Equal(t, uint64(3), keeper.GetCount(ctx, testStore, "uuid", nil).Count)
+
+	// change of owner
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+	assert.Equal(t, []string{"key0"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"key1", "key2"}, keeper.GetKeys(ctx, testStore, "uuid", otherOwner).Keys)
+	assert.Equal(t, uint64(1), keeper.GetCount(ctx, testStore, "uuid", owner).Count)
+	assert.Equal(t, uint64(2), keeper.GetCount(ctx, testStore, "uuid", otherOwner).Count)
+	assert.Equal(t, uint64(3), keeper.GetCount(ctx, testStore, "uuid", nil).Count)
+
+	// rename
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key0", "key3", false)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"key3"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+
+	// delete
+	keeper.SetLease(ctx, testStore, "uuid", "key1", 0, 0)
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key1")
+	assert.Equal(t, []string{"key2"}, keeper.GetKeys(ctx, testStore, "uuid", otherOwner).Keys)
+
+	// lease expiry
+	keeper.SetLease(ctx, testStore, "uuid", "key2", 0, 10)
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 10)
+	assert.Equal(t, uint64(0), keeper.GetCount(ctx, testStore, "uuid", otherOwner).Count)
+
+	// delete all
+	keeper.SetValue(ctx, testStore, "uuid", "key4", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+	keeper.DeleteAll(ctx, testStore, "uuid", owner)
+	assert.Empty(t, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"key4"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+	assert.Equal(t, uint64(1), keeper.GetCount(ctx, testStore, "uuid", nil).Count)
+}
+
+func TestKeeper_BuildOwnerIndex(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	// values written without going through the keeper are not in the owner index
+	testStore.Set([]byte(MakeMetaKey("uuid", "key0")), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value"), Owner: owner}))
+	testStore.Set([]byte(MakeMetaKey("uuid", "key1")), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value"), Owner: owner}))
+	assert.Empty(t, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+
+	keeper.BuildOwnerIndex(ctx, testStore)
+
+	assert.Equal(t, []string{"key0", "key1"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, uint64(2), keeper.GetCount(ctx, testStore, "uuid", owner).Count)
+}
+
+func TestKeeper_ChangeEvents(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value0"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value1"), Owner: owner})
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key")
+
+	var changes [][]string
+	for _, event := range ctx.EventManager().Events() {
+		assert.Equal(t, types.EventTypeChange, event.Type)
+		var attributes []string
+		for _, attribute := range event.Attributes {
+			attributes = append(attributes, string(attribute.Value))
+		}
+		changes = append(changes, attributes)
+	}
+
+	assert.Equal(t, [][]string{
+		{"uuid", "key", types.ActionCreate, hex.EncodeToString(types.ValueHash([]byte("value0")))},
+		{"uuid", "key", types.ActionUpdate, hex.EncodeToString(types.ValueHash([]byte("value1")))},
+		{"uuid", "key", types.ActionDelete, ""},
+	}, changes)
+}
+
+func TestKeeper_GetUUIDs(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid1", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid2", "key0", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+
+	assert.Equal(t, types.QueryResultUUIDs{Owner: owner, UUIDs: []types.UUIDCount{{UUID: "uuid0", Count: 2}, {UUID: "uuid1", Count: 1}}},
+		keeper.GetUUIDs(ctx, owner))
+
+	// a UUID drops out once the owner has no keys left in it
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid1", "key0")
+	assert.Equal(t, []types.UUIDCount{{UUID: "uuid0", Count: 2}}, keeper.GetUUIDs(ctx, owner).UUIDs)
+
+	assert.Equal(t, []types.UUIDCount{{UUID: "uuid2", Count: 1}}, keeper.GetUUIDs(ctx, otherOwner).UUIDs)
+	assert.Empty(t, keeper.GetUUIDs(ctx, sdk.AccAddress("nobody")).UUIDs)
+}
+
+func TestKeeper_GetUUIDStats(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	assert.Equal(t, types.QueryResultUUIDStats{UUID: "uuid"}, keeper.GetUUIDStats(ctx, "uuid"))
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 300, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value22"), Height: 10, Lease: 200, Owner: otherOwner})
+	keeper.SetValue(ctx, testStore, "uuid1", "key0", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 900, Owner: owner})
+
+	assert.Equal(t, types.QueryResultUUIDStats{UUID: "uuid", Keys: 3, Bytes: 17, Owners: 2, EarliestExpiry: 110, LatestExpiry: 310},
+		keeper.GetUUIDStats(ctx, "uuid"))
+	assert.Equal(t, uint64(22), keeper.GetStoredBytes(ctx))
+
+	// updates change the bytes and leases, and an owner leaves with its last key
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("v"), Height: 10, Lease: 500, Owner: owner})
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid", "key2")
+	assert.Equal(t, types.QueryResultUUIDStats{UUID: "uuid", Keys: 2, Bytes: 6, Owners: 1, EarliestExpiry: 110, LatestExpiry: 510},
+		keeper.GetUUIDStats(ctx, "uuid"))
+
+	// handing a key to another owner keeps the count of owners
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: otherOwner})
+	assert.Equal(t, uint64(2), keeper.GetUUIDStats(ctx, "uuid").Owners)
+
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid", "key0")
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid", "key1")
+	assert.Equal(t, types.QueryResultUUIDStats{UUID: "uuid"}, keeper.GetUUIDStats(ctx, "uuid"))
+	assert.Equal(t, uint64(5), keeper.GetStoredBytes(ctx))
+
+	_, broken := CountersInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
+
+func TestKeeper_GetCountAll(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid1", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid1", "key1", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+
+	assert.Equal(t, types.QueryResultCountAll{Owner: owner, Count: 3, UUIDs: 2}, keeper.GetCountAll(ctx, owner))
+	assert.Equal(t, types.QueryResultCountAll{Owner: otherOwner, Count: 1, UUIDs: 1}, keeper.GetCountAll(ctx, otherOwner))
+
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid1", "key0")
+	assert.Equal(t, types.QueryResultCountAll{Owner: owner, Count: 2, UUIDs: 1}, keeper.GetCountAll(ctx, owner))
+	assert.Equal(t, types.QueryResultCountAll{Owner: sdk.AccAddress("nobody")}, keeper.GetCountAll(ctx, sdk.AccAddress("nobody")))
+}
+
+func TestKeeper_GetKeysAll(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid1", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key2", types.BLZValue{Value: []byte("value"), Owner: otherOwner})
+
+	assert.Equal(t, types.QueryResultKeysAll{Owner: owner, Keys: []types.UUIDKey{{UUID: "uuid0", Key: "key0"}, {UUID: "uuid0", Key: "key1"}, {UUID: "uuid1", Key: "key0"}}},
+		keeper.GetKeysAll(ctx, owner))
+	assert.Equal(t, []types.UUIDKey{{UUID: "uuid0", Key: "key2"}}, keeper.GetKeysAll(ctx, otherOwner).Keys)
+	assert.Empty(t, keeper.GetKeysAll(ctx, sdk.AccAddress("nobody")).Keys)
+
+	// the listing stops at MaxKeysSize
+	keeper = NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 20})
+	assert.Equal(t, []types.UUIDKey{{UUID: "uuid0", Key: "key0"}, {UUID: "uuid0", Key: "key1"}}, keeper.GetKeysAll(ctx, owner).Keys)
+}
+
+func TestKeeper_GetKeysByExpiry(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 300, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 200, Owner: otherOwner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 400, Owner: owner})
+
+	newCtx := ctx.WithBlockHeight(20)
+
+	page := keeper.GetKeysByExpiry(newCtx, "uuid", owner, nil, 2)
+	assert.Equal(t, []types.KeyLease{{Key: "key1", Lease: 90}, {Key: "key0", Lease: 290}}, page.KeyLeases)
+	assert.Equal(t, owner, page.Owner)
+	assert.NotEmpty(t, page.Next)
+
+	// renewing a key already listed does not shift the next page
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 20, Lease: 1000, Owner: owner})
+
+	start, err := hex.DecodeString(page.Next)
+	assert.Nil(t, err)
+	page = keeper.GetKeysByExpiry(newCtx, "uuid", owner, start, 2)
+	assert.Equal(t, []types.KeyLease{{Key: "key3", Lease: 390}, {Key: "key1", Lease: 1000}}, page.KeyLeases)
+	assert.Empty(t, page.Next)
+
+	// every owner's keys without an owner
+	page = keeper.GetKeysByExpiry(newCtx, "uuid", nil, nil, 10)
+	assert.Equal(t, []types.KeyLease{{Key: "key2", Lease: 190}, {Key: "key0", Lease: 290}, {Key: "key3", Lease: 390}, {Key: "key1", Lease: 1000}}, page.KeyLeases)
+	assert.Empty(t, page.Next)
+
+	assert.Empty(t, keeper.GetKeysByExpiry(newCtx, "other", owner, nil, 10).KeyLeases)
+
+	// pages stop at MaxKeysSize but always hold a key
+	keeper = NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 2})
+	page = keeper.GetKeysByExpiry(newCtx, "uuid", nil, nil, 10)
+	assert.Equal(t, []types.KeyLease{{Key: "key2", Lease: 190}}, page.KeyLeases)
+	assert.NotEmpty(t, page.Next)
+}
+
+func TestKeeper_GetNShortestLeasesIndex(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 300, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 200, Owner: otherOwner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 400, Owner: owner})
+
+	newCtx := ctx.WithBlockHeight(20)
+
+	// Next picks up where the keys left out start
+	result := keeper.GetNShortestLeases(newCtx, testStore, "uuid", nil, 2)
+	assert.Equal(t, []types.KeyLease{{Key: "key1", Lease: 90}, {Key: "key2", Lease: 190}}, result.KeyLeases)
+	start, err := hex.DecodeString(result.Next)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.KeyLease{{Key: "key0", Lease: 290}, {Key: "key3", Lease: 390}},
+		keeper.GetKeysByExpiry(newCtx, "uuid", nil, start, 2).KeyLeases)
+
+	result = keeper.GetNShortestLeases(newCtx, testStore, "uuid", owner, 5)
+	assert.Equal(t, []types.KeyLease{{Key: "key1", Lease: 90}, {Key: "key0", Lease: 290}, {Key: "key3", Lease: 390}}, result.KeyLeases)
+	assert.Empty(t, result.Next)
+
+	// renewing a lease moves the key in the index
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Height: 20, Lease: 1000, Owner: owner})
+	assert.Equal(t, []types.KeyLease{{Key: "key2", Lease: 190}, {Key: "key0", Lease: 290}, {Key: "key3", Lease: 390}, {Key: "key1", Lease: 1000}},
+		keeper.GetNShortestLeases(newCtx, testStore, "uuid", nil, 10).KeyLeases)
+
+	keeper.DeleteValue(ctx, testStore, nil, "uuid", "key2")
+	assert.Equal(t, []types.KeyLease{{Key: "key0", Lease: 290}},
+		keeper.GetNShortestLeases(newCtx, testStore, "uuid", nil, 1).KeyLeases)
+
+	// a key reaching MaxKeysSize on its own is still returned, with the rest left to Next
+	keeper = NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 4})
+	result = keeper.GetNShortestLeases(newCtx, testStore, "uuid", nil, 10)
+	assert.Equal(t, []types.KeyLease{{Key: "key0", Lease: 290}}, result.KeyLeases)
+	assert.NotEmpty(t, result.Next)
+}
+
+func TestKeeper_GetAccountUsage(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	keeper.SetParams(ctx, params)
+
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key1", types.BLZValue{Value: []byte("value1"), Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid1", "key0", types.BLZValue{Value: []byte("value"), Lease: 1000, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key2", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: otherOwner})
+
+	// 14 and 15 bytes for 100 blocks at 0.01ubnt, then 14 bytes for 1000 blocks
+	ubnt := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ubnt", amount)) }
+	assert.Equal(t, types.QueryResultAccountUsage{
+		Owner:       owner,
+		Keys:        3,
+		Bytes:       43,
+		RenewalCost: ubnt(169),
+		UUIDs: []types.UUIDUsage{
+			{UUID: "uuid0", Keys: 2, Bytes: 29, RenewalCost: ubnt(29)},
+			{UUID: "uuid1", Keys: 1, Bytes: 14, RenewalCost: ubnt(140)},
+		},
+	}, keeper.GetAccountUsage(ctx, owner))
+
+	assert.Equal(t, types.QueryResultAccountUsage{Owner: sdk.AccAddress("nobody"), RenewalCost: sdk.NewCoins(), UUIDs: []types.UUIDUsage{}},
+		keeper.GetAccountUsage(ctx, sdk.AccAddress("nobody")))
+}
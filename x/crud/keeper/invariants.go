@@ -0,0 +1,230 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+// the index store prefixes of the counters and of the index entries, which are all
+// derived from the stored values
+var (
+	counterPrefixes    = [][]byte{types.CountPrefix, types.UUIDBytesPrefix, types.UUIDOwnersPrefix}
+	indexEntryPrefixes = [][]byte{types.ValueIndexPrefix, types.HashEntryPrefix, types.OwnerIndexPrefix, types.LeaseIndexPrefix}
+)
+
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "leases", LeasesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "counters", CountersInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "indexes", IndexesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "deposits", DepositsInvariant(k))
+}
+
+// LeasesInvariant checks that every key has exactly one entry in the lease store
+// and that every lease store entry belongs to an existing key.
+func LeasesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		store := k.GetKVStore(ctx)
+		leases := make(map[string]int)
+
+		var msg string
+		broken := false
+
+		iterator := sdk.KVStorePrefixIterator(k.GetLeaseStore(ctx), []byte{})
+		for ; iterator.Valid(); iterator.Next() {
+			metaKey := string(iterator.Key())[strings.Index(string(iterator.Key()), "\x00")+1:]
+			leases[metaKey]++
+			if !store.Has([]byte(metaKey)) {
+				broken = true
+				msg += fmt.Sprintf("\tlease %q has no key\n", iterator.Key())
+			}
+		}
+		iterator.Close()
+
+		iterator = k.GetValuesIterator(ctx, store)
+		for ; iterator.Valid(); iterator.Next() {
+			if count := leases[string(iterator.Key())]; count != 1 {
+				broken = true
+				msg += fmt.Sprintf("\tkey %q has %d leases\n", iterator.Key(), count)
+			}
+		}
+		iterator.Close()
+
+		return sdk.FormatInvariant(types.ModuleName, "leases", msg), broken
+	}
+}
+
+// CountersInvariant recounts the keys of every UUID and owner, the value bytes and
+// owners of every UUID and the value bytes stored in all, and checks the results
+// against the stored counters.
+func CountersInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		expected, storedBytes := k.expectedCounters(ctx)
+
+		var msg string
+		broken := false
+
+		if stored := k.GetStoredBytes(ctx); stored != storedBytes {
+			broken = true
+			msg += fmt.Sprintf("\tstored bytes are %d, expected %d\n", stored, storedBytes)
+		}
+
+		for _, prefix := range counterPrefixes {
+			counters := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+			for ; counters.Valid(); counters.Next() {
+				count := binary.BigEndian.Uint64(counters.Value())
+				if expected[string(counters.Key())] != count {
+					broken = true
+					msg += fmt.Sprintf("\tcounter %X is %d, expected %d\n", counters.Key(), count, expected[string(counters.Key())])
+				}
+				delete(expected, string(counters.Key()))
+			}
+			counters.Close()
+		}
+
+		for key, count := range expected {
+			broken = true
+			msg += fmt.Sprintf("\tcounter %X is missing, expected %d\n", []byte(key), count)
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "counters", msg), broken
+	}
+}
+
+// IndexesInvariant rebuilds the owner, lease, value and hash index entries from the
+// stored values and checks that the index store holds exactly those.
+func IndexesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		expected := k.expectedIndexEntries(ctx)
+
+		var msg string
+		broken := false
+
+		indexStore := k.GetIndexStore(ctx)
+		for _, prefix := range indexEntryPrefixes {
+			iterator := sdk.KVStorePrefixIterator(indexStore, prefix)
+			for ; iterator.Valid(); iterator.Next() {
+				if !expected[string(iterator.Key())] {
+					broken = true
+					msg += fmt.Sprintf("\tunexpected index entry %X\n", iterator.Key())
+				}
+				delete(expected, string(iterator.Key()))
+			}
+			iterator.Close()
+		}
+
+		for key := range expected {
+			broken = true
+			msg += fmt.Sprintf("\tindex entry %X is missing\n", []byte(key))
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "indexes", msg), broken
+	}
+}
+
+// DepositsInvariant checks that every lease deposit belongs to an existing key and that
+// together they hold exactly the coins of the lease deposit module account.
+func DepositsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		store := k.GetKVStore(ctx)
+
+		var msg string
+		broken := false
+
+		total := sdk.NewCoins()
+		for _, record := range k.GetLeaseDeposits(ctx) {
+			total = total.Add(record.Deposit.Amount...)
+			if !store.Has([]byte(MakeMetaKey(record.UUID, record.Key))) {
+				broken = true
+				msg += fmt.Sprintf("\tdeposit of %q in %q has no key\n", record.Key, record.UUID)
+			}
+		}
+
+		if held := k.supplyKeeper.GetModuleAccount(ctx, types.LeaseDepositName).GetCoins(); !held.IsEqual(total) {
+			broken = true
+			msg += fmt.Sprintf("\tdeposits total %s, module account holds %s\n", total, held)
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "deposits", msg), broken
+	}
+}
+
+// expectedCounters recounts the keys of every UUID and owner and the value bytes and
+// owners of every UUID, by counter key, and the value bytes stored in all.
+func (k Keeper) expectedCounters(ctx sdk.Context) (map[string]uint64, uint64) {
+	expected := make(map[string]uint64)
+	var storedBytes uint64
+
+	iterator := k.GetValuesIterator(ctx, k.GetKVStore(ctx))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, _ := SplitMetaKey(string(iterator.Key()))
+		value := k.unmarshalValue(iterator.Value())
+		expected[string(makeCountKey(nil, UUID))]++
+		ownerKey := string(makeCountKey(value.Owner, UUID))
+		if expected[ownerKey] == 0 {
+			expected[string(makeUUIDStatKey(types.UUIDOwnersPrefix, UUID))]++
+		}
+		expected[ownerKey]++
+		if value.Size > 0 {
+			expected[string(makeUUIDStatKey(types.UUIDBytesPrefix, UUID))] += uint64(value.Size)
+			storedBytes += uint64(value.Size)
+		}
+	}
+	return expected, storedBytes
+}
+
+// expectedIndexEntries rebuilds the keys of the owner, lease, value and hash index
+// entries from the stored values.
+func (k Keeper) expectedIndexEntries(ctx sdk.Context) map[string]bool {
+	expected := make(map[string]bool)
+	configs := make(map[string]types.IndexConfig)
+	hashed := make(map[string]bool)
+
+	iterator := k.GetValuesIterator(ctx, k.GetKVStore(ctx))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()))
+		value := k.unmarshalValue(iterator.Value())
+		expected[string(makeOwnerIndexKey(value.Owner, UUID, key))] = true
+		expected[string(makeLeaseIndexKey(nil, UUID, leaseExpiry(&value), key))] = true
+		expected[string(makeLeaseIndexKey(value.Owner, UUID, leaseExpiry(&value), key))] = true
+
+		config, ok := configs[UUID]
+		if !ok {
+			config = k.GetIndexConfig(ctx, UUID)
+			configs[UUID] = config
+		}
+		if indexed, ok := config.IndexedValue(value.Value); ok && !config.Owner.Empty() {
+			expected[string(makeValueIndexKey(UUID, types.IndexHash(indexed), key))] = true
+		}
+
+		hashIndexed, ok := hashed[UUID]
+		if !ok {
+			hashIndexed = !k.GetHashIndexConfig(ctx, UUID).Owner.Empty()
+			hashed[UUID] = hashIndexed
+		}
+		if hashIndexed {
+			expected[string(makeHashEntryKey(UUID, storedHash(&value), key))] = true
+		}
+	}
+	return expected
+}
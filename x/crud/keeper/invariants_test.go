@@ -0,0 +1,154 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCountersInvariant(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	store := keeper.GetKVStore(ctx)
+
+	keeper.SetValue(ctx, store, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, store, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.DeleteValue(ctx, store, nil, "uuid", "key0")
+
+	_, broken := CountersInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// a value written behind the keeper's back is not counted
+	store.Set([]byte(MakeMetaKey("uuid", "key2")), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value"), Owner: owner}))
+
+	msg, broken := CountersInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "expected 2")
+	assert.Contains(t, msg, "expected 10")
+
+	keeper.BuildOwnerIndex(ctx, store)
+
+	_, broken = CountersInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
+
+func TestLeasesInvariant(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	store := keeper.GetKVStore(ctx)
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	for _, key := range []string{"key0", "key1", "key2"} {
+		keeper.SetValue(ctx, store, "uuid", key, types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 10, 100)
+	}
+
+	_, broken := LeasesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// renaming moves the lease along with the key
+	_, ok := keeper.RenameKey(ctx, store, "uuid", "key0", "key3", false)
+	assert.True(t, ok)
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key3"))))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key0"))))
+
+	_, broken = LeasesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// a key without a lease
+	keeper.SetValue(ctx, store, "uuid", "key4", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+
+	msg, broken := LeasesInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "has 0 leases")
+
+	keeper.SetLease(ctx, leaseStore, "uuid", "key4", 10, 100)
+
+	// delete all removes the leases too
+	keeper.DeleteAll(ctx, store, "uuid", owner)
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key1"))))
+
+	_, broken = LeasesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// a lease without a key
+	keeper.SetLease(ctx, leaseStore, "uuid", "key5", 10, 100)
+
+	msg, broken = LeasesInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "has no key")
+}
+
+func TestIndexesInvariant(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	store := keeper.GetKVStore(ctx)
+
+	keeper.SetIndexConfig(ctx, store, "uuid", types.IndexConfig{Owner: owner})
+	keeper.SetValue(ctx, store, "uuid", "key0", types.BLZValue{Value: []byte("red"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, store, "uuid", "key1", types.BLZValue{Value: []byte("blue"), Height: 10, Lease: 200, Owner: owner})
+	keeper.SetValue(ctx, store, "uuid", "key0", types.BLZValue{Value: []byte("blue"), Height: 20, Lease: 100, Owner: owner})
+	keeper.DeleteValue(ctx, store, nil, "uuid", "key1")
+
+	_, broken := IndexesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	keeper.GetIndexStore(ctx).Delete(makeOwnerIndexKey(owner, "uuid", "key0"))
+
+	msg, broken := IndexesInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "is missing")
+
+	keeper.BuildOwnerIndex(ctx, store)
+	keeper.GetIndexStore(ctx).Set(makeOwnerIndexKey(owner, "uuid", "key1"), []byte{})
+
+	msg, broken = IndexesInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "unexpected index entry")
+}
+
+func TestDepositsInvariant(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	store := keeper.GetKVStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoin("ubnt", sdk.OneInt()))
+	keeper.SetParams(ctx, params)
+
+	keeper.SetValue(ctx, store, "uuid", "key0", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner})
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key0", 50))
+
+	_, broken := DepositsInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// coins sent to the module account without a deposit
+	supplyKeeper[types.LeaseDepositName] = supplyKeeper[types.LeaseDepositName].Add(sdk.NewInt64Coin("ubnt", 1))
+
+	msg, broken := DepositsInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "module account holds 51ubnt")
+
+	// a deposit whose key was removed behind the keeper's back
+	store.Delete([]byte(MakeMetaKey("uuid", "key0")))
+
+	msg, broken = DepositsInvariant(keeper)(ctx)
+	assert.True(t, broken)
+	assert.Contains(t, msg, "has no key")
+}
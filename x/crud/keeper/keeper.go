@@ -0,0 +1,1076 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=../mocks/mock_keeper.go -package=mocks github.com/bluzelle/curium/x/crud/keeper IKeeper
+//go:generate mockgen -destination=../mocks/mock_gas.go -package=mocks github.com/cosmos/cosmos-sdk/store/types GasMeter
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/golang/snappy"
+	"strconv"
+	"strings"
+)
+
+type MaxKeeperSizes struct {
+	MaxKeysSize           uint64
+	MaxKeyValuesSize      uint64
+	MaxDefaultLeaseBlocks int64
+}
+
+type IKeeper interface {
+	AddUploadChunk(ctx sdk.Context, UUID string, key string, data []byte)
+	ChargeLease(ctx sdk.Context, payer sdk.AccAddress, UUID string, key string, usage int64) error
+	AssembleUpload(ctx sdk.Context, UUID string, key string) []byte
+	CountWrites(ctx sdk.Context, address sdk.AccAddress, writes uint64) error
+	CopyAll(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, newUUID string, owner sdk.AccAddress, lease int64) ([]types.KeyValue, bool)
+	DeleteAll(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultDeleteAll
+	DeleteAuditConfig(ctx sdk.Context, UUID string)
+	DeleteHashIndexConfig(ctx sdk.Context, UUID string)
+	DeleteIndexConfig(ctx sdk.Context, UUID string)
+	DeleteLease(leaseStore sdk.KVStore, UUID string, key string, blockHeight int64, leaseBlocks int64)
+	DeleteRetentionPolicy(ctx sdk.Context, UUID string)
+	DeleteSchema(ctx sdk.Context, UUID string)
+	DeleteUpload(ctx sdk.Context, UUID string, key string)
+	DeleteValue(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string)
+	ExpireNow(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string) (int64, sdk.Coins)
+	DepositEscrow(ctx sdk.Context, owner sdk.AccAddress, amount sdk.Coins) error
+	FindKeys(ctx sdk.Context, UUID string, value string) types.QueryResultKeys
+	FindKeysByHash(ctx sdk.Context, UUID string, hash []byte) types.QueryResultKeys
+	Freeze(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string)
+	GetAccountUsage(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultAccountUsage
+	GetAuditConfig(ctx sdk.Context, UUID string) types.AuditConfig
+	GetAuditConfigs(ctx sdk.Context) []types.GenesisAudit
+	GetAuditLog(ctx sdk.Context, UUID string, start uint64, limit uint64) types.QueryResultAuditLog
+	GetAuditLogs(ctx sdk.Context) []types.AuditEntry
+	GetAutoRenewals(ctx sdk.Context) []types.GenesisAutoRenew
+	GetCdc() *codec.Codec
+	GetCount(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultCount
+	GetCountAll(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultCountAll
+	GetDefaultLeaseBlocks(ctx sdk.Context) int64
+	LeaseFee(ctx sdk.Context, usage int64) sdk.Coins
+	GetLeasePrice(ctx sdk.Context) types.QueryResultLeasePrice
+	GetRentHistory(ctx sdk.Context, owner sdk.AccAddress, start int64, limit uint64) types.QueryResultRentHistory
+	GetRentHistories(ctx sdk.Context) []types.GenesisRentRecord
+	ImportRentRecord(ctx sdk.Context, owner sdk.AccAddress, record types.RentRecord)
+	GetEscrow(ctx sdk.Context, owner sdk.AccAddress) sdk.Coins
+	GetEscrows(ctx sdk.Context) []types.GenesisEscrow
+	GetFrozen(ctx sdk.Context) []types.GenesisFreeze
+	GetGCStatus(ctx sdk.Context) types.QueryResultGCStatus
+	GetBeneficiaries(ctx sdk.Context) []types.GenesisBeneficiary
+	GetBeneficiary(ctx sdk.Context, UUID string, key string) types.Beneficiary
+	GetHash(ctx sdk.Context, store sdk.KVStore, UUID string, key string) types.QueryResultHash
+	GetHashIndexConfig(ctx sdk.Context, UUID string) types.HashIndexConfig
+	GetHashIndexConfigs(ctx sdk.Context) []types.GenesisHashIndex
+	GetIndexConfig(ctx sdk.Context, UUID string) types.IndexConfig
+	GetIndexConfigs(ctx sdk.Context) []types.GenesisIndex
+	GetKVStore(ctx sdk.Context) sdk.KVStore
+	GetKeyValues(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultKeyValues
+	GetKeyValuesFrom(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeyValues
+	GetKeyValuesPage(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeyValuesPage
+	GetKeys(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultKeys
+	GetKeysFrom(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeys
+	MatchKeys(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, pattern types.KeyPattern, start string) types.QueryResultMatch
+	GetKeysAll(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultKeysAll
+	GetKeysByExpiry(ctx sdk.Context, UUID string, owner sdk.AccAddress, start []byte, limit uint64) types.QueryResultKeysByExpiry
+	GetLeaseDeposit(ctx sdk.Context, UUID string, key string) types.LeaseDeposit
+	GetLeaseDeposits(ctx sdk.Context) []types.GenesisLeaseDeposit
+	GetLeaseStore(ctx sdk.Context) sdk.KVStore
+	GetMetadata(ctx sdk.Context, store sdk.KVStore, UUID string, key string) types.QueryResultMetadata
+	GetNShortestLeases(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, n uint64) types.QueryResultNShortestLeaseKeys
+	GetOwner(ctx sdk.Context, store sdk.KVStore, UUID string, key string) sdk.AccAddress
+	GetParams(ctx sdk.Context) types.Params
+	GetRetentionPolicies(ctx sdk.Context) []types.GenesisRetention
+	GetRetentionPolicy(ctx sdk.Context, UUID string) types.RetentionPolicy
+	GetSchema(ctx sdk.Context, UUID string) types.Schema
+	GetSchemas(ctx sdk.Context) []types.GenesisSchema
+	GetUpload(ctx sdk.Context, UUID string, key string) types.Upload
+	GetUploads(ctx sdk.Context) []types.GenesisUpload
+	GetValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string) types.BLZValue
+	GetUUIDs(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultUUIDs
+	GetUUIDStats(ctx sdk.Context, UUID string) types.QueryResultUUIDStats
+	GetVersion(ctx sdk.Context, store sdk.KVStore, UUID string, key string) uint64
+	GetValuesIterator(ctx sdk.Context, store sdk.KVStore) sdk.Iterator
+	HasFrozen(ctx sdk.Context, owner sdk.AccAddress, UUID string) bool
+	ImportAuditEntry(ctx sdk.Context, entry types.AuditEntry)
+	ImportBeneficiary(ctx sdk.Context, UUID string, key string, beneficiary types.Beneficiary)
+	ImportEscrow(ctx sdk.Context, owner sdk.AccAddress, balance sdk.Coins)
+	ImportLeaseDeposit(ctx sdk.Context, UUID string, key string, deposit types.LeaseDeposit)
+	ImportPurgedHeight(ctx sdk.Context, height int64)
+	ImportUpload(ctx sdk.Context, record types.GenesisUpload)
+	IsExpired(ctx sdk.Context, store sdk.KVStore, UUID string, key string) bool
+	IsFrozen(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) bool
+	IsKeyPresent(ctx sdk.Context, store sdk.KVStore, UUID string, key string) bool
+	ProcessLeasesAtBlockHeight(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, lease int64)
+	RenameKey(ctx sdk.Context, store sdk.KVStore, UUID string, key string, newkey string, overwrite bool) (int64, bool)
+	SetAuditConfig(ctx sdk.Context, UUID string, config types.AuditConfig)
+	SetAutoRenew(ctx sdk.Context, UUID string, key string, autoRenew bool)
+	SetBeneficiary(ctx sdk.Context, UUID string, key string, owner sdk.AccAddress, beneficiary sdk.AccAddress) error
+	SetHashIndexConfig(ctx sdk.Context, store sdk.KVStore, UUID string, config types.HashIndexConfig)
+	SetIndexConfig(ctx sdk.Context, store sdk.KVStore, UUID string, config types.IndexConfig)
+	SetLease(ctx sdk.Context, leaseStore sdk.KVStore, UUID string, key string, blockHeight int64, lease int64)
+	SetParams(ctx sdk.Context, params types.Params)
+	SetRetentionPolicy(ctx sdk.Context, store sdk.KVStore, UUID string, policy types.RetentionPolicy)
+	SetSchema(ctx sdk.Context, UUID string, schema types.Schema)
+	SetStoreVersion(ctx sdk.Context, version uint64)
+	SetValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string, value types.BLZValue)
+	StartUpload(ctx sdk.Context, UUID string, key string, upload types.Upload)
+	TransferValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string, recipient sdk.AccAddress) bool
+	Unfreeze(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) bool
+	WithdrawEscrow(ctx sdk.Context, owner sdk.AccAddress, amount sdk.Coins) error
+}
+
+type Keeper struct {
+	supplyKeeper types.SupplyKeeper
+	storeKey     sdk.StoreKey
+	leaseKey     sdk.StoreKey
+	indexKey     sdk.StoreKey
+	paramspace   params.Subspace
+	cdc          *codec.Codec
+	mks          MaxKeeperSizes
+	migrations   map[uint64]MigrationHandler
+	metrics      *Metrics
+	hooks        types.CrudHooks
+}
+
+// lengthPrefixed is s preceded by its length as a uvarint. Store keys begin with the
+// length prefixed UUID, so no UUID or key can be mistaken for the boundary between them.
+func lengthPrefixed(s string) []byte {
+	bz := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(s))
+	return append(bz[:binary.PutUvarint(bz, uint64(len(s)))], s...)
+}
+
+// Note: MakeMetaKey is used in query.go and keeper.go
+func MakeMetaKey(UUID string, key string) string {
+	return string(lengthPrefixed(UUID)) + key
+}
+
+// SplitMetaKey returns the UUID and key of a meta key, empty strings if it is malformed.
+func SplitMetaKey(metaKey string) (string, string) {
+	length, n := binary.Uvarint([]byte(metaKey))
+	if n <= 0 || length > uint64(len(metaKey)-n) {
+		return "", ""
+	}
+	return metaKey[n : n+int(length)], metaKey[n+int(length):]
+}
+
+func MakeLeaseKey(blockHeight int64, UUID string, key string) string {
+	return strconv.FormatInt(blockHeight, 10) + "\x00" + MakeMetaKey(UUID, key)
+}
+
+func NewKeeper(supplyKeeper types.SupplyKeeper, storeKey sdk.StoreKey, leaseKey sdk.StoreKey, indexKey sdk.StoreKey, paramspace params.Subspace, cdc *codec.Codec, mks MaxKeeperSizes) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		storeKey:     storeKey,
+		leaseKey:     leaseKey,
+		indexKey:     indexKey,
+		paramspace:   paramspace.WithKeyTable(types.ParamKeyTable()),
+		cdc:          cdc,
+		mks:          mks,
+		migrations:   defaultMigrations(),
+		metrics:      NopMetrics(),
+	}
+}
+
+// SetMetrics replaces the (discarded) metrics the keeper records to. It must be called
+// before the keeper is handed to the module.
+func (k *Keeper) SetMetrics(metrics *Metrics) {
+	k.metrics = metrics
+}
+
+// SetHooks sets the hooks told of changes to entries. Like SetMetrics, it must be
+// called before the keeper is handed to the module.
+func (k *Keeper) SetHooks(hooks types.CrudHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set crud hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
+func (k Keeper) Metrics() *Metrics {
+	if k.metrics == nil {
+		return NopMetrics()
+	}
+	return k.metrics
+}
+
+// params missing from the store (chains started before they were added) keep their defaults
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// LeaseFee returns the price of usage byte-blocks at the LeasePrice scaled by the
+// utilization band the value bytes now stored on chain fall in.
+func (k Keeper) LeaseFee(ctx sdk.Context, usage int64) sdk.Coins {
+	return k.GetParams(ctx).LeaseFeeAt(usage, k.GetStoredBytes(ctx))
+}
+
+// GetLeasePrice reports the value bytes stored on chain and the lease price they
+// currently make.
+func (k Keeper) GetLeasePrice(ctx sdk.Context) types.QueryResultLeasePrice {
+	params := k.GetParams(ctx)
+	stored := k.GetStoredBytes(ctx)
+	return types.QueryResultLeasePrice{
+		StoredBytes: stored,
+		BasePrice:   params.LeasePrice,
+		Multiplier:  params.UtilizationMultiplier(stored),
+		LeasePrice:  params.LeasePriceAt(stored),
+	}
+}
+
+// ChargeLease takes the LeaseFee of usage byte-blocks from payer as a deposit for the
+// lease of key, held in the lease deposit module account and paid to the validators
+// block by block until the key expires. It is called after every change to the key's
+// lease, so that the deposit is earned up to the new expiry.
+func (k Keeper) ChargeLease(ctx sdk.Context, payer sdk.AccAddress, UUID string, key string, usage int64) error {
+	var fee sdk.Coins
+	if usage > 0 {
+		fee = k.LeaseFee(ctx, usage)
+	}
+
+	if !fee.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, payer, types.LeaseDepositName, fee); err != nil {
+			return err
+		}
+		k.recordRent(ctx, payer, usage, fee, nil)
+	} else if !k.GetIndexStore(ctx).Has(makeLeaseDepositKey(UUID, key)) {
+		return nil
+	}
+
+	k.depositLease(ctx, UUID, key, fee)
+	return nil
+}
+
+// DistributeLeaseFees moves the lease fees collected in the block to the fee collector,
+// which the distribution module shares out among the bonded validators at the start
+// of the next block.
+func (k Keeper) DistributeLeaseFees(ctx sdk.Context) {
+	fees := k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+	if fees.IsZero() {
+		return
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, auth.FeeCollectorName, fees); err != nil {
+		panic(err)
+	}
+}
+
+// GetDefaultLeaseBlocks returns the lease given to keys created or renewed without one,
+// the DefaultLeaseBlocks param or, until governance sets it, the node's
+// MaxDefaultLeaseBlocks.
+func (k Keeper) GetDefaultLeaseBlocks(ctx sdk.Context) int64 {
+	var lease int64
+	k.paramspace.GetIfExists(ctx, types.KeyDefaultLeaseBlocks, &lease)
+	if lease > 0 {
+		return lease
+	}
+	return k.mks.MaxDefaultLeaseBlocks
+}
+
+func (k Keeper) GetKVStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.KVStore(k.storeKey)
+}
+
+func (k Keeper) GetLeaseStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.KVStore(k.leaseKey)
+}
+
+func (k Keeper) GetIndexStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.KVStore(k.indexKey)
+}
+
+// SetValue maintains the metadata of the value: the created height is kept across
+// updates and the modified height only moves when the value itself changes.
+func (k Keeper) SetValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string, value types.BLZValue) {
+	if len(value.Value) == 0 {
+		return
+	}
+
+	metaKey := []byte(MakeMetaKey(UUID, key))
+	var oldValue *types.BLZValue
+	if bz := store.Get(metaKey); bz != nil {
+		old := k.unmarshalValue(bz)
+		oldValue = &old
+		value.CreatedHeight = oldValue.CreatedHeight
+		value.ModifiedHeight = oldValue.ModifiedHeight
+		if !bytes.Equal(oldValue.Value, value.Value) {
+			value.ModifiedHeight = ctx.BlockHeight()
+		}
+	} else if value.CreatedHeight == 0 {
+		value.CreatedHeight = ctx.BlockHeight()
+		value.ModifiedHeight = ctx.BlockHeight()
+	}
+
+	// new keys start at 1, or keep the version they are imported with
+	if oldValue != nil {
+		value.Version = oldValue.Version + 1
+	} else if value.Version == 0 {
+		value.Version = 1
+	}
+	value.Size = int64(len(value.Value))
+	value.Hash = types.ValueHash(value.Value)
+
+	if !ctx.IsCheckTx() {
+		k.Metrics().ValueSize.Observe(float64(value.Size))
+	}
+
+	k.updateIndexes(ctx, UUID, key, oldValue, &value)
+	if oldValue != nil {
+		k.recordAudit(ctx, UUID, key, types.AuditOpUpdate, value.Owner, value.Hash)
+	} else {
+		k.recordAudit(ctx, UUID, key, types.AuditOpCreate, value.Owner, value.Hash)
+	}
+	store.Set(metaKey, k.cdc.MustMarshalBinaryBare(k.compressValue(ctx, value)))
+}
+
+// compressValue snappy compresses values larger than the CompressionThreshold param,
+// as long as that actually makes them smaller. Size and Hash describe the original value.
+func (k Keeper) compressValue(ctx sdk.Context, value types.BLZValue) types.BLZValue {
+	threshold := k.GetParams(ctx).CompressionThreshold
+	if threshold == 0 || uint64(len(value.Value)) <= threshold {
+		return value
+	}
+
+	compressed := snappy.Encode(nil, value.Value)
+	if len(compressed) < len(value.Value) {
+		value.Value = compressed
+		value.Compressed = true
+	}
+	return value
+}
+
+func (k Keeper) unmarshalValue(bz []byte) types.BLZValue {
+	var value types.BLZValue
+	k.cdc.MustUnmarshalBinaryBare(bz, &value)
+
+	if value.Compressed {
+		decoded, err := snappy.Decode(nil, value.Value)
+		if err != nil {
+			panic(fmt.Sprintf("could not decompress stored value: %s", err))
+		}
+		value.Value = decoded
+		value.Compressed = false
+	}
+	return value
+}
+
+// unmarshalValueMeta decodes everything of a stored value but the value itself. Value
+// is the first field of the encoding, so it is skipped without being copied or
+// decompressed and checking an owner or lease costs the same for any size of value.
+func (k Keeper) unmarshalValueMeta(bz []byte) types.BLZValue {
+	// field 1, length delimited; an empty value is left out of the encoding
+	if len(bz) > 0 && bz[0] == 1<<3|2 {
+		length, n := binary.Uvarint(bz[1:])
+		if n <= 0 || length > uint64(len(bz)-1-n) {
+			panic("could not decode stored value")
+		}
+		bz = bz[1+n+int(length):]
+	}
+
+	var value types.BLZValue
+	k.cdc.MustUnmarshalBinaryBare(bz, &value)
+	value.Compressed = false
+	return value
+}
+
+// getValueMeta is GetValue without the Value, see unmarshalValueMeta.
+func (k Keeper) getValueMeta(store sdk.KVStore, UUID string, key string) types.BLZValue {
+	bz := store.Get([]byte(MakeMetaKey(UUID, key)))
+	if bz == nil {
+		return types.BLZValue{}
+	}
+	return k.unmarshalValueMeta(bz)
+}
+
+func (k Keeper) GetValue(_ sdk.Context, store sdk.KVStore, UUID string, key string) types.BLZValue {
+	metaKey := MakeMetaKey(UUID, key)
+	if !k.isUUIDKeyPresent(store, metaKey) {
+		return types.BLZValue{}
+	}
+
+	return k.unmarshalValue(store.Get([]byte(metaKey)))
+}
+
+func (k Keeper) DeleteValue(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string) {
+	metaKey := []byte(MakeMetaKey(UUID, key))
+	bz := store.Get(metaKey)
+	if bz == nil {
+		return
+	}
+
+	value := k.unmarshalValue(bz)
+	k.updateIndexes(ctx, UUID, key, &value, nil)
+	k.recordAudit(ctx, UUID, key, types.AuditOpDelete, value.Owner, nil)
+	if leaseStore != nil {
+		k.DeleteLease(leaseStore, UUID, key, value.Height, value.Lease)
+	}
+	store.Delete(metaKey)
+}
+
+// ExpireNow ends the lease of key at the current block, as if it had run out, and
+// returns the height it expires at and the unearned part of its lease deposit, which is
+// refunded to the owner. The key then goes the way of any expired key: it is kept,
+// read-only, for ExpiryGraceBlocks blocks, in which a renewal brings it back, and is
+// then removed or handed over to its beneficiary. The value itself is not changed, so
+// it keeps its version and the audit log records the termination instead of an update.
+func (k Keeper) ExpireNow(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string) (int64, sdk.Coins) {
+	metaKey := []byte(MakeMetaKey(UUID, key))
+	bz := store.Get(metaKey)
+	if bz == nil {
+		return 0, nil
+	}
+
+	// decoded as stored, so that a compressed value is written back as it is
+	var value types.BLZValue
+	k.cdc.MustUnmarshalBinaryBare(bz, &value)
+
+	// a lease of 0 stands for the default, so the lease is made one block long
+	k.DeleteLease(leaseStore, UUID, key, value.Height, value.Lease)
+	oldValue := value
+	value.Height, value.Lease = ctx.BlockHeight()-1, 1
+	store.Set(metaKey, k.cdc.MustMarshalBinaryBare(value))
+	k.SetLease(ctx, leaseStore, UUID, key, value.Height, value.Lease)
+	k.updateLeaseIndex(ctx, UUID, key, &oldValue, &value)
+
+	k.SetAutoRenew(ctx, UUID, key, false)
+	refund := k.refundLeaseDeposit(ctx, UUID, key, value.Owner)
+	k.recordAudit(ctx, UUID, key, types.AuditOpExpireNow, value.Owner, nil)
+
+	return leaseExpiry(&value), refund
+}
+
+func (k Keeper) IsKeyPresent(_ sdk.Context, store sdk.KVStore, UUID string, key string) bool {
+	return k.isUUIDKeyPresent(store, MakeMetaKey(UUID, key))
+}
+
+// IsExpired tells if the lease of a key has run out. The key stays, read-only, for
+// ExpiryGraceBlocks blocks, in which its lease can still be renewed.
+func (k Keeper) IsExpired(ctx sdk.Context, store sdk.KVStore, UUID string, key string) bool {
+	value := k.getValueMeta(store, UUID, key)
+	if value.Owner.Empty() {
+		return false
+	}
+	return k.leaseEnd(ctx, &value) < ctx.BlockHeight()
+}
+
+func (k Keeper) isUUIDKeyPresent(store sdk.KVStore, key string) bool {
+	return store.Has([]byte(key))
+}
+
+func (k Keeper) GetValuesIterator(_ sdk.Context, store sdk.KVStore) sdk.Iterator {
+	return sdk.KVStorePrefixIterator(store, []byte{})
+}
+
+func (k Keeper) GetKeys(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultKeys {
+	return k.GetKeysFrom(ctx, store, UUID, owner, "")
+}
+
+// GetKeysFrom returns the keys of UUID (only owner's, if given) from start on, in
+// lexicographic byte order. It stops once the keys reach MaxKeysSize, Next then holding
+// the key to continue from, so that paging through a UUID neither skips nor repeats
+// keys that are not changed in the meantime.
+func (k Keeper) GetKeysFrom(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeys {
+	iterator, prefixLength := k.getKeysIteratorFrom(ctx, store, UUID, owner, start)
+	defer iterator.Close()
+	keys := types.QueryResultKeys{UUID: UUID, Keys: make([]string, 0)}
+
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key())[prefixLength:]
+		keysSize = uint64(len(key)) + keysSize
+		if ctx.GasMeter().IsPastLimit() {
+			return types.QueryResultKeys{UUID: UUID, Keys: make([]string, 0)}
+		}
+
+		// always return at least one key so that paging makes progress
+		if keysSize >= k.mks.MaxKeysSize && len(keys.Keys) > 0 {
+			keys.Next = key
+			break
+		}
+		keys.Keys = append(keys.Keys, key)
+	}
+	return keys
+}
+
+// MatchKeys returns the keys of UUID (only owner's, if given) matching pattern, from start
+// on. Only the keys beginning with the literal prefix of the pattern are visited; the page
+// ends when the keys found reach MaxKeysSize or after MaxMatchSteps steps of matching,
+// each of which is charged as gas.
+func (k Keeper) MatchKeys(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, pattern types.KeyPattern, start string) types.QueryResultMatch {
+	prefix := lengthPrefixed(UUID)
+	if owner != nil {
+		store, prefix = k.GetIndexStore(ctx), makeOwnerIndexPrefix(owner, UUID)
+	}
+
+	result := types.QueryResultMatch{UUID: UUID, Pattern: pattern.String(), Keys: make([]string, 0)}
+
+	from := pattern.Prefix()
+	if start > from {
+		if !strings.HasPrefix(start, from) {
+			return result
+		}
+		from = start
+	}
+	iterator := store.Iterator(append(append([]byte{}, prefix...), from...), sdk.PrefixEndBytes(append(append([]byte{}, prefix...), pattern.Prefix()...)))
+	defer iterator.Close()
+
+	keysSize, steps := uint64(0), uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key())[len(prefix):]
+		if steps >= types.MaxMatchSteps {
+			result.Next = key
+			break
+		}
+
+		match, n := pattern.Match(key)
+		steps += n
+		ctx.GasMeter().ConsumeGas(n, "crud match")
+		if !match {
+			continue
+		}
+
+		// always return at least one key so that paging makes progress
+		keysSize += uint64(len(key))
+		if keysSize >= k.mks.MaxKeysSize && len(result.Keys) > 0 {
+			result.Next = key
+			break
+		}
+		result.Keys = append(result.Keys, key)
+	}
+	return result
+}
+
+// getKeysIterator iterates the keys of UUID in key order, using the owner index when
+// owner is given so that only that owner's keys are visited. Keys start at prefixLength.
+func (k Keeper) getKeysIterator(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) (sdk.Iterator, int) {
+	return k.getKeysIteratorFrom(ctx, store, UUID, owner, "")
+}
+
+// getKeysIteratorFrom is getKeysIterator starting at the first key not before start
+func (k Keeper) getKeysIteratorFrom(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) (sdk.Iterator, int) {
+	prefix := lengthPrefixed(UUID)
+	if owner != nil {
+		store, prefix = k.GetIndexStore(ctx), makeOwnerIndexPrefix(owner, UUID)
+	}
+	return store.Iterator(append(append([]byte{}, prefix...), start...), sdk.PrefixEndBytes(prefix)), len(prefix)
+}
+
+func (k Keeper) GetMetadata(ctx sdk.Context, store sdk.KVStore, UUID string, key string) types.QueryResultMetadata {
+	value := k.getValueMeta(store, UUID, key)
+	return types.QueryResultMetadata{
+		UUID:           UUID,
+		Key:            key,
+		CreatedHeight:  value.CreatedHeight,
+		ModifiedHeight: value.ModifiedHeight,
+		Size:           value.Size,
+		Version:        value.Version,
+		ContentType:    value.ContentType,
+		Encoding:       value.Encoding,
+		Frozen:         !value.Owner.Empty() && k.IsFrozen(ctx, value.Owner, UUID, key),
+		Beneficiary:    k.GetBeneficiary(ctx, UUID, key).Address,
+	}
+}
+
+// GetVersion returns the version of key, 0 if it does not exist, without reading its
+// value.
+func (k Keeper) GetVersion(_ sdk.Context, store sdk.KVStore, UUID string, key string) uint64 {
+	return k.getValueMeta(store, UUID, key).Version
+}
+
+// values written before hashes were stored have theirs computed on read
+func (k Keeper) GetHash(ctx sdk.Context, store sdk.KVStore, UUID string, key string) types.QueryResultHash {
+	hash := k.getValueMeta(store, UUID, key).Hash
+	if len(hash) == 0 {
+		hash = types.ValueHash(k.GetValue(ctx, store, UUID, key).Value)
+	}
+	return types.QueryResultHash{UUID: UUID, Key: key, Hash: hex.EncodeToString(hash)}
+}
+
+func (k Keeper) GetOwner(_ sdk.Context, store sdk.KVStore, UUID string, key string) sdk.AccAddress {
+	return k.getValueMeta(store, UUID, key).Owner
+}
+
+// RenameKey moves the value of key to newKey, along with its lease entry, and returns
+// the expiry height the lease keeps. An existing newKey is deleted, with its lease,
+// if overwrite is set and otherwise makes the rename fail.
+func (k Keeper) RenameKey(ctx sdk.Context, store sdk.KVStore, UUID string, key string, newKey string, overwrite bool) (int64, bool) {
+	value := k.GetValue(ctx, store, UUID, key)
+	if value.Owner.Empty() || key == newKey {
+		return 0, false
+	}
+
+	leaseStore := k.GetLeaseStore(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
+	if k.isUUIDKeyPresent(store, MakeMetaKey(UUID, newKey)) {
+		if !overwrite {
+			return 0, false
+		}
+		k.DeleteValue(ctx, store, leaseStore, UUID, newKey)
+	}
+
+	autoRenew := k.IsAutoRenew(ctx, UUID, key)
+	k.SetValue(ctx, store, UUID, newKey, value)
+	k.SetLease(ctx, leaseStore, UUID, newKey, value.Height, value.Lease)
+	k.moveLeaseDeposit(ctx, UUID, key, newKey)
+	k.DeleteValue(ctx, store, leaseStore, UUID, key)
+	k.SetAutoRenew(ctx, UUID, newKey, autoRenew)
+
+	return leaseExpiry(&value), true
+}
+
+// TransferValue gives key to recipient with its lease, the deposit paid for it and its
+// auto-renewal, where a change of owner through SetValue refunds the deposit to the
+// old owner. It returns false if key does not exist.
+func (k Keeper) TransferValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string, recipient sdk.AccAddress) bool {
+	value := k.GetValue(ctx, store, UUID, key)
+	if value.Owner.Empty() {
+		return false
+	}
+
+	// the deposit is taken aside while the owner changes so there is nothing to refund
+	deposit, autoRenew := k.GetLeaseDeposit(ctx, UUID, key), k.IsAutoRenew(ctx, UUID, key)
+	k.GetIndexStore(ctx).Delete(makeLeaseDepositKey(UUID, key))
+	value.Owner = recipient
+	k.SetValue(ctx, store, UUID, key, value)
+	k.ImportLeaseDeposit(ctx, UUID, key, deposit)
+	k.SetAutoRenew(ctx, UUID, key, autoRenew)
+	return true
+}
+
+// CopyAll copies every key in UUID into newUUID as new entries owned by owner with
+// a fresh lease, returning the copied keys and values. Nothing is written
+// if UUID is empty or any of the keys already exist in newUUID.
+func (k Keeper) CopyAll(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, newUUID string, owner sdk.AccAddress, lease int64) ([]types.KeyValue, bool) {
+	prefix := lengthPrefixed(UUID)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+
+	var keyValues []types.KeyValue
+	var values []types.BLZValue
+	for ; iterator.Valid(); iterator.Next() {
+		value := k.unmarshalValue(iterator.Value())
+		keyValues = append(keyValues, types.KeyValue{Key: string(iterator.Key())[len(prefix):], Value: value.Value})
+		values = append(values, value)
+	}
+	iterator.Close()
+
+	if len(keyValues) == 0 {
+		return nil, false
+	}
+
+	for i := range keyValues {
+		if k.isUUIDKeyPresent(store, MakeMetaKey(newUUID, keyValues[i].Key)) {
+			return nil, false
+		}
+	}
+
+	for i := range keyValues {
+		k.SetValue(ctx, store, newUUID, keyValues[i].Key, types.BLZValue{
+			Value:       keyValues[i].Value,
+			Lease:       lease,
+			Height:      ctx.BlockHeight(),
+			Owner:       owner,
+			ContentType: values[i].ContentType,
+			Encoding:    values[i].Encoding,
+		})
+		k.SetLease(ctx, leaseStore, newUUID, keyValues[i].Key, ctx.BlockHeight(), lease)
+	}
+
+	return keyValues, true
+}
+
+func (k Keeper) GetCdc() *codec.Codec {
+	return k.cdc
+}
+
+func (k Keeper) GetKeyValues(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultKeyValues {
+	return k.GetKeyValuesFrom(ctx, store, UUID, owner, "")
+}
+
+// GetKeyValuesFrom is GetKeysFrom with the values of the keys, the page ending once
+// the keys and values reach MaxKeyValuesSize.
+func (k Keeper) GetKeyValuesFrom(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeyValues {
+	iterator, prefixLength := k.getKeysIteratorFrom(ctx, store, UUID, owner, start)
+	defer iterator.Close()
+
+	keyValues := types.QueryResultKeyValues{UUID: UUID, KeyValues: make([]types.KeyValue, 0)}
+
+	keyValuesSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key())[prefixLength:]
+		value := k.unmarshalValue(store.Get([]byte(MakeMetaKey(UUID, key))))
+		keyValuesSize = keyValuesSize + uint64(len(key)) + uint64(len(value.Value))
+
+		if ctx.GasMeter().IsPastLimit() {
+			return types.QueryResultKeyValues{UUID: UUID, KeyValues: make([]types.KeyValue, 0)}
+		}
+
+		// always return at least one key so that paging makes progress
+		if keyValuesSize >= k.mks.MaxKeyValuesSize && len(keyValues.KeyValues) > 0 {
+			keyValues.Next = key
+			break
+		}
+		keyValues.KeyValues = append(keyValues.KeyValues, types.KeyValue{
+			Key:   key,
+			Value: value.Value,
+		})
+	}
+	return keyValues
+}
+
+// GetKeyValuesPage returns the keys of UUID (only owner's, if given) from start on, in
+// key order, with their values, remaining leases and owners. It stops once the page
+// reaches MaxKeyValuesSize, Next then holding the key to continue from.
+func (k Keeper) GetKeyValuesPage(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress, start string) types.QueryResultKeyValuesPage {
+	iterator, prefixLength := k.getKeysIteratorFrom(ctx, store, UUID, owner, start)
+	defer iterator.Close()
+
+	page := types.QueryResultKeyValuesPage{UUID: UUID, KeyValues: make([]types.KeyValueLease, 0)}
+
+	keyValuesSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key())[prefixLength:]
+		value := k.unmarshalValue(store.Get([]byte(MakeMetaKey(UUID, key))))
+		keyValuesSize = keyValuesSize + uint64(len(key)) + uint64(len(value.Value))
+
+		// always return at least one key so that paging makes progress
+		if keyValuesSize >= k.mks.MaxKeyValuesSize && len(page.KeyValues) > 0 {
+			page.Next = key
+			break
+		}
+
+		page.KeyValues = append(page.KeyValues, types.KeyValueLease{
+			Key:   key,
+			Value: value.Value,
+			Lease: leaseExpiry(&value) - ctx.BlockHeight(),
+			Owner: value.Owner,
+		})
+	}
+	return page
+}
+
+func (k Keeper) GetCount(ctx sdk.Context, _ sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultCount {
+	return types.QueryResultCount{UUID: UUID, Count: k.getCounter(k.GetIndexStore(ctx), owner, UUID)}
+}
+
+// DeleteAll deletes up to MaxDeleteAllKeys keys of UUID (only owner's, if given) in
+// key order and reports how many are left.
+func (k Keeper) DeleteAll(ctx sdk.Context, store sdk.KVStore, UUID string, owner sdk.AccAddress) types.QueryResultDeleteAll {
+	iterator, prefixLength := k.getKeysIterator(ctx, store, UUID, owner)
+
+	var keys []string
+	for ; iterator.Valid() && len(keys) < types.MaxDeleteAllKeys; iterator.Next() {
+		keys = append(keys, string(iterator.Key())[prefixLength:])
+	}
+	iterator.Close()
+
+	leaseStore := k.GetLeaseStore(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
+	for i := range keys {
+		metaKey := []byte(MakeMetaKey(UUID, keys[i]))
+		value := k.unmarshalValue(store.Get(metaKey))
+		k.updateIndexes(ctx, UUID, keys[i], &value, nil)
+		k.recordAudit(ctx, UUID, keys[i], types.AuditOpDelete, value.Owner, nil)
+		k.DeleteLease(leaseStore, UUID, keys[i], value.Height, value.Lease)
+		store.Delete(metaKey)
+	}
+
+	result := types.QueryResultDeleteAll{UUID: UUID, Count: uint64(len(keys)), Remaining: k.GetCount(ctx, store, UUID, owner).Count}
+	if result.Remaining > 0 {
+		iterator, prefixLength = k.getKeysIterator(ctx, store, UUID, owner)
+		if iterator.Valid() {
+			result.Next = string(iterator.Key())[prefixLength:]
+		}
+		iterator.Close()
+	}
+	return result
+}
+
+// SetLease records the lease of key running out leaseBlocks after blockHeight, a lease
+// of 0 being the default lease.
+func (k Keeper) SetLease(ctx sdk.Context, leaseStore sdk.KVStore, UUID string, key string, blockHeight int64, leaseBlocks int64) {
+	if leaseBlocks == 0 {
+		leaseBlocks = k.GetDefaultLeaseBlocks(ctx)
+	}
+
+	leaseStore.Set([]byte(MakeLeaseKey(blockHeight+leaseBlocks, UUID, key)), make([]byte, 0))
+}
+
+func (k Keeper) DeleteLease(leaseStore sdk.KVStore, UUID string, key string, blockHeight int64, leaseBlocks int64) {
+	leaseStore.Delete([]byte(MakeLeaseKey(blockHeight+leaseBlocks, UUID, key)))
+}
+
+func (k Keeper) ProcessLeasesAtBlockHeight(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, lease int64) {
+	expired, _ := k.processLeases(ctx, store, leaseStore, lease)
+	k.Metrics().ExpiredKeys.Set(float64(expired))
+}
+
+// processLeases removes the keys whose lease runs out at height, or hands them over to
+// their beneficiaries, returning the number of keys removed and the bytes they held.
+func (k Keeper) processLeases(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, lease int64) (uint64, uint64) {
+	prefix := strconv.FormatInt(lease, 10) + "\x00"
+	iterator := sdk.KVStorePrefixIterator(leaseStore, []byte(prefix))
+
+	// keys with a beneficiary are handed over once the iterator is done with the lease store
+	type handover struct {
+		UUID, key   string
+		value       types.BLZValue
+		beneficiary types.Beneficiary
+	}
+	var handovers []handover
+
+	expired, reclaimed := uint64(0), uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		metaKey := iterator.Key()[len(prefix):]
+		if bz := store.Get(metaKey); bz != nil {
+			UUID, key := SplitMetaKey(string(metaKey))
+			value := k.unmarshalValue(bz)
+			if beneficiary := k.GetBeneficiary(ctx, UUID, key); !beneficiary.Address.Empty() {
+				handovers = append(handovers, handover{UUID: UUID, key: key, value: value, beneficiary: beneficiary})
+			} else {
+				k.updateIndexes(ctx, UUID, key, &value, nil)
+				k.recordAudit(ctx, UUID, key, types.AuditOpExpire, nil, nil)
+				store.Delete(metaKey)
+				expired++
+				reclaimed += uint64(len(UUID) + len(key) + len(value.Value))
+			}
+		}
+		leaseStore.Delete(iterator.Key())
+	}
+	iterator.Close()
+
+	for _, h := range handovers {
+		k.handOver(ctx, store, leaseStore, h.UUID, h.key, h.value, h.beneficiary)
+	}
+	return expired, reclaimed
+}
+
+// PurgeExpiredLeases processes the leases that ran out ExpiryGraceBlocks before this
+// block. The last height processed is kept so that lowering the grace period does not
+// skip the heights in between. What was purged, and the backlog of expired keys left
+// for later blocks, is recorded in the metrics and a purge event.
+func (k Keeper) PurgeExpiredLeases(ctx sdk.Context) {
+	indexStore := k.GetIndexStore(ctx)
+	purge := types.GCPurge{Height: ctx.BlockHeight()}
+
+	height := ctx.BlockHeight() - int64(k.GetParams(ctx).ExpiryGraceBlocks)
+	from := height
+	purged := indexStore.Get(types.PurgedHeightKey)
+	if purged != nil {
+		from = int64(binary.BigEndian.Uint64(purged)) + 1
+	}
+
+	// leases run out before the first block only for keys imported in their grace
+	// period, below which InitGenesis sets the purged height
+	if (height > 0 || purged != nil) && from <= height {
+		store, leaseStore := k.GetKVStore(ctx), k.GetLeaseStore(ctx)
+		for ; from <= height; from++ {
+			keys, bytes := k.processLeases(ctx, store, leaseStore, from)
+			purge.Keys += keys
+			purge.Bytes += bytes
+		}
+		indexStore.Set(types.PurgedHeightKey, sdk.Uint64ToBigEndian(uint64(height)))
+	}
+
+	if purge.Keys > 0 {
+		indexStore.Set(types.LastPurgeKey, k.cdc.MustMarshalBinaryBare(purge))
+	}
+	backlog := k.advanceBacklog(ctx, ctx.BlockHeight()-1)
+
+	k.Metrics().ExpiredKeys.Set(float64(purge.Keys))
+	k.Metrics().ReclaimedBytes.Set(float64(purge.Bytes))
+	k.Metrics().PurgeBacklog.Set(float64(backlog))
+
+	if purge.Keys > 0 || backlog > 0 {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypePurge,
+			sdk.NewAttribute(types.AttributeKeyPurgedKeys, strconv.FormatUint(purge.Keys, 10)),
+			sdk.NewAttribute(types.AttributeKeyReclaimedBytes, strconv.FormatUint(purge.Bytes, 10)),
+			sdk.NewAttribute(types.AttributeKeyBacklog, strconv.FormatUint(backlog, 10)),
+		))
+	}
+}
+
+// ImportPurgedHeight sets the height up to which expired keys have been purged, so that
+// the purge starts from the height after it.
+func (k Keeper) ImportPurgedHeight(ctx sdk.Context, height int64) {
+	k.GetIndexStore(ctx).Set(types.PurgedHeightKey, sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+// leaseEnd is the height the lease of value runs out at, a lease of 0 being the
+// default lease.
+func (k Keeper) leaseEnd(ctx sdk.Context, value *types.BLZValue) int64 {
+	if value.Lease == 0 {
+		return value.Height + k.GetDefaultLeaseBlocks(ctx)
+	}
+	return leaseExpiry(value)
+}
+
+func (k Keeper) getBacklog(indexStore sdk.KVStore) (types.GCBacklog, bool) {
+	var backlog types.GCBacklog
+	bz := indexStore.Get(types.BacklogKey)
+	if bz == nil {
+		return backlog, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &backlog)
+	return backlog, true
+}
+
+// updateBacklog keeps the count of expired keys waiting for the purge in step with a
+// key whose lease is renewed or cut short, or that is removed.
+func (k Keeper) updateBacklog(ctx sdk.Context, oldValue *types.BLZValue, value *types.BLZValue) {
+	indexStore := k.GetIndexStore(ctx)
+	backlog, ok := k.getBacklog(indexStore)
+	if !ok {
+		return
+	}
+
+	keys := backlog.Keys
+	if oldValue != nil && k.leaseEnd(ctx, oldValue) <= backlog.Height && keys > 0 {
+		keys--
+	}
+	if value != nil && k.leaseEnd(ctx, value) <= backlog.Height {
+		keys++
+	}
+	if keys != backlog.Keys {
+		backlog.Keys = keys
+		indexStore.Set(types.BacklogKey, k.cdc.MustMarshalBinaryBare(backlog))
+	}
+}
+
+// advanceBacklog adds the keys whose lease ran out after the backlog was last counted,
+// up to height, and returns the backlog. It is first counted from the purged height,
+// which for a store kept from before the count walks the expiry grace period once.
+func (k Keeper) advanceBacklog(ctx sdk.Context, height int64) uint64 {
+	indexStore := k.GetIndexStore(ctx)
+	backlog, ok := k.getBacklog(indexStore)
+	if !ok {
+		if bz := indexStore.Get(types.PurgedHeightKey); bz != nil {
+			backlog.Height = int64(binary.BigEndian.Uint64(bz))
+		}
+	}
+	if ok && backlog.Height >= height {
+		return backlog.Keys
+	}
+
+	store, leaseStore := k.GetKVStore(ctx), k.GetLeaseStore(ctx)
+	for ; backlog.Height < height; backlog.Height++ {
+		backlog.Keys += k.countExpiring(ctx, store, leaseStore, backlog.Height+1)
+	}
+	indexStore.Set(types.BacklogKey, k.cdc.MustMarshalBinaryBare(backlog))
+	return backlog.Keys
+}
+
+// countExpiring counts the keys whose lease runs out at height, passing over the lease
+// entries left by keys since renewed or removed.
+func (k Keeper) countExpiring(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, height int64) uint64 {
+	prefix := strconv.FormatInt(height, 10) + "\x00"
+	iterator := sdk.KVStorePrefixIterator(leaseStore, []byte(prefix))
+	defer iterator.Close()
+
+	count := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		if bz := store.Get(iterator.Key()[len(prefix):]); bz != nil {
+			if value := k.unmarshalValueMeta(bz); k.leaseEnd(ctx, &value) == height {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// GetGCStatus reports how far the purge of expired keys has got.
+func (k Keeper) GetGCStatus(ctx sdk.Context) types.QueryResultGCStatus {
+	indexStore := k.GetIndexStore(ctx)
+	backlog, _ := k.getBacklog(indexStore)
+	status := types.QueryResultGCStatus{Height: ctx.BlockHeight(), Backlog: backlog.Keys}
+	if bz := indexStore.Get(types.PurgedHeightKey); bz != nil {
+		status.PurgedHeight = int64(binary.BigEndian.Uint64(bz))
+	}
+	if bz := indexStore.Get(types.LastPurgeKey); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &status.LastPurge)
+	}
+	return status
+}
+
+// RecordStoreMetrics sets the key and UUID gauges from the per UUID key counters. It
+// does nothing unless metrics are being exported, the gauges costing a walk over all
+// UUIDs.
+func (k Keeper) RecordStoreMetrics(ctx sdk.Context) {
+	if !k.Metrics().enabled {
+		return
+	}
+
+	prefix := makeCountKey(nil, "")
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+	defer iterator.Close()
+
+	keys, uuids := uint64(0), 0
+	for ; iterator.Valid(); iterator.Next() {
+		keys += binary.BigEndian.Uint64(iterator.Value())
+		uuids++
+	}
+	k.Metrics().Keys.Set(float64(keys))
+	k.Metrics().UUIDs.Set(float64(uuids))
+}
+
+// GetNShortestLeases returns up to n keys of UUID (or only those of owner, if given)
+// with the least lease left, which is the first page of GetKeysByExpiry: it holds at
+// least one key however long, and Next is set when MaxKeysSize or n left keys out.
+func (k Keeper) GetNShortestLeases(ctx sdk.Context, _ sdk.KVStore, UUID string, owner sdk.AccAddress, n uint64) types.QueryResultNShortestLeaseKeys {
+	page := k.GetKeysByExpiry(ctx, UUID, owner, nil, n)
+	return types.QueryResultNShortestLeaseKeys{UUID: UUID, KeyLeases: page.KeyLeases, Next: page.Next}
+}
+
+// GetKeysByExpiry returns up to limit keys of UUID (or only those of owner, if given)
+// in order of increasing remaining lease, from the lease index position start. Next is
+// the hex encoded position of the first key left out, which unlike an offset stays put
+// when leases earlier in the order are renewed between pages.
+func (k Keeper) GetKeysByExpiry(ctx sdk.Context, UUID string, owner sdk.AccAddress, start []byte, limit uint64) types.QueryResultKeysByExpiry {
+	prefix := makeLeaseIndexPrefix(owner, UUID)
+	iterator := k.GetIndexStore(ctx).Iterator(append(append([]byte{}, prefix...), start...), sdk.PrefixEndBytes(prefix))
+	defer iterator.Close()
+
+	page := types.QueryResultKeysByExpiry{UUID: UUID, Owner: owner, KeyLeases: make([]types.KeyLease, 0)}
+	keysSize := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		indexKey := iterator.Key()[len(prefix):]
+		key := string(indexKey[8:])
+		keysSize = uint64(len(key)) + keysSize
+
+		// always return at least one key so that paging makes progress
+		if uint64(len(page.KeyLeases)) >= limit || (keysSize >= k.mks.MaxKeysSize && len(page.KeyLeases) > 0) {
+			page.Next = hex.EncodeToString(indexKey)
+			break
+		}
+
+		expiry := int64(binary.BigEndian.Uint64(indexKey[:8]))
+		page.KeyLeases = append(page.KeyLeases, types.KeyLease{Key: key, Lease: expiry - ctx.BlockHeight()})
+	}
+	return page
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UUID sizes the benchmarks run at; the 1M key store takes a while to build
+var benchSizes = []int{10000, 100000, 1000000}
+
+// benchKeeper returns a keeper whose "uuid" holds n keys of 1KB values owned by owner.
+func benchKeeper(b *testing.B, n int) (Keeper, sdk.Context, sdk.KVStore, sdk.AccAddress) {
+	b.Helper()
+
+	ctx, store, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1 << 30, MaxKeyValuesSize: 1 << 30})
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	value := make([]byte, 1024)
+	for i := 0; i < n; i++ {
+		keeper.SetValue(ctx, store, "uuid", benchKey(i), types.BLZValue{Value: value, Owner: owner, Lease: 100})
+	}
+	return keeper, ctx, store, owner
+}
+
+func benchKey(i int) string {
+	return fmt.Sprintf("key%08d", i)
+}
+
+func benchmarkSizes(b *testing.B, run func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, owner sdk.AccAddress, n int)) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			keeper, ctx, store, owner := benchKeeper(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			run(b, keeper, ctx, store, owner, n)
+		})
+	}
+}
+
+func BenchmarkKeeper_Create(b *testing.B) {
+	value := make([]byte, 1024)
+	benchmarkSizes(b, func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, owner sdk.AccAddress, n int) {
+		for i := 0; i < b.N; i++ {
+			keeper.SetValue(ctx, store, "uuid", benchKey(n+i), types.BLZValue{Value: value, Owner: owner, Lease: 100})
+		}
+	})
+}
+
+func BenchmarkKeeper_Read(b *testing.B) {
+	benchmarkSizes(b, func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, _ sdk.AccAddress, n int) {
+		for i := 0; i < b.N; i++ {
+			keeper.GetValue(ctx, store, "uuid", benchKey(i%n))
+		}
+	})
+}
+
+func BenchmarkKeeper_GetOwner(b *testing.B) {
+	benchmarkSizes(b, func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, _ sdk.AccAddress, n int) {
+		for i := 0; i < b.N; i++ {
+			keeper.GetOwner(ctx, store, "uuid", benchKey(i%n))
+		}
+	})
+}
+
+// Keys stops at MaxKeysSize, which the benchmark keeper sets high enough for every key
+func BenchmarkKeeper_Keys(b *testing.B) {
+	benchmarkSizes(b, func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, owner sdk.AccAddress, _ int) {
+		for i := 0; i < b.N; i++ {
+			keeper.GetKeys(ctx, store, "uuid", owner)
+		}
+	})
+}
+
+func BenchmarkKeeper_Count(b *testing.B) {
+	benchmarkSizes(b, func(b *testing.B, keeper Keeper, ctx sdk.Context, store sdk.KVStore, owner sdk.AccAddress, _ int) {
+		for i := 0; i < b.N; i++ {
+			keeper.GetCount(ctx, store, "uuid", owner)
+		}
+	})
+}
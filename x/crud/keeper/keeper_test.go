@@ -0,0 +1,1269 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const DefaultLeaseBlockHeight = int64(10 * 86400 / 5) // (10 days of blocks * seconds/day) / 5
+
+var (
+	testStoreKey = sdk.NewKVStoreKey(types.StoreKey)
+	testLeaseKey = sdk.NewKVStoreKey(types.LeaseKey)
+	testIndexKey = sdk.NewKVStoreKey(types.IndexKey)
+
+	testParamsKey  = sdk.NewKVStoreKey(params.StoreKey)
+	testParamsTKey = sdk.NewTransientStoreKey(params.TStoreKey)
+)
+
+func initKeeperTest() (sdk.Context, sdk.KVStore, []byte, *codec.Codec) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(testStoreKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testLeaseKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testIndexKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsTKey, sdk.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	cms := ms.CacheMultiStore()
+	return sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger()),
+		cms.GetKVStore(testStoreKey),
+		[]byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+		codec.New()
+}
+
+// each keeper needs its own subspace, a params keeper hands out a subspace name only once
+func newTestSubspace() params.Subspace {
+	return params.NewKeeper(codec.New(), testParamsKey, testParamsTKey).Subspace(types.DefaultParamspace)
+}
+
+func Test_MakeMetaKey(t *testing.T) {
+	uuid := "uuid"
+	key := "key"
+	accepted := "\x04uuidkey"
+
+	assert.Equal(t, MakeMetaKey(uuid, key), accepted)
+
+	// a UUID ending in the bytes another key starts with no longer meets it
+	assert.NotEqual(t, MakeMetaKey("uuid\x00a", "b"), MakeMetaKey("uuid", "a\x00b"))
+	assert.Equal(t, "\x80\x01"+strings.Repeat("u", 128)+"key", MakeMetaKey(strings.Repeat("u", 128), "key"))
+}
+
+func Test_SplitMetaKey(t *testing.T) {
+	UUID, key := SplitMetaKey(MakeMetaKey("uu\x00id", "k\x00ey"))
+	assert.Equal(t, "uu\x00id", UUID)
+	assert.Equal(t, "k\x00ey", key)
+
+	UUID, key = SplitMetaKey(MakeMetaKey("uuid", ""))
+	assert.Equal(t, "uuid", UUID)
+	assert.Equal(t, "", key)
+
+	// the length runs past the end
+	UUID, key = SplitMetaKey("\x09uuid")
+	assert.Equal(t, "", UUID)
+	assert.Equal(t, "", key)
+}
+
+func Test_MakeLeaseKey(t *testing.T) {
+	uuid := "uuid"
+	key := "key"
+	accepted := "123\x00\x04uuidkey"
+	assert.Equal(t, MakeLeaseKey(123, uuid, key), accepted)
+}
+
+func TestKeeper_SetValue(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	acceptedValue := types.BLZValue{
+		Value: []byte("value"),
+		Owner: owner,
+	}
+
+	keeper.SetValue(ctx.WithBlockHeight(10), testStore, "uuid", "key", acceptedValue)
+
+	result := testStore.Get([]byte(MakeMetaKey("uuid", "key")))
+
+	var value types.BLZValue
+	cdc.MustUnmarshalBinaryBare(result, &value)
+
+	acceptedValue.CreatedHeight = 10
+	acceptedValue.ModifiedHeight = 10
+	acceptedValue.Size = 5
+	acceptedValue.Hash = types.ValueHash([]byte("value"))
+	acceptedValue.Version = 1
+	assert.True(t, reflect.DeepEqual(acceptedValue, value))
+
+	// the created height is kept, the modified height follows changes to the value
+	keeper.SetValue(ctx.WithBlockHeight(20), testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Lease: 100, Owner: owner})
+	value = keeper.GetValue(ctx, testStore, "uuid", "key")
+	assert.Equal(t, int64(10), value.CreatedHeight)
+	assert.Equal(t, int64(10), value.ModifiedHeight)
+	assert.Equal(t, uint64(2), value.Version)
+
+	keeper.SetValue(ctx.WithBlockHeight(30), testStore, "uuid", "key", types.BLZValue{Value: []byte("new value"), Owner: owner})
+	value = keeper.GetValue(ctx, testStore, "uuid", "key")
+	assert.Equal(t, int64(10), value.CreatedHeight)
+	assert.Equal(t, int64(30), value.ModifiedHeight)
+	assert.Equal(t, int64(9), value.Size)
+	assert.Equal(t, uint64(3), value.Version)
+	assert.Equal(t, uint64(3), keeper.GetVersion(ctx, testStore, "uuid", "key"))
+	assert.Equal(t, uint64(0), keeper.GetVersion(ctx, testStore, "uuid", "nokey"))
+
+	// imported values keep their version
+	keeper.SetValue(ctx, testStore, "uuid", "imported", types.BLZValue{Value: []byte("value"), Owner: owner, Version: 7})
+	assert.Equal(t, uint64(7), keeper.GetVersion(ctx, testStore, "uuid", "imported"))
+
+	acceptedValue = types.BLZValue{
+		Owner: owner,
+	}
+
+	keeper.SetValue(ctx, testStore, "uuid", "key00", acceptedValue)
+	assert.False(t, testStore.Has([]byte(MakeMetaKey("uuid", "key00"))))
+}
+
+func TestKeeper_GetValue(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	// test value not found
+	result := keeper.GetValue(ctx, testStore, "uuid", "key")
+
+	assert.True(t, reflect.DeepEqual(types.BLZValue{}, result))
+
+	acceptedValue := types.BLZValue{
+		Value: []byte("value"),
+		Owner: owner,
+	}
+
+	// set the value and test that it is found\
+	keeper.SetValue(ctx, testStore, "uuid", "key", acceptedValue)
+	result = keeper.GetValue(ctx, testStore, "uuid", "key")
+
+	acceptedValue.Size = 5
+	acceptedValue.Hash = types.ValueHash([]byte("value"))
+	acceptedValue.Version = 1
+	assert.True(t, reflect.DeepEqual(acceptedValue, result))
+}
+
+func TestKeeper_DeleteValue(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{
+		Value: []byte("value"),
+		Owner: owner,
+	})
+	keeper.SetLease(ctx, testStore, "uuid", "key", 0, 0)
+
+	assert.True(t, testStore.Has([]byte(MakeLeaseKey(0, "uuid", "key"))))
+
+	keeper.DeleteValue(ctx, testStore, testStore, "uuid", "key")
+
+	assert.False(t, testStore.Has([]byte(MakeLeaseKey(0, "uuid", "key"))))
+
+	result := keeper.GetValue(ctx, testStore, "uuid", "key")
+
+	assert.True(t, reflect.DeepEqual(types.BLZValue{}, result))
+}
+
+func TestKeeper_IsKeyPresent(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key"))
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{
+		Value: []byte("value"),
+		Owner: owner,
+	})
+
+	assert.True(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key"))
+}
+
+func TestKeeper_GetValuesIterator(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	result := keeper.GetValuesIterator(ctx, testStore)
+
+	assert.False(t, result.Valid())
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{
+		Value: []byte("value"),
+		Owner: owner,
+	})
+
+	result = keeper.GetValuesIterator(ctx, testStore)
+
+	assert.True(t, result.Valid())
+
+	result.Next()
+
+	assert.False(t, result.Valid())
+}
+
+func TestKeeper_GetKeys(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	keys := keeper.GetKeys(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", keys.UUID)
+	assert.Empty(t, keys.Keys)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	keys = keeper.GetKeys(ctx, testStore, "uuid", owner)
+
+	assert.Equal(t, "uuid", keys.UUID)
+	assert.Len(t, keys.Keys, 4)
+
+	assert.Equal(t, keys.Keys[0], "key0")
+	assert.Equal(t, keys.Keys[1], "key1")
+	assert.Equal(t, keys.Keys[2], "key2")
+	assert.Equal(t, keys.Keys[3], "key3")
+
+	keys = keeper.GetKeys(ctx, testStore, "uuid0", nil)
+
+	assert.Equal(t, "uuid0", keys.UUID)
+	assert.Len(t, keys.Keys, 1)
+}
+
+func TestKeeper_GetKeys_separatorInUUID(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid\x00key", "0", types.BLZValue{Value: []byte("other"), Owner: owner})
+
+	assert.Equal(t, []string{"key"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+	assert.Equal(t, []string{"key"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"0"}, keeper.GetKeys(ctx, testStore, "uuid\x00key", nil).Keys)
+	assert.Equal(t, []byte("value"), keeper.GetValue(ctx, testStore, "uuid", "key").Value)
+}
+
+func TestKeeper_GetKeys_no_owner_for_query_usage(t *testing.T) {
+	// TODO: ensure that we only get keys associated with the owner
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	keys := keeper.GetKeys(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", keys.UUID)
+	assert.Empty(t, keys.Keys)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	keys = keeper.GetKeys(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", keys.UUID)
+	assert.Len(t, keys.Keys, 4)
+
+	assert.Equal(t, keys.Keys[0], "key0")
+	assert.Equal(t, keys.Keys[1], "key1")
+	assert.Equal(t, keys.Keys[2], "key2")
+	assert.Equal(t, keys.Keys[3], "key3")
+
+	keys = keeper.GetKeys(ctx, testStore, "uuid0", nil)
+
+	assert.Equal(t, "uuid0", keys.UUID)
+	assert.Len(t, keys.Keys, 1)
+}
+
+func TestKeeper_GetKeys_MaxSize(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+
+	// test max keys size
+	{
+		keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 9})
+		keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+		keys := keeper.GetKeys(ctx, testStore, "uuid", nil)
+
+		assert.Len(t, keys.Keys, 2)
+		assert.Equal(t, "key2", keys.Next)
+
+		keys = keeper.GetKeysFrom(ctx, testStore, "uuid", nil, keys.Next)
+		assert.Equal(t, []string{"key2", "key3"}, keys.Keys)
+		assert.Equal(t, "", keys.Next)
+	}
+
+	// test out of gas
+	{
+		mockCtrl := gomock.NewController(t)
+		mockGasMeter := mocks.NewMockGasMeter(mockCtrl)
+		keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+		mockGasMeter.EXPECT().IsPastLimit().Return(true)
+		keys := keeper.GetKeys(ctx.WithGasMeter(mockGasMeter), testStore, "uuid", nil)
+
+		assert.Len(t, keys.Keys, 0)
+	}
+}
+
+// keys come back in byte order whatever order they were written in, and paging with
+// Next visits every key exactly once, also while keys around the cursor change
+func TestKeeper_GetKeysFrom_deterministic(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 8, MaxKeyValuesSize: 16})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	written := []string{"b", "a\x00", "10", "Z", "é", "a", "9", "a/b", "ab", "\xff", "b0", "A", " "}
+	sorted := append([]string(nil), written...)
+	sort.Strings(sorted)
+
+	for i, key := range written {
+		keyOwner := owner
+		if i%4 == 3 {
+			keyOwner = otherOwner
+		}
+		keeper.SetValue(ctx, testStore, "uuid", key, types.BLZValue{Value: []byte("v" + key), Owner: keyOwner})
+	}
+
+	pageKeys := func(owner sdk.AccAddress) []string {
+		var keys []string
+		page := keeper.GetKeysFrom(ctx, testStore, "uuid", owner, "")
+		for {
+			assert.NotEmpty(t, page.Keys)
+			keys = append(keys, page.Keys...)
+			if page.Next == "" {
+				return keys
+			}
+			page = keeper.GetKeysFrom(ctx, testStore, "uuid", owner, page.Next)
+		}
+	}
+
+	assert.Equal(t, sorted, pageKeys(nil))
+
+	var ownerKeys []string
+	for _, key := range sorted {
+		if keeper.GetOwner(ctx, testStore, "uuid", key).Equals(owner) {
+			ownerKeys = append(ownerKeys, key)
+		}
+	}
+	assert.Equal(t, ownerKeys, pageKeys(owner))
+
+	var keyValues []types.KeyValue
+	page := keeper.GetKeyValuesFrom(ctx, testStore, "uuid", nil, "")
+	for ; page.Next != ""; page = keeper.GetKeyValuesFrom(ctx, testStore, "uuid", nil, page.Next) {
+		keyValues = append(keyValues, page.KeyValues...)
+	}
+	keyValues = append(keyValues, page.KeyValues...)
+	assert.Len(t, keyValues, len(sorted))
+	for i, key := range sorted {
+		assert.Equal(t, types.KeyValue{Key: key, Value: []byte("v" + key)}, keyValues[i])
+	}
+
+	// a key deleted before the cursor and one created after it change nothing in
+	// between; the page goes on from the cursor
+	first := keeper.GetKeysFrom(ctx, testStore, "uuid", nil, "")
+	assert.NotEqual(t, "", first.Next)
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid", first.Keys[0])
+	keeper.SetValue(ctx, testStore, "uuid", "\xffz", types.BLZValue{Value: []byte("v"), Owner: owner})
+
+	keys := first.Keys
+	for page := keeper.GetKeysFrom(ctx, testStore, "uuid", nil, first.Next); ; page = keeper.GetKeysFrom(ctx, testStore, "uuid", nil, page.Next) {
+		keys = append(keys, page.Keys...)
+		if page.Next == "" {
+			break
+		}
+	}
+	assert.Equal(t, append(append([]string(nil), sorted...), "\xffz"), keys)
+
+	// a key larger than a page still makes progress
+	keeper.SetValue(ctx, testStore, "big", "0123456789", types.BLZValue{Value: []byte("v"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "big", "1", types.BLZValue{Value: []byte("v"), Owner: owner})
+	assert.Equal(t, types.QueryResultKeys{UUID: "big", Keys: []string{"0123456789"}, Next: "1"}, keeper.GetKeysFrom(ctx, testStore, "big", nil, ""))
+	assert.Equal(t, types.QueryResultKeys{UUID: "big", Keys: []string{"1"}}, keeper.GetKeysFrom(ctx, testStore, "big", nil, "1"))
+}
+
+func TestKeeper_GetOwner(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	actual := keeper.GetOwner(ctx, testStore, "uuid", "key0")
+
+	assert.True(t, reflect.DeepEqual([]byte(actual), owner))
+	assert.Empty(t, keeper.GetOwner(ctx, testStore, "notauuid", "notakey"))
+}
+
+func TestKeeper_getValueMeta(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1 << 20})
+	keeper.SetParams(ctx, types.NewParams(64))
+
+	large := strings.Repeat("compressible ", 1000)
+	keeper.SetValue(ctx.WithBlockHeight(7), testStore, "uuid", "large", types.BLZValue{Value: []byte(large), Owner: owner, Height: 7, Lease: 100})
+	keeper.SetValue(ctx, testStore, "uuid", "small", types.BLZValue{Value: []byte("v"), Owner: owner, Lease: 5})
+
+	for _, key := range []string{"large", "small"} {
+		expected := keeper.GetValue(ctx, testStore, "uuid", key)
+		expected.Value = nil
+		assert.Equal(t, expected, keeper.getValueMeta(testStore, "uuid", key))
+	}
+	assert.Equal(t, types.BLZValue{}, keeper.getValueMeta(testStore, "uuid", "missing"))
+
+	assert.Panics(t, func() { keeper.unmarshalValueMeta([]byte{1<<3 | 2, 200}) })
+}
+
+func TestKeeper_RenameKey(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx.WithBlockHeight(10), testStore, "uuid", "key", types.BLZValue{
+		Value: []byte("a value"),
+		Owner: owner,
+	})
+
+	ctx = ctx.WithBlockHeight(20)
+
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "badkey", "newkey", false)
+	assert.False(t, ok)
+
+	expiry, ok := keeper.RenameKey(ctx, testStore, "uuid", "key", "newkey", false)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), expiry)
+
+	_, ok = keeper.RenameKey(ctx, testStore, "uuid", "key", "newkey", false)
+	assert.False(t, ok)
+
+	assert.True(t, reflect.DeepEqual(keeper.GetValue(ctx, testStore, "uuid", "newkey"), types.BLZValue{
+		Value:          []byte("a value"),
+		Owner:          owner,
+		CreatedHeight:  10,
+		ModifiedHeight: 10,
+		Size:           7,
+		Hash:           types.ValueHash([]byte("a value")),
+		Version:        1,
+	}))
+
+}
+
+func TestKeeper_RenameKey_lease(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Owner: owner, Height: 10, Lease: 100})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key0", 10, 100)
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Owner: owner, Height: 10, Lease: 50})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key1", 10, 50)
+
+	// the lease goes with the value
+	expiry, ok := keeper.RenameKey(ctx, testStore, "uuid", "key0", "key2", false)
+	assert.True(t, ok)
+	assert.Equal(t, int64(110), expiry)
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key2"))))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key0"))))
+
+	// an existing key is only replaced when asked to
+	_, ok = keeper.RenameKey(ctx, testStore, "uuid", "key2", "key1", false)
+	assert.False(t, ok)
+	assert.Equal(t, []byte("value1"), keeper.GetValue(ctx, testStore, "uuid", "key1").Value)
+
+	expiry, ok = keeper.RenameKey(ctx, testStore, "uuid", "key2", "key1", true)
+	assert.True(t, ok)
+	assert.Equal(t, int64(110), expiry)
+	assert.Equal(t, []byte("value0"), keeper.GetValue(ctx, testStore, "uuid", "key1").Value)
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key2"))
+
+	// the replaced key's lease is gone, the moved one is left
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(60, "uuid", "key1"))))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key2"))))
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key1"))))
+
+	_, ok = keeper.RenameKey(ctx, testStore, "uuid", "key1", "key1", true)
+	assert.False(t, ok)
+}
+
+func TestKeeper_GetKeyValues(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1024})
+
+	kvs := keeper.GetKeyValues(ctx, testStore, "uuid", owner)
+
+	assert.Equal(t, "uuid", kvs.UUID)
+	assert.Empty(t, kvs.KeyValues)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value2"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value3")})
+
+	kvs = keeper.GetKeyValues(ctx, testStore, "uuid", owner)
+	assert.Equal(t, "uuid", kvs.UUID)
+	assert.Len(t, kvs.KeyValues, 3)
+
+	assert.Equal(t, types.KeyValue{Key: "key0", Value: []byte("value0")}, kvs.KeyValues[0])
+	assert.Equal(t, types.KeyValue{Key: "key1", Value: []byte("value1")}, kvs.KeyValues[1])
+	assert.Equal(t, types.KeyValue{Key: "key2", Value: []byte("value2")}, kvs.KeyValues[2])
+}
+
+func TestKeeper_GetKeyValuesPage(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 25})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Height: 10, Lease: 200, Owner: otherOwner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value2"), Height: 10, Lease: 300, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value3"), Height: 10, Lease: 400, Owner: owner})
+
+	newCtx := ctx.WithBlockHeight(50)
+
+	page := keeper.GetKeyValuesPage(newCtx, testStore, "uuid", nil, "")
+	assert.Equal(t, types.QueryResultKeyValuesPage{UUID: "uuid", Next: "key2", KeyValues: []types.KeyValueLease{
+		{Key: "key0", Value: []byte("value0"), Lease: 60, Owner: owner},
+		{Key: "key1", Value: []byte("value1"), Lease: 160, Owner: otherOwner},
+	}}, page)
+
+	page = keeper.GetKeyValuesPage(newCtx, testStore, "uuid", nil, page.Next)
+	assert.Equal(t, "", page.Next)
+	assert.Equal(t, []string{"key2", "key3"}, []string{page.KeyValues[0].Key, page.KeyValues[1].Key})
+
+	page = keeper.GetKeyValuesPage(newCtx, testStore, "uuid", owner, "key1")
+	assert.Equal(t, []types.KeyValueLease{
+		{Key: "key2", Value: []byte("value2"), Lease: 260, Owner: owner},
+		{Key: "key3", Value: []byte("value3"), Lease: 360, Owner: owner},
+	}, page.KeyValues)
+}
+
+func TestKeeper_MatchKeys(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 30})
+	otherOwner := sdk.AccAddress("otherowner")
+
+	for key, keyOwner := range map[string]sdk.AccAddress{
+		"a:sensor:1:temp":   owner,
+		"sensor:1:humidity": owner,
+		"sensor:1:temp":     owner,
+		"sensor:2:temp":     otherOwner,
+		"sensor:3:temp":     owner,
+		"status":            owner,
+	} {
+		keeper.SetValue(ctx, testStore, "uuid", key, types.BLZValue{Value: []byte("value"), Owner: keyOwner})
+	}
+
+	pattern, err := types.CompileKeyPattern("sensor:*:temp")
+	assert.Nil(t, err)
+
+	gas := ctx.GasMeter().GasConsumed()
+	page := keeper.MatchKeys(ctx, testStore, "uuid", nil, pattern, "")
+	assert.Equal(t, types.QueryResultMatch{UUID: "uuid", Pattern: "sensor:*:temp", Keys: []string{"sensor:1:temp", "sensor:2:temp"}, Next: "sensor:3:temp"}, page)
+	assert.True(t, ctx.GasMeter().GasConsumed() > gas)
+
+	page = keeper.MatchKeys(ctx, testStore, "uuid", nil, pattern, page.Next)
+	assert.Equal(t, types.QueryResultMatch{UUID: "uuid", Pattern: "sensor:*:temp", Keys: []string{"sensor:3:temp"}}, page)
+
+	page = keeper.MatchKeys(ctx, testStore, "uuid", owner, pattern, "")
+	assert.Equal(t, []string{"sensor:1:temp", "sensor:3:temp"}, page.Keys)
+	assert.Equal(t, "", page.Next)
+
+	// a start past the keys the pattern can match ends the search
+	page = keeper.MatchKeys(ctx, testStore, "uuid", nil, pattern, "t")
+	assert.Empty(t, page.Keys)
+
+	// without a literal prefix every key is looked at
+	pattern, err = types.CompileKeyPattern("*:1:*")
+	assert.Nil(t, err)
+	page = keeper.MatchKeys(ctx, testStore, "uuid", nil, pattern, "")
+	assert.Equal(t, []string{"a:sensor:1:temp"}, page.Keys)
+	assert.Equal(t, "sensor:1:humidity", page.Next)
+
+	page = keeper.MatchKeys(ctx, testStore, "other", nil, pattern, "")
+	assert.Equal(t, types.QueryResultMatch{UUID: "other", Pattern: "*:1:*", Keys: []string{}}, page)
+}
+
+func TestKeeper_GetKeyValues_no_owner_for_query_usage(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1024})
+
+	kvs := keeper.GetKeyValues(ctx, testStore, "uuid", owner)
+
+	assert.Equal(t, "uuid", kvs.UUID)
+	assert.Empty(t, kvs.KeyValues)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value2"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value3"),
+		Owner: []byte("bluzelle1rnnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wq")})
+
+	kvs = keeper.GetKeyValues(ctx, testStore, "uuid", nil)
+	assert.Equal(t, "uuid", kvs.UUID)
+	assert.Len(t, kvs.KeyValues, 4)
+
+	assert.Equal(t, kvs.KeyValues[0], types.KeyValue{Key: "key0", Value: []byte("value0")})
+	assert.Equal(t, kvs.KeyValues[1], types.KeyValue{Key: "key1", Value: []byte("value1")})
+	assert.Equal(t, kvs.KeyValues[2], types.KeyValue{Key: "key2", Value: []byte("value2")})
+	assert.Equal(t, kvs.KeyValues[3], types.KeyValue{Key: "key3", Value: []byte("value3")})
+}
+
+func TestKeeper_GetKeyValues_MaxSize(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+
+	// test max keys size
+	{
+		keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 19})
+		keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+		keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+		keyValues := keeper.GetKeyValues(ctx, testStore, "uuid", owner)
+
+		assert.Len(t, keyValues.KeyValues, 2)
+		assert.Equal(t, "key2", keyValues.Next)
+	}
+
+	// test out of gas
+	{
+		mockCtrl := gomock.NewController(t)
+		mockGasMeter := mocks.NewMockGasMeter(mockCtrl)
+		keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1024})
+		mockGasMeter.EXPECT().ConsumeGas(gomock.Any(), gomock.Any()).AnyTimes()
+		mockGasMeter.EXPECT().IsPastLimit().Return(true)
+		keyValues := keeper.GetKeyValues(ctx.WithGasMeter(mockGasMeter), testStore, "uuid", owner)
+
+		assert.Len(t, keyValues.KeyValues, 0)
+	}
+}
+
+func TestKeeper_GetCount(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	count := keeper.GetCount(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(0), count.Count)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid0", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	count = keeper.GetCount(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(4), count.Count)
+
+	count = keeper.GetCount(ctx, testStore, "uuid0", owner)
+
+	assert.Equal(t, "uuid0", count.UUID)
+	assert.Equal(t, uint64(1), count.Count)
+}
+
+func TestKeeper_GetCount_no_owner_for_query_usage(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	count := keeper.GetCount(ctx, testStore, "uuid", nil)
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(0), count.Count)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"),
+		Owner: []byte("bluzelle1rnnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wq")})
+
+	count = keeper.GetCount(ctx, testStore, "uuid", nil)
+
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(4), count.Count)
+}
+
+func TestKeeper_DeleteAll(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Owner: []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")})
+
+	result := keeper.DeleteAll(ctx, testStore, "uuid", owner)
+	assert.Equal(t, types.QueryResultDeleteAll{UUID: "uuid", Count: 4}, result)
+
+	count := keeper.GetCount(ctx, testStore, "uuid", owner)
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(0), count.Count)
+
+	count = keeper.GetCount(ctx, testStore, "uuid", []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr"))
+	assert.Equal(t, "uuid", count.UUID)
+	assert.Equal(t, uint64(1), count.Count)
+}
+
+func TestKeeper_DeleteAll_MaxKeys(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	for i := 0; i < types.MaxDeleteAllKeys+2; i++ {
+		keeper.SetValue(ctx, testStore, "uuid", fmt.Sprintf("key%04d", i), types.BLZValue{Value: []byte("value"), Owner: owner})
+	}
+
+	result := keeper.DeleteAll(ctx, testStore, "uuid", owner)
+	assert.Equal(t, types.QueryResultDeleteAll{UUID: "uuid", Count: types.MaxDeleteAllKeys, Remaining: 2, Next: "key1000"}, result)
+
+	// sending it again picks up where the first one stopped
+	result = keeper.DeleteAll(ctx, testStore, "uuid", owner)
+	assert.Equal(t, types.QueryResultDeleteAll{UUID: "uuid", Count: 2}, result)
+}
+
+func TestKeeper_DeleteAll_counterDrift(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	// a counter ahead of the keys leaves nothing to continue from
+	keeper.addToCounter(keeper.GetIndexStore(ctx), nil, "uuid", 1)
+
+	result := keeper.DeleteAll(ctx, testStore, "uuid", nil)
+	assert.Equal(t, types.QueryResultDeleteAll{UUID: "uuid", Count: 1, Remaining: 1}, result)
+}
+
+func TestKeeper_SetLease(t *testing.T) {
+	ctx, testStore, _, cdc := initKeeperTest()
+	ctx = ctx.WithBlockHeight(2000)
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	keeper.SetLease(ctx, testStore, "uuid", "key", ctx.BlockHeight(), 0)
+	leaseKey := strconv.FormatInt(ctx.BlockHeight()+DefaultLeaseBlockHeight, 10) + "\x00" + MakeMetaKey("uuid", "key")
+
+	assert.True(t, testStore.Has([]byte(leaseKey)))
+
+	keeper.SetLease(ctx, testStore, "uuid00", "key00", ctx.BlockHeight(), 600000)
+	leaseKey = strconv.FormatInt(ctx.BlockHeight()+int64(600000), 10) + "\x00" + MakeMetaKey("uuid00", "key00")
+	assert.True(t, testStore.Has([]byte(leaseKey)))
+}
+
+func TestKeeper_DeleteLease(t *testing.T) {
+	ctx, testStore, _, cdc := initKeeperTest()
+	ctx = ctx.WithBlockHeight(2000)
+
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	keeper.SetLease(ctx, testStore, "uuid", "key", ctx.BlockHeight(), 0)
+
+	leaseKey := strconv.FormatInt(ctx.BlockHeight()+DefaultLeaseBlockHeight, 10) + "\x00" + MakeMetaKey("uuid", "key")
+	assert.True(t, testStore.Has([]byte(leaseKey)))
+
+	keeper.DeleteLease(testStore, "uuid", "key", ctx.BlockHeight(), DefaultLeaseBlockHeight)
+	assert.False(t, testStore.Has([]byte(leaseKey)))
+}
+
+func TestKeeper_ProcessLeasesAtBlockHeight(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key00", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid", "key00", 0, 1)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key01", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid", "key01", 0, 2000)
+
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 2000)
+
+	assert.True(t, testStore.Has([]byte(MakeLeaseKey(1, "uuid", "key00"))))
+	assert.False(t, testStore.Has([]byte(MakeLeaseKey(2000, "uuid", "key01"))))
+
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 1)
+	assert.False(t, testStore.Has([]byte(MakeLeaseKey(1, "uuid", "key00"))))
+}
+
+func TestKeeper_PurgeExpiredLeases(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	store, leaseStore := keeper.GetKVStore(ctx), keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.ExpiryGraceBlocks = 10
+	keeper.SetParams(ctx, params)
+
+	for i, lease := range []int64{100, 103, 105} {
+		key := fmt.Sprintf("key%d", i)
+		keeper.SetValue(ctx, store, "uuid", key, types.BLZValue{Value: []byte("value"), Owner: owner, Lease: lease})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 0, lease)
+	}
+
+	// key0 ran out at 100 and is read-only, but kept, until 110
+	assert.False(t, keeper.IsExpired(ctx.WithBlockHeight(100), store, "uuid", "key0"))
+	assert.True(t, keeper.IsExpired(ctx.WithBlockHeight(101), store, "uuid", "key0"))
+	assert.False(t, keeper.IsExpired(ctx.WithBlockHeight(101), store, "uuid", "key1"))
+	assert.False(t, keeper.IsExpired(ctx.WithBlockHeight(101), store, "uuid", "missing"))
+
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(109))
+	assert.True(t, keeper.IsKeyPresent(ctx, store, "uuid", "key0"))
+	assert.Equal(t, types.QueryResultGCStatus{Height: 109, PurgedHeight: 99, Backlog: 3}, keeper.GetGCStatus(ctx.WithBlockHeight(109)))
+
+	purgeCtx := ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	keeper.PurgeExpiredLeases(purgeCtx)
+	assert.False(t, keeper.IsKeyPresent(ctx, store, "uuid", "key0"))
+	assert.True(t, keeper.IsKeyPresent(ctx, store, "uuid", "key1"))
+
+	// uuid + key + value of key0 is reclaimed, key1 and key2 are still waiting
+	events := purgeCtx.EventManager().Events()
+	assert.Equal(t, sdk.NewEvent(types.EventTypePurge,
+		sdk.NewAttribute(types.AttributeKeyPurgedKeys, "1"),
+		sdk.NewAttribute(types.AttributeKeyReclaimedBytes, "13"),
+		sdk.NewAttribute(types.AttributeKeyBacklog, "2"),
+	), events[len(events)-1])
+	assert.Equal(t, types.QueryResultGCStatus{Height: 110, PurgedHeight: 100, Backlog: 2, LastPurge: types.GCPurge{Height: 110, Keys: 1, Bytes: 13}},
+		keeper.GetGCStatus(ctx.WithBlockHeight(110)))
+
+	// lowering the grace period catches up on the heights it skips
+	params.ExpiryGraceBlocks = 0
+	keeper.SetParams(ctx, params)
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(104))
+	assert.False(t, keeper.IsKeyPresent(ctx, store, "uuid", "key1"))
+	assert.True(t, keeper.IsKeyPresent(ctx, store, "uuid", "key2"))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(103, "uuid", "key1"))))
+}
+
+func TestKeeper_PurgeBacklog(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	store, leaseStore := keeper.GetKVStore(ctx), keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.ExpiryGraceBlocks = 10
+	keeper.SetParams(ctx, params)
+
+	for i, lease := range []int64{100, 103, 105} {
+		key := fmt.Sprintf("key%d", i)
+		keeper.SetValue(ctx, store, "uuid", key, types.BLZValue{Value: []byte("value"), Owner: owner, Lease: lease})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 0, lease)
+	}
+
+	// the first purge counts the keys that ran out since the purged height
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(105))
+	assert.Equal(t, uint64(2), keeper.GetGCStatus(ctx.WithBlockHeight(105)).Backlog)
+
+	// renewing key0 and removing key1 take them out of the backlog
+	ctx = ctx.WithBlockHeight(105)
+	keeper.SetValue(ctx, store, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner, Height: 105, Lease: 100})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key0", 105, 100)
+	keeper.DeleteValue(ctx, store, leaseStore, "uuid", "key1")
+	assert.Equal(t, uint64(0), keeper.GetGCStatus(ctx).Backlog)
+
+	// key2 is renewed before its lease is counted, which passes over the lease it had,
+	// and key0, expired now, is counted from the block after its lease ran out
+	keeper.SetValue(ctx, store, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner, Height: 104, Lease: 100})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key2", 104, 100)
+	ctx = ctx.WithBlockHeight(106)
+	keeper.ExpireNow(ctx, store, leaseStore, "uuid", "key0")
+	keeper.PurgeExpiredLeases(ctx)
+	assert.Equal(t, uint64(0), keeper.GetGCStatus(ctx).Backlog)
+
+	purgeCtx := ctx.WithBlockHeight(107).WithEventManager(sdk.NewEventManager())
+	keeper.PurgeExpiredLeases(purgeCtx)
+	assert.Equal(t, uint64(1), keeper.GetGCStatus(purgeCtx).Backlog)
+	events := purgeCtx.EventManager().Events()
+	assert.Equal(t, sdk.NewEvent(types.EventTypePurge,
+		sdk.NewAttribute(types.AttributeKeyPurgedKeys, "0"),
+		sdk.NewAttribute(types.AttributeKeyReclaimedBytes, "0"),
+		sdk.NewAttribute(types.AttributeKeyBacklog, "1"),
+	), events[len(events)-1])
+}
+
+func TestKeeper_Metrics(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	keys, uuids, expired := generic.NewGauge("keys"), generic.NewGauge("uuids"), generic.NewGauge("expired_keys")
+	reclaimed, backlog := generic.NewGauge("reclaimed_bytes"), generic.NewGauge("purge_backlog")
+	keeper.SetMetrics(&Metrics{
+		Messages:       discard.NewCounter(),
+		ValueSize:      discard.NewHistogram(),
+		Keys:           keys,
+		UUIDs:          uuids,
+		ExpiredKeys:    expired,
+		ReclaimedBytes: reclaimed,
+		PurgeBacklog:   backlog,
+		enabled:        true,
+	})
+
+	keeper.SetValue(ctx, testStore, "uuid0", "key00", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid0", "key00", 0, 1)
+	keeper.SetValue(ctx, testStore, "uuid0", "key01", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid0", "key01", 0, 2000)
+	keeper.SetValue(ctx, testStore, "uuid1", "key00", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetLease(ctx, testStore, "uuid1", "key00", 0, 2000)
+
+	keeper.RecordStoreMetrics(ctx)
+	assert.Equal(t, float64(3), keys.Value())
+	assert.Equal(t, float64(2), uuids.Value())
+
+	keeper.ProcessLeasesAtBlockHeight(ctx, testStore, testStore, 2000)
+	keeper.RecordStoreMetrics(ctx)
+	assert.Equal(t, float64(2), expired.Value())
+	assert.Equal(t, float64(1), keys.Value())
+	assert.Equal(t, float64(1), uuids.Value())
+
+	// the purge reports the bytes of uuid2 + key00 + value, and nothing left waiting
+	keeper.SetValue(ctx, testStore, "uuid2", "key00", types.BLZValue{Value: []byte("value"), Lease: 1, Owner: owner})
+	keeper.SetLease(ctx, keeper.GetLeaseStore(ctx), "uuid2", "key00", 0, 1)
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(1))
+	assert.Equal(t, float64(1), expired.Value())
+	assert.Equal(t, float64(15), reclaimed.Value())
+	assert.Equal(t, float64(0), backlog.Value())
+}
+
+func TestKeeper_GetDefaultLeaseBlocks(t *testing.T) {
+	ctx, _, _, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	assert.Equal(t, DefaultLeaseBlockHeight, keeper.GetDefaultLeaseBlocks(ctx))
+
+	// governance sets its own
+	params := types.DefaultParams()
+	params.DefaultLeaseBlocks = 1000
+	keeper.SetParams(ctx, params)
+	assert.Equal(t, int64(1000), keeper.GetDefaultLeaseBlocks(ctx))
+
+	params.DefaultLeaseBlocks = 0
+	keeper.SetParams(ctx, params)
+	assert.Equal(t, DefaultLeaseBlockHeight, keeper.GetDefaultLeaseBlocks(ctx))
+}
+
+func TestKeeper_defaultLeaseParam(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	params := types.DefaultParams()
+	params.DefaultLeaseBlocks = 1000
+	keeper.SetParams(ctx, params)
+
+	// a lease of 0 runs out after the governance default wherever it is read
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Height: 10, Owner: owner})
+	keeper.SetLease(ctx, keeper.GetLeaseStore(ctx), "uuid", "key", 10, 0)
+	assert.True(t, keeper.GetLeaseStore(ctx).Has([]byte(MakeLeaseKey(1010, "uuid", "key"))))
+
+	assert.False(t, keeper.IsExpired(ctx.WithBlockHeight(1010), testStore, "uuid", "key"))
+	assert.True(t, keeper.IsExpired(ctx.WithBlockHeight(1011), testStore, "uuid", "key"))
+
+	for _, inconsistency := range keeper.Verify(ctx, false) {
+		assert.NotEqual(t, types.LeaseKey, inconsistency.Store, inconsistency.String())
+	}
+}
+
+func TestKeeper_GetCdc(t *testing.T) {
+	_, _, _, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+
+	assert.Equal(t, cdc, keeper.GetCdc())
+}
+
+func TestKeeper_GetNShortestLeases(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	currentBlockHeight := int64(1)
+
+	for i := 0; i < 10; i++ {
+		l := int64(10000 - 10*i)
+		value := types.BLZValue{
+			Value:  []byte("value"),
+			Lease:  l,
+			Height: 1000,
+			Owner:  owner,
+		}
+		keeper.SetValue(ctx, testStore, "uuid", fmt.Sprintf("key%d", l), value)
+	}
+
+	// there are at least 10 keys
+	newCtx := ctx.WithBlockHeight(currentBlockHeight)
+	response := keeper.GetNShortestLeases(newCtx, testStore, "uuid", owner, 5)
+
+	assert.Equal(t, "uuid", response.UUID)
+	assert.Equal(t, 5, len(response.KeyLeases))
+
+	assert.Equal(t, "key9910", response.KeyLeases[0].Key)
+	assert.Equal(t, 9910+1000-currentBlockHeight, response.KeyLeases[0].Lease)
+	assert.NotEmpty(t, response.Next)
+
+	response = keeper.GetNShortestLeases(newCtx, testStore, "wronguuid", owner, 5)
+	assert.Equal(t, "wronguuid", response.UUID)
+	assert.Equal(t, 0, len(response.KeyLeases))
+
+	response = keeper.GetNShortestLeases(newCtx, testStore, "uuid", owner, 11)
+	assert.Equal(t, "uuid", response.UUID)
+	assert.Equal(t, 10, len(response.KeyLeases))
+	assert.Empty(t, response.Next)
+
+}
+
+func TestKeeper_CopyAll(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	ctx = ctx.WithBlockHeight(100)
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	newOwner := []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
+
+	// nothing to copy
+	_, ok := keeper.CopyAll(ctx, testStore, testStore, "uuid", "newuuid", newOwner, 50)
+	assert.False(t, ok)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value0"), Lease: 10, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Lease: 10, Owner: owner})
+
+	keyValues, ok := keeper.CopyAll(ctx, testStore, testStore, "uuid", "newuuid", newOwner, 50)
+	assert.True(t, ok)
+	assert.Equal(t, []types.KeyValue{{Key: "key0", Value: []byte("value0")}, {Key: "key1", Value: []byte("value1")}}, keyValues)
+
+	assert.Equal(t, types.BLZValue{Value: []byte("value0"), Lease: 50, Height: 100, Owner: newOwner, CreatedHeight: 100, ModifiedHeight: 100, Size: 6, Hash: types.ValueHash([]byte("value0")), Version: 1}, keeper.GetValue(ctx, testStore, "newuuid", "key0"))
+	assert.Equal(t, types.BLZValue{Value: []byte("value1"), Lease: 50, Height: 100, Owner: newOwner, CreatedHeight: 100, ModifiedHeight: 100, Size: 6, Hash: types.ValueHash([]byte("value1")), Version: 1}, keeper.GetValue(ctx, testStore, "newuuid", "key1"))
+	assert.True(t, testStore.Has([]byte(MakeLeaseKey(150, "newuuid", "key0"))))
+	assert.True(t, testStore.Has([]byte(MakeLeaseKey(150, "newuuid", "key1"))))
+
+	// the source is left untouched
+	assert.Equal(t, types.BLZValue{Value: []byte("value0"), Lease: 10, Owner: owner, CreatedHeight: 100, ModifiedHeight: 100, Size: 6, Hash: types.ValueHash([]byte("value0")), Version: 1}, keeper.GetValue(ctx, testStore, "uuid", "key0"))
+
+	// destination keys already exist, nothing is written
+	keeper.SetValue(ctx, testStore, "uuid", "key2", types.BLZValue{Value: []byte("value2"), Owner: owner})
+	_, ok = keeper.CopyAll(ctx, testStore, testStore, "uuid", "newuuid", newOwner, 50)
+	assert.False(t, ok)
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "newuuid", "key2"))
+}
+
+func TestKeeper_GetMetadata(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx.WithBlockHeight(10), testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(25), testStore, "uuid", "key", types.BLZValue{Value: []byte("new value"), Owner: owner,
+		ContentType: "text/plain", Encoding: "identity"})
+
+	assert.Equal(t, types.QueryResultMetadata{UUID: "uuid", Key: "key", CreatedHeight: 10, ModifiedHeight: 25, Size: 9, Version: 2,
+		ContentType: "text/plain", Encoding: "identity"}, keeper.GetMetadata(ctx, testStore, "uuid", "key"))
+}
+
+func TestKeeper_GetHash(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Owner: owner})
+
+	// sha256("value")
+	expected := "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619"
+	assert.Equal(t, types.QueryResultHash{UUID: "uuid", Key: "key", Hash: expected}, keeper.GetHash(ctx, testStore, "uuid", "key"))
+
+	// values stored without a hash
+	testStore.Set([]byte(MakeMetaKey("uuid", "oldkey")), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value"), Owner: owner}))
+	assert.Equal(t, expected, keeper.GetHash(ctx, testStore, "uuid", "oldkey").Hash)
+}
+
+func TestKeeper_Params(t *testing.T) {
+	ctx, _, _, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	// nothing stored yet
+	assert.Equal(t, types.DefaultParams(), keeper.GetParams(ctx))
+
+	keeper.SetParams(ctx, types.NewParams(1024))
+	assert.Equal(t, types.NewParams(1024), keeper.GetParams(ctx))
+}
+
+// fakeSupplyKeeper holds the balances of accounts and module accounts by address or name
+type fakeSupplyKeeper map[string]sdk.Coins
+
+func (sk fakeSupplyKeeper) GetModuleAccount(_ sdk.Context, moduleName string) supplyexported.ModuleAccountI {
+	acc := supply.NewEmptyModuleAccount(moduleName)
+	_ = acc.SetCoins(sk[moduleName])
+	return acc
+}
+
+func (sk fakeSupplyKeeper) send(from, to string, amt sdk.Coins) error {
+	if !sk[from].IsAllGTE(amt) {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	sk[from] = sk[from].Sub(amt)
+	sk[to] = sk[to].Add(amt...)
+	return nil
+}
+
+func (sk fakeSupplyKeeper) SendCoinsFromAccountToModule(_ sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	return sk.send(string(senderAddr), recipientModule, amt)
+}
+
+func (sk fakeSupplyKeeper) SendCoinsFromModuleToAccount(_ sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	return sk.send(senderModule, string(recipientAddr), amt)
+}
+
+func (sk fakeSupplyKeeper) SendCoinsFromModuleToModule(_ sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	return sk.send(senderModule, recipientModule, amt)
+}
+
+func TestKeeper_ChargeLease(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	// leases are free until a price is set
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key", 1000))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), supplyKeeper[string(owner)])
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	keeper.SetParams(ctx, params)
+
+	// 1001 byte-blocks at 0.01ubnt round up to 11ubnt, earned at once with no lease to
+	// hold the deposit over
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key", 1001))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 89)), supplyKeeper[string(owner)])
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 11)), supplyKeeper[types.ModuleName])
+
+	// nothing is charged or refunded for a shrinking lease
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key", -1000))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 89)), supplyKeeper[string(owner)])
+
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(keeper.ChargeLease(ctx, owner, "uuid", "key", 100000)))
+
+	keeper.DistributeLeaseFees(ctx)
+	assert.True(t, supplyKeeper[types.ModuleName].IsZero())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 11)), supplyKeeper[auth.FeeCollectorName])
+
+	// an empty module account is left alone
+	keeper.DistributeLeaseFees(ctx)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 11)), supplyKeeper[auth.FeeCollectorName])
+}
+
+func TestKeeper_LeaseFee_Utilization(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	params.UtilizationBands = []types.UtilizationBand{{MinBytes: 10, Multiplier: sdk.NewDec(2)}, {MinBytes: 20, Multiplier: sdk.NewDec(5)}}
+	keeper.SetParams(ctx, params)
+
+	// below the first band the price is unscaled
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), keeper.LeaseFee(ctx, 1000))
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("0123456789"), Owner: owner})
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 20)), keeper.LeaseFee(ctx, 1000))
+
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("0123456789"), Owner: owner})
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 50)), keeper.LeaseFee(ctx, 1000))
+
+	price := keeper.GetLeasePrice(ctx)
+	assert.Equal(t, uint64(20), price.StoredBytes)
+	assert.True(t, price.Multiplier.Equal(sdk.NewDec(5)))
+	assert.True(t, price.BasePrice.IsEqual(params.LeasePrice))
+	assert.True(t, price.LeasePrice.IsEqual(sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(5, 2)))))
+
+	// and it falls back as the state shrinks
+	keeper.DeleteValue(ctx, testStore, keeper.GetLeaseStore(ctx), "uuid", "key1")
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 20)), keeper.LeaseFee(ctx, 1000))
+}
+
+func TestKeeper_SetValue_Compression(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1 << 20})
+	keeper.SetParams(ctx, types.NewParams(64))
+
+	large := strings.Repeat("compressible ", 100)
+
+	keeper.SetValue(ctx, testStore, "uuid", "small", types.BLZValue{Value: []byte("small value"), Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "large", types.BLZValue{Value: []byte(large), Owner: owner})
+
+	var raw types.BLZValue
+	cdc.MustUnmarshalBinaryBare(testStore.Get([]byte(MakeMetaKey("uuid", "small"))), &raw)
+	assert.False(t, raw.Compressed)
+
+	cdc.MustUnmarshalBinaryBare(testStore.Get([]byte(MakeMetaKey("uuid", "large"))), &raw)
+	assert.True(t, raw.Compressed)
+	assert.Less(t, len(raw.Value), len(large))
+	assert.Equal(t, int64(len(large)), raw.Size)
+	assert.Equal(t, types.ValueHash([]byte(large)), raw.Hash)
+
+	// reads see the original value
+	value := keeper.GetValue(ctx, testStore, "uuid", "large")
+	assert.Equal(t, []byte(large), value.Value)
+	assert.False(t, value.Compressed)
+
+	keyValues := keeper.GetKeyValues(ctx, testStore, "uuid", nil)
+	assert.Equal(t, []types.KeyValue{{Key: "large", Value: []byte(large)}, {Key: "small", Value: []byte("small value")}}, keyValues.KeyValues)
+
+	// rewriting the same value does not count as a modification
+	keeper.SetValue(ctx.WithBlockHeight(10), testStore, "uuid", "large", types.BLZValue{Value: []byte(large), Owner: owner})
+	assert.Equal(t, int64(0), keeper.GetValue(ctx, testStore, "uuid", "large").ModifiedHeight)
+}
+
+func TestKeeper_SetValue_Binary(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeyValuesSize: 1 << 20})
+	keeper.SetParams(ctx, types.NewParams(64))
+
+	// not valid UTF-8, and long and repetitive enough to be stored compressed
+	value := bytes.Repeat([]byte{0x00, 0xff, 0xfe, 0x80}, 100)
+	keeper.SetValue(ctx, testStore, "uuid", "blob", types.BLZValue{Value: value, Owner: owner})
+
+	result := keeper.GetValue(ctx, testStore, "uuid", "blob")
+	assert.Equal(t, value, result.Value)
+	assert.Equal(t, int64(len(value)), result.Size)
+	assert.Equal(t, types.ValueHash(value), result.Hash)
+
+	bz, err := json.Marshal(types.QueryResultRead{UUID: "uuid", Key: "blob", Value: result.Value})
+	assert.Nil(t, err)
+
+	var read types.QueryResultRead
+	assert.Nil(t, json.Unmarshal(bz, &read))
+	assert.Equal(t, value, read.Value)
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// lease deposits are kept as prefix | len(UUID) | UUID | key
+func makeLeaseDepositKey(UUID string, key string) []byte {
+	return append(append([]byte{}, types.LeaseDepositPrefix...), []byte(MakeMetaKey(UUID, key))...)
+}
+
+func (k Keeper) GetLeaseDeposit(ctx sdk.Context, UUID string, key string) types.LeaseDeposit {
+	bz := k.GetIndexStore(ctx).Get(makeLeaseDepositKey(UUID, key))
+	if bz == nil {
+		return types.LeaseDeposit{Amount: sdk.NewCoins()}
+	}
+
+	var deposit types.LeaseDeposit
+	k.cdc.MustUnmarshalBinaryBare(bz, &deposit)
+	return deposit
+}
+
+// ImportLeaseDeposit sets the lease deposit of key, whose coins are already held in the
+// lease deposit module account.
+func (k Keeper) ImportLeaseDeposit(ctx sdk.Context, UUID string, key string, deposit types.LeaseDeposit) {
+	if deposit.Amount.IsZero() {
+		k.GetIndexStore(ctx).Delete(makeLeaseDepositKey(UUID, key))
+		return
+	}
+	k.GetIndexStore(ctx).Set(makeLeaseDepositKey(UUID, key), k.cdc.MustMarshalBinaryBare(deposit))
+}
+
+// GetLeaseDeposits returns the deposit of every key with one.
+func (k Keeper) GetLeaseDeposits(ctx sdk.Context) []types.GenesisLeaseDeposit {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.LeaseDepositPrefix)
+	defer iterator.Close()
+
+	var deposits []types.GenesisLeaseDeposit
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(types.LeaseDepositPrefix):]))
+		var deposit types.LeaseDeposit
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &deposit)
+		deposits = append(deposits, types.GenesisLeaseDeposit{UUID: UUID, Key: key, Deposit: deposit})
+	}
+	return deposits
+}
+
+// depositLease adds fee, already moved to the lease deposit module account, to the
+// deposit of key, which from now on is earned up to the key's current expiry.
+func (k Keeper) depositLease(ctx sdk.Context, UUID string, key string, fee sdk.Coins) {
+	deposit := k.settleLeaseDeposit(ctx, UUID, key)
+	deposit.Amount = deposit.Amount.Add(fee...)
+	value := k.getValueMeta(k.GetKVStore(ctx), UUID, key)
+	deposit.From, deposit.To = ctx.BlockHeight(), leaseExpiry(&value)
+
+	// with no blocks left to earn it over the whole deposit is earned now
+	if deposit.To <= deposit.From {
+		k.releaseLeaseFees(ctx, deposit.Amount)
+		deposit.Amount = sdk.NewCoins()
+	}
+	k.ImportLeaseDeposit(ctx, UUID, key, deposit)
+}
+
+// settleLeaseDeposit passes the part of key's deposit earned so far on to the lease
+// fees and returns what is left of it, earned from the current block.
+func (k Keeper) settleLeaseDeposit(ctx sdk.Context, UUID string, key string) types.LeaseDeposit {
+	deposit := k.GetLeaseDeposit(ctx, UUID, key)
+	if deposit.Amount.IsZero() {
+		return deposit
+	}
+
+	unearned := deposit.Unearned(ctx.BlockHeight())
+	k.releaseLeaseFees(ctx, deposit.Amount.Sub(unearned))
+	deposit.Amount = unearned
+	if deposit.From < ctx.BlockHeight() {
+		deposit.From = ctx.BlockHeight()
+	}
+	return deposit
+}
+
+// refundLeaseDeposit drops the deposit of a key that is deleted or changes hands,
+// paying the earned part to the lease fees and refunding the rest, which it returns,
+// to refundTo.
+func (k Keeper) refundLeaseDeposit(ctx sdk.Context, UUID string, key string, refundTo sdk.AccAddress) sdk.Coins {
+	if !k.GetIndexStore(ctx).Has(makeLeaseDepositKey(UUID, key)) {
+		return sdk.NewCoins()
+	}
+
+	deposit := k.settleLeaseDeposit(ctx, UUID, key)
+	k.GetIndexStore(ctx).Delete(makeLeaseDepositKey(UUID, key))
+	if !deposit.Amount.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.LeaseDepositName, refundTo, deposit.Amount); err != nil {
+			panic(err)
+		}
+		k.recordRent(ctx, refundTo, 0, nil, deposit.Amount)
+	}
+	return deposit.Amount
+}
+
+// moveLeaseDeposit carries the deposit of key over to newKey when it is renamed.
+func (k Keeper) moveLeaseDeposit(ctx sdk.Context, UUID string, key string, newKey string) {
+	if !k.GetIndexStore(ctx).Has(makeLeaseDepositKey(UUID, key)) {
+		return
+	}
+
+	k.ImportLeaseDeposit(ctx, UUID, newKey, k.GetLeaseDeposit(ctx, UUID, key))
+	k.GetIndexStore(ctx).Delete(makeLeaseDepositKey(UUID, key))
+}
+
+func (k Keeper) releaseLeaseFees(ctx sdk.Context, fees sdk.Coins) {
+	if fees.IsZero() {
+		return
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.LeaseDepositName, types.ModuleName, fees); err != nil {
+		panic(err)
+	}
+}
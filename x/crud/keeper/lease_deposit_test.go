@@ -0,0 +1,165 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_LeaseDeposit(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoin("ubnt", sdk.OneInt()))
+	keeper.SetParams(ctx, params)
+	ubnt := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ubnt", amount)) }
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key0", 0, 10)
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key0", 100))
+	assert.Equal(t, types.LeaseDeposit{Amount: ubnt(100), From: 0, To: 10}, keeper.GetLeaseDeposit(ctx, "uuid", "key0"))
+	assert.Equal(t, ubnt(100), supplyKeeper[types.LeaseDepositName])
+
+	// extending the lease pays out the blocks earned so far and spreads the rest with
+	// the new fee up to the new expiry
+	ctx = ctx.WithBlockHeight(4)
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Lease: 20, Owner: owner})
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key0", 60))
+	assert.Equal(t, types.LeaseDeposit{Amount: ubnt(120), From: 4, To: 20}, keeper.GetLeaseDeposit(ctx, "uuid", "key0"))
+	assert.Equal(t, ubnt(40), supplyKeeper[types.ModuleName])
+	assert.Equal(t, ubnt(840), supplyKeeper[string(owner)])
+
+	// the deposit goes with a renamed key
+	_, ok := keeper.RenameKey(ctx, testStore, "uuid", "key0", "key1", false)
+	assert.True(t, ok)
+	assert.Equal(t, []types.GenesisLeaseDeposit{{UUID: "uuid", Key: "key1", Deposit: types.LeaseDeposit{Amount: ubnt(120), From: 4, To: 20}}}, keeper.GetLeaseDeposits(ctx))
+	assert.Equal(t, ubnt(840), supplyKeeper[string(owner)])
+
+	// deleted early, the blocks left are refunded
+	ctx = ctx.WithBlockHeight(12)
+	keeper.DeleteValue(ctx, testStore, leaseStore, "uuid", "key1")
+	assert.Empty(t, keeper.GetLeaseDeposits(ctx))
+	assert.Equal(t, ubnt(900), supplyKeeper[string(owner)])
+	assert.Equal(t, ubnt(100), supplyKeeper[types.ModuleName])
+	assert.True(t, supplyKeeper[types.LeaseDepositName].IsZero())
+}
+
+func TestKeeper_TransferValue(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	recipient := sdk.AccAddress("recipient")
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoin("ubnt", sdk.OneInt()))
+	keeper.SetParams(ctx, params)
+	ubnt := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ubnt", amount)) }
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key", 0, 10)
+	keeper.SetAutoRenew(ctx, "uuid", "key", true)
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key", 100))
+
+	assert.False(t, keeper.TransferValue(ctx, testStore, "uuid", "missing", recipient))
+
+	// the deposit and auto-renewal go with the key rather than back to the old owner
+	ctx = ctx.WithBlockHeight(4)
+	assert.True(t, keeper.TransferValue(ctx, testStore, "uuid", "key", recipient))
+	assert.Equal(t, recipient, keeper.GetOwner(ctx, testStore, "uuid", "key"))
+	assert.Equal(t, types.LeaseDeposit{Amount: ubnt(100), From: 0, To: 10}, keeper.GetLeaseDeposit(ctx, "uuid", "key"))
+	assert.True(t, keeper.IsAutoRenew(ctx, "uuid", "key"))
+	assert.Equal(t, ubnt(900), supplyKeeper[string(owner)])
+	assert.Equal(t, ubnt(100), supplyKeeper[types.LeaseDepositName])
+
+	assert.Empty(t, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"key"}, keeper.GetKeys(ctx, testStore, "uuid", recipient).Keys)
+	for _, invariant := range []sdk.Invariant{LeasesInvariant(keeper), IndexesInvariant(keeper), CountersInvariant(keeper), DepositsInvariant(keeper)} {
+		msg, broken := invariant(ctx)
+		assert.False(t, broken, msg)
+	}
+
+	// the remaining blocks are refunded to the new owner
+	ctx = ctx.WithBlockHeight(6)
+	keeper.DeleteValue(ctx, testStore, leaseStore, "uuid", "key")
+	assert.Equal(t, ubnt(40), supplyKeeper[string(recipient)])
+	assert.Equal(t, ubnt(60), supplyKeeper[types.ModuleName])
+}
+
+func TestKeeper_ExpireNow(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoin("ubnt", sdk.OneInt()))
+	params.ExpiryGraceBlocks = 2
+	keeper.SetParams(ctx, params)
+	ubnt := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("ubnt", amount)) }
+
+	keeper.SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key", 0, 10)
+	keeper.SetAutoRenew(ctx, "uuid", "key", true)
+	assert.Nil(t, keeper.ChargeLease(ctx, owner, "uuid", "key", 100))
+	assert.Equal(t, ubnt(900), supplyKeeper[string(owner)])
+
+	expiry, refund := keeper.ExpireNow(ctx, testStore, leaseStore, "missing", "key")
+	assert.Equal(t, int64(0), expiry)
+	assert.True(t, refund.IsZero())
+
+	// the 6 blocks of lease left are refunded and the lease runs out at this block
+	ctx = ctx.WithBlockHeight(4)
+	expiry, refund = keeper.ExpireNow(ctx, testStore, leaseStore, "uuid", "key")
+	assert.Equal(t, int64(4), expiry)
+	assert.Equal(t, ubnt(60), refund)
+	assert.Equal(t, ubnt(960), supplyKeeper[string(owner)])
+	assert.Equal(t, ubnt(40), supplyKeeper[types.ModuleName])
+	assert.Empty(t, keeper.GetLeaseDeposits(ctx))
+	assert.False(t, keeper.IsAutoRenew(ctx, "uuid", "key"))
+
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(10, "uuid", "key"))))
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(4, "uuid", "key"))))
+	for _, invariant := range []sdk.Invariant{LeasesInvariant(keeper), IndexesInvariant(keeper), CountersInvariant(keeper)} {
+		msg, broken := invariant(ctx)
+		assert.False(t, broken, msg)
+	}
+
+	// the value is left as it is, and the termination audited
+	value := keeper.GetValue(ctx, testStore, "uuid", "key")
+	assert.Equal(t, []byte("value"), value.Value)
+	assert.Equal(t, uint64(1), value.Version)
+	log := keeper.GetAuditLog(ctx, "uuid", 1, 10).Entries
+	assert.Len(t, log, 2)
+	assert.Equal(t, types.AuditOpExpireNow, log[1].Op)
+	assert.Equal(t, owner, log[1].Actor)
+
+	// the key is expired, and can be read, from the next block and removed after the
+	// grace period
+	assert.False(t, keeper.IsExpired(ctx, testStore, "uuid", "key"))
+	assert.True(t, keeper.IsExpired(ctx.WithBlockHeight(5), testStore, "uuid", "key"))
+
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(5))
+	assert.True(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key"))
+	keeper.PurgeExpiredLeases(ctx.WithBlockHeight(6))
+	assert.False(t, keeper.IsKeyPresent(ctx, testStore, "uuid", "key"))
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsSubsystem is the subsystem of the crud metrics, which are exported alongside
+// tendermint's through the node's prometheus endpoint
+const MetricsSubsystem = "crud"
+
+type Metrics struct {
+	// Number of crud messages delivered, labelled by message type
+	Messages metrics.Counter
+	// Size in bytes of the values written
+	ValueSize metrics.Histogram
+	// Number of keys in the store
+	Keys metrics.Gauge
+	// Number of UUIDs with keys in the store
+	UUIDs metrics.Gauge
+	// Number of keys whose lease ran out in the last block
+	ExpiredKeys metrics.Gauge
+	// Bytes of UUID, key and value held by the keys purged in the last block
+	ReclaimedBytes metrics.Gauge
+	// Number of expired keys kept for the grace period and not yet purged
+	PurgeBacklog metrics.Gauge
+
+	// whether the store wide gauges are worth computing
+	enabled bool
+}
+
+// PrometheusMetrics registers the crud metrics with the default prometheus registry,
+// so it can only be called once.
+func PrometheusMetrics(namespace string) *Metrics {
+	return &Metrics{
+		Messages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "messages",
+			Help:      "Number of crud messages delivered.",
+		}, []string{"msg_type"}),
+		ValueSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "value_size_bytes",
+			Help:      "Size of the values written.",
+			Buckets:   stdprometheus.ExponentialBuckets(16, 4, 8),
+		}, nil),
+		Keys: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "keys",
+			Help:      "Number of keys, and so of lease entries, in the store.",
+		}, nil),
+		UUIDs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "uuids",
+			Help:      "Number of UUIDs with keys in the store.",
+		}, nil),
+		ExpiredKeys: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "expired_keys",
+			Help:      "Number of keys removed by lease expiry in the last block.",
+		}, nil),
+		ReclaimedBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reclaimed_bytes",
+			Help:      "Bytes held by the keys removed by lease expiry in the last block.",
+		}, nil),
+		PurgeBacklog: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "purge_backlog",
+			Help:      "Number of expired keys waiting out the grace period before they are removed.",
+		}, nil),
+		enabled: true,
+	}
+}
+
+// NopMetrics returns metrics that are discarded.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Messages:       discard.NewCounter(),
+		ValueSize:      discard.NewHistogram(),
+		Keys:           discard.NewGauge(),
+		UUIDs:          discard.NewGauge(),
+		ExpiredKeys:    discard.NewGauge(),
+		ReclaimedBytes: discard.NewGauge(),
+		PurgeBacklog:   discard.NewGauge(),
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+// ConsensusVersion is the version of the stored crud state written by this code. It
+// must be bumped, and a migration from the previous version registered, whenever the
+// stored format changes.
+const ConsensusVersion uint64 = 7
+
+// stores written before versioning was introduced are at version 1
+const initialStoreVersion uint64 = 1
+
+// MigrationHandler rewrites the crud state from one store version to the next.
+type MigrationHandler func(ctx sdk.Context, k Keeper) error
+
+func defaultMigrations() map[uint64]MigrationHandler {
+	return map[uint64]MigrationHandler{
+		1: migrateV1ToV2,
+		2: migrateV2ToV3,
+		3: migrateV3ToV4,
+		4: migrateV4ToV5,
+		5: migrateV5ToV6,
+		6: migrateV6ToV7,
+	}
+}
+
+// RegisterMigration registers the migration of the store from fromVersion to
+// fromVersion+1.
+func (k Keeper) RegisterMigration(fromVersion uint64, handler MigrationHandler) error {
+	if _, ok := k.migrations[fromVersion]; ok {
+		return fmt.Errorf("a migration from version %d is already registered", fromVersion)
+	}
+	k.migrations[fromVersion] = handler
+	return nil
+}
+
+func (k Keeper) GetStoreVersion(ctx sdk.Context) uint64 {
+	bz := k.GetIndexStore(ctx).Get(types.StoreVersionKey)
+	if bz == nil {
+		return initialStoreVersion
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetStoreVersion(ctx sdk.Context, version uint64) {
+	k.GetIndexStore(ctx).Set(types.StoreVersionKey, sdk.Uint64ToBigEndian(version))
+}
+
+// RunMigrations brings the store up to ConsensusVersion one version at a time. It does
+// nothing once the store is current.
+func (k Keeper) RunMigrations(ctx sdk.Context) error {
+	for version := k.GetStoreVersion(ctx); version < ConsensusVersion; version++ {
+		handler, ok := k.migrations[version]
+		if !ok {
+			return fmt.Errorf("no migration registered from crud store version %d", version)
+		}
+
+		if err := handler(ctx, k); err != nil {
+			return fmt.Errorf("crud store migration from version %d failed: %s", version, err)
+		}
+		k.SetStoreVersion(ctx, version+1)
+		ctx.Logger().Info(fmt.Sprintf("migrated crud store to version %d", version+1))
+	}
+	return nil
+}
+
+// MigrateValues rewrites every stored value with update, for migrations adding or
+// changing BLZValue fields. Values are written directly, so update must not change
+// the owner or lease of a value.
+func (k Keeper) MigrateValues(ctx sdk.Context, update func(UUID string, key string, value *types.BLZValue)) {
+	k.migrateValues(ctx, SplitMetaKey, update)
+}
+
+// migrateValues is MigrateValues for a store whose meta keys split into UUID and key
+// with split.
+func (k Keeper) migrateValues(ctx sdk.Context, split func(string) (string, string), update func(UUID string, key string, value *types.BLZValue)) {
+	store := k.GetKVStore(ctx)
+	iterator := k.GetValuesIterator(ctx, store)
+
+	var metaKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		metaKeys = append(metaKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for i := range metaKeys {
+		UUID, key := split(string(metaKeys[i]))
+		value := k.unmarshalValue(store.Get(metaKeys[i]))
+		update(UUID, key, &value)
+		store.Set(metaKeys[i], k.cdc.MustMarshalBinaryBare(k.compressValue(ctx, value)))
+	}
+}
+
+// version 2 adds the value metadata and hash, and the owner, lease and counter
+// indexes
+func migrateV1ToV2(ctx sdk.Context, k Keeper) error {
+	k.migrateValues(ctx, splitV1MetaKey, func(_ string, _ string, value *types.BLZValue) {
+		if value.CreatedHeight == 0 {
+			value.CreatedHeight = value.Height
+			value.ModifiedHeight = value.Height
+		}
+		value.Size = int64(len(value.Value))
+		value.Hash = types.ValueHash(value.Value)
+	})
+	k.buildOwnerIndex(ctx, k.GetKVStore(ctx), splitV1MetaKey)
+	return nil
+}
+
+// version 3 stores values as bytes rather than strings. Amino encodes the two alike,
+// so stored values read back unchanged; their sizes and hashes are recomputed from the
+// bytes so that nothing written by an older version can disagree with them.
+func migrateV2ToV3(ctx sdk.Context, k Keeper) error {
+	k.migrateValues(ctx, splitV1MetaKey, func(_ string, _ string, value *types.BLZValue) {
+		value.Size = int64(len(value.Value))
+		value.Hash = types.ValueHash(value.Value)
+	})
+	return nil
+}
+
+// version 4 length prefixes the UUID in store keys instead of ending it with 0x00, so
+// UUIDs and keys may hold any bytes. Old keys are split at their first 0x00, as that
+// is how they were read.
+func migrateV3ToV4(ctx sdk.Context, k Keeper) error {
+	indexStore := k.GetIndexStore(ctx)
+
+	rekey(k.GetKVStore(ctx), nil, func(old []byte) []byte {
+		UUID, key := splitV1MetaKey(string(old))
+		return []byte(MakeMetaKey(UUID, key))
+	})
+
+	// lease keys are height | 0x00 | meta key, and heights have no 0x00 in them
+	rekey(k.GetLeaseStore(ctx), nil, func(old []byte) []byte {
+		parts := strings.SplitN(string(old), "\x00", 2)
+		UUID, key := splitV1MetaKey(parts[1])
+		return []byte(parts[0] + "\x00" + MakeMetaKey(UUID, key))
+	})
+
+	for _, prefix := range [][]byte{types.BeneficiaryPrefix, types.UploadPrefix} {
+		prefix := prefix
+		rekey(indexStore, prefix, func(old []byte) []byte {
+			UUID, key := splitV1MetaKey(string(old[len(prefix):]))
+			return append(append([]byte{}, prefix...), MakeMetaKey(UUID, key)...)
+		})
+	}
+
+	// chunks were prefix | UUID | 0x00 | key | 0x00 | index
+	rekey(indexStore, types.UploadChunkPrefix, func(old []byte) []byte {
+		UUID, key := splitV1MetaKey(string(old[len(types.UploadChunkPrefix) : len(old)-9]))
+		return makeUploadChunkKey(UUID, key, binary.BigEndian.Uint64(old[len(old)-8:]))
+	})
+
+	// freezes were prefix | len(owner) | owner | UUID | 0x00 | key
+	rekey(indexStore, types.FreezePrefix, func(old []byte) []byte {
+		bz := old[len(types.FreezePrefix):]
+		UUID, key := splitV1MetaKey(string(bz[1+int(bz[0]):]))
+		return makeFreezeKey(bz[1:1+int(bz[0])], UUID, key)
+	})
+
+	// the indexes are rebuilt rather than rewritten
+	k.clearPrefix(indexStore, types.ValueIndexPrefix)
+	for _, index := range k.GetIndexConfigs(ctx) {
+		k.SetIndexConfig(ctx, k.GetKVStore(ctx), index.UUID, index.Config)
+	}
+	k.BuildOwnerIndex(ctx, k.GetKVStore(ctx))
+	return nil
+}
+
+// version 5 adds the value bytes and distinct owners counters of each UUID, built
+// along with the rest of the owner index
+func migrateV4ToV5(ctx sdk.Context, k Keeper) error {
+	k.BuildOwnerIndex(ctx, k.GetKVStore(ctx))
+	return nil
+}
+
+// version 6 adds the counter of the value bytes stored over every UUID, which prices
+// leases by utilization
+func migrateV5ToV6(ctx sdk.Context, k Keeper) error {
+	k.BuildOwnerIndex(ctx, k.GetKVStore(ctx))
+	return nil
+}
+
+// version 7 flips the sign bit of the epoch start heights in the rent record keys, so
+// that the negative heights of an imported history sort first
+func migrateV6ToV7(ctx sdk.Context, k Keeper) error {
+	rekey(k.GetIndexStore(ctx), types.RentPrefix, func(old []byte) []byte {
+		key := append([]byte{}, old...)
+		key[len(key)-8] ^= 0x80
+		return key
+	})
+	return nil
+}
+
+// meta keys were UUID | 0x00 | key up to version 3
+func splitV1MetaKey(metaKey string) (string, string) {
+	parts := strings.SplitN(metaKey, "\x00", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// rekey moves every entry under prefix in store to the key newKey returns for it. All
+// of the old entries are removed before any is written back, so that new keys cannot
+// overwrite old ones that are still to be moved.
+func rekey(store sdk.KVStore, prefix []byte, newKey func(old []byte) []byte) {
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+
+	var keys, values [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	iterator.Close()
+
+	for i := range keys {
+		store.Delete(keys[i])
+	}
+	for i := range keys {
+		store.Set(newKey(keys[i]), values[i])
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"errors"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_RunMigrations(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	// values as stored before metadata, hashes and indexes were added
+	testStore.Set([]byte("uuid\x00key0"), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value0"), Height: 10, Lease: 100, Owner: owner}))
+	testStore.Set([]byte("uuid\x00key1"), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value1"), Height: 20, Lease: 100, Owner: owner}))
+
+	assert.Equal(t, uint64(1), keeper.GetStoreVersion(ctx))
+
+	assert.Nil(t, keeper.RunMigrations(ctx))
+
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+	assert.Equal(t, types.BLZValue{
+		Value:          []byte("value1"),
+		Height:         20,
+		Lease:          100,
+		Owner:          owner,
+		CreatedHeight:  20,
+		ModifiedHeight: 20,
+		Size:           6,
+		Hash:           types.ValueHash([]byte("value1")),
+	}, keeper.GetValue(ctx, testStore, "uuid", "key1"))
+	assert.Equal(t, []string{"key0", "key1"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, uint64(2), keeper.GetCount(ctx, testStore, "uuid", owner).Count)
+
+	_, broken := IndexesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+
+	// nothing more to do once the store is current
+	assert.Nil(t, keeper.RunMigrations(ctx))
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+}
+
+func TestKeeper_migrateV1ToV2(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	testStore.Set([]byte("uuid\x00key0"), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value0"), Height: 10, Lease: 100, Owner: owner}))
+	testStore.Set([]byte("uuid\x00key1"), cdc.MustMarshalBinaryBare(types.BLZValue{Value: []byte("value1"), Height: 20, Lease: 100, Owner: owner}))
+
+	// the step alone counts the keys under the UUID of the version 1 keys
+	assert.Nil(t, migrateV1ToV2(ctx, keeper))
+	indexStore := keeper.GetIndexStore(ctx)
+	assert.Equal(t, uint64(2), keeper.getCounter(indexStore, nil, "uuid"))
+	assert.Equal(t, uint64(2), keeper.getCounter(indexStore, owner, "uuid"))
+	assert.Equal(t, uint64(12), keeper.getUUIDStat(indexStore, types.UUIDBytesPrefix, "uuid"))
+	assert.True(t, indexStore.Has(makeOwnerIndexKey(owner, "uuid", "key1")))
+}
+
+func TestKeeper_RunMigrations_binaryValues(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	// a value as stored by version 2, with a string value and a stale size
+	type blzValueV2 struct {
+		Value          string
+		Lease          int64
+		Height         int64
+		Owner          sdk.AccAddress
+		CreatedHeight  int64
+		ModifiedHeight int64
+		Size           int64
+		Hash           []byte
+		Compressed     bool
+	}
+	testStore.Set([]byte("uuid\x00key"), cdc.MustMarshalBinaryBare(blzValueV2{Value: "value", Height: 10, Lease: 100,
+		Owner: owner, CreatedHeight: 10, ModifiedHeight: 10}))
+	keeper.SetStoreVersion(ctx, 2)
+
+	assert.Nil(t, keeper.RunMigrations(ctx))
+
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+	assert.Equal(t, types.BLZValue{
+		Value:          []byte("value"),
+		Height:         10,
+		Lease:          100,
+		Owner:          owner,
+		CreatedHeight:  10,
+		ModifiedHeight: 10,
+		Size:           5,
+		Hash:           types.ValueHash([]byte("value")),
+	}, keeper.GetValue(ctx, testStore, "uuid", "key"))
+}
+
+func TestKeeper_RunMigrations_lengthPrefixedKeys(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	indexStore := keeper.GetIndexStore(ctx)
+
+	// state as stored by version 3, with UUIDs ended by 0x00
+	value := types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner, CreatedHeight: 10, ModifiedHeight: 10,
+		Size: 5, Hash: types.ValueHash([]byte("value"))}
+	testStore.Set([]byte("uuid\x00key"), cdc.MustMarshalBinaryBare(value))
+	keeper.GetLeaseStore(ctx).Set([]byte("110\x00uuid\x00key"), []byte{})
+	indexStore.Set(append(append([]byte{}, types.IndexConfigPrefix...), "uuid"...), cdc.MustMarshalBinaryBare(types.IndexConfig{Owner: owner}))
+	indexStore.Set(append(append([]byte{}, types.ValueIndexPrefix...), "uuid\x00stale"...), []byte{})
+	indexStore.Set(append(append([]byte{}, types.FreezePrefix...), append(append([]byte{byte(len(owner))}, owner...), "uuid\x00key"...)...), []byte{})
+	indexStore.Set(append(append([]byte{}, types.BeneficiaryPrefix...), "uuid\x00key"...), cdc.MustMarshalBinaryBare(types.Beneficiary{Address: owner}))
+	indexStore.Set(append(append([]byte{}, types.UploadPrefix...), "uuid\x00up"...), cdc.MustMarshalBinaryBare(types.Upload{Owner: owner, Size: 5, Chunks: 1, Received: 5}))
+	indexStore.Set(append(append([]byte{}, types.UploadChunkPrefix...), "uuid\x00up\x00\x00\x00\x00\x00\x00\x00\x00\x00"...), []byte("chunk"))
+	keeper.SetStoreVersion(ctx, 3)
+
+	assert.Nil(t, keeper.RunMigrations(ctx))
+
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+	assert.Equal(t, value, keeper.GetValue(ctx, testStore, "uuid", "key"))
+	assert.Equal(t, []string{"key"}, keeper.GetKeys(ctx, testStore, "uuid", owner).Keys)
+	assert.Equal(t, []string{"key"}, keeper.FindKeys(ctx, "uuid", "value").Keys)
+	assert.True(t, keeper.GetLeaseStore(ctx).Has([]byte(MakeLeaseKey(110, "uuid", "key"))))
+	assert.True(t, keeper.IsFrozen(ctx, owner, "uuid", "key"))
+	assert.Equal(t, sdk.AccAddress(owner), keeper.GetBeneficiary(ctx, "uuid", "key").Address)
+	assert.Equal(t, int64(5), keeper.GetUpload(ctx, "uuid", "up").Received)
+	assert.Equal(t, []byte("chunk"), keeper.AssembleUpload(ctx, "uuid", "up"))
+
+	_, broken := IndexesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
+
+func TestKeeper_RunMigrations_uuidStats(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	keeper.SetValue(ctx, testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+	keeper.SetValue(ctx, testStore, "uuid", "key1", types.BLZValue{Value: []byte("value1"), Height: 10, Lease: 200, Owner: sdk.AccAddress("otherowner")})
+
+	// state as stored by version 4, without the stats counters
+	keeper.clearPrefix(keeper.GetIndexStore(ctx), types.UUIDBytesPrefix)
+	keeper.clearPrefix(keeper.GetIndexStore(ctx), types.UUIDOwnersPrefix)
+	keeper.GetIndexStore(ctx).Delete(types.StoredBytesKey)
+	keeper.SetStoreVersion(ctx, 4)
+
+	assert.Nil(t, keeper.RunMigrations(ctx))
+
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+	assert.Equal(t, types.QueryResultUUIDStats{UUID: "uuid", Keys: 2, Bytes: 11, Owners: 2, EarliestExpiry: 110, LatestExpiry: 210},
+		keeper.GetUUIDStats(ctx, "uuid"))
+	assert.Equal(t, uint64(11), keeper.GetStoredBytes(ctx))
+
+	_, broken := CountersInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
+
+func TestKeeper_RunMigrations_rentKeys(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	// a record as stored by version 6, its height not sign flipped
+	record := types.RentRecord{StartHeight: 100, Charges: 1, ByteBlocks: 10}
+	keeper.GetIndexStore(ctx).Set(append(makeRentPrefix(owner), sdk.Uint64ToBigEndian(100)...), cdc.MustMarshalBinaryBare(record))
+	keeper.SetStoreVersion(ctx, 6)
+
+	assert.Nil(t, keeper.RunMigrations(ctx))
+	assert.Equal(t, ConsensusVersion, keeper.GetStoreVersion(ctx))
+
+	// an imported epoch from before the chain sorts first
+	imported := types.RentRecord{StartHeight: -100, Charges: 2, ByteBlocks: 20}
+	keeper.ImportRentRecord(ctx, owner, imported)
+	assert.Equal(t, []types.RentRecord{imported, record}, keeper.GetRentHistory(ctx, owner, -1000, 10).Records)
+	assert.Equal(t, []types.RentRecord{record}, keeper.GetRentHistory(ctx, owner, 0, 10).Records)
+}
+
+func TestKeeper_RegisterMigration(t *testing.T) {
+	ctx, _, _, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	assert.NotNil(t, keeper.RegisterMigration(1, migrateV1ToV2))
+
+	// a store version without a registered migration cannot be migrated
+	keeper.SetStoreVersion(ctx, 0)
+	assert.NotNil(t, keeper.RunMigrations(ctx))
+
+	failed := errors.New("failed")
+	assert.Nil(t, keeper.RegisterMigration(0, func(_ sdk.Context, _ Keeper) error { return failed }))
+	assert.Contains(t, keeper.RunMigrations(ctx).Error(), "failed")
+	assert.Equal(t, uint64(0), keeper.GetStoreVersion(ctx))
+}
@@ -0,0 +1,592 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+	"time"
+)
+
+const (
+	QueryRead               = "read"
+	QueryReadMeta           = "readmeta"
+	QueryHas                = "has"
+	QueryOwner              = "owner"
+	QueryKeys               = "keys"
+	QueryKeyValues          = "keyvalues"
+	QueryKeyValuesPage      = "keyvaluespage"
+	QueryCount              = "count"
+	QueryGetLease           = "getlease"
+	QueryGetNShortestLeases = "getnshortestleases"
+	QueryGetLeaseAll        = "getleaseall"
+	QueryFind               = "find"
+	QueryGetMetadata        = "getmetadata"
+	QueryGetHash            = "gethash"
+	QueryMyUUIDs            = "myuuids"
+	QueryEscrow             = "escrow"
+	QueryAccountUsage       = "accountusage"
+	QueryGCStatus           = "gcstatus"
+	QueryCountAll           = "countall"
+	QueryKeysAll            = "keysall"
+	QueryKeysByExpiry       = "keysbyexpiry"
+	QueryUUIDStats          = "uuidstats"
+	QueryAuditLog           = "auditlog"
+	QueryMatch              = "match"
+	QueryFindByHash         = "findbyhash"
+	QueryDefaultLease       = "defaultlease"
+	QueryLeasePrice         = "leaseprice"
+	QueryRentHistory        = "renthistory"
+	QuerySchema             = "schema"
+)
+
+func NewQuerier(keeper IKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case QueryRead:
+			return queryRead(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryReadMeta:
+			return queryReadMeta(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryHas:
+			return queryHas(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryOwner:
+			return queryOwner(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryKeys:
+			return queryKeys(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryKeyValues:
+			return queryKeyValues(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryKeyValuesPage:
+			return queryKeyValuesPage(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryCount:
+			return queryCount(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGetLease:
+			return queryGetLease(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGetNShortestLeases:
+			return queryGetNShortestLeases(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGetLeaseAll:
+			return queryGetLeaseAll(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryFind:
+			return queryFind(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGetMetadata:
+			return queryGetMetadata(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGetHash:
+			return queryGetHash(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryMyUUIDs:
+			return queryMyUUIDs(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryEscrow:
+			return queryEscrow(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryAccountUsage:
+			return queryAccountUsage(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryGCStatus:
+			return queryGCStatus(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryCountAll:
+			return queryCountAll(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryKeysAll:
+			return queryKeysAll(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryKeysByExpiry:
+			return queryKeysByExpiry(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryUUIDStats:
+			return queryUUIDStats(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryAuditLog:
+			return queryAuditLog(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryMatch:
+			return queryMatch(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryFindByHash:
+			return queryFindByHash(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryDefaultLease:
+			return queryDefaultLease(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryLeasePrice:
+			return queryLeasePrice(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QueryRentHistory:
+			return queryRentHistory(ctx, path[1:], req, keeper, keeper.GetCdc())
+		case QuerySchema:
+			return querySchema(ctx, path[1:], req, keeper, keeper.GetCdc())
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown crud query endpoint")
+		}
+	}
+}
+
+func queryRead(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), path[0], path[1])
+
+	if len(blzValue.Owner) == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultRead{UUID: path[0], Key: path[1], Value: blzValue.Value, Version: blzValue.Version,
+		ContentType: blzValue.ContentType, Encoding: blzValue.Encoding})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryReadMeta(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), path[0], path[1])
+
+	if len(blzValue.Owner) == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultReadMeta{
+		UUID:           path[0],
+		Key:            path[1],
+		Value:          blzValue.Value,
+		Owner:          blzValue.Owner,
+		Lease:          blzValue.Height + blzValue.Lease - ctx.BlockHeight(),
+		CreatedHeight:  blzValue.CreatedHeight,
+		ModifiedHeight: blzValue.ModifiedHeight,
+		Version:        blzValue.Version,
+		ContentType:    blzValue.ContentType,
+		Encoding:       blzValue.Encoding,
+	})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryHas(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	has := keeper.IsKeyPresent(ctx, keeper.GetKVStore(ctx), path[0], path[1])
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultHas{UUID: path[0], Key: path[1], Has: has})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryOwner(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner := keeper.GetOwner(ctx, keeper.GetKVStore(ctx), path[0], path[1])
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultOwner{UUID: path[0], Key: path[1], Has: !owner.Empty(), Owner: owner})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the key to start from is sent as the request data, see queryKeyValuesPage
+func queryKeys(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetKeysFrom(ctx, keeper.GetKVStore(ctx), path[0], nil, string(req.Data)))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryKeyValues(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetKeyValuesFrom(ctx, keeper.GetKVStore(ctx), path[0], nil, string(req.Data)))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the path is UUID and optionally an owner, the key to start from is sent as the
+// request data as keys are not safe to use as path elements
+func queryKeyValuesPage(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	var owner sdk.AccAddress
+	if len(path) > 1 && len(path[1]) > 0 {
+		var err error
+		if owner, err = sdk.AccAddressFromBech32(path[1]); err != nil {
+			return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetKeyValuesPage(ctx, keeper.GetKVStore(ctx), path[0], owner, string(req.Data)))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryCount(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetCount(ctx, keeper.GetKVStore(ctx), path[0], nil))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryGetLease(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	blzValue := keeper.GetValue(ctx, keeper.GetKVStore(ctx), path[0], path[1])
+
+	if len(blzValue.Owner) == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultLease{UUID: path[0], Key: path[1], Lease: blzValue.Height + blzValue.Lease - ctx.BlockHeight()})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the path is UUID and N, and the request data the hex encoded Next of the previous
+// page, the keys of every owner being read from the lease index
+func queryGetNShortestLeases(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+
+	N, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if N == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid N")
+	}
+
+	start, err := hex.DecodeString(string(req.Data))
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid start")
+	}
+
+	page := keeper.GetKeysByExpiry(ctx, path[0], nil, start, N)
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultNShortestLeaseKeys{UUID: path[0], KeyLeases: page.KeyLeases, Next: page.Next})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the path is UUID, owner and limit, and the request data the hex encoded Next of the
+// previous page, the owner's keys being returned soonest expiring first
+func queryGetLeaseAll(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	limit, err := strconv.ParseUint(path[2], 10, 64)
+	if err != nil || limit == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid limit")
+	}
+
+	start, err := hex.DecodeString(string(req.Data))
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid start")
+	}
+
+	page := keeper.GetKeysByExpiry(ctx, path[0], owner, start, limit)
+
+	leases := make([]types.KeyLeaseExpiry, 0, len(page.KeyLeases))
+	for _, keyLease := range page.KeyLeases {
+		leases = append(leases, types.KeyLeaseExpiry{
+			Key:        keyLease.Key,
+			Lease:      keyLease.Lease,
+			ExpiryTime: ctx.BlockTime().Add(time.Duration(keyLease.Lease) * types.BlockTimeEstimate),
+		})
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultLeaseAll{UUID: path[0], Owner: owner, Leases: leases, Next: page.Next})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the path is UUID, owner (empty for every owner) and limit, and the request data the
+// hex encoded Next of the previous page
+func queryKeysByExpiry(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	var owner sdk.AccAddress
+	if len(path[1]) > 0 {
+		var err error
+		if owner, err = sdk.AccAddressFromBech32(path[1]); err != nil {
+			return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	limit, err := strconv.ParseUint(path[2], 10, 64)
+	if err != nil || limit == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid limit")
+	}
+
+	start, err := hex.DecodeString(string(req.Data))
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid start")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetKeysByExpiry(ctx, path[0], owner, start, limit))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the audit log is paged by sequence number, the first entry being 1
+func queryAuditLog(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	start, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid start")
+	}
+
+	limit, err := strconv.ParseUint(path[2], 10, 64)
+	if err != nil || limit == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid limit")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetAuditLog(ctx, path[0], start, limit))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the rent history is paged by the start height of its epochs
+func queryRentHistory(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	start, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid start")
+	}
+
+	limit, err := strconv.ParseUint(path[2], 10, 64)
+	if err != nil || limit == 0 {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid limit")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetRentHistory(ctx, owner, start, limit))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the path is UUID and optionally an owner, the pattern and start are sent as the request
+// data as neither is safe to use as a path element
+func queryMatch(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	var owner sdk.AccAddress
+	if len(path) > 1 && len(path[1]) > 0 {
+		var err error
+		if owner, err = sdk.AccAddressFromBech32(path[1]); err != nil {
+			return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	var params types.QueryMatchParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	pattern, err := types.CompileKeyPattern(params.Pattern)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.MatchKeys(ctx, keeper.GetKVStore(ctx), path[0], owner, pattern, params.Start))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the value being searched for is sent as the request data, values are not safe to use as path elements
+func queryFind(ctx sdk.Context, path []string, req abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	if keeper.GetIndexConfig(ctx, path[0]).Owner.Empty() {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "UUID is not indexed")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.FindKeys(ctx, path[0], string(req.Data)))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// the hash is the hex encoded SHA-256 of the whole value, as reported by gethash
+func queryFindByHash(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	if keeper.GetHashIndexConfig(ctx, path[0]).Owner.Empty() {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "UUID has no hash index")
+	}
+
+	hash, err := hex.DecodeString(path[1])
+	if err != nil || len(hash) != sha256.Size {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid hash")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.FindKeysByHash(ctx, path[0], hash))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryGetMetadata(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	if !keeper.IsKeyPresent(ctx, keeper.GetKVStore(ctx), path[0], path[1]) {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetMetadata(ctx, keeper.GetKVStore(ctx), path[0], path[1]))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryGetHash(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	if !keeper.IsKeyPresent(ctx, keeper.GetKVStore(ctx), path[0], path[1]) {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetHash(ctx, keeper.GetKVStore(ctx), path[0], path[1]))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryMyUUIDs(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetUUIDs(ctx, owner))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryEscrow(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultEscrow{Owner: owner, Balance: keeper.GetEscrow(ctx, owner)})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryAccountUsage(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetAccountUsage(ctx, owner))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryUUIDStats(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetUUIDStats(ctx, path[0]))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func querySchema(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultSchema{UUID: path[0], Schema: keeper.GetSchema(ctx, path[0])})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryCountAll(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetCountAll(ctx, owner))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryKeysAll(ctx sdk.Context, path []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetKeysAll(ctx, owner))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryDefaultLease(ctx sdk.Context, _ []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultDefaultLease{LeaseBlocks: keeper.GetDefaultLeaseBlocks(ctx)})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryLeasePrice(ctx sdk.Context, _ []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetLeasePrice(ctx))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryGCStatus(ctx sdk.Context, _ []string, _ abci.RequestQuery, keeper IKeeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetGCStatus(ctx))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
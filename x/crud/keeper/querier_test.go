@@ -0,0 +1,778 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func initTest(t *testing.T) (sdk.Context, *codec.Codec, *mocks.MockIKeeper) {
+	mockCtrl := gomock.NewController(t)
+	ctx := sdk.Context{}
+	var cdc = codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	return ctx, cdc, mockKeeper
+}
+
+func Test_queryRead(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	// simple test
+	expectedValue := "value"
+	expectedOwner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").
+		Return(types.BLZValue{
+			Value:       []byte(expectedValue),
+			Owner:       expectedOwner,
+			Version:     5,
+			ContentType: "text/plain",
+			Encoding:    "gzip",
+		})
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"read", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultRead{}
+	cdc.MustUnmarshalJSON(result, &jsonResult)
+
+	assert.Equal(t, jsonResult.Value, []byte(expectedValue))
+	assert.Equal(t, uint64(5), jsonResult.Version)
+	assert.Equal(t, "text/plain", jsonResult.ContentType)
+	assert.Equal(t, "gzip", jsonResult.Encoding)
+
+	// item does not exist.
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key")
+	result, err = queryRead(ctx, []string{"uuid", "key"}, abci.RequestQuery{}, mockKeeper, cdc)
+
+	assert.NotNil(t, err)
+
+	// nonexistent path test
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"badpath", "uuid", "key"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryReadMeta(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	ctx = ctx.WithBlockHeight(100)
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key").
+		Return(types.BLZValue{Value: []byte("value"), Owner: owner, Height: 50, Lease: 200, CreatedHeight: 20, ModifiedHeight: 50})
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"readmeta", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	var jsonResult types.QueryResultReadMeta
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultReadMeta{UUID: "uuid", Key: "key", Value: []byte("value"), Owner: owner, Lease: 150,
+		CreatedHeight: 20, ModifiedHeight: 50}, jsonResult)
+
+	// item does not exist
+	mockKeeper.EXPECT().GetValue(ctx, nil, "uuid", "key")
+	_, err = queryReadMeta(ctx, []string{"uuid", "key"}, abci.RequestQuery{}, mockKeeper, cdc)
+	assert.NotNil(t, err)
+}
+
+func Test_queryHas(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(true)
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"has", "uuid", "key"}, abci.RequestQuery{})
+
+	if err != nil {
+		t.Error("Expected nil error")
+	}
+
+	jsonResult := types.QueryResultHas{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.True(t, jsonResult.Has)
+}
+
+func Test_queryOwner(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "key").Return(owner)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"owner", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultOwner{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultOwner{UUID: "uuid", Key: "key", Has: true, Owner: owner}, jsonResult)
+
+	mockKeeper.EXPECT().GetOwner(ctx, nil, "uuid", "missing")
+
+	result, err = NewQuerier(mockKeeper)(ctx, []string{"owner", "uuid", "missing"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult = types.QueryResultOwner{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.False(t, jsonResult.Has)
+	assert.True(t, jsonResult.Owner.Empty())
+}
+
+func Test_queryKeys(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	acceptedKeys := []string{"key", "key1"}
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetKeysFrom(ctx, nil, "uuid", nil, "").Return(types.QueryResultKeys{
+		UUID: "uuid",
+		Keys: acceptedKeys,
+		Next: "key2",
+	})
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"keys", "uuid"}, abci.RequestQuery{})
+
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeys{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.True(t, reflect.DeepEqual(acceptedKeys, jsonResult.Keys))
+	assert.Equal(t, "key2", jsonResult.Next)
+
+	// the next page starts at the key sent as the request data
+	mockKeeper.EXPECT().GetKeysFrom(ctx, nil, "uuid", nil, "key2").Return(types.QueryResultKeys{UUID: "uuid", Keys: []string{"key2"}})
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err = NewQuerier(mockKeeper)(ctx, []string{"keys", "uuid"}, abci.RequestQuery{Data: []byte("key2")})
+	assert.Nil(t, err)
+
+	jsonResult = types.QueryResultKeys{}
+	json.Unmarshal(result, &jsonResult)
+	assert.Equal(t, types.QueryResultKeys{UUID: "uuid", Keys: []string{"key2"}}, jsonResult)
+}
+
+func Test_queryKeyValues(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	var keyValues []types.KeyValue
+	keyValues = append(keyValues, types.KeyValue{Key: "key0", Value: []byte("value0")})
+	keyValues = append(keyValues, types.KeyValue{Key: "key1", Value: []byte("value1")})
+
+	acceptedKeyValues := types.QueryResultKeyValues{UUID: "uuid", KeyValues: keyValues}
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetKeyValuesFrom(ctx, nil, "uuid", gomock.Any(), "key0").Return(acceptedKeyValues)
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"keyvalues", "uuid"}, abci.RequestQuery{Data: []byte("key0")})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeyValues{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.True(t, reflect.DeepEqual(acceptedKeyValues, jsonResult))
+}
+
+func Test_queryKeyValuesPage(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	page := types.QueryResultKeyValuesPage{UUID: "uuid", Next: "key1", KeyValues: []types.KeyValueLease{{Key: "key0", Value: []byte("value0"), Lease: 10, Owner: owner}}}
+
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKeyValuesPage(ctx, nil, "uuid", nil, "").Return(page)
+	mockKeeper.EXPECT().GetKeyValuesPage(ctx, nil, "uuid", owner, "key/0").Return(page)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"keyvaluespage", "uuid"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeyValuesPage{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, page, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keyvaluespage", "uuid", owner.String()}, abci.RequestQuery{Data: []byte("key/0")})
+	assert.Nil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keyvaluespage", "uuid", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryCount(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	// always return nil for a store...
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetCount(ctx, nil, "uuid", nil).Return(types.QueryResultCount{
+		UUID:  "uuid",
+		Count: 2,
+	})
+	mockKeeper.EXPECT().GetCdc().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"count", "uuid"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultCount{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, uint64(2), jsonResult.Count)
+}
+
+func Test_queryGetLease(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	expectedOwner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key").Return(types.BLZValue{
+		Value:  []byte("test"),
+		Lease:  10,
+		Height: 1000,
+		Owner:  expectedOwner,
+	})
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+
+	newCtx := ctx.WithBlockHeight(1009)
+
+	result, err := NewQuerier(mockKeeper)(newCtx, []string{"getlease", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultLease{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, "key", jsonResult.Key)
+	assert.Equal(t, int64(1), jsonResult.Lease)
+
+	mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key")
+
+	_, err = NewQuerier(mockKeeper)(newCtx, []string{"getlease", "uuid", "key"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryGetNShortestLeases(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	mockKeeper.EXPECT().GetKeysByExpiry(ctx, "uuid", nil, []byte{}, uint64(10)).Return(types.QueryResultKeysByExpiry{
+		UUID: "uuid",
+		KeyLeases: types.KeyLeases{
+			types.KeyLease{
+				Key:   "key00",
+				Lease: 100,
+			},
+		},
+		Next: "0a",
+	})
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"getnshortestleases", "uuid", "10"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultNShortestLeaseKeys{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, "key00", jsonResult.KeyLeases[0].Key)
+	assert.Equal(t, int64(100), jsonResult.KeyLeases[0].Lease)
+	assert.Equal(t, "0a", jsonResult.Next)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getnshortestleases", "uuid", "abcd"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getnshortestleases", "uuid", "0"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+
+	// paged from the next of the previous page
+	mockKeeper.EXPECT().GetKeysByExpiry(ctx, "uuid", nil, []byte{0x0a}, uint64(10)).Return(types.QueryResultKeysByExpiry{
+		UUID:      "uuid",
+		KeyLeases: types.KeyLeases{types.KeyLease{Key: "key20", Lease: 300}},
+	})
+
+	result, err = NewQuerier(mockKeeper)(ctx, []string{"getnshortestleases", "uuid", "10"}, abci.RequestQuery{Data: []byte("0a")})
+	assert.Nil(t, err)
+
+	jsonResult = types.QueryResultNShortestLeaseKeys{}
+	json.Unmarshal(result, &jsonResult)
+	assert.Equal(t, "key20", jsonResult.KeyLeases[0].Key)
+	assert.Empty(t, jsonResult.Next)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getnshortestleases", "uuid", "10"}, abci.RequestQuery{Data: []byte("x")})
+	assert.NotNil(t, err)
+}
+
+func Test_queryGetLeaseAll(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	mockKeeper.EXPECT().GetKeysByExpiry(ctx, "uuid", owner, []byte{0x01, 0x02}, uint64(10)).Return(types.QueryResultKeysByExpiry{
+		UUID:      "uuid",
+		Owner:     owner,
+		KeyLeases: types.KeyLeases{{Key: "key00", Lease: 100}, {Key: "key01", Lease: 200}},
+		Next:      "0a",
+	})
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"getleaseall", "uuid", owner.String(), "10"}, abci.RequestQuery{Data: []byte("0102")})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultLeaseAll{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, owner, jsonResult.Owner)
+	assert.Equal(t, 2, len(jsonResult.Leases))
+	assert.Equal(t, "key01", jsonResult.Leases[1].Key)
+	assert.Equal(t, int64(200), jsonResult.Leases[1].Lease)
+	assert.True(t, time.Unix(2000, 0).Equal(jsonResult.Leases[1].ExpiryTime))
+	assert.Equal(t, "0a", jsonResult.Next)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getleaseall", "uuid", "owner", "10"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getleaseall", "uuid", owner.String(), "abcd"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getleaseall", "uuid", owner.String(), "10"}, abci.RequestQuery{Data: []byte("x")})
+	assert.NotNil(t, err)
+}
+
+func Test_queryMyUUIDs(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetUUIDs(ctx, owner).Return(types.QueryResultUUIDs{Owner: owner, UUIDs: []types.UUIDCount{{UUID: "uuid", Count: 3}}})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"myuuids", owner.String()}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultUUIDs{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultUUIDs{Owner: owner, UUIDs: []types.UUIDCount{{UUID: "uuid", Count: 3}}}, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"myuuids", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryKeysByExpiry(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	page := types.QueryResultKeysByExpiry{UUID: "uuid", Owner: owner, KeyLeases: []types.KeyLease{{Key: "key0", Lease: 10}}, Next: "0a"}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKeysByExpiry(ctx, "uuid", owner, []byte{0x01, 0x02}, uint64(5)).Return(page)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"keysbyexpiry", "uuid", owner.String(), "5"}, abci.RequestQuery{Data: []byte("0102")})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeysByExpiry{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, page, jsonResult)
+
+	// without an owner every owner's keys are listed
+	mockKeeper.EXPECT().GetKeysByExpiry(ctx, "uuid", nil, []byte{}, uint64(5)).Return(types.QueryResultKeysByExpiry{UUID: "uuid"})
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keysbyexpiry", "uuid", "", "5"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keysbyexpiry", "uuid", "owner", "5"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keysbyexpiry", "uuid", owner.String(), "0"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keysbyexpiry", "uuid", owner.String(), "5"}, abci.RequestQuery{Data: []byte("xyz")})
+	assert.NotNil(t, err)
+}
+
+func Test_queryMatch(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	page := types.QueryResultMatch{UUID: "uuid", Pattern: "sensor:*:temp", Keys: []string{"sensor:1:temp"}, Next: "sensor:2:temp"}
+
+	pattern, err := types.CompileKeyPattern("sensor:*:temp")
+	assert.Nil(t, err)
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKVStore(ctx).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().MatchKeys(ctx, nil, "uuid", owner, pattern, "sensor:0").Return(page)
+
+	data := cdc.MustMarshalJSON(types.QueryMatchParams{Pattern: "sensor:*:temp", Start: "sensor:0"})
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"match", "uuid", owner.String()}, abci.RequestQuery{Data: data})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultMatch{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, page, jsonResult)
+
+	// without an owner every owner's keys are matched
+	data = cdc.MustMarshalJSON(types.QueryMatchParams{Pattern: "sensor:*:temp"})
+	mockKeeper.EXPECT().MatchKeys(ctx, nil, "uuid", nil, pattern, "").Return(types.QueryResultMatch{UUID: "uuid"})
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"match", "uuid", ""}, abci.RequestQuery{Data: data})
+	assert.Nil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"match", "uuid", "owner"}, abci.RequestQuery{Data: data})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"match", "uuid", ""}, abci.RequestQuery{Data: []byte("xyz")})
+	assert.NotNil(t, err)
+
+	data = cdc.MustMarshalJSON(types.QueryMatchParams{Pattern: "sensor:[1"})
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"match", "uuid", ""}, abci.RequestQuery{Data: data})
+	assert.Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "pattern has an unterminated [").Error(), err.Error())
+}
+
+func Test_queryAuditLog(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+
+	page := types.QueryResultAuditLog{UUID: "uuid", Entries: []types.AuditEntry{
+		{UUID: "uuid", Seq: 3, Op: types.AuditOpUpdate, Key: "key", Actor: owner, Height: 10, ValueHash: types.ValueHash([]byte("value"))},
+	}, Next: 4}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetAuditLog(ctx, "uuid", uint64(3), uint64(1)).Return(page)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"auditlog", "uuid", "3", "1"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultAuditLog{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, page, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"auditlog", "uuid", "x", "1"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"auditlog", "uuid", "1", "0"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryRentHistory(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	paid := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 15))
+
+	history := types.QueryResultRentHistory{Owner: owner, Records: []types.RentRecord{
+		{StartHeight: 100, Charges: 2, ByteBlocks: 1500, Paid: paid, Refunded: sdk.NewCoins()},
+	}, Paid: paid, Refunded: sdk.NewCoins(), Next: 200}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetRentHistory(ctx, owner, int64(100), uint64(1)).Return(history)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"renthistory", owner.String(), "100", "1"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultRentHistory{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, int64(200), jsonResult.Next)
+	assert.Equal(t, uint64(2), jsonResult.Records[0].Charges)
+	assert.True(t, paid.IsEqual(jsonResult.Paid))
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"renthistory", "nobody", "0", "1"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"renthistory", owner.String(), "x", "1"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"renthistory", owner.String(), "0", "0"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryUUIDStats(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	stats := types.QueryResultUUIDStats{UUID: "uuid", Keys: 3, Bytes: 120, Owners: 2, EarliestExpiry: 100, LatestExpiry: 900}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetUUIDStats(ctx, "uuid").Return(stats)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"uuidstats", "uuid"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultUUIDStats{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, stats, jsonResult)
+}
+
+func Test_querySchema(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	schema := types.Schema{Owner: sdk.AccAddress("bluzelle1t0ywtmrdu12"), MaxValueSize: 1024, JSONSchema: `{"type":"object"}`}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetSchema(ctx, "uuid").Return(schema)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"schema", "uuid"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultSchema{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultSchema{UUID: "uuid", Schema: schema}, jsonResult)
+}
+
+func Test_queryCountAll(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetCountAll(ctx, owner).Return(types.QueryResultCountAll{Owner: owner, Count: 7, UUIDs: 2})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"countall", owner.String()}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultCountAll{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultCountAll{Owner: owner, Count: 7, UUIDs: 2}, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"countall", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryKeysAll(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	keys := types.QueryResultKeysAll{Owner: owner, Keys: []types.UUIDKey{{UUID: "uuid0", Key: "key0"}, {UUID: "uuid1", Key: "key0"}}}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKeysAll(ctx, owner).Return(keys)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"keysall", owner.String()}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeysAll{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, keys, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"keysall", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryEscrow(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	balance := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 250))
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetEscrow(ctx, owner).Return(balance)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"escrow", owner.String()}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultEscrow{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, types.QueryResultEscrow{Owner: owner, Balance: balance}, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"escrow", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryFind(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")})
+	mockKeeper.EXPECT().FindKeys(ctx, "uuid", "red").Return(types.QueryResultKeys{UUID: "uuid", Keys: []string{"key0", "key2"}})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"find", "uuid"}, abci.RequestQuery{Data: []byte("red")})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeys{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, []string{"key0", "key2"}, jsonResult.Keys)
+
+	// not indexed
+	mockKeeper.EXPECT().GetIndexConfig(ctx, "uuid").Return(types.IndexConfig{})
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"find", "uuid"}, abci.RequestQuery{Data: []byte("red")})
+	assert.NotNil(t, err)
+}
+
+func Test_queryFindByHash(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	hash := types.ValueHash([]byte("red"))
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "uuid").AnyTimes().Return(types.HashIndexConfig{Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")})
+	mockKeeper.EXPECT().FindKeysByHash(ctx, "uuid", hash).Return(types.QueryResultKeys{UUID: "uuid", Keys: []string{"key0", "key2"}})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"findbyhash", "uuid", hex.EncodeToString(hash)}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultKeys{}
+	json.Unmarshal(result, &jsonResult)
+
+	assert.Equal(t, "uuid", jsonResult.UUID)
+	assert.Equal(t, []string{"key0", "key2"}, jsonResult.Keys)
+
+	// not a SHA-256 hash
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"findbyhash", "uuid", "cd42404d"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"findbyhash", "uuid", "not hex"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+
+	// not indexed
+	mockKeeper.EXPECT().GetHashIndexConfig(ctx, "otheruuid").Return(types.HashIndexConfig{})
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"findbyhash", "otheruuid", hex.EncodeToString(hash)}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryGetMetadata(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(true)
+	mockKeeper.EXPECT().GetMetadata(ctx, nil, "uuid", "key").Return(types.QueryResultMetadata{
+		UUID:           "uuid",
+		Key:            "key",
+		CreatedHeight:  10,
+		ModifiedHeight: 25,
+		Size:           9,
+	})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"getmetadata", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultMetadata{}
+	cdc.MustUnmarshalJSON(result, &jsonResult)
+
+	assert.Equal(t, int64(10), jsonResult.CreatedHeight)
+	assert.Equal(t, int64(25), jsonResult.ModifiedHeight)
+	assert.Equal(t, int64(9), jsonResult.Size)
+
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"getmetadata", "uuid", "key"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryGetHash(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(true)
+	mockKeeper.EXPECT().GetHash(ctx, nil, "uuid", "key").Return(types.QueryResultHash{UUID: "uuid", Key: "key", Hash: "cd42404d"})
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"gethash", "uuid", "key"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultHash{}
+	json.Unmarshal(result, &jsonResult)
+	assert.Equal(t, "cd42404d", jsonResult.Hash)
+
+	mockKeeper.EXPECT().IsKeyPresent(ctx, nil, "uuid", "key").Return(false)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"gethash", "uuid", "key"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryAccountUsage(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	usage := types.QueryResultAccountUsage{
+		Owner:       owner,
+		Keys:        2,
+		Bytes:       30,
+		RenewalCost: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 3)),
+		UUIDs:       []types.UUIDUsage{{UUID: "uuid", Keys: 2, Bytes: 30, RenewalCost: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 3))}},
+	}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetAccountUsage(ctx, owner).Return(usage)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"accountusage", owner.String()}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultAccountUsage{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, usage, jsonResult)
+
+	_, err = NewQuerier(mockKeeper)(ctx, []string{"accountusage", "owner"}, abci.RequestQuery{})
+	assert.NotNil(t, err)
+}
+
+func Test_queryDefaultLease(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(ctx).Return(int64(5000))
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"defaultlease"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultDefaultLease{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, int64(5000), jsonResult.LeaseBlocks)
+}
+
+func Test_queryLeasePrice(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	price := types.QueryResultLeasePrice{
+		StoredBytes: 5000,
+		BasePrice:   sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 3))),
+		Multiplier:  sdk.NewDec(2),
+		LeasePrice:  sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(2, 3))),
+	}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetLeasePrice(ctx).Return(price)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"leaseprice"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultLeasePrice{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, uint64(5000), jsonResult.StoredBytes)
+	assert.True(t, price.Multiplier.Equal(jsonResult.Multiplier))
+	assert.True(t, price.LeasePrice.IsEqual(jsonResult.LeasePrice))
+}
+
+func Test_queryGCStatus(t *testing.T) {
+	ctx, cdc, mockKeeper := initTest(t)
+	status := types.QueryResultGCStatus{Height: 110, PurgedHeight: 100, Backlog: 2, LastPurge: types.GCPurge{Height: 110, Keys: 1, Bytes: 13}}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetGCStatus(ctx).Return(status)
+
+	result, err := NewQuerier(mockKeeper)(ctx, []string{"gcstatus"}, abci.RequestQuery{})
+	assert.Nil(t, err)
+
+	jsonResult := types.QueryResultGCStatus{}
+	assert.Nil(t, cdc.UnmarshalJSON(result, &jsonResult))
+	assert.Equal(t, status, jsonResult)
+}
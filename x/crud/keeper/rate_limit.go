@@ -0,0 +1,76 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// write counters are kept as prefix | window | address, so that the counters of past
+// windows are a range of their own to delete
+func makeRateLimitKey(window uint64, address sdk.AccAddress) []byte {
+	return append(append(append([]byte{}, types.RateLimitPrefix...), sdk.Uint64ToBigEndian(window)...), address...)
+}
+
+// GetWriteCount returns the crud writes counted against address in the current window.
+func (k Keeper) GetWriteCount(ctx sdk.Context, address sdk.AccAddress) uint64 {
+	bz := k.GetIndexStore(ctx).Get(makeRateLimitKey(k.GetParams(ctx).RateLimitWindowAt(ctx.BlockHeight()), address))
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// CountWrites counts writes more crud write messages from address in the current
+// window, failing without counting them if that takes it past MaxWritesPerWindow.
+func (k Keeper) CountWrites(ctx sdk.Context, address sdk.AccAddress, writes uint64) error {
+	params := k.GetParams(ctx)
+	if params.MaxWritesPerWindow == 0 {
+		return nil
+	}
+
+	key := makeRateLimitKey(params.RateLimitWindowAt(ctx.BlockHeight()), address)
+	count := uint64(0)
+	if bz := k.GetIndexStore(ctx).Get(key); bz != nil {
+		count = sdk.BigEndianToUint64(bz)
+	}
+
+	if count+writes > params.MaxWritesPerWindow {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is limited to %d crud writes per %d blocks",
+			address, params.MaxWritesPerWindow, params.RateLimitBlocks())
+	}
+
+	k.GetIndexStore(ctx).Set(key, sdk.Uint64ToBigEndian(count+writes))
+	return nil
+}
+
+// PruneRateLimits deletes the write counters of windows that have ended.
+func (k Keeper) PruneRateLimits(ctx sdk.Context) {
+	store := k.GetIndexStore(ctx)
+	end := append(append([]byte{}, types.RateLimitPrefix...), sdk.Uint64ToBigEndian(k.GetParams(ctx).RateLimitWindowAt(ctx.BlockHeight()))...)
+	iterator := store.Iterator(types.RateLimitPrefix, end)
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
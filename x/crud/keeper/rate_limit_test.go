@@ -0,0 +1,55 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_CountWrites(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+	other := sdk.AccAddress("other")
+
+	// unlimited by default
+	ctx = ctx.WithBlockHeight(10)
+	assert.Nil(t, keeper.CountWrites(ctx, owner, 1000))
+	assert.Equal(t, uint64(0), keeper.GetWriteCount(ctx, owner))
+
+	params := types.DefaultParams()
+	params.MaxWritesPerWindow = 3
+	params.RateLimitWindow = 5
+	keeper.SetParams(ctx, params)
+
+	assert.Nil(t, keeper.CountWrites(ctx, owner, 2))
+	assert.NotNil(t, keeper.CountWrites(ctx.WithBlockHeight(14), owner, 2))
+	assert.Nil(t, keeper.CountWrites(ctx.WithBlockHeight(14), owner, 1))
+	assert.NotNil(t, keeper.CountWrites(ctx, owner, 1))
+	assert.Equal(t, uint64(3), keeper.GetWriteCount(ctx, owner))
+
+	// counters are per address and per window
+	assert.Nil(t, keeper.CountWrites(ctx, other, 3))
+	assert.Nil(t, keeper.CountWrites(ctx.WithBlockHeight(15), owner, 3))
+
+	// the window 10-14 is pruned once it has ended
+	keeper.PruneRateLimits(ctx.WithBlockHeight(14))
+	assert.Equal(t, uint64(3), keeper.GetWriteCount(ctx, other))
+	keeper.PruneRateLimits(ctx.WithBlockHeight(15))
+	assert.Equal(t, uint64(0), keeper.GetWriteCount(ctx, other))
+	assert.Equal(t, uint64(3), keeper.GetWriteCount(ctx.WithBlockHeight(15), owner))
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// rent records are laid out as prefix | len(owner) | owner | epoch start height, the
+// sign bit of the height flipped so that the negative heights of a history imported
+// from an exported chain sort before the epochs of this one
+func makeRentPrefix(owner sdk.AccAddress) []byte {
+	prefix := append(append([]byte{}, types.RentPrefix...), byte(len(owner)))
+	return append(prefix, owner...)
+}
+
+const rentHeightSignBit = 1 << 63
+
+func makeRentKey(owner sdk.AccAddress, startHeight int64) []byte {
+	return append(makeRentPrefix(owner), sdk.Uint64ToBigEndian(uint64(startHeight)^rentHeightSignBit)...)
+}
+
+// recordRent adds a lease payment of paid for byteBlocks, or a refund, to owner's rent
+// record of the current epoch.
+func (k Keeper) recordRent(ctx sdk.Context, owner sdk.AccAddress, byteBlocks int64, paid sdk.Coins, refunded sdk.Coins) {
+	epoch := int64(k.GetParams(ctx).RentEpoch())
+	record := k.getRentRecord(ctx, owner, ctx.BlockHeight()-ctx.BlockHeight()%epoch)
+	if !paid.IsZero() {
+		record.Charges++
+		record.ByteBlocks += byteBlocks
+		record.Paid = record.Paid.Add(paid...)
+	}
+	record.Refunded = record.Refunded.Add(refunded...)
+	k.ImportRentRecord(ctx, owner, record)
+}
+
+func (k Keeper) getRentRecord(ctx sdk.Context, owner sdk.AccAddress, startHeight int64) types.RentRecord {
+	bz := k.GetIndexStore(ctx).Get(makeRentKey(owner, startHeight))
+	if bz == nil {
+		return types.RentRecord{StartHeight: startHeight, Paid: sdk.NewCoins(), Refunded: sdk.NewCoins()}
+	}
+
+	var record types.RentRecord
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return record
+}
+
+// ImportRentRecord stores record as owner's rent record of the epoch it starts.
+func (k Keeper) ImportRentRecord(ctx sdk.Context, owner sdk.AccAddress, record types.RentRecord) {
+	k.GetIndexStore(ctx).Set(makeRentKey(owner, record.StartHeight), k.cdc.MustMarshalBinaryBare(record))
+}
+
+// GetRentHistory returns up to limit of owner's rent records, oldest first, from the
+// epoch starting at or after start on, with what they add up to.
+func (k Keeper) GetRentHistory(ctx sdk.Context, owner sdk.AccAddress, start int64, limit uint64) types.QueryResultRentHistory {
+	prefix := makeRentPrefix(owner)
+	iterator := k.GetIndexStore(ctx).Iterator(makeRentKey(owner, start), sdk.PrefixEndBytes(prefix))
+	defer iterator.Close()
+
+	history := types.QueryResultRentHistory{Owner: owner, Records: make([]types.RentRecord, 0), Paid: sdk.NewCoins(), Refunded: sdk.NewCoins()}
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.RentRecord
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &record)
+
+		if uint64(len(history.Records)) >= limit {
+			history.Next = record.StartHeight
+			break
+		}
+		history.Records = append(history.Records, record)
+		history.Paid = history.Paid.Add(record.Paid...)
+		history.Refunded = history.Refunded.Add(record.Refunded...)
+	}
+	return history
+}
+
+// GetRentHistories returns the rent records of every owner, for export.
+func (k Keeper) GetRentHistories(ctx sdk.Context) []types.GenesisRentRecord {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.RentPrefix)
+	defer iterator.Close()
+
+	var records []types.GenesisRentRecord
+	for ; iterator.Valid(); iterator.Next() {
+		bz := iterator.Key()[len(types.RentPrefix):]
+		var record types.RentRecord
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &record)
+		records = append(records, types.GenesisRentRecord{Owner: bz[1 : 1+int(bz[0])], Record: record})
+	}
+	return records
+}
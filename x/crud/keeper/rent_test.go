@@ -0,0 +1,75 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_GetRentHistory(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	supplyKeeper := fakeSupplyKeeper{string(owner): sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	params.RentEpochBlocks = 100
+	keeper.SetParams(ctx, params)
+
+	keeper.SetValue(ctx, testStore, "uuid", "key", types.BLZValue{Value: []byte("value"), Lease: 1000, Owner: owner})
+	assert.Empty(t, keeper.GetRentHistory(ctx, owner, 0, 10).Records)
+
+	// two charges in the epoch from 0, and a refund in the one from 100
+	assert.Nil(t, keeper.ChargeLease(ctx.WithBlockHeight(10), owner, "uuid", "key", 1000))
+	assert.Nil(t, keeper.ChargeLease(ctx.WithBlockHeight(50), owner, "uuid", "key", 500))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 85)), supplyKeeper[string(owner)])
+
+	keeper.DeleteValue(ctx.WithBlockHeight(150), testStore, keeper.GetLeaseStore(ctx), "uuid", "key")
+	refund := supplyKeeper[string(owner)].Sub(sdk.NewCoins(sdk.NewInt64Coin("ubnt", 85)))
+	assert.False(t, refund.IsZero())
+
+	history := keeper.GetRentHistory(ctx, owner, 0, 10)
+	assert.Len(t, history.Records, 2)
+	assert.Equal(t, int64(0), history.Records[0].StartHeight)
+	assert.Equal(t, uint64(2), history.Records[0].Charges)
+	assert.Equal(t, int64(1500), history.Records[0].ByteBlocks)
+	assert.True(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 15)).IsEqual(history.Records[0].Paid))
+	assert.Equal(t, int64(100), history.Records[1].StartHeight)
+	assert.Equal(t, uint64(0), history.Records[1].Charges)
+	assert.True(t, refund.IsEqual(history.Records[1].Refunded))
+	assert.True(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 15)).IsEqual(history.Paid))
+	assert.True(t, refund.IsEqual(history.Refunded))
+	assert.Equal(t, int64(0), history.Next)
+
+	// paged by epoch
+	page := keeper.GetRentHistory(ctx, owner, 0, 1)
+	assert.Len(t, page.Records, 1)
+	assert.Equal(t, int64(100), page.Next)
+	page = keeper.GetRentHistory(ctx, owner, page.Next, 1)
+	assert.Equal(t, int64(100), page.Records[0].StartHeight)
+	assert.Empty(t, keeper.GetRentHistory(ctx, sdk.AccAddress("nobody"), 0, 10).Records)
+
+	// exported and imported whole
+	exported := keeper.GetRentHistories(ctx)
+	assert.Len(t, exported, 2)
+	assert.Equal(t, owner, []byte(exported[1].Owner))
+
+	other := sdk.AccAddress("otherowner")
+	keeper.ImportRentRecord(ctx, other, exported[0].Record)
+	assert.Equal(t, exported[0].Record, keeper.GetRentHistory(ctx, other, 0, 10).Records[0])
+}
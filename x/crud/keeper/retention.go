@@ -0,0 +1,191 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maxEvictionsPerBlock bounds the work EnforceRetention does in one block; UUIDs still
+// over their cap are left for the next block
+const maxEvictionsPerBlock = 1000
+
+func makeRetentionKey(UUID string) []byte {
+	return append(append([]byte{}, types.RetentionPrefix...), []byte(UUID)...)
+}
+
+// the keys a retention policy covers are indexed by age, the height its order evicts by,
+// as prefix | len(UUID) | UUID | age | key
+func makeRetentionAgePrefix(UUID string) []byte {
+	return append(append([]byte{}, types.RetentionAgePrefix...), lengthPrefixed(UUID)...)
+}
+
+func makeRetentionAgeKey(UUID string, age int64, key string) []byte {
+	return append(append(makeRetentionAgePrefix(UUID), sdk.Uint64ToBigEndian(uint64(age))...), []byte(key)...)
+}
+
+// a UUID is marked due when it may have gone over its cap, for EnforceRetention to check
+func makeRetentionDueKey(UUID string) []byte {
+	return append(append([]byte{}, types.RetentionDuePrefix...), []byte(UUID)...)
+}
+
+func (k Keeper) GetRetentionPolicy(ctx sdk.Context, UUID string) types.RetentionPolicy {
+	bz := k.GetIndexStore(ctx).Get(makeRetentionKey(UUID))
+	if bz == nil {
+		return types.RetentionPolicy{}
+	}
+
+	var policy types.RetentionPolicy
+	k.cdc.MustUnmarshalBinaryBare(bz, &policy)
+	return policy
+}
+
+// SetRetentionPolicy sets (or changes) the retention policy of UUID, indexing the keys
+// its owner already has there. Keys over the cap are evicted at the end of the block.
+func (k Keeper) SetRetentionPolicy(ctx sdk.Context, store sdk.KVStore, UUID string, policy types.RetentionPolicy) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearPrefix(indexStore, makeRetentionAgePrefix(UUID))
+	indexStore.Set(makeRetentionKey(UUID), k.cdc.MustMarshalBinaryBare(policy))
+
+	prefix := makeOwnerIndexPrefix(policy.Owner, UUID)
+	iterator := sdk.KVStorePrefixIterator(indexStore, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := string(iterator.Key()[len(prefix):])
+		value := k.getValueMeta(store, UUID, key)
+		indexStore.Set(makeRetentionAgeKey(UUID, policy.Age(value), key), []byte{})
+	}
+	indexStore.Set(makeRetentionDueKey(UUID), []byte{})
+}
+
+func (k Keeper) DeleteRetentionPolicy(ctx sdk.Context, UUID string) {
+	indexStore := k.GetIndexStore(ctx)
+	k.clearPrefix(indexStore, makeRetentionAgePrefix(UUID))
+	indexStore.Delete(makeRetentionKey(UUID))
+	indexStore.Delete(makeRetentionDueKey(UUID))
+}
+
+// GetRetentionPolicies returns the retention policy of every UUID that has one.
+func (k Keeper) GetRetentionPolicies(ctx sdk.Context) []types.GenesisRetention {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.RetentionPrefix)
+	defer iterator.Close()
+
+	var policies []types.GenesisRetention
+	for ; iterator.Valid(); iterator.Next() {
+		var policy types.RetentionPolicy
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &policy)
+		policies = append(policies, types.GenesisRetention{UUID: string(iterator.Key()[len(types.RetentionPrefix):]), Policy: policy})
+	}
+	return policies
+}
+
+// updateRetentionIndex keeps the age index of a UUID with a retention policy, marking
+// the UUID due whenever its owner gains a key there.
+func (k Keeper) updateRetentionIndex(ctx sdk.Context, UUID string, key string, oldValue *types.BLZValue, value *types.BLZValue) {
+	policy := k.GetRetentionPolicy(ctx, UUID)
+	if policy.Owner.Empty() {
+		return
+	}
+
+	indexStore := k.GetIndexStore(ctx)
+	covered := oldValue != nil && oldValue.Owner.Equals(policy.Owner)
+	if covered {
+		indexStore.Delete(makeRetentionAgeKey(UUID, policy.Age(*oldValue), key))
+	}
+	if value != nil && value.Owner.Equals(policy.Owner) {
+		indexStore.Set(makeRetentionAgeKey(UUID, policy.Age(*value), key), []byte{})
+		if !covered {
+			indexStore.Set(makeRetentionDueKey(UUID), []byte{})
+		}
+	}
+}
+
+// EnforceRetention evicts the oldest keys of the UUIDs that went over the cap of their
+// retention policy, emitting an evict event for each key.
+func (k Keeper) EnforceRetention(ctx sdk.Context) {
+	indexStore := k.GetIndexStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(indexStore, types.RetentionDuePrefix)
+
+	var due []string
+	for ; iterator.Valid(); iterator.Next() {
+		due = append(due, string(iterator.Key()[len(types.RetentionDuePrefix):]))
+	}
+	iterator.Close()
+
+	budget := maxEvictionsPerBlock
+	for _, UUID := range due {
+		if budget == 0 {
+			return
+		}
+
+		evicted, done := k.enforceRetention(ctx, UUID, budget)
+		budget -= evicted
+		if done {
+			indexStore.Delete(makeRetentionDueKey(UUID))
+		}
+	}
+}
+
+// enforceRetention evicts up to budget keys of UUID, reporting how many it evicted and
+// whether the UUID is back within its cap, or can get no closer for its frozen keys.
+func (k Keeper) enforceRetention(ctx sdk.Context, UUID string, budget int) (int, bool) {
+	policy := k.GetRetentionPolicy(ctx, UUID)
+	indexStore := k.GetIndexStore(ctx)
+	count := k.getCounter(indexStore, policy.Owner, UUID)
+	if count <= policy.MaxKeys {
+		return 0, true
+	}
+
+	excess := count - policy.MaxKeys
+	prefix := makeRetentionAgePrefix(UUID)
+	iterator := sdk.KVStorePrefixIterator(indexStore, prefix)
+
+	var keys []string
+	for ; iterator.Valid() && uint64(len(keys)) < excess && len(keys) < budget; iterator.Next() {
+		key := string(iterator.Key()[len(prefix)+8:])
+		if !k.IsFrozen(ctx, policy.Owner, UUID, key) {
+			keys = append(keys, key)
+		}
+	}
+	exhausted := !iterator.Valid()
+	iterator.Close()
+
+	store, leaseStore := k.GetKVStore(ctx), k.GetLeaseStore(ctx)
+	for _, key := range keys {
+		k.evict(ctx, store, leaseStore, UUID, key)
+	}
+	return len(keys), uint64(len(keys)) == excess || exhausted
+}
+
+// evict deletes key like DeleteValue, refunding the rest of its lease to its owner, but
+// records the deletion as an eviction.
+func (k Keeper) evict(ctx sdk.Context, store sdk.KVStore, leaseStore sdk.KVStore, UUID string, key string) {
+	metaKey := []byte(MakeMetaKey(UUID, key))
+	value := k.unmarshalValue(store.Get(metaKey))
+
+	k.updateIndexes(ctx, UUID, key, &value, nil)
+	k.recordAudit(ctx, UUID, key, types.AuditOpEvict, nil, nil)
+	k.DeleteLease(leaseStore, UUID, key, value.Height, value.Lease)
+	store.Delete(metaKey)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeEvict,
+		sdk.NewAttribute(types.AttributeKeyUUID, UUID),
+		sdk.NewAttribute(types.AttributeKeyKey, key),
+		sdk.NewAttribute(types.AttributeKeyOwner, value.Owner.String()),
+	))
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_EnforceRetentionFIFO(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	other := []byte("bluzelle1nnpyp9wr6law2u5jwa23t0ywtmrduldf6h4wqr")
+	leaseStore := keeper.GetLeaseStore(ctx)
+
+	keeper.SetValue(ctx.WithBlockHeight(1), testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner, Height: 1, Lease: 100})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key2", 1, 100)
+	keeper.SetValue(ctx.WithBlockHeight(2), testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(3), testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(1), testStore, "uuid", "other", types.BLZValue{Value: []byte("value"), Owner: other})
+
+	// the keys already there are indexed when the policy is set
+	keeper.SetRetentionPolicy(ctx, testStore, "uuid", types.RetentionPolicy{Owner: owner, MaxKeys: 2, Order: types.RetentionOrderFIFO})
+	assert.Equal(t, []types.GenesisRetention{{UUID: "uuid", Policy: types.RetentionPolicy{Owner: owner, MaxKeys: 2, Order: types.RetentionOrderFIFO}}},
+		keeper.GetRetentionPolicies(ctx))
+
+	// changing a key does not make it any younger
+	keeper.SetValue(ctx.WithBlockHeight(4), testStore, "uuid", "key2", types.BLZValue{Value: []byte("changed"), Owner: owner, Height: 1, Lease: 100})
+
+	evictCtx := ctx.WithBlockHeight(5).WithEventManager(sdk.NewEventManager())
+	keeper.EnforceRetention(evictCtx)
+
+	// the keys of other owners are left alone
+	assert.Equal(t, []string{"key0", "key1", "other"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(101, "uuid", "key2"))))
+
+	events := evictCtx.EventManager().Events()
+	assert.Equal(t, sdk.NewEvent(types.EventTypeEvict,
+		sdk.NewAttribute(types.AttributeKeyUUID, "uuid"),
+		sdk.NewAttribute(types.AttributeKeyKey, "key2"),
+		sdk.NewAttribute(types.AttributeKeyOwner, sdk.AccAddress(owner).String()),
+	), events[len(events)-1])
+
+	// a new key evicts the oldest at the end of the block
+	keeper.SetValue(ctx.WithBlockHeight(6), testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.EnforceRetention(ctx.WithBlockHeight(6))
+	assert.Equal(t, []string{"key0", "key3", "other"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+
+	// without the policy nothing is evicted
+	keeper.DeleteRetentionPolicy(ctx, "uuid")
+	assert.Empty(t, keeper.GetRetentionPolicies(ctx))
+	keeper.SetValue(ctx.WithBlockHeight(7), testStore, "uuid", "key4", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.EnforceRetention(ctx.WithBlockHeight(7))
+	assert.Equal(t, []string{"key0", "key3", "key4", "other"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+}
+
+func TestKeeper_EnforceRetentionLRU(t *testing.T) {
+	ctx, testStore, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+	keeper.SetRetentionPolicy(ctx, testStore, "uuid", types.RetentionPolicy{Owner: owner, MaxKeys: 1, Order: types.RetentionOrderLRU})
+	keeper.SetAuditConfig(ctx, "uuid", types.AuditConfig{Owner: owner})
+
+	keeper.SetValue(ctx.WithBlockHeight(1), testStore, "uuid", "key0", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(2), testStore, "uuid", "key1", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(3), testStore, "uuid", "key2", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.SetValue(ctx.WithBlockHeight(4), testStore, "uuid", "key0", types.BLZValue{Value: []byte("changed"), Owner: owner})
+
+	// frozen keys are never evicted, key2 and key0 go in the order they were last changed
+	keeper.Freeze(ctx, owner, "uuid", "key1")
+	keeper.EnforceRetention(ctx.WithBlockHeight(4))
+	assert.Equal(t, []string{"key1"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+
+	entries := keeper.GetAuditLog(ctx, "uuid", 5, 10).Entries
+	assert.Equal(t, []types.AuditEntry{
+		{UUID: "uuid", Seq: 5, Op: types.AuditOpEvict, Key: "key2", Height: 4},
+		{UUID: "uuid", Seq: 6, Op: types.AuditOpEvict, Key: "key0", Height: 4},
+	}, entries)
+
+	// the frozen key keeps the UUID over its cap, which is not retried every block
+	keeper.SetValue(ctx.WithBlockHeight(5), testStore, "uuid", "key3", types.BLZValue{Value: []byte("value"), Owner: owner})
+	keeper.Freeze(ctx, owner, "uuid", "key3")
+	keeper.EnforceRetention(ctx.WithBlockHeight(5))
+	assert.Equal(t, []string{"key1", "key3"}, keeper.GetKeys(ctx, testStore, "uuid", nil).Keys)
+	assert.False(t, keeper.GetIndexStore(ctx).Has(makeRetentionDueKey("uuid")))
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func makeSchemaKey(UUID string) []byte {
+	return append(append([]byte{}, types.SchemaPrefix...), []byte(UUID)...)
+}
+
+// GetSchema returns the schema of UUID, the zero Schema accepting any value if it has
+// none.
+func (k Keeper) GetSchema(ctx sdk.Context, UUID string) types.Schema {
+	bz := k.GetIndexStore(ctx).Get(makeSchemaKey(UUID))
+	if bz == nil {
+		return types.Schema{}
+	}
+
+	var schema types.Schema
+	k.cdc.MustUnmarshalBinaryBare(bz, &schema)
+	return schema
+}
+
+// SetSchema sets (or replaces) the schema the values written to UUID are checked
+// against. The values already there are left as they are.
+func (k Keeper) SetSchema(ctx sdk.Context, UUID string, schema types.Schema) {
+	k.GetIndexStore(ctx).Set(makeSchemaKey(UUID), k.cdc.MustMarshalBinaryBare(schema))
+}
+
+func (k Keeper) DeleteSchema(ctx sdk.Context, UUID string) {
+	k.GetIndexStore(ctx).Delete(makeSchemaKey(UUID))
+}
+
+// GetSchemas returns the schema of every UUID that has one.
+func (k Keeper) GetSchemas(ctx sdk.Context) []types.GenesisSchema {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.SchemaPrefix)
+	defer iterator.Close()
+
+	var schemas []types.GenesisSchema
+	for ; iterator.Valid(); iterator.Next() {
+		var schema types.Schema
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schema)
+		schemas = append(schemas, types.GenesisSchema{UUID: string(iterator.Key()[len(types.SchemaPrefix):]), Schema: schema})
+	}
+	return schemas
+}
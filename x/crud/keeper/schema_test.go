@@ -0,0 +1,41 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_Schema(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxKeysSize: 1024})
+
+	assert.Equal(t, types.Schema{}, keeper.GetSchema(ctx, "uuid"))
+	assert.Empty(t, keeper.GetSchemas(ctx))
+
+	schema := types.Schema{Owner: owner, MaxValueSize: 1024, ContentType: "application/json", JSONSchema: `{"type":"object"}`}
+	keeper.SetSchema(ctx, "uuid", schema)
+	keeper.SetSchema(ctx, "other", types.Schema{Owner: owner, MaxValueSize: 10})
+
+	assert.Equal(t, schema, keeper.GetSchema(ctx, "uuid"))
+	assert.Equal(t, []types.GenesisSchema{{UUID: "other", Schema: types.Schema{Owner: owner, MaxValueSize: 10}}, {UUID: "uuid", Schema: schema}},
+		keeper.GetSchemas(ctx))
+
+	keeper.DeleteSchema(ctx, "uuid")
+	assert.Equal(t, types.Schema{}, keeper.GetSchema(ctx, "uuid"))
+	assert.Equal(t, []types.GenesisSchema{{UUID: "other", Schema: types.Schema{Owner: owner, MaxValueSize: 10}}}, keeper.GetSchemas(ctx))
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func makeUploadKey(UUID string, key string) []byte {
+	return append(append([]byte{}, types.UploadPrefix...), []byte(MakeMetaKey(UUID, key))...)
+}
+
+// chunks are keyed by big endian index so that iteration returns them in upload order.
+// The key is length prefixed too, as it does not end the chunk keys.
+func makeUploadChunkPrefix(UUID string, key string) []byte {
+	prefix := append(append([]byte{}, types.UploadChunkPrefix...), lengthPrefixed(UUID)...)
+	return append(prefix, lengthPrefixed(key)...)
+}
+
+func makeUploadChunkKey(UUID string, key string, index uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, index)
+	return append(makeUploadChunkPrefix(UUID, key), bz...)
+}
+
+func (k Keeper) GetUpload(ctx sdk.Context, UUID string, key string) types.Upload {
+	bz := k.GetIndexStore(ctx).Get(makeUploadKey(UUID, key))
+	if bz == nil {
+		return types.Upload{}
+	}
+
+	var upload types.Upload
+	k.cdc.MustUnmarshalBinaryBare(bz, &upload)
+	return upload
+}
+
+// StartUpload records a pending upload, discarding any chunks of a previous one.
+func (k Keeper) StartUpload(ctx sdk.Context, UUID string, key string, upload types.Upload) {
+	k.DeleteUpload(ctx, UUID, key)
+	k.GetIndexStore(ctx).Set(makeUploadKey(UUID, key), k.cdc.MustMarshalBinaryBare(upload))
+}
+
+func (k Keeper) AddUploadChunk(ctx sdk.Context, UUID string, key string, data []byte) {
+	upload := k.GetUpload(ctx, UUID, key)
+
+	indexStore := k.GetIndexStore(ctx)
+	indexStore.Set(makeUploadChunkKey(UUID, key, upload.Chunks), data)
+
+	upload.Chunks++
+	upload.Received += int64(len(data))
+	indexStore.Set(makeUploadKey(UUID, key), k.cdc.MustMarshalBinaryBare(upload))
+}
+
+func (k Keeper) AssembleUpload(ctx sdk.Context, UUID string, key string) []byte {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), makeUploadChunkPrefix(UUID, key))
+	defer iterator.Close()
+
+	var buf bytes.Buffer
+	for ; iterator.Valid(); iterator.Next() {
+		buf.Write(iterator.Value())
+	}
+	return buf.Bytes()
+}
+
+func (k Keeper) DeleteUpload(ctx sdk.Context, UUID string, key string) {
+	indexStore := k.GetIndexStore(ctx)
+	iterator := sdk.KVStorePrefixIterator(indexStore, makeUploadChunkPrefix(UUID, key))
+
+	var chunkKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		chunkKeys = append(chunkKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for i := range chunkKeys {
+		indexStore.Delete(chunkKeys[i])
+	}
+	indexStore.Delete(makeUploadKey(UUID, key))
+}
+
+// GetUploads returns the pending uploads with their chunks, for export.
+func (k Keeper) GetUploads(ctx sdk.Context) []types.GenesisUpload {
+	iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), types.UploadPrefix)
+	defer iterator.Close()
+
+	var uploads []types.GenesisUpload
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()[len(types.UploadPrefix):]))
+		record := types.GenesisUpload{UUID: UUID, Key: key}
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &record.Upload)
+
+		chunks := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), makeUploadChunkPrefix(UUID, key))
+		for ; chunks.Valid(); chunks.Next() {
+			record.Chunks = append(record.Chunks, chunks.Value())
+		}
+		chunks.Close()
+
+		uploads = append(uploads, record)
+	}
+	return uploads
+}
+
+// ImportUpload restores an exported pending upload with its chunks.
+func (k Keeper) ImportUpload(ctx sdk.Context, record types.GenesisUpload) {
+	k.StartUpload(ctx, record.UUID, record.Key, record.Upload)
+
+	indexStore := k.GetIndexStore(ctx)
+	for i, chunk := range record.Chunks {
+		indexStore.Set(makeUploadChunkKey(record.UUID, record.Key, uint64(i)), chunk)
+	}
+}
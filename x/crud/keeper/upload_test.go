@@ -0,0 +1,56 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_Upload(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{})
+
+	assert.True(t, keeper.GetUpload(ctx, "uuid", "key").Owner.Empty())
+
+	upload := types.Upload{Owner: owner, Size: 1000, Hash: types.ValueHash([]byte("unused")), Lease: 100, Height: 5}
+	keeper.StartUpload(ctx, "uuid", "key", upload)
+	assert.Equal(t, upload, keeper.GetUpload(ctx, "uuid", "key"))
+
+	// more than 255 chunks, the order must survive the index rolling over a byte
+	for i := 0; i < 300; i++ {
+		keeper.AddUploadChunk(ctx, "uuid", "key", []byte{byte('a' + i%26)})
+	}
+
+	upload = keeper.GetUpload(ctx, "uuid", "key")
+	assert.Equal(t, uint64(300), upload.Chunks)
+	assert.Equal(t, int64(300), upload.Received)
+
+	var expected []byte
+	for i := 0; i < 300; i++ {
+		expected = append(expected, byte('a'+i%26))
+	}
+	assert.Equal(t, expected, keeper.AssembleUpload(ctx, "uuid", "key"))
+
+	// restarting discards the chunks received so far
+	keeper.StartUpload(ctx, "uuid", "key", types.Upload{Owner: owner, Size: 10})
+	assert.Empty(t, keeper.AssembleUpload(ctx, "uuid", "key"))
+
+	keeper.AddUploadChunk(ctx, "uuid", "key", []byte("chunk"))
+	keeper.DeleteUpload(ctx, "uuid", "key")
+	assert.True(t, keeper.GetUpload(ctx, "uuid", "key").Owner.Empty())
+	assert.Empty(t, keeper.AssembleUpload(ctx, "uuid", "key"))
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"sort"
+	"strings"
+)
+
+// Inconsistency is an entry of the lease or index store that disagrees with the stored
+// values: one that should not be there, is missing or holds the wrong count.
+type Inconsistency struct {
+	Store   string
+	Key     []byte
+	Problem string
+
+	// what the entry should hold, nil when it should not exist
+	expected []byte
+}
+
+func (i Inconsistency) String() string {
+	if i.Store == types.LeaseKey {
+		return fmt.Sprintf("%s %q: %s", i.Store, i.Key, i.Problem)
+	}
+	return fmt.Sprintf("%s %X: %s", i.Store, i.Key, i.Problem)
+}
+
+// Verify checks the lease store, the index entries and the counters against the stored
+// values, as the leases, indexes and counters invariants do, and returns every
+// disagreement. With repair the disagreeing entries are also rewritten to match the
+// values, which is deterministic and so safe to run in a block.
+func (k Keeper) Verify(ctx sdk.Context, repair bool) []Inconsistency {
+	var found []Inconsistency
+	found = append(found, k.verifyLeases(ctx)...)
+	found = append(found, k.verifyIndexEntries(ctx)...)
+	found = append(found, k.verifyCounters(ctx)...)
+
+	if repair {
+		stores := map[string]sdk.KVStore{types.LeaseKey: k.GetLeaseStore(ctx), types.IndexKey: k.GetIndexStore(ctx)}
+		for _, inconsistency := range found {
+			if inconsistency.expected == nil {
+				stores[inconsistency.Store].Delete(inconsistency.Key)
+			} else {
+				stores[inconsistency.Store].Set(inconsistency.Key, inconsistency.expected)
+			}
+		}
+	}
+	return found
+}
+
+// verifyLeases expects exactly one lease for every key, at the height its lease runs
+// out, taking a lease of 0 as the default lease as SetLease does.
+func (k Keeper) verifyLeases(ctx sdk.Context) []Inconsistency {
+	store := k.GetKVStore(ctx)
+	expected := make(map[string]bool)
+
+	iterator := k.GetValuesIterator(ctx, store)
+	for ; iterator.Valid(); iterator.Next() {
+		UUID, key := SplitMetaKey(string(iterator.Key()))
+		value := k.unmarshalValue(iterator.Value())
+		lease := value.Lease
+		if lease == 0 {
+			lease = k.GetDefaultLeaseBlocks(ctx)
+		}
+		expected[MakeLeaseKey(value.Height+lease, UUID, key)] = true
+	}
+	iterator.Close()
+
+	var found []Inconsistency
+	iterator = sdk.KVStorePrefixIterator(k.GetLeaseStore(ctx), []byte{})
+	for ; iterator.Valid(); iterator.Next() {
+		leaseKey := string(iterator.Key())
+		if expected[leaseKey] {
+			delete(expected, leaseKey)
+			continue
+		}
+
+		problem := "lease is not at the expiry of its key"
+		if !store.Has([]byte(leaseKey[strings.Index(leaseKey, "\x00")+1:])) {
+			problem = "lease has no key"
+		}
+		found = append(found, Inconsistency{Store: types.LeaseKey, Key: iterator.Key(), Problem: problem})
+	}
+	iterator.Close()
+
+	for _, leaseKey := range sortedKeys(expected) {
+		found = append(found, Inconsistency{Store: types.LeaseKey, Key: []byte(leaseKey), Problem: "key has no lease", expected: []byte{}})
+	}
+	return found
+}
+
+func (k Keeper) verifyIndexEntries(ctx sdk.Context) []Inconsistency {
+	expected := k.expectedIndexEntries(ctx)
+
+	var found []Inconsistency
+	for _, prefix := range indexEntryPrefixes {
+		iterator := sdk.KVStorePrefixIterator(k.GetIndexStore(ctx), prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			if !expected[string(iterator.Key())] {
+				found = append(found, Inconsistency{Store: types.IndexKey, Key: iterator.Key(), Problem: "unexpected index entry"})
+			}
+			delete(expected, string(iterator.Key()))
+		}
+		iterator.Close()
+	}
+
+	for _, key := range sortedKeys(expected) {
+		found = append(found, Inconsistency{Store: types.IndexKey, Key: []byte(key), Problem: "index entry is missing", expected: []byte{}})
+	}
+	return found
+}
+
+func (k Keeper) verifyCounters(ctx sdk.Context) []Inconsistency {
+	indexStore := k.GetIndexStore(ctx)
+	expected, storedBytes := k.expectedCounters(ctx)
+	if storedBytes > 0 {
+		expected[string(types.StoredBytesKey)] = storedBytes
+	}
+
+	var found []Inconsistency
+	check := func(key []byte, count uint64) {
+		switch want, ok := expected[string(key)]; {
+		case !ok:
+			found = append(found, Inconsistency{Store: types.IndexKey, Key: key, Problem: fmt.Sprintf("counter is %d, expected none", count)})
+		case want != count:
+			found = append(found, Inconsistency{Store: types.IndexKey, Key: key, Problem: fmt.Sprintf("counter is %d, expected %d", count, want), expected: sdk.Uint64ToBigEndian(want)})
+		}
+		delete(expected, string(key))
+	}
+
+	if bz := indexStore.Get(types.StoredBytesKey); bz != nil {
+		check(types.StoredBytesKey, binary.BigEndian.Uint64(bz))
+	}
+	for _, prefix := range counterPrefixes {
+		iterator := sdk.KVStorePrefixIterator(indexStore, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			check(iterator.Key(), binary.BigEndian.Uint64(iterator.Value()))
+		}
+		iterator.Close()
+	}
+
+	keys := make(map[string]bool, len(expected))
+	for key := range expected {
+		keys[key] = true
+	}
+	for _, key := range sortedKeys(keys) {
+		found = append(found, Inconsistency{Store: types.IndexKey, Key: []byte(key), Problem: fmt.Sprintf("counter is missing, expected %d", expected[key]), expected: sdk.Uint64ToBigEndian(expected[key])})
+	}
+	return found
+}
+
+// sortedKeys returns the keys of set in byte order, so that what is reported and
+// repaired does not depend on map order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeeper_Verify(t *testing.T) {
+	ctx, _, owner, cdc := initKeeperTest()
+	keeper := NewKeeper(nil, testStoreKey, testLeaseKey, testIndexKey, newTestSubspace(), cdc, MaxKeeperSizes{MaxDefaultLeaseBlocks: DefaultLeaseBlockHeight})
+	store := keeper.GetKVStore(ctx)
+	leaseStore := keeper.GetLeaseStore(ctx)
+	indexStore := keeper.GetIndexStore(ctx)
+
+	for _, key := range []string{"key0", "key1", "key2"} {
+		keeper.SetValue(ctx, store, "uuid", key, types.BLZValue{Value: []byte("value"), Height: 10, Lease: 100, Owner: owner})
+		keeper.SetLease(ctx, leaseStore, "uuid", key, 10, 100)
+	}
+	keeper.SetValue(ctx, store, "uuid", "key3", types.BLZValue{Value: []byte("value"), Height: 10, Owner: owner})
+	keeper.SetLease(ctx, leaseStore, "uuid", "key3", 10, 0)
+
+	assert.Empty(t, keeper.Verify(ctx, false))
+
+	// an orphaned lease, a lease at the wrong height, a missing index entry and a
+	// counter that drifted
+	keeper.SetLease(ctx, leaseStore, "uuid", "gone", 10, 100)
+	keeper.DeleteLease(leaseStore, "uuid", "key1", 10, 100)
+	keeper.SetLease(ctx, leaseStore, "uuid", "key1", 10, 50)
+	indexStore.Delete(makeOwnerIndexKey(owner, "uuid", "key2"))
+	keeper.addToStoredBytes(indexStore, 3)
+
+	found := keeper.Verify(ctx, false)
+	var problems []string
+	for _, inconsistency := range found {
+		problems = append(problems, inconsistency.Problem)
+	}
+	assert.ElementsMatch(t, []string{
+		"lease has no key",
+		"lease is not at the expiry of its key",
+		"key has no lease",
+		"index entry is missing",
+		"counter is 23, expected 20",
+	}, problems)
+
+	// nothing is written without repair
+	assert.Len(t, keeper.Verify(ctx, false), len(found))
+
+	assert.Len(t, keeper.Verify(ctx, true), len(found))
+	assert.Empty(t, keeper.Verify(ctx, false))
+	assert.True(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "key1"))))
+	assert.False(t, leaseStore.Has([]byte(MakeLeaseKey(110, "uuid", "gone"))))
+	assert.Equal(t, uint64(20), keeper.GetStoredBytes(ctx))
+
+	_, broken := LeasesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+	_, broken = IndexesInvariant(keeper)(ctx)
+	assert.False(t, broken)
+	_, broken = CountersInvariant(keeper)(ctx)
+	assert.False(t, broken)
+}
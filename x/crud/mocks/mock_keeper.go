@@ -1,11 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/bluzelle/curium/x/crud/internal/keeper (interfaces: IKeeper)
+// Source: github.com/bluzelle/curium/x/crud/keeper (interfaces: IKeeper)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	types "github.com/bluzelle/curium/x/crud/internal/types"
+	types "github.com/bluzelle/curium/x/crud/types"
 	types0 "github.com/cosmos/cosmos-sdk/store/types"
 	types1 "github.com/cosmos/cosmos-sdk/types"
 	gomock "github.com/golang/mock/gomock"
@@ -37,166 +37,910 @@ func (m *MockIKeeper) EXPECT() *MockIKeeperMockRecorder {
 	return m.recorder
 }
 
+// AddUploadChunk mocks base method
+func (m *MockIKeeper) AddUploadChunk(arg0 types1.Context, arg1, arg2 string, arg3 []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUploadChunk", arg0, arg1, arg2, arg3)
+}
+
+// AddUploadChunk indicates an expected call of AddUploadChunk
+func (mr *MockIKeeperMockRecorder) AddUploadChunk(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUploadChunk", reflect.TypeOf((*MockIKeeper)(nil).AddUploadChunk), arg0, arg1, arg2, arg3)
+}
+
+// AssembleUpload mocks base method
+func (m *MockIKeeper) AssembleUpload(arg0 types1.Context, arg1, arg2 string) []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssembleUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// AssembleUpload indicates an expected call of AssembleUpload
+func (mr *MockIKeeperMockRecorder) AssembleUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssembleUpload", reflect.TypeOf((*MockIKeeper)(nil).AssembleUpload), arg0, arg1, arg2)
+}
+
+// ChargeLease mocks base method
+func (m *MockIKeeper) ChargeLease(arg0 types1.Context, arg1 types1.AccAddress, arg2, arg3 string, arg4 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeLease", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChargeLease indicates an expected call of ChargeLease
+func (mr *MockIKeeperMockRecorder) ChargeLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeLease", reflect.TypeOf((*MockIKeeper)(nil).ChargeLease), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CopyAll mocks base method
+func (m *MockIKeeper) CopyAll(arg0 types1.Context, arg1, arg2 types0.KVStore, arg3, arg4 string, arg5 types1.AccAddress, arg6 int64) ([]types.KeyValue, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyAll", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]types.KeyValue)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CopyAll indicates an expected call of CopyAll
+func (mr *MockIKeeperMockRecorder) CopyAll(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyAll", reflect.TypeOf((*MockIKeeper)(nil).CopyAll), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// CountWrites mocks base method
+func (m *MockIKeeper) CountWrites(arg0 types1.Context, arg1 types1.AccAddress, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWrites", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CountWrites indicates an expected call of CountWrites
+func (mr *MockIKeeperMockRecorder) CountWrites(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWrites", reflect.TypeOf((*MockIKeeper)(nil).CountWrites), arg0, arg1, arg2)
+}
+
 // DeleteAll mocks base method
-func (m *MockIKeeper) DeleteAll(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) {
+func (m *MockIKeeper) DeleteAll(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultDeleteAll {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultDeleteAll)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll
+func (mr *MockIKeeperMockRecorder) DeleteAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockIKeeper)(nil).DeleteAll), arg0, arg1, arg2, arg3)
+}
+
+// DeleteAuditConfig mocks base method
+func (m *MockIKeeper) DeleteAuditConfig(arg0 types1.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteAuditConfig", arg0, arg1)
+}
+
+// DeleteAuditConfig indicates an expected call of DeleteAuditConfig
+func (mr *MockIKeeperMockRecorder) DeleteAuditConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditConfig", reflect.TypeOf((*MockIKeeper)(nil).DeleteAuditConfig), arg0, arg1)
+}
+
+// DeleteHashIndexConfig mocks base method
+func (m *MockIKeeper) DeleteHashIndexConfig(arg0 types1.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteHashIndexConfig", arg0, arg1)
+}
+
+// DeleteHashIndexConfig indicates an expected call of DeleteHashIndexConfig
+func (mr *MockIKeeperMockRecorder) DeleteHashIndexConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHashIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).DeleteHashIndexConfig), arg0, arg1)
+}
+
+// DeleteIndexConfig mocks base method
+func (m *MockIKeeper) DeleteIndexConfig(arg0 types1.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteIndexConfig", arg0, arg1)
+}
+
+// DeleteIndexConfig indicates an expected call of DeleteIndexConfig
+func (mr *MockIKeeperMockRecorder) DeleteIndexConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).DeleteIndexConfig), arg0, arg1)
+}
+
+// DeleteLease mocks base method
+func (m *MockIKeeper) DeleteLease(arg0 types0.KVStore, arg1, arg2 string, arg3, arg4 int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLease", arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteLease indicates an expected call of DeleteLease
+func (mr *MockIKeeperMockRecorder) DeleteLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLease", reflect.TypeOf((*MockIKeeper)(nil).DeleteLease), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteRetentionPolicy mocks base method
+func (m *MockIKeeper) DeleteRetentionPolicy(arg0 types1.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteRetentionPolicy", arg0, arg1)
+}
+
+// DeleteRetentionPolicy indicates an expected call of DeleteRetentionPolicy
+func (mr *MockIKeeperMockRecorder) DeleteRetentionPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetentionPolicy", reflect.TypeOf((*MockIKeeper)(nil).DeleteRetentionPolicy), arg0, arg1)
+}
+
+// DeleteSchema mocks base method
+func (m *MockIKeeper) DeleteSchema(arg0 sdk.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSchema", arg0, arg1)
+}
+
+// DeleteSchema indicates an expected call of DeleteSchema
+func (mr *MockIKeeperMockRecorder) DeleteSchema(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchema", reflect.TypeOf((*MockIKeeper)(nil).DeleteSchema), arg0, arg1)
+}
+
+// DeleteUpload mocks base method
+func (m *MockIKeeper) DeleteUpload(arg0 types1.Context, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUpload", arg0, arg1, arg2)
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload
+func (mr *MockIKeeperMockRecorder) DeleteUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockIKeeper)(nil).DeleteUpload), arg0, arg1, arg2)
+}
+
+// DeleteValue mocks base method
+func (m *MockIKeeper) DeleteValue(arg0 types1.Context, arg1, arg2 types0.KVStore, arg3, arg4 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteValue", arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteValue indicates an expected call of DeleteValue
+func (mr *MockIKeeperMockRecorder) DeleteValue(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockIKeeper)(nil).DeleteValue), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DepositEscrow mocks base method
+func (m *MockIKeeper) DepositEscrow(arg0 types1.Context, arg1 types1.AccAddress, arg2 types1.Coins) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositEscrow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DepositEscrow indicates an expected call of DepositEscrow
+func (mr *MockIKeeperMockRecorder) DepositEscrow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositEscrow", reflect.TypeOf((*MockIKeeper)(nil).DepositEscrow), arg0, arg1, arg2)
+}
+
+// FindKeys mocks base method
+func (m *MockIKeeper) FindKeys(arg0 types1.Context, arg1, arg2 string) types.QueryResultKeys {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindKeys", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.QueryResultKeys)
+	return ret0
+}
+
+// FindKeys indicates an expected call of FindKeys
+func (mr *MockIKeeperMockRecorder) FindKeys(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindKeys", reflect.TypeOf((*MockIKeeper)(nil).FindKeys), arg0, arg1, arg2)
+}
+
+// FindKeysByHash mocks base method
+func (m *MockIKeeper) FindKeysByHash(arg0 types1.Context, arg1 string, arg2 []byte) types.QueryResultKeys {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindKeysByHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.QueryResultKeys)
+	return ret0
+}
+
+// FindKeysByHash indicates an expected call of FindKeysByHash
+func (mr *MockIKeeperMockRecorder) FindKeysByHash(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindKeysByHash", reflect.TypeOf((*MockIKeeper)(nil).FindKeysByHash), arg0, arg1, arg2)
+}
+
+// Freeze mocks base method
+func (m *MockIKeeper) Freeze(arg0 types1.Context, arg1 types1.AccAddress, arg2, arg3 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Freeze", arg0, arg1, arg2, arg3)
+}
+
+// Freeze indicates an expected call of Freeze
+func (mr *MockIKeeperMockRecorder) Freeze(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockIKeeper)(nil).Freeze), arg0, arg1, arg2, arg3)
+}
+
+// GetAccountUsage mocks base method
+func (m *MockIKeeper) GetAccountUsage(arg0 types1.Context, arg1 types1.AccAddress) types.QueryResultAccountUsage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountUsage", arg0, arg1)
+	ret0, _ := ret[0].(types.QueryResultAccountUsage)
+	return ret0
+}
+
+// GetAccountUsage indicates an expected call of GetAccountUsage
+func (mr *MockIKeeperMockRecorder) GetAccountUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountUsage", reflect.TypeOf((*MockIKeeper)(nil).GetAccountUsage), arg0, arg1)
+}
+
+// ExpireNow mocks base method
+func (m *MockIKeeper) ExpireNow(arg0 types1.Context, arg1 types0.KVStore, arg2 types0.KVStore, arg3 string, arg4 string) (int64, types1.Coins) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireNow", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(types1.Coins)
+	return ret0, ret1
+}
+
+// ExpireNow indicates an expected call of ExpireNow
+func (mr *MockIKeeperMockRecorder) ExpireNow(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireNow", reflect.TypeOf((*MockIKeeper)(nil).ExpireNow), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetAuditConfig mocks base method
+func (m *MockIKeeper) GetAuditConfig(arg0 types1.Context, arg1 string) types.AuditConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditConfig", arg0, arg1)
+	ret0, _ := ret[0].(types.AuditConfig)
+	return ret0
+}
+
+// GetAuditConfig indicates an expected call of GetAuditConfig
+func (mr *MockIKeeperMockRecorder) GetAuditConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditConfig", reflect.TypeOf((*MockIKeeper)(nil).GetAuditConfig), arg0, arg1)
+}
+
+// GetAuditConfigs mocks base method
+func (m *MockIKeeper) GetAuditConfigs(arg0 types1.Context) []types.GenesisAudit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditConfigs", arg0)
+	ret0, _ := ret[0].([]types.GenesisAudit)
+	return ret0
+}
+
+// GetAuditConfigs indicates an expected call of GetAuditConfigs
+func (mr *MockIKeeperMockRecorder) GetAuditConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditConfigs", reflect.TypeOf((*MockIKeeper)(nil).GetAuditConfigs), arg0)
+}
+
+// GetAuditLog mocks base method
+func (m *MockIKeeper) GetAuditLog(arg0 types1.Context, arg1 string, arg2, arg3 uint64) types.QueryResultAuditLog {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultAuditLog)
+	return ret0
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog
+func (mr *MockIKeeperMockRecorder) GetAuditLog(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockIKeeper)(nil).GetAuditLog), arg0, arg1, arg2, arg3)
+}
+
+// GetAuditLogs mocks base method
+func (m *MockIKeeper) GetAuditLogs(arg0 types1.Context) []types.AuditEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", arg0)
+	ret0, _ := ret[0].([]types.AuditEntry)
+	return ret0
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs
+func (mr *MockIKeeperMockRecorder) GetAuditLogs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIKeeper)(nil).GetAuditLogs), arg0)
+}
+
+// GetAutoRenewals mocks base method
+func (m *MockIKeeper) GetAutoRenewals(arg0 types1.Context) []types.GenesisAutoRenew {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoRenewals", arg0)
+	ret0, _ := ret[0].([]types.GenesisAutoRenew)
+	return ret0
+}
+
+// GetAutoRenewals indicates an expected call of GetAutoRenewals
+func (mr *MockIKeeperMockRecorder) GetAutoRenewals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoRenewals", reflect.TypeOf((*MockIKeeper)(nil).GetAutoRenewals), arg0)
+}
+
+// GetBeneficiaries mocks base method
+func (m *MockIKeeper) GetBeneficiaries(arg0 types1.Context) []types.GenesisBeneficiary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiaries", arg0)
+	ret0, _ := ret[0].([]types.GenesisBeneficiary)
+	return ret0
+}
+
+// GetBeneficiaries indicates an expected call of GetBeneficiaries
+func (mr *MockIKeeperMockRecorder) GetBeneficiaries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaries", reflect.TypeOf((*MockIKeeper)(nil).GetBeneficiaries), arg0)
+}
+
+// GetBeneficiary mocks base method
+func (m *MockIKeeper) GetBeneficiary(arg0 types1.Context, arg1, arg2 string) types.Beneficiary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.Beneficiary)
+	return ret0
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary
+func (mr *MockIKeeperMockRecorder) GetBeneficiary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockIKeeper)(nil).GetBeneficiary), arg0, arg1, arg2)
+}
+
+// GetCdc mocks base method
+func (m *MockIKeeper) GetCdc() *amino.Codec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCdc")
+	ret0, _ := ret[0].(*amino.Codec)
+	return ret0
+}
+
+// GetCdc indicates an expected call of GetCdc
+func (mr *MockIKeeperMockRecorder) GetCdc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCdc", reflect.TypeOf((*MockIKeeper)(nil).GetCdc))
+}
+
+// GetCount mocks base method
+func (m *MockIKeeper) GetCount(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultCount {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultCount)
+	return ret0
+}
+
+// GetCount indicates an expected call of GetCount
+func (mr *MockIKeeperMockRecorder) GetCount(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIKeeper)(nil).GetCount), arg0, arg1, arg2, arg3)
+}
+
+// GetCountAll mocks base method
+func (m *MockIKeeper) GetCountAll(arg0 types1.Context, arg1 types1.AccAddress) types.QueryResultCountAll {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountAll", arg0, arg1)
+	ret0, _ := ret[0].(types.QueryResultCountAll)
+	return ret0
+}
+
+// GetCountAll indicates an expected call of GetCountAll
+func (mr *MockIKeeperMockRecorder) GetCountAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountAll", reflect.TypeOf((*MockIKeeper)(nil).GetCountAll), arg0, arg1)
+}
+
+// GetDefaultLeaseBlocks mocks base method
+func (m *MockIKeeper) GetDefaultLeaseBlocks(arg0 types1.Context) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultLeaseBlocks", arg0)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetDefaultLeaseBlocks indicates an expected call of GetDefaultLeaseBlocks
+func (mr *MockIKeeperMockRecorder) GetDefaultLeaseBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultLeaseBlocks", reflect.TypeOf((*MockIKeeper)(nil).GetDefaultLeaseBlocks), arg0)
+}
+
+// GetEscrow mocks base method
+func (m *MockIKeeper) GetEscrow(arg0 types1.Context, arg1 types1.AccAddress) types1.Coins {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", arg0, arg1)
+	ret0, _ := ret[0].(types1.Coins)
+	return ret0
+}
+
+// GetEscrow indicates an expected call of GetEscrow
+func (mr *MockIKeeperMockRecorder) GetEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockIKeeper)(nil).GetEscrow), arg0, arg1)
+}
+
+// GetEscrows mocks base method
+func (m *MockIKeeper) GetEscrows(arg0 types1.Context) []types.GenesisEscrow {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrows", arg0)
+	ret0, _ := ret[0].([]types.GenesisEscrow)
+	return ret0
+}
+
+// GetEscrows indicates an expected call of GetEscrows
+func (mr *MockIKeeperMockRecorder) GetEscrows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrows", reflect.TypeOf((*MockIKeeper)(nil).GetEscrows), arg0)
+}
+
+// GetFrozen mocks base method
+func (m *MockIKeeper) GetFrozen(arg0 types1.Context) []types.GenesisFreeze {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrozen", arg0)
+	ret0, _ := ret[0].([]types.GenesisFreeze)
+	return ret0
+}
+
+// GetFrozen indicates an expected call of GetFrozen
+func (mr *MockIKeeperMockRecorder) GetFrozen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrozen", reflect.TypeOf((*MockIKeeper)(nil).GetFrozen), arg0)
+}
+
+// GetGCStatus mocks base method
+func (m *MockIKeeper) GetGCStatus(arg0 types1.Context) types.QueryResultGCStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGCStatus", arg0)
+	ret0, _ := ret[0].(types.QueryResultGCStatus)
+	return ret0
+}
+
+// GetGCStatus indicates an expected call of GetGCStatus
+func (mr *MockIKeeperMockRecorder) GetGCStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCStatus", reflect.TypeOf((*MockIKeeper)(nil).GetGCStatus), arg0)
+}
+
+// GetHash mocks base method
+func (m *MockIKeeper) GetHash(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) types.QueryResultHash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHash", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultHash)
+	return ret0
+}
+
+// GetHash indicates an expected call of GetHash
+func (mr *MockIKeeperMockRecorder) GetHash(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHash", reflect.TypeOf((*MockIKeeper)(nil).GetHash), arg0, arg1, arg2, arg3)
+}
+
+// GetHashIndexConfig mocks base method
+func (m *MockIKeeper) GetHashIndexConfig(arg0 types1.Context, arg1 string) types.HashIndexConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashIndexConfig", arg0, arg1)
+	ret0, _ := ret[0].(types.HashIndexConfig)
+	return ret0
+}
+
+// GetHashIndexConfig indicates an expected call of GetHashIndexConfig
+func (mr *MockIKeeperMockRecorder) GetHashIndexConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).GetHashIndexConfig), arg0, arg1)
+}
+
+// GetHashIndexConfigs mocks base method
+func (m *MockIKeeper) GetHashIndexConfigs(arg0 types1.Context) []types.GenesisHashIndex {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashIndexConfigs", arg0)
+	ret0, _ := ret[0].([]types.GenesisHashIndex)
+	return ret0
+}
+
+// GetHashIndexConfigs indicates an expected call of GetHashIndexConfigs
+func (mr *MockIKeeperMockRecorder) GetHashIndexConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashIndexConfigs", reflect.TypeOf((*MockIKeeper)(nil).GetHashIndexConfigs), arg0)
+}
+
+// GetIndexConfig mocks base method
+func (m *MockIKeeper) GetIndexConfig(arg0 types1.Context, arg1 string) types.IndexConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexConfig", arg0, arg1)
+	ret0, _ := ret[0].(types.IndexConfig)
+	return ret0
+}
+
+// GetIndexConfig indicates an expected call of GetIndexConfig
+func (mr *MockIKeeperMockRecorder) GetIndexConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).GetIndexConfig), arg0, arg1)
+}
+
+// GetIndexConfigs mocks base method
+func (m *MockIKeeper) GetIndexConfigs(arg0 types1.Context) []types.GenesisIndex {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexConfigs", arg0)
+	ret0, _ := ret[0].([]types.GenesisIndex)
+	return ret0
+}
+
+// GetIndexConfigs indicates an expected call of GetIndexConfigs
+func (mr *MockIKeeperMockRecorder) GetIndexConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexConfigs", reflect.TypeOf((*MockIKeeper)(nil).GetIndexConfigs), arg0)
+}
+
+// GetKVStore mocks base method
+func (m *MockIKeeper) GetKVStore(arg0 types1.Context) types0.KVStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKVStore", arg0)
+	ret0, _ := ret[0].(types0.KVStore)
+	return ret0
+}
+
+// GetKVStore indicates an expected call of GetKVStore
+func (mr *MockIKeeperMockRecorder) GetKVStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVStore", reflect.TypeOf((*MockIKeeper)(nil).GetKVStore), arg0)
+}
+
+// GetKeyValuesFrom mocks base method
+func (m *MockIKeeper) GetKeyValuesFrom(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 string) types.QueryResultKeyValues {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyValuesFrom", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(types.QueryResultKeyValues)
+	return ret0
+}
+
+// GetKeyValuesFrom indicates an expected call of GetKeyValuesFrom
+func (mr *MockIKeeperMockRecorder) GetKeyValuesFrom(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyValuesFrom", reflect.TypeOf((*MockIKeeper)(nil).GetKeyValuesFrom), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetKeyValues mocks base method
+func (m *MockIKeeper) GetKeyValues(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultKeyValues {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyValues", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultKeyValues)
+	return ret0
+}
+
+// GetKeyValues indicates an expected call of GetKeyValues
+func (mr *MockIKeeperMockRecorder) GetKeyValues(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyValues", reflect.TypeOf((*MockIKeeper)(nil).GetKeyValues), arg0, arg1, arg2, arg3)
+}
+
+// GetKeyValuesPage mocks base method
+func (m *MockIKeeper) GetKeyValuesPage(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 string) types.QueryResultKeyValuesPage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyValuesPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(types.QueryResultKeyValuesPage)
+	return ret0
+}
+
+// GetKeyValuesPage indicates an expected call of GetKeyValuesPage
+func (mr *MockIKeeperMockRecorder) GetKeyValuesPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyValuesPage", reflect.TypeOf((*MockIKeeper)(nil).GetKeyValuesPage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetKeysFrom mocks base method
+func (m *MockIKeeper) GetKeysFrom(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 string) types.QueryResultKeys {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysFrom", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(types.QueryResultKeys)
+	return ret0
+}
+
+// GetKeysFrom indicates an expected call of GetKeysFrom
+func (mr *MockIKeeperMockRecorder) GetKeysFrom(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysFrom", reflect.TypeOf((*MockIKeeper)(nil).GetKeysFrom), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetKeys mocks base method
+func (m *MockIKeeper) GetKeys(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultKeys {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultKeys)
+	return ret0
+}
+
+// GetKeys indicates an expected call of GetKeys
+func (mr *MockIKeeperMockRecorder) GetKeys(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockIKeeper)(nil).GetKeys), arg0, arg1, arg2, arg3)
+}
+
+// GetKeysAll mocks base method
+func (m *MockIKeeper) GetKeysAll(arg0 types1.Context, arg1 types1.AccAddress) types.QueryResultKeysAll {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysAll", arg0, arg1)
+	ret0, _ := ret[0].(types.QueryResultKeysAll)
+	return ret0
+}
+
+// GetKeysAll indicates an expected call of GetKeysAll
+func (mr *MockIKeeperMockRecorder) GetKeysAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysAll", reflect.TypeOf((*MockIKeeper)(nil).GetKeysAll), arg0, arg1)
+}
+
+// GetKeysByExpiry mocks base method
+func (m *MockIKeeper) GetKeysByExpiry(arg0 types1.Context, arg1 string, arg2 types1.AccAddress, arg3 []byte, arg4 uint64) types.QueryResultKeysByExpiry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysByExpiry", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(types.QueryResultKeysByExpiry)
+	return ret0
+}
+
+// GetKeysByExpiry indicates an expected call of GetKeysByExpiry
+func (mr *MockIKeeperMockRecorder) GetKeysByExpiry(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysByExpiry", reflect.TypeOf((*MockIKeeper)(nil).GetKeysByExpiry), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetLeaseDeposit mocks base method
+func (m *MockIKeeper) GetLeaseDeposit(arg0 types1.Context, arg1, arg2 string) types.LeaseDeposit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaseDeposit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.LeaseDeposit)
+	return ret0
+}
+
+// GetLeaseDeposit indicates an expected call of GetLeaseDeposit
+func (mr *MockIKeeperMockRecorder) GetLeaseDeposit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaseDeposit", reflect.TypeOf((*MockIKeeper)(nil).GetLeaseDeposit), arg0, arg1, arg2)
+}
+
+// GetLeaseDeposits mocks base method
+func (m *MockIKeeper) GetLeaseDeposits(arg0 types1.Context) []types.GenesisLeaseDeposit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaseDeposits", arg0)
+	ret0, _ := ret[0].([]types.GenesisLeaseDeposit)
+	return ret0
+}
+
+// GetLeaseDeposits indicates an expected call of GetLeaseDeposits
+func (mr *MockIKeeperMockRecorder) GetLeaseDeposits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaseDeposits", reflect.TypeOf((*MockIKeeper)(nil).GetLeaseDeposits), arg0)
+}
+
+// GetLeasePrice mocks base method
+func (m *MockIKeeper) GetLeasePrice(arg0 types1.Context) types.QueryResultLeasePrice {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeasePrice", arg0)
+	ret0, _ := ret[0].(types.QueryResultLeasePrice)
+	return ret0
+}
+
+// GetLeasePrice indicates an expected call of GetLeasePrice
+func (mr *MockIKeeperMockRecorder) GetLeasePrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeasePrice", reflect.TypeOf((*MockIKeeper)(nil).GetLeasePrice), arg0)
+}
+
+// GetLeaseStore mocks base method
+func (m *MockIKeeper) GetLeaseStore(arg0 types1.Context) types0.KVStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaseStore", arg0)
+	ret0, _ := ret[0].(types0.KVStore)
+	return ret0
+}
+
+// GetLeaseStore indicates an expected call of GetLeaseStore
+func (mr *MockIKeeperMockRecorder) GetLeaseStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaseStore", reflect.TypeOf((*MockIKeeper)(nil).GetLeaseStore), arg0)
+}
+
+// GetMetadata mocks base method
+func (m *MockIKeeper) GetMetadata(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) types.QueryResultMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultMetadata)
+	return ret0
+}
+
+// GetMetadata indicates an expected call of GetMetadata
+func (mr *MockIKeeperMockRecorder) GetMetadata(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockIKeeper)(nil).GetMetadata), arg0, arg1, arg2, arg3)
+}
+
+// GetNShortestLeases mocks base method
+func (m *MockIKeeper) GetNShortestLeases(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 uint64) types.QueryResultNShortestLeaseKeys {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNShortestLeases", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(types.QueryResultNShortestLeaseKeys)
+	return ret0
+}
+
+// GetNShortestLeases indicates an expected call of GetNShortestLeases
+func (mr *MockIKeeperMockRecorder) GetNShortestLeases(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNShortestLeases", reflect.TypeOf((*MockIKeeper)(nil).GetNShortestLeases), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetOwner mocks base method
+func (m *MockIKeeper) GetOwner(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) types1.AccAddress {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeleteAll", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "GetOwner", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types1.AccAddress)
+	return ret0
 }
 
-// DeleteAll indicates an expected call of DeleteAll
-func (mr *MockIKeeperMockRecorder) DeleteAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetOwner indicates an expected call of GetOwner
+func (mr *MockIKeeperMockRecorder) GetOwner(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockIKeeper)(nil).DeleteAll), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockIKeeper)(nil).GetOwner), arg0, arg1, arg2, arg3)
 }
 
-// DeleteLease mocks base method
-func (m *MockIKeeper) DeleteLease(arg0 types0.KVStore, arg1, arg2 string, arg3, arg4 int64) {
+// GetParams mocks base method
+func (m *MockIKeeper) GetParams(arg0 types1.Context) types.Params {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeleteLease", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "GetParams", arg0)
+	ret0, _ := ret[0].(types.Params)
+	return ret0
 }
 
-// DeleteLease indicates an expected call of DeleteLease
-func (mr *MockIKeeperMockRecorder) DeleteLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// GetParams indicates an expected call of GetParams
+func (mr *MockIKeeperMockRecorder) GetParams(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLease", reflect.TypeOf((*MockIKeeper)(nil).DeleteLease), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParams", reflect.TypeOf((*MockIKeeper)(nil).GetParams), arg0)
 }
 
-// DeleteValue mocks base method
-func (m *MockIKeeper) DeleteValue(arg0 types1.Context, arg1, arg2 types0.KVStore, arg3, arg4 string) {
+// GetRentHistories mocks base method
+func (m *MockIKeeper) GetRentHistories(arg0 types1.Context) []types.GenesisRentRecord {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeleteValue", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "GetRentHistories", arg0)
+	ret0, _ := ret[0].([]types.GenesisRentRecord)
+	return ret0
 }
 
-// DeleteValue indicates an expected call of DeleteValue
-func (mr *MockIKeeperMockRecorder) DeleteValue(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// GetRentHistories indicates an expected call of GetRentHistories
+func (mr *MockIKeeperMockRecorder) GetRentHistories(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockIKeeper)(nil).DeleteValue), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentHistories", reflect.TypeOf((*MockIKeeper)(nil).GetRentHistories), arg0)
 }
 
-// GetCdc mocks base method
-func (m *MockIKeeper) GetCdc() *amino.Codec {
+// GetRentHistory mocks base method
+func (m *MockIKeeper) GetRentHistory(arg0 types1.Context, arg1 types1.AccAddress, arg2 int64, arg3 uint64) types.QueryResultRentHistory {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCdc")
-	ret0, _ := ret[0].(*amino.Codec)
+	ret := m.ctrl.Call(m, "GetRentHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.QueryResultRentHistory)
 	return ret0
 }
 
-// GetCdc indicates an expected call of GetCdc
-func (mr *MockIKeeperMockRecorder) GetCdc() *gomock.Call {
+// GetRentHistory indicates an expected call of GetRentHistory
+func (mr *MockIKeeperMockRecorder) GetRentHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCdc", reflect.TypeOf((*MockIKeeper)(nil).GetCdc))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentHistory", reflect.TypeOf((*MockIKeeper)(nil).GetRentHistory), arg0, arg1, arg2, arg3)
 }
 
-// GetCount mocks base method
-func (m *MockIKeeper) GetCount(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultCount {
+// GetRetentionPolicies mocks base method
+func (m *MockIKeeper) GetRetentionPolicies(arg0 types1.Context) []types.GenesisRetention {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(types.QueryResultCount)
+	ret := m.ctrl.Call(m, "GetRetentionPolicies", arg0)
+	ret0, _ := ret[0].([]types.GenesisRetention)
 	return ret0
 }
 
-// GetCount indicates an expected call of GetCount
-func (mr *MockIKeeperMockRecorder) GetCount(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetRetentionPolicies indicates an expected call of GetRetentionPolicies
+func (mr *MockIKeeperMockRecorder) GetRetentionPolicies(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIKeeper)(nil).GetCount), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicies", reflect.TypeOf((*MockIKeeper)(nil).GetRetentionPolicies), arg0)
 }
 
-// GetDefaultLeaseBlocks mocks base method
-func (m *MockIKeeper) GetDefaultLeaseBlocks() int64 {
+// GetRetentionPolicy mocks base method
+func (m *MockIKeeper) GetRetentionPolicy(arg0 types1.Context, arg1 string) types.RetentionPolicy {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDefaultLeaseBlocks")
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetRetentionPolicy", arg0, arg1)
+	ret0, _ := ret[0].(types.RetentionPolicy)
 	return ret0
 }
 
-// GetDefaultLeaseBlocks indicates an expected call of GetDefaultLeaseBlocks
-func (mr *MockIKeeperMockRecorder) GetDefaultLeaseBlocks() *gomock.Call {
+// GetRetentionPolicy indicates an expected call of GetRetentionPolicy
+func (mr *MockIKeeperMockRecorder) GetRetentionPolicy(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultLeaseBlocks", reflect.TypeOf((*MockIKeeper)(nil).GetDefaultLeaseBlocks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicy", reflect.TypeOf((*MockIKeeper)(nil).GetRetentionPolicy), arg0, arg1)
 }
 
-// GetKVStore mocks base method
-func (m *MockIKeeper) GetKVStore(arg0 types1.Context) types0.KVStore {
+// GetUUIDStats mocks base method
+func (m *MockIKeeper) GetUUIDStats(arg0 types1.Context, arg1 string) types.QueryResultUUIDStats {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKVStore", arg0)
-	ret0, _ := ret[0].(types0.KVStore)
+	ret := m.ctrl.Call(m, "GetUUIDStats", arg0, arg1)
+	ret0, _ := ret[0].(types.QueryResultUUIDStats)
 	return ret0
 }
 
-// GetKVStore indicates an expected call of GetKVStore
-func (mr *MockIKeeperMockRecorder) GetKVStore(arg0 interface{}) *gomock.Call {
+// GetUUIDStats indicates an expected call of GetUUIDStats
+func (mr *MockIKeeperMockRecorder) GetUUIDStats(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVStore", reflect.TypeOf((*MockIKeeper)(nil).GetKVStore), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUUIDStats", reflect.TypeOf((*MockIKeeper)(nil).GetUUIDStats), arg0, arg1)
 }
 
-// GetKeyValues mocks base method
-func (m *MockIKeeper) GetKeyValues(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultKeyValues {
+// GetUUIDs mocks base method
+func (m *MockIKeeper) GetUUIDs(arg0 types1.Context, arg1 types1.AccAddress) types.QueryResultUUIDs {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKeyValues", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(types.QueryResultKeyValues)
+	ret := m.ctrl.Call(m, "GetUUIDs", arg0, arg1)
+	ret0, _ := ret[0].(types.QueryResultUUIDs)
 	return ret0
 }
 
-// GetKeyValues indicates an expected call of GetKeyValues
-func (mr *MockIKeeperMockRecorder) GetKeyValues(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetUUIDs indicates an expected call of GetUUIDs
+func (mr *MockIKeeperMockRecorder) GetUUIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyValues", reflect.TypeOf((*MockIKeeper)(nil).GetKeyValues), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUUIDs", reflect.TypeOf((*MockIKeeper)(nil).GetUUIDs), arg0, arg1)
 }
 
-// GetKeys mocks base method
-func (m *MockIKeeper) GetKeys(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress) types.QueryResultKeys {
+// GetSchema mocks base method
+func (m *MockIKeeper) GetSchema(arg0 sdk.Context, arg1 string) types.Schema {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKeys", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(types.QueryResultKeys)
+	ret := m.ctrl.Call(m, "GetSchema", arg0, arg1)
+	ret0, _ := ret[0].(types.Schema)
 	return ret0
 }
 
-// GetKeys indicates an expected call of GetKeys
-func (mr *MockIKeeperMockRecorder) GetKeys(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetSchema indicates an expected call of GetSchema
+func (mr *MockIKeeperMockRecorder) GetSchema(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockIKeeper)(nil).GetKeys), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchema", reflect.TypeOf((*MockIKeeper)(nil).GetSchema), arg0, arg1)
 }
 
-// GetLeaseStore mocks base method
-func (m *MockIKeeper) GetLeaseStore(arg0 types1.Context) types0.KVStore {
+// GetSchemas mocks base method
+func (m *MockIKeeper) GetSchemas(arg0 sdk.Context) []types.GenesisSchema {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLeaseStore", arg0)
-	ret0, _ := ret[0].(types0.KVStore)
+	ret := m.ctrl.Call(m, "GetSchemas", arg0)
+	ret0, _ := ret[0].([]types.GenesisSchema)
 	return ret0
 }
 
-// GetLeaseStore indicates an expected call of GetLeaseStore
-func (mr *MockIKeeperMockRecorder) GetLeaseStore(arg0 interface{}) *gomock.Call {
+// GetSchemas indicates an expected call of GetSchemas
+func (mr *MockIKeeperMockRecorder) GetSchemas(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaseStore", reflect.TypeOf((*MockIKeeper)(nil).GetLeaseStore), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemas", reflect.TypeOf((*MockIKeeper)(nil).GetSchemas), arg0)
 }
 
-// GetNShortestLeases mocks base method
-func (m *MockIKeeper) GetNShortestLeases(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 uint64) types.QueryResultNShortestLeaseKeys {
+// GetUpload mocks base method
+func (m *MockIKeeper) GetUpload(arg0 types1.Context, arg1, arg2 string) types.Upload {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNShortestLeases", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(types.QueryResultNShortestLeaseKeys)
+	ret := m.ctrl.Call(m, "GetUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.Upload)
 	return ret0
 }
 
-// GetNShortestLeases indicates an expected call of GetNShortestLeases
-func (mr *MockIKeeperMockRecorder) GetNShortestLeases(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// GetUpload indicates an expected call of GetUpload
+func (mr *MockIKeeperMockRecorder) GetUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNShortestLeases", reflect.TypeOf((*MockIKeeper)(nil).GetNShortestLeases), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockIKeeper)(nil).GetUpload), arg0, arg1, arg2)
 }
 
-// GetOwner mocks base method
-func (m *MockIKeeper) GetOwner(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) types1.AccAddress {
+// GetUploads mocks base method
+func (m *MockIKeeper) GetUploads(arg0 types1.Context) []types.GenesisUpload {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOwner", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(types1.AccAddress)
+	ret := m.ctrl.Call(m, "GetUploads", arg0)
+	ret0, _ := ret[0].([]types.GenesisUpload)
 	return ret0
 }
 
-// GetOwner indicates an expected call of GetOwner
-func (mr *MockIKeeperMockRecorder) GetOwner(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetUploads indicates an expected call of GetUploads
+func (mr *MockIKeeperMockRecorder) GetUploads(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockIKeeper)(nil).GetOwner), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploads", reflect.TypeOf((*MockIKeeper)(nil).GetUploads), arg0)
 }
 
 // GetValue mocks base method
@@ -227,6 +971,146 @@ func (mr *MockIKeeperMockRecorder) GetValuesIterator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesIterator", reflect.TypeOf((*MockIKeeper)(nil).GetValuesIterator), arg0, arg1)
 }
 
+// GetVersion mocks base method
+func (m *MockIKeeper) GetVersion(arg0 types1.Context, arg1 types1.KVStore, arg2, arg3 string) uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetVersion indicates an expected call of GetVersion
+func (mr *MockIKeeperMockRecorder) GetVersion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockIKeeper)(nil).GetVersion), arg0, arg1, arg2, arg3)
+}
+
+// HasFrozen mocks base method
+func (m *MockIKeeper) HasFrozen(arg0 types1.Context, arg1 types1.AccAddress, arg2 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasFrozen", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasFrozen indicates an expected call of HasFrozen
+func (mr *MockIKeeperMockRecorder) HasFrozen(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasFrozen", reflect.TypeOf((*MockIKeeper)(nil).HasFrozen), arg0, arg1, arg2)
+}
+
+// ImportAuditEntry mocks base method
+func (m *MockIKeeper) ImportAuditEntry(arg0 types1.Context, arg1 types.AuditEntry) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportAuditEntry", arg0, arg1)
+}
+
+// ImportAuditEntry indicates an expected call of ImportAuditEntry
+func (mr *MockIKeeperMockRecorder) ImportAuditEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAuditEntry", reflect.TypeOf((*MockIKeeper)(nil).ImportAuditEntry), arg0, arg1)
+}
+
+// ImportBeneficiary mocks base method
+func (m *MockIKeeper) ImportBeneficiary(arg0 types1.Context, arg1, arg2 string, arg3 types.Beneficiary) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportBeneficiary", arg0, arg1, arg2, arg3)
+}
+
+// ImportBeneficiary indicates an expected call of ImportBeneficiary
+func (mr *MockIKeeperMockRecorder) ImportBeneficiary(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBeneficiary", reflect.TypeOf((*MockIKeeper)(nil).ImportBeneficiary), arg0, arg1, arg2, arg3)
+}
+
+// ImportEscrow mocks base method
+func (m *MockIKeeper) ImportEscrow(arg0 types1.Context, arg1 types1.AccAddress, arg2 types1.Coins) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportEscrow", arg0, arg1, arg2)
+}
+
+// ImportEscrow indicates an expected call of ImportEscrow
+func (mr *MockIKeeperMockRecorder) ImportEscrow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEscrow", reflect.TypeOf((*MockIKeeper)(nil).ImportEscrow), arg0, arg1, arg2)
+}
+
+// ImportLeaseDeposit mocks base method
+func (m *MockIKeeper) ImportLeaseDeposit(arg0 types1.Context, arg1, arg2 string, arg3 types.LeaseDeposit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportLeaseDeposit", arg0, arg1, arg2, arg3)
+}
+
+// ImportLeaseDeposit indicates an expected call of ImportLeaseDeposit
+func (mr *MockIKeeperMockRecorder) ImportLeaseDeposit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLeaseDeposit", reflect.TypeOf((*MockIKeeper)(nil).ImportLeaseDeposit), arg0, arg1, arg2, arg3)
+}
+
+// ImportPurgedHeight mocks base method
+func (m *MockIKeeper) ImportPurgedHeight(arg0 types1.Context, arg1 int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportPurgedHeight", arg0, arg1)
+}
+
+// ImportPurgedHeight indicates an expected call of ImportPurgedHeight
+func (mr *MockIKeeperMockRecorder) ImportPurgedHeight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPurgedHeight", reflect.TypeOf((*MockIKeeper)(nil).ImportPurgedHeight), arg0, arg1)
+}
+
+// ImportRentRecord mocks base method
+func (m *MockIKeeper) ImportRentRecord(arg0 types1.Context, arg1 types1.AccAddress, arg2 types.RentRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportRentRecord", arg0, arg1, arg2)
+}
+
+// ImportRentRecord indicates an expected call of ImportRentRecord
+func (mr *MockIKeeperMockRecorder) ImportRentRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRentRecord", reflect.TypeOf((*MockIKeeper)(nil).ImportRentRecord), arg0, arg1, arg2)
+}
+
+// ImportUpload mocks base method
+func (m *MockIKeeper) ImportUpload(arg0 types1.Context, arg1 types.GenesisUpload) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportUpload", arg0, arg1)
+}
+
+// ImportUpload indicates an expected call of ImportUpload
+func (mr *MockIKeeperMockRecorder) ImportUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUpload", reflect.TypeOf((*MockIKeeper)(nil).ImportUpload), arg0, arg1)
+}
+
+// IsExpired mocks base method
+func (m *MockIKeeper) IsExpired(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExpired", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExpired indicates an expected call of IsExpired
+func (mr *MockIKeeperMockRecorder) IsExpired(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExpired", reflect.TypeOf((*MockIKeeper)(nil).IsExpired), arg0, arg1, arg2, arg3)
+}
+
+// IsFrozen mocks base method
+func (m *MockIKeeper) IsFrozen(arg0 types1.Context, arg1 types1.AccAddress, arg2, arg3 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFrozen", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFrozen indicates an expected call of IsFrozen
+func (mr *MockIKeeperMockRecorder) IsFrozen(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFrozen", reflect.TypeOf((*MockIKeeper)(nil).IsFrozen), arg0, arg1, arg2, arg3)
+}
+
 // IsKeyPresent mocks base method
 func (m *MockIKeeper) IsKeyPresent(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string) bool {
 	m.ctrl.T.Helper()
@@ -241,6 +1125,34 @@ func (mr *MockIKeeperMockRecorder) IsKeyPresent(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKeyPresent", reflect.TypeOf((*MockIKeeper)(nil).IsKeyPresent), arg0, arg1, arg2, arg3)
 }
 
+// LeaseFee mocks base method
+func (m *MockIKeeper) LeaseFee(arg0 types1.Context, arg1 int64) types1.Coins {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseFee", arg0, arg1)
+	ret0, _ := ret[0].(types1.Coins)
+	return ret0
+}
+
+// LeaseFee indicates an expected call of LeaseFee
+func (mr *MockIKeeperMockRecorder) LeaseFee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseFee", reflect.TypeOf((*MockIKeeper)(nil).LeaseFee), arg0, arg1)
+}
+
+// MatchKeys mocks base method
+func (m *MockIKeeper) MatchKeys(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types1.AccAddress, arg4 types.KeyPattern, arg5 string) types.QueryResultMatch {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchKeys", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(types.QueryResultMatch)
+	return ret0
+}
+
+// MatchKeys indicates an expected call of MatchKeys
+func (mr *MockIKeeperMockRecorder) MatchKeys(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchKeys", reflect.TypeOf((*MockIKeeper)(nil).MatchKeys), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // ProcessLeasesAtBlockHeight mocks base method
 func (m *MockIKeeper) ProcessLeasesAtBlockHeight(arg0 types1.Context, arg1, arg2 types0.KVStore, arg3 int64) {
 	m.ctrl.T.Helper()
@@ -254,29 +1166,140 @@ func (mr *MockIKeeperMockRecorder) ProcessLeasesAtBlockHeight(arg0, arg1, arg2,
 }
 
 // RenameKey mocks base method
-func (m *MockIKeeper) RenameKey(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3, arg4 string) bool {
+func (m *MockIKeeper) RenameKey(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3, arg4 string, arg5 bool) (int64, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RenameKey", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(bool)
-	return ret0
+	ret := m.ctrl.Call(m, "RenameKey", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
 }
 
 // RenameKey indicates an expected call of RenameKey
-func (mr *MockIKeeperMockRecorder) RenameKey(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockIKeeperMockRecorder) RenameKey(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameKey", reflect.TypeOf((*MockIKeeper)(nil).RenameKey), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SetAuditConfig mocks base method
+func (m *MockIKeeper) SetAuditConfig(arg0 types1.Context, arg1 string, arg2 types.AuditConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAuditConfig", arg0, arg1, arg2)
+}
+
+// SetAuditConfig indicates an expected call of SetAuditConfig
+func (mr *MockIKeeperMockRecorder) SetAuditConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuditConfig", reflect.TypeOf((*MockIKeeper)(nil).SetAuditConfig), arg0, arg1, arg2)
+}
+
+// SetAutoRenew mocks base method
+func (m *MockIKeeper) SetAutoRenew(arg0 types1.Context, arg1, arg2 string, arg3 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAutoRenew", arg0, arg1, arg2, arg3)
+}
+
+// SetAutoRenew indicates an expected call of SetAutoRenew
+func (mr *MockIKeeperMockRecorder) SetAutoRenew(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoRenew", reflect.TypeOf((*MockIKeeper)(nil).SetAutoRenew), arg0, arg1, arg2, arg3)
+}
+
+// SetBeneficiary mocks base method
+func (m *MockIKeeper) SetBeneficiary(arg0 types1.Context, arg1, arg2 string, arg3, arg4 types1.AccAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBeneficiary", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBeneficiary indicates an expected call of SetBeneficiary
+func (mr *MockIKeeperMockRecorder) SetBeneficiary(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBeneficiary", reflect.TypeOf((*MockIKeeper)(nil).SetBeneficiary), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetHashIndexConfig mocks base method
+func (m *MockIKeeper) SetHashIndexConfig(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types.HashIndexConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHashIndexConfig", arg0, arg1, arg2, arg3)
+}
+
+// SetHashIndexConfig indicates an expected call of SetHashIndexConfig
+func (mr *MockIKeeperMockRecorder) SetHashIndexConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHashIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).SetHashIndexConfig), arg0, arg1, arg2, arg3)
+}
+
+// SetIndexConfig mocks base method
+func (m *MockIKeeper) SetIndexConfig(arg0 types1.Context, arg1 types0.KVStore, arg2 string, arg3 types.IndexConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetIndexConfig", arg0, arg1, arg2, arg3)
+}
+
+// SetIndexConfig indicates an expected call of SetIndexConfig
+func (mr *MockIKeeperMockRecorder) SetIndexConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameKey", reflect.TypeOf((*MockIKeeper)(nil).RenameKey), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIndexConfig", reflect.TypeOf((*MockIKeeper)(nil).SetIndexConfig), arg0, arg1, arg2, arg3)
 }
 
 // SetLease mocks base method
-func (m *MockIKeeper) SetLease(arg0 types0.KVStore, arg1, arg2 string, arg3, arg4 int64) {
+func (m *MockIKeeper) SetLease(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string, arg4, arg5 int64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetLease", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "SetLease", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // SetLease indicates an expected call of SetLease
-func (mr *MockIKeeperMockRecorder) SetLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockIKeeperMockRecorder) SetLease(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLease", reflect.TypeOf((*MockIKeeper)(nil).SetLease), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SetParams mocks base method
+func (m *MockIKeeper) SetParams(arg0 types1.Context, arg1 types.Params) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParams", arg0, arg1)
+}
+
+// SetParams indicates an expected call of SetParams
+func (mr *MockIKeeperMockRecorder) SetParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParams", reflect.TypeOf((*MockIKeeper)(nil).SetParams), arg0, arg1)
+}
+
+// SetRetentionPolicy mocks base method
+func (m *MockIKeeper) SetRetentionPolicy(arg0 types1.Context, arg1 types1.KVStore, arg2 string, arg3 types.RetentionPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRetentionPolicy", arg0, arg1, arg2, arg3)
+}
+
+// SetRetentionPolicy indicates an expected call of SetRetentionPolicy
+func (mr *MockIKeeperMockRecorder) SetRetentionPolicy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetentionPolicy", reflect.TypeOf((*MockIKeeper)(nil).SetRetentionPolicy), arg0, arg1, arg2, arg3)
+}
+
+// SetSchema mocks base method
+func (m *MockIKeeper) SetSchema(arg0 sdk.Context, arg1 string, arg2 types.Schema) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSchema", arg0, arg1, arg2)
+}
+
+// SetSchema indicates an expected call of SetSchema
+func (mr *MockIKeeperMockRecorder) SetSchema(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchema", reflect.TypeOf((*MockIKeeper)(nil).SetSchema), arg0, arg1, arg2)
+}
+
+// SetStoreVersion mocks base method
+func (m *MockIKeeper) SetStoreVersion(arg0 types1.Context, arg1 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStoreVersion", arg0, arg1)
+}
+
+// SetStoreVersion indicates an expected call of SetStoreVersion
+func (mr *MockIKeeperMockRecorder) SetStoreVersion(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLease", reflect.TypeOf((*MockIKeeper)(nil).SetLease), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStoreVersion", reflect.TypeOf((*MockIKeeper)(nil).SetStoreVersion), arg0, arg1)
 }
 
 // SetValue mocks base method
@@ -290,3 +1313,57 @@ func (mr *MockIKeeperMockRecorder) SetValue(arg0, arg1, arg2, arg3, arg4 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValue", reflect.TypeOf((*MockIKeeper)(nil).SetValue), arg0, arg1, arg2, arg3, arg4)
 }
+
+// StartUpload mocks base method
+func (m *MockIKeeper) StartUpload(arg0 types1.Context, arg1, arg2 string, arg3 types.Upload) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartUpload", arg0, arg1, arg2, arg3)
+}
+
+// StartUpload indicates an expected call of StartUpload
+func (mr *MockIKeeperMockRecorder) StartUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartUpload", reflect.TypeOf((*MockIKeeper)(nil).StartUpload), arg0, arg1, arg2, arg3)
+}
+
+// TransferValue mocks base method
+func (m *MockIKeeper) TransferValue(arg0 types1.Context, arg1 types0.KVStore, arg2, arg3 string, arg4 types1.AccAddress) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferValue", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TransferValue indicates an expected call of TransferValue
+func (mr *MockIKeeperMockRecorder) TransferValue(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferValue", reflect.TypeOf((*MockIKeeper)(nil).TransferValue), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Unfreeze mocks base method
+func (m *MockIKeeper) Unfreeze(arg0 types1.Context, arg1 types1.AccAddress, arg2, arg3 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfreeze", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Unfreeze indicates an expected call of Unfreeze
+func (mr *MockIKeeperMockRecorder) Unfreeze(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfreeze", reflect.TypeOf((*MockIKeeper)(nil).Unfreeze), arg0, arg1, arg2, arg3)
+}
+
+// WithdrawEscrow mocks base method
+func (m *MockIKeeper) WithdrawEscrow(arg0 types1.Context, arg1 types1.AccAddress, arg2 types1.Coins) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawEscrow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawEscrow indicates an expected call of WithdrawEscrow
+func (mr *MockIKeeperMockRecorder) WithdrawEscrow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawEscrow", reflect.TypeOf((*MockIKeeper)(nil).WithdrawEscrow), arg0, arg1, arg2)
+}
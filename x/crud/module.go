@@ -18,20 +18,24 @@ import (
 	"encoding/json"
 	"github.com/bluzelle/curium/x/crud/client/cli"
 	"github.com/bluzelle/curium/x/crud/client/rest"
-	"github.com/bluzelle/curium/x/crud/internal/types"
+	"github.com/bluzelle/curium/x/crud/simulation"
+	"github.com/bluzelle/curium/x/crud/types"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	sim "github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"math/rand"
 )
 
 var (
-	_ module.AppModule      = AppModule{}
-	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule           = AppModule{}
+	_ module.AppModuleBasic      = AppModuleBasic{}
+	_ module.AppModuleSimulation = AppModule{}
 )
 
 type AppModuleBasic struct{}
@@ -72,17 +76,19 @@ func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
 
 type AppModule struct {
 	AppModuleBasic
-	keeper       Keeper
-	coinKeeper   bank.Keeper
-	crudDisabled bool // called disabled as default construction will set it false
+	keeper        Keeper
+	coinKeeper    bank.Keeper
+	accountKeeper types.AccountKeeper
+	crudDisabled  bool // called disabled as default construction will set it false
 }
 
-func NewAppModule(crudDisabled bool, k Keeper, bankkeeper bank.Keeper) AppModule {
+func NewAppModule(crudDisabled bool, k Keeper, bankkeeper bank.Keeper, accountKeeper types.AccountKeeper) AppModule {
 
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
 		coinKeeper:     bankkeeper,
+		accountKeeper:  accountKeeper,
 		crudDisabled:   crudDisabled,
 	}
 }
@@ -91,7 +97,9 @@ func (AppModule) Name() string {
 	return ModuleName
 }
 
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, am.keeper)
+}
 
 func (am AppModule) Route() string {
 	if !am.crudDisabled {
@@ -103,7 +111,14 @@ func (am AppModule) Route() string {
 
 func (am AppModule) NewHandler() sdk.Handler {
 	if !am.crudDisabled {
-		return NewHandler(am.keeper)
+		handler, messages := NewHandler(am.keeper), am.keeper.Metrics().Messages
+		return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			// simulations run in check mode too and are not counted
+			if !ctx.IsCheckTx() {
+				messages.With("msg_type", msg.Type()).Add(1)
+			}
+			return handler(ctx, msg)
+		}
 	} else {
 		return nil
 	}
@@ -121,7 +136,14 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 	return NewQuerier(am.keeper)
 }
 
-func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
+// RegisterMigration registers a migration of the crud store from fromVersion to the
+// next version, run by the UpgradeName upgrade handler at the height governance
+// schedules the upgrade for.
+func (am AppModule) RegisterMigration(fromVersion uint64, handler MigrationHandler) error {
+	return am.keeper.RegisterMigration(fromVersion, handler)
+}
+
+func (am AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
 
 func (am AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate {
 
@@ -140,3 +162,25 @@ func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
 	gs := ExportGenesis(ctx, am.keeper)
 	return ModuleCdc.MustMarshalJSON(gs)
 }
+
+// AppModuleSimulation functions
+
+func (AppModule) GenerateGenesisState(simState *module.SimulationState) {
+	simulation.RandomizedGenState(simState)
+}
+
+func (AppModule) ProposalContents(_ module.SimulationState) []sim.WeightedProposalContent {
+	return nil
+}
+
+func (AppModule) RandomizedParams(r *rand.Rand) []sim.ParamChange {
+	return simulation.ParamChanges(r)
+}
+
+func (AppModule) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
+	sdr[StoreKey] = simulation.DecodeStore
+}
+
+func (am AppModule) WeightedOperations(simState module.SimulationState) []sim.WeightedOperation {
+	return simulation.WeightedOperations(simState.AppParams, simState.Cdc, am.accountKeeper, am.keeper)
+}
@@ -34,7 +34,7 @@ func TestAppModuleBasic_DefaultGenesis(t *testing.T) {
 	assert.NotNil(t, genesis)
 
 	// Note: see crud/genesis.go func DefaultGenesisState() GenesisState
-	assert.Equal(t, string(genesis), "{\"BlzValues\":null}")
+	assert.Equal(t, string(genesis), "{\"BlzValues\":null,\"Indexes\":null,\"Frozen\":null,\"Beneficiaries\":null,\"Escrows\":null,\"AutoRenew\":null,\"LeaseDeposits\":null,\"Audits\":null,\"AuditLog\":null,\"Retention\":null,\"HashIndexes\":null,\"RentHistory\":null,\"Schemas\":null,\"Params\":{\"compression_threshold\":\"0\",\"min_msg_gas\":null,\"lease_price\":null,\"expiry_grace_blocks\":\"0\",\"max_writes_per_window\":\"0\",\"rate_limit_window\":\"0\",\"base_msg_gas\":[{\"msg_type\":\"create\",\"gas\":\"2000\"},{\"msg_type\":\"read\",\"gas\":\"1000\"},{\"msg_type\":\"update\",\"gas\":\"2000\"},{\"msg_type\":\"delete\",\"gas\":\"1000\"},{\"msg_type\":\"keys\",\"gas\":\"2000\"},{\"msg_type\":\"has\",\"gas\":\"1000\"},{\"msg_type\":\"rename\",\"gas\":\"2000\"},{\"msg_type\":\"keyvalues\",\"gas\":\"2000\"},{\"msg_type\":\"count\",\"gas\":\"1000\"},{\"msg_type\":\"deleteall\",\"gas\":\"5000\"},{\"msg_type\":\"multiupdate\",\"gas\":\"2000\"},{\"msg_type\":\"getlease\",\"gas\":\"1000\"},{\"msg_type\":\"getnshortestleases\",\"gas\":\"2000\"},{\"msg_type\":\"renewlease\",\"gas\":\"1000\"},{\"msg_type\":\"renewleaseall\",\"gas\":\"2000\"},{\"msg_type\":\"copy\",\"gas\":\"2000\"},{\"msg_type\":\"copyuuid\",\"gas\":\"5000\"},{\"msg_type\":\"patch\",\"gas\":\"2000\"}],\"audit_retention_blocks\":\"0\",\"default_lease_blocks\":\"0\",\"utilization_bands\":null,\"rent_epoch_blocks\":\"0\",\"max_batch_size\":\"100\",\"batch_item_gas\":\"500\",\"batch_byte_gas\":\"2\"}}")
 }
 
 func TestAppModuleBasic_ValidateGenesis(t *testing.T) {
@@ -75,10 +75,10 @@ func TestAppModuleBasic_GetQueryCmd_CrudQueries(t *testing.T) {
 	command := AppModuleBasic{}.GetQueryCmd(&cdc)
 
 	commands := command.Commands()
-	assert.Len(t, command.Commands(), 7)
+	assert.Len(t, command.Commands(), 24)
 
-	expectedUses := [...]string{"count [UUID]", "getlease [UUID] [key]", "getnshortestleases [UUID] [N]", "has [UUID] [key]", "keys [UUID]", "keyvalues [UUID]", "read [UUID] [key]"}
-	expectedNames := [...]string{"count", "getlease", "getnshortestleases", "has", "keys", "keyvalues", "read"}
+	expectedUses := [...]string{"account-usage [owner]", "audit-log [UUID]", "count [UUID]", "count-all [owner]", "escrow [owner]", "estimate-lease", "export [UUID]", "find [UUID] [value]", "gc-status", "gethash [UUID] [key]", "getlease [UUID] [key]", "getleaseall [UUID] [owner]", "getmetadata [UUID] [key]", "getnshortestleases [UUID] [N]", "has [UUID] [key]", "keys [UUID]", "keys-all [owner]", "keys-by-expiry [UUID] [owner]", "keyvalues [UUID]", "my-uuids [owner]", "owner [UUID] [key]", "read [UUID] [key]", "uuid-stats [UUID]"}
+	expectedNames := [...]string{"account-usage", "audit-log", "count", "count-all", "escrow", "estimate-lease", "export", "find", "gc-status", "gethash", "getlease", "getleaseall", "getmetadata", "getnshortestleases", "has", "keys", "keys-all", "keys-by-expiry", "keyvalues", "my-uuids", "owner", "read", "uuid-stats"}
 
 	for i := 0; i < len(command.Commands()); i++ {
 		expectedUse := expectedUses[i]
@@ -102,14 +102,14 @@ func TestAppModuleBasic_GetTxCmd(t *testing.T) {
 		assert.Equal(t, 2, cmd.SuggestionsMinimumDistance)
 
 		commands := cmd.Commands()
-		assert.Len(t, commands, 15)
+		assert.Len(t, commands, 34)
 	}
 }
 
 func TestNewAppModule(t *testing.T) {
 	k := Keeper{}
 	var bankkeeper bank.Keeper
-	sut := NewAppModule(false, k, bankkeeper)
+	sut := NewAppModule(false, k, bankkeeper, nil)
 
 	assert.Equal(t, "crud", sut.Route())
 }
@@ -117,7 +117,7 @@ func TestNewAppModule(t *testing.T) {
 func TestAppModule_Name(t *testing.T) {
 	k := Keeper{}
 	var bankkeeper bank.Keeper
-	sut := NewAppModule(false, k, bankkeeper)
+	sut := NewAppModule(false, k, bankkeeper, nil)
 
 	assert.Equal(t, "crud", sut.Name())
 }
@@ -130,7 +130,7 @@ func TestAppModule_Route(t *testing.T) {
 	{
 		k := Keeper{}
 		var bankkeeper bank.Keeper
-		sut := NewAppModule(true, k, bankkeeper)
+		sut := NewAppModule(true, k, bankkeeper, nil)
 		assert.Equal(t, "", sut.Route())
 	}
 }
@@ -143,7 +143,7 @@ func TestAppModule_QuerierRoute(t *testing.T) {
 	{
 		k := Keeper{}
 		var bankkeeper bank.Keeper
-		sut := NewAppModule(true, k, bankkeeper)
+		sut := NewAppModule(true, k, bankkeeper, nil)
 		assert.Equal(t, "", sut.QuerierRoute())
 	}
 }
@@ -0,0 +1,162 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package crud
+
+import (
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"math/rand"
+)
+
+const (
+	QueryEstimateLease = "estimatelease"
+	QuerySimulate      = "simulate"
+)
+
+// NewQuerier adds the queries that need the message handlers to those of the keeper.
+func NewQuerier(k keeper.IKeeper) sdk.Querier {
+	keeperQuerier := keeper.NewQuerier(k)
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case QueryEstimateLease:
+			return queryEstimateLease(ctx, req, k)
+		case QuerySimulate:
+			return querySimulate(ctx, req, k)
+		default:
+			return keeperQuerier(ctx, path, req)
+		}
+	}
+}
+
+// estimateKeeper adds up the lease the handlers charge for instead of taking the fee,
+// the estimated owner need not hold it.
+type estimateKeeper struct {
+	keeper.IKeeper
+	usage int64
+}
+
+func (k *estimateKeeper) ChargeLease(_ sdk.Context, _ sdk.AccAddress, _ string, _ string, usage int64) error {
+	if usage > 0 {
+		k.usage += usage
+	}
+	return nil
+}
+
+// queryEstimateLease runs the create or renew handler on a throwaway branch of the
+// store and reports the gas it consumed and the lease fee it charged, so the estimate
+// follows whatever the handler charges. The transaction's own costs (signatures, size)
+// are not included.
+func queryEstimateLease(ctx sdk.Context, req abci.RequestQuery, k keeper.IKeeper) ([]byte, error) {
+	var params types.QueryEstimateLeaseParams
+	if err := k.GetCdc().UnmarshalJSON(req.Data, &params); err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	estimateCtx, _ := ctx.CacheContext()
+	estimateCtx = estimateCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	estimator := &estimateKeeper{IKeeper: k}
+
+	var err error
+	switch params.Operation {
+	case types.EstimateCreate:
+		// random bytes, so that compression does not make the estimate too low
+		value := make([]byte, params.Size)
+		rand.New(rand.NewSource(int64(params.Size))).Read(value)
+
+		msg := types.NewMsgCreate(params.UUID, params.Key, value, params.Lease, sdk.AccAddress(make([]byte, sdk.AddrLen)))
+		if err = msg.ValidateBasic(); err == nil {
+			_, err = handleMsgCreate(estimateCtx, estimator, msg)
+		}
+	case types.EstimateRenew:
+		owner := k.GetOwner(ctx, k.GetKVStore(ctx), params.UUID, params.Key)
+		if owner.Empty() {
+			return []byte{}, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "key not found")
+		}
+		_, err = handleMsgRenewLease(estimateCtx, estimator, types.MsgRenewLease{UUID: params.UUID, Key: params.Key, Lease: params.Lease, Owner: owner})
+	default:
+		return []byte{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown operation %q", params.Operation)
+	}
+	if err != nil {
+		return []byte{}, err
+	}
+
+	result := types.QueryResultEstimateLease{
+		Gas:      estimateCtx.GasMeter().GasConsumed(),
+		Fees:     feesAt(estimateCtx.GasMeter().GasConsumed(), params.GasPrices),
+		LeaseFee: k.LeaseFee(estimateCtx, estimator.usage),
+	}
+
+	res, err := codec.MarshalJSONIndent(k.GetCdc(), result)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// querySimulate runs any crud message on a throwaway branch of the store, charging the
+// gas the MinGasDecorator and the handler would, and reports the gas consumed and the
+// lease fee charged. As with estimatelease, the signer need not hold the lease fee and
+// the transaction's own costs (signatures, size) are not included.
+func querySimulate(ctx sdk.Context, req abci.RequestQuery, k keeper.IKeeper) ([]byte, error) {
+	var params types.QuerySimulateParams
+	if err := k.GetCdc().UnmarshalJSON(req.Data, &params); err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if params.Msg == nil || params.Msg.Route() != RouterKey {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "not a crud message")
+	}
+	if err := params.Msg.ValidateBasic(); err != nil {
+		return []byte{}, err
+	}
+
+	simulateCtx, _ := ctx.CacheContext()
+	simulateCtx = simulateCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	simulateCtx.GasMeter().ConsumeGas(k.GetParams(ctx).MinGas(params.Msg.Type()), "crud "+params.Msg.Type())
+
+	estimator := &estimateKeeper{IKeeper: k}
+	if _, err := NewHandler(estimator)(simulateCtx, params.Msg); err != nil {
+		return []byte{}, err
+	}
+
+	result := types.QueryResultSimulate{
+		Gas:      simulateCtx.GasMeter().GasConsumed(),
+		Fees:     feesAt(simulateCtx.GasMeter().GasConsumed(), params.GasPrices),
+		LeaseFee: k.LeaseFee(simulateCtx, estimator.usage),
+	}
+
+	res, err := codec.MarshalJSONIndent(k.GetCdc(), result)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// feesAt returns the fees of gas at gasPrices, rounded up the way the transaction
+// builder does it.
+func feesAt(gas uint64, gasPrices sdk.DecCoins) sdk.Coins {
+	fees := sdk.NewCoins()
+	for _, price := range gasPrices {
+		fees = fees.Add(sdk.NewCoin(price.Denom, price.Amount.Mul(sdk.NewDec(int64(gas))).Ceil().RoundInt()))
+	}
+	return fees
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package crud
+
+import (
+	"github.com/bluzelle/curium/x/crud/mocks"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+)
+
+func Test_queryEstimateLease(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	cdc := codec.New()
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	assert.Nil(t, cms.LoadLatestVersion())
+	ctx := sdk.NewContext(cms, abci.Header{Height: 100}, false, log.NewNopLogger())
+
+	consumeGas := func(gas uint64) func(ctx sdk.Context, _ sdk.KVStore, _ string, _ string, _ types.BLZValue) {
+		return func(ctx sdk.Context, _ sdk.KVStore, _ string, _ string, _ types.BLZValue) {
+			ctx.GasMeter().ConsumeGas(gas, "test")
+		}
+	}
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetDefaultLeaseBlocks(gomock.Any()).AnyTimes().Return(int64(1000))
+
+	leaseParams := types.DefaultParams()
+	leaseParams.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 3)))
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(leaseParams)
+	mockKeeper.EXPECT().LeaseFee(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ sdk.Context, usage int64) sdk.Coins {
+		return leaseParams.LeaseFee(usage)
+	})
+
+	// create
+	{
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key")
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key", gomock.Any()).Do(consumeGas(2500))
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, "uuid", "key", int64(100), int64(500))
+
+		params := types.QueryEstimateLeaseParams{Operation: types.EstimateCreate, UUID: "uuid", Key: "key", Size: 1000, Lease: 500,
+			GasPrices: sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(15, 1)))}
+		res, err := NewQuerier(mockKeeper)(ctx, []string{"estimatelease"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		assert.Nil(t, err)
+
+		var result types.QueryResultEstimateLease
+		cdc.MustUnmarshalJSON(res, &result)
+		// (uuid + key + 1000 bytes) * 500 blocks at 0.001ubnt, rounded up
+		assert.Equal(t, types.QueryResultEstimateLease{Gas: 2500, Fees: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 3750)),
+			LeaseFee: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 504))}, result)
+	}
+
+	// renew
+	{
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key").Return(owner)
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "key").Return(owner)
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key").Return(types.BLZValue{Value: []byte("value"), Lease: 10, Height: 50, Owner: owner})
+		mockKeeper.EXPECT().DeleteLease(nil, "uuid", "key", int64(50), int64(10))
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key", gomock.Any()).Do(consumeGas(700))
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, "uuid", "key", int64(100), int64(1000))
+
+		params := types.QueryEstimateLeaseParams{Operation: types.EstimateRenew, UUID: "uuid", Key: "key"}
+		res, err := NewQuerier(mockKeeper)(ctx, []string{"estimatelease"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		assert.Nil(t, err)
+
+		var result types.QueryResultEstimateLease
+		cdc.MustUnmarshalJSON(res, &result)
+		assert.Equal(t, uint64(700), result.Gas)
+		assert.True(t, result.Fees.Empty())
+		// the old lease has run out, so all 1000 blocks of the 12 bytes are charged
+		assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 12)), result.LeaseFee)
+	}
+
+	// renewing a key that does not exist
+	{
+		mockKeeper.EXPECT().GetOwner(gomock.Any(), nil, "uuid", "missing")
+
+		params := types.QueryEstimateLeaseParams{Operation: types.EstimateRenew, UUID: "uuid", Key: "missing"}
+		_, err := NewQuerier(mockKeeper)(ctx, []string{"estimatelease"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		assert.NotNil(t, err)
+	}
+
+	// unknown operations and bad parameters
+	{
+		params := types.QueryEstimateLeaseParams{Operation: "delete", UUID: "uuid", Key: "key"}
+		_, err := NewQuerier(mockKeeper)(ctx, []string{"estimatelease"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		assert.NotNil(t, err)
+
+		_, err = NewQuerier(mockKeeper)(ctx, []string{"estimatelease"}, abci.RequestQuery{Data: []byte("{")})
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_querySimulate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockKeeper := mocks.NewMockIKeeper(mockCtrl)
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	owner := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	assert.Nil(t, cms.LoadLatestVersion())
+	ctx := sdk.NewContext(cms, abci.Header{Height: 100}, false, log.NewNopLogger())
+
+	mockKeeper.EXPECT().GetCdc().AnyTimes().Return(cdc)
+	mockKeeper.EXPECT().GetKVStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetLeaseStore(gomock.Any()).AnyTimes().Return(nil)
+	mockKeeper.EXPECT().GetSchema(gomock.Any(), gomock.Any()).AnyTimes().Return(types.Schema{})
+
+	params := types.DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 3)))
+	params.MinMsgGas = []types.MsgGas{{MsgType: "create", Gas: 1000}}
+	mockKeeper.EXPECT().GetParams(gomock.Any()).AnyTimes().Return(params)
+	mockKeeper.EXPECT().LeaseFee(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ sdk.Context, usage int64) sdk.Coins {
+		return params.LeaseFee(usage)
+	})
+
+	// the minimum, base and store gas of a create, and its lease fee
+	{
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key")
+		mockKeeper.EXPECT().SetValue(gomock.Any(), nil, "uuid", "key", gomock.Any()).Do(func(ctx sdk.Context, _ sdk.KVStore, _ string, _ string, _ types.BLZValue) {
+			ctx.GasMeter().ConsumeGas(2500, "test")
+		})
+		mockKeeper.EXPECT().SetLease(gomock.Any(), nil, "uuid", "key", int64(100), int64(500))
+
+		msg := types.NewMsgCreate("uuid", "key", make([]byte, 1000), 500, owner)
+		data := cdc.MustMarshalJSON(types.QuerySimulateParams{Msg: msg, GasPrices: sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(15, 1)))})
+		res, err := NewQuerier(mockKeeper)(ctx, []string{"simulate"}, abci.RequestQuery{Data: data})
+		assert.Nil(t, err)
+
+		var result types.QueryResultSimulate
+		cdc.MustUnmarshalJSON(res, &result)
+		assert.Equal(t, types.QueryResultSimulate{Gas: 1000 + 2000 + 2500, Fees: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 8250)),
+			LeaseFee: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 504))}, result)
+	}
+
+	// messages the handler rejects
+	{
+		mockKeeper.EXPECT().GetValue(gomock.Any(), nil, "uuid", "key").Return(types.BLZValue{Owner: owner})
+
+		msg := types.NewMsgCreate("uuid", "key", []byte("value"), 0, owner)
+		_, err := NewQuerier(mockKeeper)(ctx, []string{"simulate"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(types.QuerySimulateParams{Msg: msg})})
+		assert.EqualError(t, err, "Key already exists: invalid request")
+	}
+
+	// no message and bad parameters
+	{
+		_, err := NewQuerier(mockKeeper)(ctx, []string{"simulate"}, abci.RequestQuery{Data: cdc.MustMarshalJSON(types.QuerySimulateParams{})})
+		assert.NotNil(t, err)
+
+		_, err = NewQuerier(mockKeeper)(ctx, []string{"simulate"}, abci.RequestQuery{Data: []byte("{")})
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+)
+
+// DecodeStore unmarshals the KVPair's values of the crud store to the corresponding
+// BLZValue type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	if !bytes.Equal(kvA.Key, kvB.Key) {
+		panic(fmt.Sprintf("invalid crud key %X", kvA.Key))
+	}
+
+	var valueA, valueB types.BLZValue
+	cdc.MustUnmarshalBinaryBare(kvA.Value, &valueA)
+	cdc.MustUnmarshalBinaryBare(kvB.Value, &valueB)
+	return fmt.Sprintf("%v\n%v", valueA, valueB)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+	"testing"
+)
+
+func TestDecodeStore(t *testing.T) {
+	cdc := codec.New()
+	value := types.BLZValue{Value: []byte("value"), Lease: 100, Height: 10, Owner: sdk.AccAddress("owner")}
+
+	kvPair := tmkv.Pair{Key: []byte("uuid\x00key"), Value: cdc.MustMarshalBinaryBare(value)}
+	assert.Equal(t, fmt.Sprintf("%v\n%v", value, value), DecodeStore(cdc, kvPair, kvPair))
+
+	assert.Panics(t, func() { DecodeStore(cdc, kvPair, tmkv.Pair{Key: []byte("uuid\x00other")}) })
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"math/rand"
+)
+
+// simulation parameter constants
+const (
+	CompressionThreshold = "compression_threshold"
+)
+
+// GenCompressionThreshold leaves compression off half of the time so both storage
+// formats are exercised
+func GenCompressionThreshold(r *rand.Rand) uint64 {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return uint64(r.Intn(256))
+}
+
+// RandomizedGenState generates a random GenesisState for crud
+func RandomizedGenState(simState *module.SimulationState) {
+	var compressionThreshold uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, CompressionThreshold, &compressionThreshold, simState.Rand,
+		func(r *rand.Rand) { compressionThreshold = GenCompressionThreshold(r) },
+	)
+
+	genesis := types.GenesisState{Params: types.NewParams(compressionThreshold)}
+
+	fmt.Printf("Selected randomly generated crud parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, genesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(genesis)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"fmt"
+	"github.com/bluzelle/curium/x/crud/keeper"
+	"github.com/bluzelle/curium/x/crud/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"math/rand"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgCreate      = "op_weight_msg_create"
+	OpWeightMsgUpdate      = "op_weight_msg_update"
+	OpWeightMsgDelete      = "op_weight_msg_delete"
+	OpWeightMsgRename      = "op_weight_msg_rename"
+	OpWeightMsgRenewLease  = "op_weight_msg_renew_lease"
+	OpWeightMsgMultiUpdate = "op_weight_msg_multi_update"
+
+	DefaultWeightMsgCreate      = 100
+	DefaultWeightMsgUpdate      = 50
+	DefaultWeightMsgDelete      = 20
+	DefaultWeightMsgRename      = 20
+	DefaultWeightMsgRenewLease  = 20
+	DefaultWeightMsgMultiUpdate = 20
+
+	// keys are spread over a few UUIDs so that accounts share them
+	simUUIDs = 3
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, k keeper.IKeeper) simulation.WeightedOperations {
+	var (
+		weightMsgCreate      int
+		weightMsgUpdate      int
+		weightMsgDelete      int
+		weightMsgRename      int
+		weightMsgRenewLease  int
+		weightMsgMultiUpdate int
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgCreate, &weightMsgCreate, nil,
+		func(_ *rand.Rand) { weightMsgCreate = DefaultWeightMsgCreate })
+	appParams.GetOrGenerate(cdc, OpWeightMsgUpdate, &weightMsgUpdate, nil,
+		func(_ *rand.Rand) { weightMsgUpdate = DefaultWeightMsgUpdate })
+	appParams.GetOrGenerate(cdc, OpWeightMsgDelete, &weightMsgDelete, nil,
+		func(_ *rand.Rand) { weightMsgDelete = DefaultWeightMsgDelete })
+	appParams.GetOrGenerate(cdc, OpWeightMsgRename, &weightMsgRename, nil,
+		func(_ *rand.Rand) { weightMsgRename = DefaultWeightMsgRename })
+	appParams.GetOrGenerate(cdc, OpWeightMsgRenewLease, &weightMsgRenewLease, nil,
+		func(_ *rand.Rand) { weightMsgRenewLease = DefaultWeightMsgRenewLease })
+	appParams.GetOrGenerate(cdc, OpWeightMsgMultiUpdate, &weightMsgMultiUpdate, nil,
+		func(_ *rand.Rand) { weightMsgMultiUpdate = DefaultWeightMsgMultiUpdate })
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(weightMsgCreate, SimulateMsgCreate(ak, k)),
+		simulation.NewWeightedOperation(weightMsgUpdate, SimulateMsgUpdate(ak, k)),
+		simulation.NewWeightedOperation(weightMsgDelete, SimulateMsgDelete(ak, k)),
+		simulation.NewWeightedOperation(weightMsgRename, SimulateMsgRename(ak, k)),
+		simulation.NewWeightedOperation(weightMsgRenewLease, SimulateMsgRenewLease(ak, k)),
+		simulation.NewWeightedOperation(weightMsgMultiUpdate, SimulateMsgMultiUpdate(ak, k)),
+	}
+}
+
+func SimulateMsgCreate(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		UUID := randomUUID(r)
+		key := randomKey(r)
+
+		if k.IsKeyPresent(ctx, k.GetKVStore(ctx), UUID, key) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgCreate(UUID, key, randomValue(r), randomLease(r), simAccount.Address)
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+func SimulateMsgUpdate(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, UUID, keys := randomOwnedKeys(r, ctx, k, accs)
+		if len(keys) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.MsgUpdate{UUID: UUID, Key: keys[r.Intn(len(keys))], Value: randomValue(r), Owner: simAccount.Address}
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+func SimulateMsgDelete(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, UUID, keys := randomOwnedKeys(r, ctx, k, accs)
+		if len(keys) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgDelete(UUID, keys[r.Intn(len(keys))], simAccount.Address)
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+func SimulateMsgRename(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, UUID, keys := randomOwnedKeys(r, ctx, k, accs)
+		newKey := randomKey(r)
+		if len(keys) == 0 || k.IsKeyPresent(ctx, k.GetKVStore(ctx), UUID, newKey) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgRename(UUID, keys[r.Intn(len(keys))], newKey, simAccount.Address)
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+func SimulateMsgRenewLease(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, UUID, keys := randomOwnedKeys(r, ctx, k, accs)
+		if len(keys) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.MsgRenewLease{UUID: UUID, Key: keys[r.Intn(len(keys))], Lease: randomLease(r), Owner: simAccount.Address}
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+func SimulateMsgMultiUpdate(ak types.AccountKeeper, k keeper.IKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+		simAccount, UUID, keys := randomOwnedKeys(r, ctx, k, accs)
+		if len(keys) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		n := simulation.RandIntBetween(r, 1, len(keys)+1)
+		if max := k.GetParams(ctx).MaxBatchSize; max != 0 && uint64(n) > max {
+			n = int(max)
+		}
+
+		var keyValues []types.KeyValue
+		for _, i := range r.Perm(len(keys))[:n] {
+			keyValues = append(keyValues, types.KeyValue{Key: keys[i], Value: randomValue(r)})
+		}
+
+		msg := types.NewMsgMultiUpdate(UUID, simAccount.Address, keyValues)
+		return deliver(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+// randomOwnedKeys picks a random account and UUID and returns the keys the account
+// owns in it
+func randomOwnedKeys(r *rand.Rand, ctx sdk.Context, k keeper.IKeeper, accs []simulation.Account) (simulation.Account, string, []string) {
+	simAccount, _ := simulation.RandomAcc(r, accs)
+	UUID := randomUUID(r)
+	return simAccount, UUID, k.GetKeys(ctx, k.GetKVStore(ctx), UUID, simAccount.Address).Keys
+}
+
+func randomUUID(r *rand.Rand) string {
+	return fmt.Sprintf("uuid%d", r.Intn(simUUIDs))
+}
+
+func randomKey(r *rand.Rand) string {
+	return simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 8))
+}
+
+// values are arbitrary bytes, as the store is binary safe
+func randomValue(r *rand.Rand) []byte {
+	value := make([]byte, simulation.RandIntBetween(r, 1, 512))
+	r.Read(value)
+	return value
+}
+
+// leases are kept short so that keys also expire during a simulation
+func randomLease(r *rand.Rand) int64 {
+	return int64(simulation.RandIntBetween(r, 10, 500))
+}
+
+func deliver(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, ak types.AccountKeeper, simAccount simulation.Account, msg sdk.Msg, chainID string,
+) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+	account := ak.GetAccount(ctx, simAccount.Address)
+	fees, err := simulation.RandomFees(r, ctx, account.SpendableCoins(ctx.BlockTime()))
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	tx := helpers.GenTx(
+		[]sdk.Msg{msg},
+		fees,
+		helpers.DefaultGenTxGas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+
+	_, _, err = app.Deliver(tx)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	return simulation.NewOperationMsg(msg, true, ""), nil, nil
+}
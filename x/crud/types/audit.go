@@ -0,0 +1,49 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// the operations recorded in the audit log
+const (
+	AuditOpCreate = "create"
+	AuditOpUpdate = "update"
+	AuditOpDelete = "delete"
+	AuditOpExpire = "expire"
+	AuditOpEvict  = "evict"
+	// the owner ended the lease with a MsgExpireNow; the removal is recorded as
+	// expire at the end of the grace period
+	AuditOpExpireNow = "expirenow"
+)
+
+// AuditConfig enables the audit log of a UUID. Like an index it is owned by the account
+// that enabled it.
+type AuditConfig struct {
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// AuditEntry records one change to a key of an audited UUID. Seq numbers the entries of
+// the UUID from 1, Actor is the owner the change was made as (empty for keys removed at
+// the end of their lease) and ValueHash is the hash of the value written, empty for
+// removals.
+type AuditEntry struct {
+	UUID      string         `json:"uuid"`
+	Seq       uint64         `json:"seq,string"`
+	Op        string         `json:"op"`
+	Key       string         `json:"key"`
+	Actor     sdk.AccAddress `json:"actor"`
+	Height    int64          `json:"height,string"`
+	ValueHash []byte         `json:"value_hash"`
+}
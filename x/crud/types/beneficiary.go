@@ -0,0 +1,24 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// Beneficiary takes over a key when its lease runs out, with a fresh default lease
+// paid for by Escrow, which the owner deposited when naming the beneficiary.
+type Beneficiary struct {
+	Address sdk.AccAddress `json:"address"`
+	Escrow  sdk.Coins      `json:"escrow"`
+}
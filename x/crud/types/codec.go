@@ -25,19 +25,43 @@ func init() {
 }
 
 func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgAtomic{}, "crud/atomic", nil)
+	cdc.RegisterConcrete(MsgCommitUpload{}, "crud/commitupload", nil)
+	cdc.RegisterConcrete(MsgCopy{}, "crud/copy", nil)
+	cdc.RegisterConcrete(MsgCopyUUID{}, "crud/copyuuid", nil)
 	cdc.RegisterConcrete(MsgCount{}, "crud/count", nil)
 	cdc.RegisterConcrete(MsgCreate{}, "crud/create", nil)
 	cdc.RegisterConcrete(MsgDeleteAll{}, "crud/deleteall", nil)
 	cdc.RegisterConcrete(MsgDelete{}, "crud/delete", nil)
+	cdc.RegisterConcrete(MsgDeleteAudit{}, "crud/deleteaudit", nil)
+	cdc.RegisterConcrete(MsgDeleteHashIndex{}, "crud/deletehashindex", nil)
+	cdc.RegisterConcrete(MsgDeleteIndex{}, "crud/deleteindex", nil)
+	cdc.RegisterConcrete(MsgDeleteRetention{}, "crud/deleteretention", nil)
+	cdc.RegisterConcrete(MsgDeleteSchema{}, "crud/deleteschema", nil)
+	cdc.RegisterConcrete(MsgDepositEscrow{}, "crud/depositescrow", nil)
+	cdc.RegisterConcrete(MsgWithdrawEscrow{}, "crud/withdrawescrow", nil)
+	cdc.RegisterConcrete(MsgExpireNow{}, "crud/expirenow", nil)
+	cdc.RegisterConcrete(MsgFreeze{}, "crud/freeze", nil)
 	cdc.RegisterConcrete(MsgGetLease{}, "crud/getlease", nil)
 	cdc.RegisterConcrete(MsgGetNShortestLeases{}, "crud/getnshortestleases", nil)
 	cdc.RegisterConcrete(MsgHas{}, "crud/has", nil)
 	cdc.RegisterConcrete(MsgKeyValues{}, "crud/keyvalues", nil)
 	cdc.RegisterConcrete(MsgKeys{}, "crud/keys", nil)
 	cdc.RegisterConcrete(MsgMultiUpdate{}, "crud/multiupdate", nil)
+	cdc.RegisterConcrete(MsgPatch{}, "crud/patch", nil)
 	cdc.RegisterConcrete(MsgRead{}, "crud/read", nil)
 	cdc.RegisterConcrete(MsgRename{}, "crud/rename", nil)
 	cdc.RegisterConcrete(MsgRenewLease{}, "crud/renewlease", nil)
 	cdc.RegisterConcrete(MsgRenewLeaseAll{}, "crud/renewleaseall", nil)
+	cdc.RegisterConcrete(MsgSetAudit{}, "crud/setaudit", nil)
+	cdc.RegisterConcrete(MsgSetAutoRenew{}, "crud/setautorenew", nil)
+	cdc.RegisterConcrete(MsgSetBeneficiary{}, "crud/setbeneficiary", nil)
+	cdc.RegisterConcrete(MsgSetHashIndex{}, "crud/sethashindex", nil)
+	cdc.RegisterConcrete(MsgSetIndex{}, "crud/setindex", nil)
+	cdc.RegisterConcrete(MsgSetRetention{}, "crud/setretention", nil)
+	cdc.RegisterConcrete(MsgSetSchema{}, "crud/setschema", nil)
+	cdc.RegisterConcrete(MsgStartUpload{}, "crud/startupload", nil)
+	cdc.RegisterConcrete(MsgUnfreeze{}, "crud/unfreeze", nil)
 	cdc.RegisterConcrete(MsgUpdate{}, "crud/update", nil)
+	cdc.RegisterConcrete(MsgUploadChunk{}, "crud/uploadchunk", nil)
 }
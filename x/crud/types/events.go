@@ -0,0 +1,44 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+// crud module event types and attribute keys
+const (
+	EventTypeRenewLease = "renew_lease"
+	EventTypeAutoRenew  = "auto_renew"
+	EventTypeChange     = "crud_change"
+	EventTypePurge      = "purge"
+	EventTypeEvict      = "evict"
+	EventTypeExpireNow  = "expire_now"
+
+	AttributeKeyUUID           = "uuid"
+	AttributeKeyKey            = "key"
+	AttributeKeyExpiry         = "expiry"
+	AttributeKeyAction         = "action"
+	AttributeKeyHash           = "hash"
+	AttributeKeyOwner          = "owner"
+	AttributeKeyCost           = "cost"
+	AttributeKeyRefund         = "refund"
+	AttributeKeyPurgedKeys     = "purged_keys"
+	AttributeKeyReclaimedBytes = "reclaimed_bytes"
+	AttributeKeyBacklog        = "backlog"
+)
+
+// values of the action attribute of a crud_change event
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
@@ -0,0 +1,133 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+type GenesisState struct {
+	BlzValues     []GenesisValue
+	Indexes       []GenesisIndex
+	Frozen        []GenesisFreeze
+	Beneficiaries []GenesisBeneficiary
+	Escrows       []GenesisEscrow
+	AutoRenew     []GenesisAutoRenew
+	LeaseDeposits []GenesisLeaseDeposit
+	Audits        []GenesisAudit
+	AuditLog      []AuditEntry
+	Retention     []GenesisRetention
+	HashIndexes   []GenesisHashIndex
+	RentHistory   []GenesisRentRecord
+	Schemas       []GenesisSchema
+	Uploads       []GenesisUpload
+	Params        Params
+}
+
+// GenesisValue is a stored key with its value. Value.Height is exported relative to the
+// export height, so the lease, Value.Height + Value.Lease blocks from the height the
+// genesis is imported at, has run out for a key exported in its expiry grace period.
+type GenesisValue struct {
+	UUID  string
+	Key   string
+	Value BLZValue
+}
+
+// GenesisIndex is the value index configuration of a UUID.
+type GenesisIndex struct {
+	UUID   string
+	Config IndexConfig
+}
+
+// GenesisFreeze is a key, or with an empty Key all of Owner's keys in a UUID, frozen
+// against writes.
+type GenesisFreeze struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+// GenesisBeneficiary is the beneficiary of a key. Its escrow stays in the escrow module
+// account, exported with the other accounts.
+type GenesisBeneficiary struct {
+	UUID        string
+	Key         string
+	Beneficiary Beneficiary
+}
+
+// GenesisEscrow is what an owner has deposited to pay for auto-renewed leases. Like the
+// beneficiary escrows the coins stay in the escrow module account.
+type GenesisEscrow struct {
+	Owner   sdk.AccAddress
+	Balance sdk.Coins
+}
+
+// GenesisAutoRenew is a key whose lease is renewed from its owner's escrow.
+type GenesisAutoRenew struct {
+	UUID string
+	Key  string
+}
+
+// GenesisAudit is the audit configuration of a UUID. The audit log itself is exported
+// entry by entry, including the entries of UUIDs no longer audited that have not been
+// pruned yet; imported entries are kept for AuditRetentionBlocks from the import.
+type GenesisAudit struct {
+	UUID   string
+	Config AuditConfig
+}
+
+// GenesisRetention is the retention policy of a UUID.
+type GenesisRetention struct {
+	UUID   string
+	Policy RetentionPolicy
+}
+
+// GenesisSchema is the schema of a UUID.
+type GenesisSchema struct {
+	UUID   string
+	Schema Schema
+}
+
+// GenesisHashIndex is the hash index configuration of a UUID, the index itself being
+// rebuilt from the values on import.
+type GenesisHashIndex struct {
+	UUID   string
+	Config HashIndexConfig
+}
+
+// GenesisRentRecord is a rent record of Owner. Like the leases, Record.StartHeight is
+// exported relative to the export height, so the epochs of the exported chain start at
+// negative heights of the chain the genesis is imported into.
+type GenesisRentRecord struct {
+	Owner  sdk.AccAddress
+	Record RentRecord
+}
+
+// GenesisLeaseDeposit is the unearned lease fee of a key, its coins kept in the lease
+// deposit module account. Like the leases, Deposit.From and Deposit.To are exported
+// relative to the export height and count from the height the genesis is imported at.
+type GenesisLeaseDeposit struct {
+	UUID    string
+	Key     string
+	Deposit LeaseDeposit
+}
+
+// GenesisUpload is a pending chunked upload with the chunks received so far. Like the
+// leases, Upload.Height is exported relative to the export height, so the upload keeps
+// the blocks it has left before it times out.
+type GenesisUpload struct {
+	UUID   string
+	Key    string
+	Upload Upload
+	Chunks [][]byte
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxKeyPatternSize  = 256
+	MaxKeyPatternStars = 8
+
+	// MaxMatchSteps bounds the work of one match query, a step being one character of a
+	// key compared against one token of the pattern. A query that runs out returns the
+	// keys matched so far and where to continue.
+	MaxMatchSteps = 1 << 22
+)
+
+const (
+	tokenLiteral = iota
+	tokenAny
+	tokenStar
+	tokenClass
+)
+
+type runeRange struct {
+	lo, hi rune
+}
+
+type patternToken struct {
+	kind    int
+	literal rune
+	ranges  []runeRange
+	negated bool
+}
+
+func (t patternToken) matches(r rune) bool {
+	switch t.kind {
+	case tokenLiteral:
+		return r == t.literal
+	case tokenClass:
+		for _, rr := range t.ranges {
+			if rr.lo <= r && r <= rr.hi {
+				return !t.negated
+			}
+		}
+		return t.negated
+	}
+	return true
+}
+
+// KeyPattern is a glob pattern over keys: * matches any run of characters (: included),
+// ? any one character, [...] one character of a set, which may hold ranges such as a-z and
+// is negated by a leading !, and \ takes the character after it literally.
+type KeyPattern struct {
+	pattern string
+	tokens  []patternToken
+	prefix  string
+}
+
+// CompileKeyPattern parses a glob pattern, refusing ones longer than MaxKeyPatternSize
+// or with more than MaxKeyPatternStars stars.
+func CompileKeyPattern(pattern string) (KeyPattern, error) {
+	if len(pattern) == 0 {
+		return KeyPattern{}, errors.New("pattern empty")
+	}
+	if len(pattern) > MaxKeyPatternSize {
+		return KeyPattern{}, errors.New("pattern too large")
+	}
+	if !utf8.ValidString(pattern) {
+		return KeyPattern{}, errors.New("pattern is not valid UTF-8")
+	}
+
+	p := KeyPattern{pattern: pattern}
+	runes := []rune(pattern)
+	stars := 0
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			// a run of stars matches what one does
+			if len(p.tokens) > 0 && p.tokens[len(p.tokens)-1].kind == tokenStar {
+				continue
+			}
+			if stars++; stars > MaxKeyPatternStars {
+				return KeyPattern{}, errors.New("pattern has too many *")
+			}
+			p.tokens = append(p.tokens, patternToken{kind: tokenStar})
+		case '?':
+			p.tokens = append(p.tokens, patternToken{kind: tokenAny})
+		case '[':
+			token, next, err := parseClass(runes, i+1)
+			if err != nil {
+				return KeyPattern{}, err
+			}
+			p.tokens = append(p.tokens, token)
+			i = next
+		case '\\':
+			if i++; i == len(runes) {
+				return KeyPattern{}, errors.New("pattern ends in \\")
+			}
+			p.tokens = append(p.tokens, patternToken{kind: tokenLiteral, literal: runes[i]})
+		default:
+			p.tokens = append(p.tokens, patternToken{kind: tokenLiteral, literal: runes[i]})
+		}
+	}
+
+	var prefix strings.Builder
+	for _, token := range p.tokens {
+		if token.kind != tokenLiteral {
+			break
+		}
+		prefix.WriteRune(token.literal)
+	}
+	p.prefix = prefix.String()
+
+	return p, nil
+}
+
+// parseClass parses the set of a [...] starting at runes[i], returning the index of its ]
+func parseClass(runes []rune, i int) (patternToken, int, error) {
+	token := patternToken{kind: tokenClass}
+	if i < len(runes) && runes[i] == '!' {
+		token.negated = true
+		i++
+	}
+
+	// a ] right after the [ (or [!) is taken literally
+	for first := true; i < len(runes); i, first = i+1, false {
+		if runes[i] == ']' && !first {
+			return token, i, nil
+		}
+
+		lo := runes[i]
+		if lo == '\\' {
+			if i++; i == len(runes) {
+				break
+			}
+			lo = runes[i]
+		}
+
+		hi := lo
+		if i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']' {
+			i += 2
+			if hi = runes[i]; hi == '\\' {
+				if i++; i == len(runes) {
+					break
+				}
+				hi = runes[i]
+			}
+			if hi < lo {
+				return patternToken{}, 0, errors.New("pattern has a reversed range")
+			}
+		}
+		token.ranges = append(token.ranges, runeRange{lo, hi})
+	}
+
+	return patternToken{}, 0, errors.New("pattern has an unterminated [")
+}
+
+func (p KeyPattern) String() string {
+	return p.pattern
+}
+
+// Prefix is the literal start of the pattern, which every matching key begins with.
+func (p KeyPattern) Prefix() string {
+	return p.prefix
+}
+
+// Match reports whether the whole of key matches the pattern and the number of steps it
+// took, at most the length of the key times the number of tokens of the pattern.
+func (p KeyPattern) Match(key string) (bool, uint64) {
+	runes := []rune(key)
+	steps := uint64(0)
+
+	// on a mismatch the last star seen takes one more character and matching resumes
+	// after it, earlier stars never need to be revisited
+	t, k := 0, 0
+	starT, starK := -1, 0
+	for k < len(runes) {
+		steps++
+		switch {
+		case t < len(p.tokens) && p.tokens[t].kind == tokenStar:
+			starT, starK = t, k
+			t++
+		case t < len(p.tokens) && p.tokens[t].matches(runes[k]):
+			t++
+			k++
+		case starT >= 0:
+			starK++
+			t, k = starT+1, starK
+		default:
+			return false, steps
+		}
+	}
+
+	for t < len(p.tokens) && p.tokens[t].kind == tokenStar {
+		t++
+	}
+	return t == len(p.tokens), steps
+}
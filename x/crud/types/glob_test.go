@@ -0,0 +1,109 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestKeyPattern_Match(t *testing.T) {
+	cases := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"sensor:*:temp", "sensor:1:temp", true},
+		{"sensor:*:temp", "sensor:kitchen:2:temp", true},
+		{"sensor:*:temp", "sensor::temp", true},
+		{"sensor:*:temp", "sensor:1:humidity", false},
+		{"sensor:*:temp", "sensor:1:temp:max", false},
+		{"*", "", true},
+		{"*", "anything", true},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "abcabc", true},
+		{"a*b*c", "acb", false},
+		{"a**b", "ab", true},
+		{"?", "é", true},
+		{"?", "", false},
+		{"key?", "key1", true},
+		{"key?", "key12", false},
+		{"key[0-9]", "key7", true},
+		{"key[0-9]", "keyx", false},
+		{"key[!0-9]", "keyx", true},
+		{"key[!0-9]", "key7", false},
+		{"key[abc-]", "key-", true},
+		{"[]]", "]", true},
+		{"[!]]", "]", false},
+		{"a\\*", "a*", true},
+		{"a\\*", "ab", false},
+		{"[\\]]", "]", true},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+	}
+
+	for _, c := range cases {
+		p, err := CompileKeyPattern(c.pattern)
+		assert.Nil(t, err, c.pattern)
+		match, _ := p.Match(c.key)
+		assert.Equal(t, c.match, match, c.pattern+" "+c.key)
+	}
+}
+
+func TestKeyPattern_Prefix(t *testing.T) {
+	for pattern, prefix := range map[string]string{
+		"sensor:*:temp": "sensor:",
+		"exact":         "exact",
+		"*end":          "",
+		"a\\*b*":        "a*b",
+		"key[0-9]":      "key",
+		"key?":          "key",
+	} {
+		p, err := CompileKeyPattern(pattern)
+		assert.Nil(t, err)
+		assert.Equal(t, prefix, p.Prefix(), pattern)
+	}
+}
+
+func TestCompileKeyPattern_Limits(t *testing.T) {
+	for pattern, msg := range map[string]string{
+		"":                                       "pattern empty",
+		strings.Repeat("a", MaxKeyPatternSize+1): "pattern too large",
+		strings.Repeat("a*", MaxKeyPatternStars+1): "pattern has too many *",
+		"key[0-9":  "pattern has an unterminated [",
+		"key[9-0]": "pattern has a reversed range",
+		"key\\":    "pattern ends in \\",
+		"key\xff":  "pattern is not valid UTF-8",
+	} {
+		_, err := CompileKeyPattern(pattern)
+		assert.EqualError(t, err, msg, pattern)
+	}
+
+	// runs of stars count as one
+	_, err := CompileKeyPattern(strings.Repeat("*", MaxKeyPatternSize))
+	assert.Nil(t, err)
+}
+
+func TestKeyPattern_MatchSteps(t *testing.T) {
+	// the steps of the worst case are bounded by the key length times the pattern's tokens
+	p, err := CompileKeyPattern(strings.Repeat("*a", MaxKeyPatternStars) + "b")
+	assert.Nil(t, err)
+
+	key := strings.Repeat("a", 1000)
+	match, steps := p.Match(key)
+	assert.False(t, match)
+	assert.True(t, steps <= uint64(len(key)*(2*MaxKeyPatternStars+1)))
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// CrudHooks are told of every change to a crud entry, from transactions, expiring
+// leases and genesis alike. They are called before the change is written, so they
+// must work from the values they are given rather than read the entry back.
+type CrudHooks interface {
+	// AfterValueSet is called when key is created (oldValue is nil), updated or
+	// handed to a new owner.
+	AfterValueSet(ctx sdk.Context, UUID string, key string, oldValue *BLZValue, value BLZValue)
+	// AfterValueRemoved is called when key is deleted, renamed away, evicted or
+	// removed at the end of its lease.
+	AfterValueRemoved(ctx sdk.Context, UUID string, key string, value BLZValue)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+// IndexConfig enables the value index of a UUID. With an empty Field the whole value
+// is indexed, otherwise the (dot separated) JSON field is extracted and indexed.
+type IndexConfig struct {
+	Field string         `json:"field"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// IndexedValue returns the part of value covered by the index, false if the value
+// has nothing to index (not JSON or missing the field).
+func (c IndexConfig) IndexedValue(value []byte) (string, bool) {
+	if len(c.Field) == 0 {
+		return string(value), true
+	}
+
+	var doc interface{}
+	if err := decodeJSON(value, &doc); err != nil {
+		return "", false
+	}
+
+	for _, name := range strings.Split(c.Field, ".") {
+		object, ok := doc.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+
+		if doc, ok = object[name]; !ok {
+			return "", false
+		}
+	}
+
+	if s, ok := doc.(string); ok {
+		return s, true
+	}
+
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// HashIndexConfig enables the hash index of a UUID, which finds keys by the ValueHash
+// of their whole value. Like a value index it is owned by the account that enabled it.
+type HashIndexConfig struct {
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+func IndexHash(value string) []byte {
+	hash := sha256.Sum256([]byte(value))
+	return hash[:]
+}
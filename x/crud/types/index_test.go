@@ -0,0 +1,55 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIndexConfig_IndexedValue(t *testing.T) {
+	value, ok := IndexConfig{}.IndexedValue([]byte("plain value"))
+	assert.True(t, ok)
+	assert.Equal(t, "plain value", value)
+
+	config := IndexConfig{Field: "user.name"}
+
+	value, ok = config.IndexedValue([]byte(`{"user":{"name":"bob","age":5}}`))
+	assert.True(t, ok)
+	assert.Equal(t, "bob", value)
+
+	_, ok = config.IndexedValue([]byte(`{"user":{"age":5}}`))
+	assert.False(t, ok)
+
+	_, ok = config.IndexedValue([]byte(`{"user":"bob"}`))
+	assert.False(t, ok)
+
+	_, ok = config.IndexedValue([]byte("not json"))
+	assert.False(t, ok)
+
+	value, ok = IndexConfig{Field: "user"}.IndexedValue([]byte(`{"user":{"name":"bob","age":5}}`))
+	assert.True(t, ok)
+	assert.Equal(t, `{"age":5,"name":"bob"}`, value)
+
+	value, ok = IndexConfig{Field: "n"}.IndexedValue([]byte(`{"n":10.0}`))
+	assert.True(t, ok)
+	assert.Equal(t, "10.0", value)
+}
+
+func TestIndexHash(t *testing.T) {
+	assert.Len(t, IndexHash("value"), 32)
+	assert.Equal(t, IndexHash("value"), IndexHash("value"))
+	assert.NotEqual(t, IndexHash("value"), IndexHash("other"))
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+const (
+	// module name
+	ModuleName = "crud"
+
+	// EscrowName is the module account holding beneficiary deposits
+	EscrowName = "crud_escrow"
+
+	// LeaseDepositName is the module account holding lease fees until they are earned
+	LeaseDepositName = "crud_lease"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+	LeaseKey = "crudLease"
+	IndexKey = "crudIndex"
+)
+
+// prefixes for the entries held in the index store
+var (
+	IndexConfigPrefix  = []byte{0x00}
+	ValueIndexPrefix   = []byte{0x01}
+	UploadPrefix       = []byte{0x02}
+	UploadChunkPrefix  = []byte{0x03}
+	OwnerIndexPrefix   = []byte{0x04}
+	CountPrefix        = []byte{0x05}
+	LeaseIndexPrefix   = []byte{0x06}
+	StoreVersionKey    = []byte{0x07}
+	FreezePrefix       = []byte{0x08}
+	BeneficiaryPrefix  = []byte{0x09}
+	PurgedHeightKey    = []byte{0x0a}
+	EscrowPrefix       = []byte{0x0b}
+	AutoRenewPrefix    = []byte{0x0c}
+	LeaseDepositPrefix = []byte{0x0d}
+	LastPurgeKey       = []byte{0x0e}
+	RateLimitPrefix    = []byte{0x0f}
+	UUIDBytesPrefix    = []byte{0x10}
+	UUIDOwnersPrefix   = []byte{0x11}
+	AuditConfigPrefix  = []byte{0x12}
+	AuditSeqPrefix     = []byte{0x13}
+	AuditLogPrefix     = []byte{0x14}
+	AuditPrunePrefix   = []byte{0x15}
+	RetentionPrefix    = []byte{0x16}
+	RetentionAgePrefix = []byte{0x17}
+	RetentionDuePrefix = []byte{0x18}
+	HashIndexPrefix    = []byte{0x19}
+	HashEntryPrefix    = []byte{0x1a}
+	StoredBytesKey     = []byte{0x1b}
+	RentPrefix         = []byte{0x1c}
+	SchemaPrefix       = []byte{0x1d}
+	BacklogKey         = []byte{0x1e}
+)
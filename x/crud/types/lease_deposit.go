@@ -0,0 +1,42 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// LeaseDeposit is the lease fee paid for a key and not yet passed on to the validators.
+// It is earned evenly over the blocks From to To, the key's expiry, and what is still
+// unearned is refunded to the owner if the key is deleted early.
+type LeaseDeposit struct {
+	Amount sdk.Coins `json:"amount"`
+	From   int64     `json:"from"`
+	To     int64     `json:"to"`
+}
+
+// Unearned returns the share of Amount for the blocks left after height, rounded down.
+func (d LeaseDeposit) Unearned(height int64) sdk.Coins {
+	if height < d.From {
+		height = d.From
+	}
+	if height >= d.To {
+		return sdk.NewCoins()
+	}
+
+	unearned := sdk.NewCoins()
+	for _, coin := range d.Amount {
+		unearned = unearned.Add(sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(d.To-height).QuoRaw(d.To-d.From)))
+	}
+	return unearned
+}
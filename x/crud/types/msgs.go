@@ -0,0 +1,1634 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	RouterKey    = ModuleName
+	MaxKeySize   = 4097 // UUID and key together, any bytes allowed
+	MaxValueSize = 262144
+
+	// MaxContentTypeSize bounds both the content type and the encoding of a value
+	MaxContentTypeSize = 128
+
+	// MsgDeleteAll deletes at most this many keys, the rest are left for another message
+	MaxDeleteAllKeys = 1000
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Create
+type MsgCreate struct {
+	UUID  string
+	Key   string
+	Value []byte
+	Lease int64
+	Owner sdk.AccAddress
+	// optional, e.g. "application/json" and "gzip", returned with the value
+	ContentType string `json:",omitempty"`
+	Encoding    string `json:",omitempty"`
+}
+
+func NewMsgCreate(UUID string, key string, value []byte, lease int64, owner sdk.AccAddress) MsgCreate {
+
+	return MsgCreate{
+		UUID:  UUID,
+		Key:   key,
+		Value: value,
+		Lease: lease,
+		Owner: owner,
+	}
+}
+
+func (msg MsgCreate) Route() string { return RouterKey }
+
+func (msg MsgCreate) Type() string { return "create" }
+
+func (msg MsgCreate) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	if len(msg.Value) > MaxValueSize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	if err := ValidateContentType(msg.ContentType, msg.Encoding); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (msg MsgCreate) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCreate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Read
+type MsgRead struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgRead(UUID string, key string, owner sdk.AccAddress) MsgRead {
+	return MsgRead{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgRead) Route() string { return RouterKey }
+
+func (msg MsgRead) Type() string { return "read" }
+
+func (msg MsgRead) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+	return nil
+}
+
+func (msg MsgRead) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRead) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Update
+type MsgUpdate struct {
+	UUID  string
+	Key   string
+	Value []byte
+	Lease int64
+	Owner sdk.AccAddress
+	// when set, the update fails unless the key is at this version
+	Version uint64 `json:",omitempty"`
+	// when set, replace the content type and encoding of the key, see MsgCreate
+	ContentType string `json:",omitempty"`
+	Encoding    string `json:",omitempty"`
+}
+
+func (msg MsgUpdate) Route() string { return RouterKey }
+
+func (msg MsgUpdate) Type() string { return "update" }
+
+func (msg MsgUpdate) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.Value) > MaxValueSize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large")
+	}
+
+	if err := ValidateContentType(msg.ContentType, msg.Encoding); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (msg MsgUpdate) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUpdate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Delete
+type MsgDelete struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+	// when set, the delete fails unless the key is at this version
+	Version uint64 `json:",omitempty"`
+}
+
+func NewMsgDelete(UUID string, key string, owner sdk.AccAddress) MsgDelete {
+	return MsgDelete{
+		UUID:  UUID,
+		Key:   key,
+		Owner: owner,
+	}
+}
+
+func (msg MsgDelete) Route() string { return RouterKey }
+
+func (msg MsgDelete) Type() string { return "delete" }
+
+func (msg MsgDelete) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+	return nil
+}
+
+func (msg MsgDelete) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDelete) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Keys
+type MsgKeys struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgKeys(UUID string, owner sdk.AccAddress) MsgKeys {
+	return MsgKeys{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgKeys) Route() string { return RouterKey }
+
+func (msg MsgKeys) Type() string { return "keys" }
+
+func (msg MsgKeys) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+	return nil
+}
+
+func (msg MsgKeys) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgKeys) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Has
+type MsgHas struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgHas(UUID string, key string, owner sdk.AccAddress) MsgHas {
+	return MsgHas{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgHas) Route() string { return RouterKey }
+
+func (msg MsgHas) Type() string { return "has" }
+
+func (msg MsgHas) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "key empty")
+	}
+	return nil
+}
+
+func (msg MsgHas) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgHas) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Rename
+// An existing NewKey is only replaced if Overwrite is set.
+type MsgRename struct {
+	UUID      string
+	Key       string
+	NewKey    string
+	Overwrite bool
+	Owner     sdk.AccAddress
+}
+
+func NewMsgRename(uuid string, key string, newKey string, owner sdk.AccAddress) MsgRename {
+	return MsgRename{UUID: uuid, Key: key, NewKey: newKey, Owner: owner}
+}
+
+func (msg MsgRename) Route() string { return "crud" }
+
+func (msg MsgRename) Type() string { return "rename" }
+
+func (msg MsgRename) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "key empty")
+	}
+
+	if len(msg.NewKey) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new key empty")
+	}
+
+	if msg.NewKey == msg.Key {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new key same as key")
+	}
+
+	if len(msg.UUID)+len(msg.NewKey) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+NewKey too large")
+	}
+
+	return nil
+}
+
+func (msg MsgRename) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRename) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Owner} }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// KeyValue
+type MsgKeyValues struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgKeyValues(uuid string, owner sdk.AccAddress) MsgKeyValues {
+	return MsgKeyValues{UUID: uuid, Owner: owner}
+}
+
+func (msg MsgKeyValues) Route() string { return "crud" }
+
+func (msg MsgKeyValues) Type() string { return "keyvalues" }
+
+func (msg MsgKeyValues) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgKeyValues) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgKeyValues) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Owner} }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Count
+type MsgCount struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgCount(UUID string, owner sdk.AccAddress) MsgCount {
+	return MsgCount{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgCount) Route() string { return RouterKey }
+
+func (msg MsgCount) Type() string { return "count" }
+
+func (msg MsgCount) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+	return nil
+}
+
+func (msg MsgCount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteAll
+type MsgDeleteAll struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteAll(UUID string, owner sdk.AccAddress) MsgDeleteAll {
+	return MsgDeleteAll{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteAll) Route() string { return RouterKey }
+
+func (msg MsgDeleteAll) Type() string { return "deleteall" }
+
+func (msg MsgDeleteAll) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+	return nil
+}
+
+func (msg MsgDeleteAll) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteAll) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// MultiUpdate
+type MsgMultiUpdate struct {
+	UUID      string
+	Owner     sdk.AccAddress
+	KeyValues []KeyValue
+	// apply the key values that can be, rather than none of them when one cannot
+	Partial bool `json:",omitempty"`
+}
+
+func NewMsgMultiUpdate(UUID string, owner sdk.AccAddress, keyValues []KeyValue) MsgMultiUpdate {
+	return MsgMultiUpdate{UUID: UUID, Owner: owner, KeyValues: keyValues}
+}
+
+func (msg MsgMultiUpdate) Route() string { return RouterKey }
+
+func (msg MsgMultiUpdate) Type() string { return "multiupdate" }
+
+func (msg MsgMultiUpdate) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.KeyValues) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "KeyValues empty")
+	}
+
+	// scan key/values...
+	for i := range msg.KeyValues[:] {
+		if len(msg.UUID)+len(msg.KeyValues[i].Key) > MaxKeySize {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("UUID+Key too large [%d]", i))
+		}
+
+		if len(msg.KeyValues[i].Value) > MaxValueSize {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Value too large [%d]", i))
+		}
+	}
+
+	return nil
+}
+
+func (msg MsgMultiUpdate) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgMultiUpdate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Atomic
+// Applies creates, updates and deletes across any number of UUIDs, all of them or none.
+type MsgAtomic struct {
+	Ops   []AtomicOp
+	Owner sdk.AccAddress
+}
+
+func NewMsgAtomic(ops []AtomicOp, owner sdk.AccAddress) MsgAtomic {
+	return MsgAtomic{Ops: ops, Owner: owner}
+}
+
+func (msg MsgAtomic) Route() string { return RouterKey }
+
+func (msg MsgAtomic) Type() string { return "atomic" }
+
+func (msg MsgAtomic) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.Ops) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Ops empty")
+	}
+
+	for i := range msg.Ops {
+		op := msg.Ops[i].Msg(msg.Owner)
+		if op == nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Unknown operation %s [%d]", msg.Ops[i].Op, i))
+		}
+
+		if err := op.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, fmt.Sprintf("[%d]", i))
+		}
+	}
+
+	return nil
+}
+
+func (msg MsgAtomic) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAtomic) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// GetLease
+type MsgGetLease struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func (msg MsgGetLease) Route() string { return RouterKey }
+
+func (msg MsgGetLease) Type() string { return "getlease" }
+
+func (msg MsgGetLease) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty")
+	}
+
+	return nil
+}
+
+func (msg MsgGetLease) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgGetLease) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// GetLease
+type MsgGetNShortestLeases struct {
+	UUID  string
+	N     uint64
+	Owner sdk.AccAddress
+}
+
+func (msg MsgGetNShortestLeases) Route() string { return RouterKey }
+
+func (msg MsgGetNShortestLeases) Type() string { return "getnshortestleases" }
+
+func (msg MsgGetNShortestLeases) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if msg.N == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "N must be larger than 0")
+	}
+
+	return nil
+}
+
+func (msg MsgGetNShortestLeases) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgGetNShortestLeases) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// RenewLease
+type MsgRenewLease struct {
+	UUID  string
+	Key   string
+	Lease int64
+	Owner sdk.AccAddress
+}
+
+func (msg MsgRenewLease) Route() string { return RouterKey }
+
+func (msg MsgRenewLease) Type() string { return "renewlease" }
+
+func (msg MsgRenewLease) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or Key empty")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	return nil
+}
+
+func (msg MsgRenewLease) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRenewLease) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// RenewLeaseAll
+type MsgRenewLeaseAll struct {
+	UUID  string
+	Lease int64
+	Owner sdk.AccAddress
+}
+
+func (msg MsgRenewLeaseAll) Route() string { return RouterKey }
+
+func (msg MsgRenewLeaseAll) Type() string { return "renewleaseall" }
+
+func (msg MsgRenewLeaseAll) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	return nil
+}
+
+func (msg MsgRenewLeaseAll) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRenewLeaseAll) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Copy
+type MsgCopy struct {
+	UUID    string
+	Key     string
+	NewUUID string
+	NewKey  string
+	Lease   int64
+	Owner   sdk.AccAddress
+}
+
+func NewMsgCopy(UUID string, key string, newUUID string, newKey string, lease int64, owner sdk.AccAddress) MsgCopy {
+	return MsgCopy{UUID: UUID, Key: key, NewUUID: newUUID, NewKey: newKey, Lease: lease, Owner: owner}
+}
+
+func (msg MsgCopy) Route() string { return RouterKey }
+
+func (msg MsgCopy) Type() string { return "copy" }
+
+func (msg MsgCopy) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.NewUUID) == 0 || len(msg.NewKey) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new UUID or new key Empty")
+	}
+
+	if len(msg.NewUUID)+len(msg.NewKey) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "NewUUID+NewKey too large")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	return nil
+}
+
+func (msg MsgCopy) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCopy) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// CopyUUID
+type MsgCopyUUID struct {
+	UUID    string
+	NewUUID string
+	Lease   int64
+	Owner   sdk.AccAddress
+}
+
+func NewMsgCopyUUID(UUID string, newUUID string, lease int64, owner sdk.AccAddress) MsgCopyUUID {
+	return MsgCopyUUID{UUID: UUID, NewUUID: newUUID, Lease: lease, Owner: owner}
+}
+
+func (msg MsgCopyUUID) Route() string { return RouterKey }
+
+func (msg MsgCopyUUID) Type() string { return "copyuuid" }
+
+func (msg MsgCopyUUID) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.NewUUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or new UUID empty")
+	}
+
+	if msg.UUID == msg.NewUUID {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID and new UUID are the same")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	return nil
+}
+
+func (msg MsgCopyUUID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCopyUUID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Patch
+type MsgPatch struct {
+	UUID  string
+	Key   string
+	Patch string
+	Owner sdk.AccAddress
+}
+
+func NewMsgPatch(UUID string, key string, patch string, owner sdk.AccAddress) MsgPatch {
+	return MsgPatch{UUID: UUID, Key: key, Patch: patch, Owner: owner}
+}
+
+func (msg MsgPatch) Route() string { return RouterKey }
+
+func (msg MsgPatch) Type() string { return "patch" }
+
+func (msg MsgPatch) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.Patch) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch empty")
+	}
+
+	if len(msg.Patch) > MaxValueSize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch too large")
+	}
+
+	if !json.Valid([]byte(msg.Patch)) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch is not valid JSON")
+	}
+
+	return nil
+}
+
+func (msg MsgPatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgPatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetBeneficiary
+// An empty Beneficiary removes the key's beneficiary.
+type MsgSetBeneficiary struct {
+	UUID        string
+	Key         string
+	Beneficiary sdk.AccAddress
+	Owner       sdk.AccAddress
+}
+
+func NewMsgSetBeneficiary(UUID string, key string, beneficiary sdk.AccAddress, owner sdk.AccAddress) MsgSetBeneficiary {
+	return MsgSetBeneficiary{UUID: UUID, Key: key, Beneficiary: beneficiary, Owner: owner}
+}
+
+func (msg MsgSetBeneficiary) Route() string { return RouterKey }
+
+func (msg MsgSetBeneficiary) Type() string { return "setbeneficiary" }
+
+func (msg MsgSetBeneficiary) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	return nil
+}
+
+func (msg MsgSetBeneficiary) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetBeneficiary) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DepositEscrow
+// Amount is added to the escrow Owner's auto-renewed leases are paid from.
+type MsgDepositEscrow struct {
+	Amount sdk.Coins
+	Owner  sdk.AccAddress
+}
+
+func NewMsgDepositEscrow(amount sdk.Coins, owner sdk.AccAddress) MsgDepositEscrow {
+	return MsgDepositEscrow{Amount: amount, Owner: owner}
+}
+
+func (msg MsgDepositEscrow) Route() string { return RouterKey }
+
+func (msg MsgDepositEscrow) Type() string { return "depositescrow" }
+
+func (msg MsgDepositEscrow) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+
+	return nil
+}
+
+func (msg MsgDepositEscrow) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDepositEscrow) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// WithdrawEscrow
+// Amount is paid back to Owner out of their escrow.
+type MsgWithdrawEscrow struct {
+	Amount sdk.Coins
+	Owner  sdk.AccAddress
+}
+
+func NewMsgWithdrawEscrow(amount sdk.Coins, owner sdk.AccAddress) MsgWithdrawEscrow {
+	return MsgWithdrawEscrow{Amount: amount, Owner: owner}
+}
+
+func (msg MsgWithdrawEscrow) Route() string { return RouterKey }
+
+func (msg MsgWithdrawEscrow) Type() string { return "withdrawescrow" }
+
+func (msg MsgWithdrawEscrow) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+
+	return nil
+}
+
+func (msg MsgWithdrawEscrow) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgWithdrawEscrow) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetAutoRenew
+type MsgSetAutoRenew struct {
+	UUID      string
+	Key       string
+	AutoRenew bool
+	Owner     sdk.AccAddress
+}
+
+func NewMsgSetAutoRenew(UUID string, key string, autoRenew bool, owner sdk.AccAddress) MsgSetAutoRenew {
+	return MsgSetAutoRenew{UUID: UUID, Key: key, AutoRenew: autoRenew, Owner: owner}
+}
+
+func (msg MsgSetAutoRenew) Route() string { return RouterKey }
+
+func (msg MsgSetAutoRenew) Type() string { return "setautorenew" }
+
+func (msg MsgSetAutoRenew) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	return nil
+}
+
+func (msg MsgSetAutoRenew) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetAutoRenew) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetIndex
+type MsgSetIndex struct {
+	UUID  string
+	Field string
+	Owner sdk.AccAddress
+}
+
+func NewMsgSetIndex(UUID string, field string, owner sdk.AccAddress) MsgSetIndex {
+	return MsgSetIndex{UUID: UUID, Field: field, Owner: owner}
+}
+
+func (msg MsgSetIndex) Route() string { return RouterKey }
+
+func (msg MsgSetIndex) Type() string { return "setindex" }
+
+func (msg MsgSetIndex) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.Field) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Field too large")
+	}
+
+	return nil
+}
+
+func (msg MsgSetIndex) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetIndex) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteIndex
+type MsgDeleteIndex struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteIndex(UUID string, owner sdk.AccAddress) MsgDeleteIndex {
+	return MsgDeleteIndex{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteIndex) Route() string { return RouterKey }
+
+func (msg MsgDeleteIndex) Type() string { return "deleteindex" }
+
+func (msg MsgDeleteIndex) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgDeleteIndex) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteIndex) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetAudit
+type MsgSetAudit struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgSetAudit(UUID string, owner sdk.AccAddress) MsgSetAudit {
+	return MsgSetAudit{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgSetAudit) Route() string { return RouterKey }
+
+func (msg MsgSetAudit) Type() string { return "setaudit" }
+
+func (msg MsgSetAudit) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgSetAudit) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetAudit) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteAudit
+// Stops recording changes, the entries already recorded are kept until they are pruned.
+type MsgDeleteAudit struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteAudit(UUID string, owner sdk.AccAddress) MsgDeleteAudit {
+	return MsgDeleteAudit{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteAudit) Route() string { return RouterKey }
+
+func (msg MsgDeleteAudit) Type() string { return "deleteaudit" }
+
+func (msg MsgDeleteAudit) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgDeleteAudit) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteAudit) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetHashIndex
+type MsgSetHashIndex struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgSetHashIndex(UUID string, owner sdk.AccAddress) MsgSetHashIndex {
+	return MsgSetHashIndex{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgSetHashIndex) Route() string { return RouterKey }
+
+func (msg MsgSetHashIndex) Type() string { return "sethashindex" }
+
+func (msg MsgSetHashIndex) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgSetHashIndex) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetHashIndex) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteHashIndex
+type MsgDeleteHashIndex struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteHashIndex(UUID string, owner sdk.AccAddress) MsgDeleteHashIndex {
+	return MsgDeleteHashIndex{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteHashIndex) Route() string { return RouterKey }
+
+func (msg MsgDeleteHashIndex) Type() string { return "deletehashindex" }
+
+func (msg MsgDeleteHashIndex) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgDeleteHashIndex) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteHashIndex) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetRetention
+// Order is one of RetentionOrderFIFO and RetentionOrderLRU.
+type MsgSetRetention struct {
+	UUID    string
+	MaxKeys uint64
+	Order   string
+	Owner   sdk.AccAddress
+}
+
+func NewMsgSetRetention(UUID string, maxKeys uint64, order string, owner sdk.AccAddress) MsgSetRetention {
+	return MsgSetRetention{UUID: UUID, MaxKeys: maxKeys, Order: order, Owner: owner}
+}
+
+func (msg MsgSetRetention) Route() string { return RouterKey }
+
+func (msg MsgSetRetention) Type() string { return "setretention" }
+
+func (msg MsgSetRetention) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if msg.MaxKeys == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "MaxKeys must be positive")
+	}
+
+	if !IsValidRetentionOrder(msg.Order) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Invalid order: %s", msg.Order))
+	}
+
+	return nil
+}
+
+func (msg MsgSetRetention) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetRetention) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteRetention
+type MsgDeleteRetention struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteRetention(UUID string, owner sdk.AccAddress) MsgDeleteRetention {
+	return MsgDeleteRetention{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteRetention) Route() string { return RouterKey }
+
+func (msg MsgDeleteRetention) Type() string { return "deleteretention" }
+
+func (msg MsgDeleteRetention) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgDeleteRetention) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteRetention) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Freeze
+// An empty Key freezes every key Owner has in the UUID.
+type MsgFreeze struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgFreeze(UUID string, key string, owner sdk.AccAddress) MsgFreeze {
+	return MsgFreeze{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgFreeze) Route() string { return RouterKey }
+
+func (msg MsgFreeze) Type() string { return "freeze" }
+
+func (msg MsgFreeze) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	return nil
+}
+
+func (msg MsgFreeze) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgFreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Unfreeze
+// An empty Key unfreezes every key Owner has in the UUID.
+type MsgUnfreeze struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgUnfreeze(UUID string, key string, owner sdk.AccAddress) MsgUnfreeze {
+	return MsgUnfreeze{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgUnfreeze) Route() string { return RouterKey }
+
+func (msg MsgUnfreeze) Type() string { return "unfreeze" }
+
+func (msg MsgUnfreeze) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	return nil
+}
+
+func (msg MsgUnfreeze) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUnfreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// StartUpload
+type MsgStartUpload struct {
+	UUID  string
+	Key   string
+	Size  int64
+	Hash  string // hex encoded SHA-256 of the complete value
+	Lease int64
+	Owner sdk.AccAddress
+}
+
+func NewMsgStartUpload(UUID string, key string, size int64, hash string, lease int64, owner sdk.AccAddress) MsgStartUpload {
+	return MsgStartUpload{UUID: UUID, Key: key, Size: size, Hash: hash, Lease: lease, Owner: owner}
+}
+
+func (msg MsgStartUpload) Route() string { return RouterKey }
+
+func (msg MsgStartUpload) Type() string { return "startupload" }
+
+func (msg MsgStartUpload) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.UUID)+len(msg.Key) > MaxKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large")
+	}
+
+	if msg.Size <= 0 || msg.Size > MaxUploadSize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid upload size")
+	}
+
+	if hash, err := hex.DecodeString(msg.Hash); err != nil || len(hash) != sha256.Size {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid hash")
+	}
+
+	if msg.Lease < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative")
+	}
+
+	return nil
+}
+
+func (msg MsgStartUpload) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgStartUpload) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// UploadChunk
+type MsgUploadChunk struct {
+	UUID  string
+	Key   string
+	Index uint64
+	Data  []byte
+	Owner sdk.AccAddress
+}
+
+func NewMsgUploadChunk(UUID string, key string, index uint64, data []byte, owner sdk.AccAddress) MsgUploadChunk {
+	return MsgUploadChunk{UUID: UUID, Key: key, Index: index, Data: data, Owner: owner}
+}
+
+func (msg MsgUploadChunk) Route() string { return RouterKey }
+
+func (msg MsgUploadChunk) Type() string { return "uploadchunk" }
+
+func (msg MsgUploadChunk) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	if len(msg.Data) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Chunk empty")
+	}
+
+	if len(msg.Data) > MaxValueSize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Chunk too large")
+	}
+
+	return nil
+}
+
+func (msg MsgUploadChunk) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUploadChunk) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// CommitUpload
+type MsgCommitUpload struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgCommitUpload(UUID string, key string, owner sdk.AccAddress) MsgCommitUpload {
+	return MsgCommitUpload{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgCommitUpload) Route() string { return RouterKey }
+
+func (msg MsgCommitUpload) Type() string { return "commitupload" }
+
+func (msg MsgCommitUpload) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+
+	return nil
+}
+
+func (msg MsgCommitUpload) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCommitUpload) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// SetSchema
+// The values later written to the UUID are checked against the schema, see Schema.
+type MsgSetSchema struct {
+	UUID         string
+	MaxValueSize uint64
+	ContentType  string
+	JSONSchema   string
+	Owner        sdk.AccAddress
+}
+
+func NewMsgSetSchema(UUID string, maxValueSize uint64, contentType string, jsonSchema string, owner sdk.AccAddress) MsgSetSchema {
+	return MsgSetSchema{UUID: UUID, MaxValueSize: maxValueSize, ContentType: contentType, JSONSchema: jsonSchema, Owner: owner}
+}
+
+func (msg MsgSetSchema) Route() string { return RouterKey }
+
+func (msg MsgSetSchema) Type() string { return "setschema" }
+
+func (msg MsgSetSchema) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	if err := msg.Schema().ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+// Schema returns the schema msg sets.
+func (msg MsgSetSchema) Schema() Schema {
+	return Schema{Owner: msg.Owner, MaxValueSize: msg.MaxValueSize, ContentType: msg.ContentType, JSONSchema: msg.JSONSchema}
+}
+
+func (msg MsgSetSchema) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetSchema) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// DeleteSchema
+type MsgDeleteSchema struct {
+	UUID  string
+	Owner sdk.AccAddress
+}
+
+func NewMsgDeleteSchema(UUID string, owner sdk.AccAddress) MsgDeleteSchema {
+	return MsgDeleteSchema{UUID: UUID, Owner: owner}
+}
+
+func (msg MsgDeleteSchema) Route() string { return RouterKey }
+
+func (msg MsgDeleteSchema) Type() string { return "deleteschema" }
+
+func (msg MsgDeleteSchema) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+
+	if len(msg.UUID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty")
+	}
+
+	return nil
+}
+
+func (msg MsgDeleteSchema) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeleteSchema) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// ExpireNow
+type MsgExpireNow struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgExpireNow(UUID string, key string, owner sdk.AccAddress) MsgExpireNow {
+	return MsgExpireNow{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgExpireNow) Route() string { return RouterKey }
+
+func (msg MsgExpireNow) Type() string { return "expirenow" }
+
+func (msg MsgExpireNow) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty")
+	}
+	return nil
+}
+
+func (msg MsgExpireNow) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgExpireNow) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
@@ -0,0 +1,1711 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	. "github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewMsgBLZCreate(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCreate("uuid", "key", []byte("value"), 0, owner)
+
+	IsType(t, sut, MsgCreate{})
+	True(t, reflect.DeepEqual(sut, MsgCreate{
+		UUID:  "uuid",
+		Key:   "key",
+		Value: []byte("value"),
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZCreate_Route(t *testing.T) {
+	Equal(t, MsgCreate{}.Route(), "crud")
+}
+
+func TestMsgBLZCreate_Type(t *testing.T) {
+	Equal(t, "create", MsgCreate{}.Type())
+}
+
+func TestMsgBLZCreate_ValidateBasic(t *testing.T) {
+	sut := NewMsgCreate("uuid", "key", []byte("value"), 0, nil)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	// test max sizes...
+	sut.Key = string(make([]byte, MaxKeySize/2))
+	sut.UUID = string(make([]byte, MaxKeySize/2+2))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "Key"
+	sut.UUID = "UUID"
+	sut.Value = make([]byte, MaxValueSize+1)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "Key"
+	sut.UUID = "UUID"
+	sut.Lease = -1
+	sut.Value = []byte("just a value")
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+
+	sut.Lease = 0
+	sut.ContentType = "application/json"
+	sut.Encoding = "gzip"
+	Nil(t, sut.ValidateBasic())
+
+	sut.ContentType = "text/plain\r\nX-Injected: 1"
+	NotNil(t, sut.ValidateBasic())
+}
+
+func TestMsgBLZCreate_GetSignBytes(t *testing.T) {
+	sut := NewMsgCreate("uuid", "key", []byte("value"), 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/create\",\"value\":{\"Key\":\"key\",\"Lease\":\"0\",\"Owner\":\"cosmos1"+
+		"vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\",\"Value\":\"dmFsdWU=\"}}",
+		string(sut.GetSignBytes()),
+	)
+}
+
+func TestMsgBLZCreate_GetSigners(t *testing.T) {
+	msg := NewMsgCreate("uuid", "key", []byte("value"), 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZRead(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgRead("uuid", "key", owner)
+
+	IsType(t, sut, MsgRead{})
+	True(t, reflect.DeepEqual(sut, MsgRead{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZRead_Route(t *testing.T) {
+	Equal(t, MsgRead{}.Route(), "crud")
+}
+
+func TestMsgBLZRead_Type(t *testing.T) {
+	Equal(t, "read", MsgRead{}.Type())
+}
+
+func TestMsgBLZRead_ValidateBasic(t *testing.T) {
+	sut := NewMsgRead("uuid", "key", nil)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgBLZRead_GetSignBytes(t *testing.T) {
+	sut := NewMsgRead("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, string(sut.GetSignBytes()), "{\"type\":\"crud/read\",\"value\":{\"Key\":\"key\",\"Owner\":\""+
+		"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}")
+}
+
+func TestMsgBLZRead_GetSigners(t *testing.T) {
+	msg := NewMsgRead("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZUpdate(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgUpdate{"uuid", "key", []byte("value"), 0, owner, 0, "", ""}
+
+	IsType(t, sut, MsgUpdate{})
+	True(t, reflect.DeepEqual(sut, MsgUpdate{
+		UUID:  "uuid",
+		Key:   "key",
+		Value: []byte("value"),
+		Lease: 0,
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZUpdate_Route(t *testing.T) {
+	Equal(t, MsgUpdate{}.Route(), "crud")
+}
+
+func TestMsgBLZUpdate_Type(t *testing.T) {
+	Equal(t, "update", MsgUpdate{}.Type())
+}
+
+func TestMsgBLZUpdate_ValidateBasic(t *testing.T) {
+	sut := MsgUpdate{"uuid", "key", []byte("new"), 0, nil, 0, "", ""}
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "Key"
+	sut.UUID = "UUID"
+	sut.Value = make([]byte, MaxValueSize+1)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large").Error(), sut.ValidateBasic().Error())
+
+	sut.Value = []byte("new")
+	sut.Encoding = string(make([]byte, MaxContentTypeSize+1))
+	NotNil(t, sut.ValidateBasic())
+}
+
+func TestMsgBLZUpdate_GetSignBytes(t *testing.T) {
+	sut := MsgUpdate{"uuid", "key", []byte("value"), 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"), 0, "", ""}
+	Equal(t, "{\"type\":\"crud/update\",\"value\":{\"Key\":\"key\",\"Lease\":\"0\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\",\"Value\":\"dmFsdWU=\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgBLZUpdate_GetSigners(t *testing.T) {
+	msg := MsgUpdate{"uuid", "key", []byte("value"), 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"), 0, "", ""}
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZDelete(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDelete("uuid", "key", owner)
+
+	IsType(t, sut, MsgDelete{})
+	True(t, reflect.DeepEqual(sut, MsgDelete{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZDelete_Route(t *testing.T) {
+	Equal(t, MsgDelete{}.Route(), "crud")
+}
+
+func TestMsgBLZDelete_Type(t *testing.T) {
+	Equal(t, "delete", MsgDelete{}.Type())
+}
+
+func TestMsgBLZDelete_ValidateBasic(t *testing.T) {
+	sut := NewMsgDelete("uuid", "key", nil)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgBLZDelete_GetSignBytes(t *testing.T) {
+	sut := NewMsgDelete("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, string(sut.GetSignBytes()), "{\"type\":\"crud/delete\",\"value\":{\"Key\":\"key\",\"Owner\":\""+
+		"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}")
+}
+
+func TestMsgBLZDelete_GetSigners(t *testing.T) {
+	msg := NewMsgDelete("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZKeys(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgKeys("uuid", owner)
+
+	IsType(t, sut, MsgKeys{})
+	True(t, reflect.DeepEqual(sut, MsgKeys{
+		UUID:  "uuid",
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZKeys_Route(t *testing.T) {
+	Equal(t, MsgKeys{}.Route(), "crud")
+}
+
+func TestMsgBLZKeys_Type(t *testing.T) {
+	Equal(t, "keys", MsgKeys{}.Type())
+}
+
+func TestMsgBLZKeys_ValidateBasic(t *testing.T) {
+	sut := NewMsgKeys("uuid", nil)
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error())
+}
+
+func TestMsgBLZKeys_GetSignBytes(t *testing.T) {
+	sut := NewMsgKeys("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/keys\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmx"+
+		"sdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgBLZKeys_GetSigners(t *testing.T) {
+	msg := NewMsgKeys("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZHas(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgHas("uuid", "key", owner)
+
+	IsType(t, sut, MsgHas{})
+	True(t, reflect.DeepEqual(sut, MsgHas{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: owner,
+	}))
+}
+
+func TestMsgBLZHas_Route(t *testing.T) {
+	Equal(t, MsgHas{}.Route(), "crud")
+}
+
+func TestMsgBLZHas_Type(t *testing.T) {
+	Equal(t, "has", MsgHas{}.Type())
+}
+
+func TestMsgBLZHas_ValidateBasic(t *testing.T) {
+	sut := NewMsgHas("uuid", "key", nil)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "key empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgBLZHas_GetSignBytes(t *testing.T) {
+	sut := NewMsgHas("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/has\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhw"+
+		"ardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgBLZHas_GetSigners(t *testing.T) {
+	msg := NewMsgHas("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZRename(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	accepted := MsgRename{UUID: "uuid", Key: "key", NewKey: "newkey", Owner: owner}
+
+	sut := NewMsgRename(accepted.UUID, accepted.Key, accepted.NewKey, owner)
+	IsType(t, MsgRename{}, sut)
+
+	True(t, reflect.DeepEqual(accepted, sut))
+}
+
+func TestMsgBLZRename_Route(t *testing.T) {
+	Equal(t, "crud", MsgRename{}.Route())
+}
+
+func TestMsgBLZRename_Type(t *testing.T) {
+	Equal(t, "rename", MsgRename{}.Type())
+}
+
+func TestMsgBLZRename_ValidateBasic(t *testing.T) {
+	sut := NewMsgRename("uuid", "key", "newkey", []byte(""))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "key"
+	sut.NewKey = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.NewKey = "key"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new key same as key").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "Key"
+	sut.NewKey = string(make([]byte, MaxKeySize+1))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+NewKey too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgBLZRename_GetSignBytes(t *testing.T) {
+	sut := NewMsgRename("uuid", "key", "newkey", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	exp := "{\"type\":\"crud/rename\",\"value\":{\"Key\":\"key\",\"NewKey\":\"newkey\",\"Overwrite\":false,\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}"
+	Equal(t, exp, string(sut.GetSignBytes()))
+}
+
+func TestMsgBLZRename_GetSigners(t *testing.T) {
+	msg := NewMsgRename("uuid", "key", "newkey", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, []sdk.AccAddress{msg.Owner}, msg.GetSigners())
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgBLZKeyValues(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	accepted := MsgKeyValues{UUID: "uuid", Owner: owner}
+
+	sut := NewMsgKeyValues(accepted.UUID, owner)
+	IsType(t, MsgKeyValues{}, sut)
+
+	True(t, reflect.DeepEqual(accepted, sut))
+}
+
+func TestMsgBLZKeyValues_Route(t *testing.T) {
+	Equal(t, "crud", MsgKeyValues{}.Route())
+}
+
+func TestMsgBLZKeyValues_Type(t *testing.T) {
+	Equal(t, "keyvalues", MsgKeyValues{}.Type())
+}
+
+func TestMsgBLZKeyValues_ValidateBasic(t *testing.T) {
+	sut := NewMsgKeyValues("uuid", []byte(""))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgBLZKeyValues_GetSignBytes(t *testing.T) {
+	sut := NewMsgKeyValues("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	exp := "{\"type\":\"crud/keyvalues\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}"
+	Equal(t, exp, string(sut.GetSignBytes()))
+}
+
+func TestMsgBLZKeyValues_GetSigners(t *testing.T) {
+	msg := NewMsgKeyValues("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, []sdk.AccAddress{msg.Owner}, msg.GetSigners())
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgCount(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCount("uuid", owner)
+
+	IsType(t, sut, MsgCount{})
+	True(t, reflect.DeepEqual(sut, MsgCount{
+		UUID:  "uuid",
+		Owner: owner,
+	}))
+}
+
+func TestMsgCount_Route(t *testing.T) {
+	Equal(t, "crud", MsgCount{}.Route())
+}
+
+func TestMsgCount_Type(t *testing.T) {
+	Equal(t, "count", MsgCount{}.Type())
+}
+
+func TestMsgCount_ValidateBasic(t *testing.T) {
+	sut := NewMsgCount("uuid", nil)
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error())
+}
+
+func TestMsgCount_GetSignBytes(t *testing.T) {
+	sut := NewMsgCount("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/count\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgCount_GetSigners(t *testing.T) {
+	msg := NewMsgCount("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgDeleteAll(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteAll("uuid", owner)
+
+	IsType(t, MsgDeleteAll{}, sut)
+	True(t, reflect.DeepEqual(sut, MsgDeleteAll{
+		UUID:  "uuid",
+		Owner: owner,
+	}))
+}
+
+func TestMsgDeleteAll_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteAll{}.Route())
+}
+
+func TestMsgDeleteAll_Type(t *testing.T) {
+	Equal(t, "deleteall", MsgDeleteAll{}.Type())
+}
+
+func TestMsgDeleteAll_ValidateBasic(t *testing.T) {
+	sut := NewMsgDeleteAll("uuid", nil)
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sut.ValidateBasic().Error(), sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error())
+}
+
+func TestMsgDeleteAll_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteAll("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/deleteall\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteAll_GetSigners(t *testing.T) {
+	msg := NewMsgDeleteAll("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgMultiUpdate(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	var keyValues []KeyValue
+	keyValues = append(keyValues, KeyValue{Key: "key0", Value: []byte("value0")})
+	keyValues = append(keyValues, KeyValue{Key: "key1", Value: []byte("value1")})
+
+	sut := NewMsgMultiUpdate("uuid", owner, keyValues)
+
+	IsType(t, MsgMultiUpdate{}, sut)
+	True(t, reflect.DeepEqual(sut, MsgMultiUpdate{
+		UUID:      "uuid",
+		Owner:     owner,
+		KeyValues: keyValues,
+	}))
+}
+
+func TestMsgMultiUpdate_Route(t *testing.T) {
+	Equal(t, "crud", MsgMultiUpdate{}.Route())
+}
+
+func TestMsgMultiUpdate_Type(t *testing.T) {
+	Equal(t, "multiupdate", MsgMultiUpdate{}.Type())
+}
+
+func TestMsgMultiUpdate_ValidateBasic(t *testing.T) {
+	sut := NewMsgMultiUpdate("uuid", nil, []KeyValue{{"key", []byte("value")}})
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.KeyValues = nil
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "KeyValues empty").Error(), sut.ValidateBasic().Error())
+
+	// test max key sizes...
+	sut.KeyValues = append(sut.KeyValues, KeyValue{Key: "key", Value: []byte("value")})
+	sut.KeyValues = append(sut.KeyValues, KeyValue{Key: string(make([]byte, MaxKeySize)), Value: []byte("value")})
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large [1]").Error(), sut.ValidateBasic().Error())
+
+	// test max value sizes...
+	sut.KeyValues = nil
+	sut.KeyValues = append(sut.KeyValues, KeyValue{Key: "key1", Value: []byte("value")})
+	sut.KeyValues = append(sut.KeyValues, KeyValue{Key: "key2", Value: make([]byte, MaxValueSize+1)})
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large [1]").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgMultiUpdate_GetSignBytes(t *testing.T) {
+	sut := NewMsgMultiUpdate("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"), nil)
+	Equal(t, "{\"type\":\"crud/multiupdate\",\"value\":{\"KeyValues\":null,\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgMultiUpdate_GetSigners(t *testing.T) {
+	msg := NewMsgMultiUpdate("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"), nil)
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgAtomic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	ops := []AtomicOp{
+		{Op: AtomicOpCreate, UUID: "uuid0", Key: "key", Value: []byte("value")},
+		{Op: AtomicOpDelete, UUID: "uuid1", Key: "key"},
+	}
+
+	sut := NewMsgAtomic(ops, owner)
+
+	IsType(t, MsgAtomic{}, sut)
+	True(t, reflect.DeepEqual(sut, MsgAtomic{Ops: ops, Owner: owner}))
+}
+
+func TestMsgAtomic_Route(t *testing.T) {
+	Equal(t, "crud", MsgAtomic{}.Route())
+}
+
+func TestMsgAtomic_Type(t *testing.T) {
+	Equal(t, "atomic", MsgAtomic{}.Type())
+}
+
+func TestMsgAtomic_ValidateBasic(t *testing.T) {
+	sut := NewMsgAtomic([]AtomicOp{
+		{Op: AtomicOpCreate, UUID: "uuid0", Key: "key", Value: []byte("value")},
+		{Op: AtomicOpUpdate, UUID: "uuid1", Key: "key", Value: []byte("value"), Version: 2},
+		{Op: AtomicOpDelete, UUID: "uuid2", Key: "key"},
+	}, nil)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	Nil(t, sut.ValidateBasic())
+
+	// every op is validated as the message it stands for
+	sut.Ops[2].Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty"), "[2]").Error(), sut.ValidateBasic().Error())
+
+	sut.Ops[2].Key = "key"
+	sut.Ops[0].Value = make([]byte, MaxValueSize+1)
+	Equal(t, sdkerrors.Wrap(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Value too large"), "[0]").Error(), sut.ValidateBasic().Error())
+
+	sut.Ops[0].Value = []byte("value")
+	sut.Ops[1].Op = "rename"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Unknown operation rename [1]").Error(), sut.ValidateBasic().Error())
+
+	sut.Ops = nil
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Ops empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgAtomic_GetSignBytes(t *testing.T) {
+	sut := NewMsgAtomic([]AtomicOp{{Op: AtomicOpDelete, UUID: "uuid", Key: "key"}}, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, "{\"type\":\"crud/atomic\",\"value\":{\"Ops\":[{\"key\":\"key\",\"op\":\"delete\",\"uuid\":\"uuid\"}],\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgAtomic_GetSigners(t *testing.T) {
+	msg := NewMsgAtomic(nil, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, msg.GetSigners(), []sdk.AccAddress{msg.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestMsgGetLease_Route(t *testing.T) {
+	Equal(t, "crud", MsgGetLease{}.Route())
+}
+
+func TestMsgGetLease_Type(t *testing.T) {
+	Equal(t, "getlease", MsgGetLease{}.Type())
+}
+
+func TestMsgGetLease_ValidateBasic(t *testing.T) {
+	sut := MsgGetLease{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = "key"
+	sut.Owner = nil
+
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "").Error(), sut.ValidateBasic().Error())
+
+}
+
+func TestMsgGetLease_GetSignBytes(t *testing.T) {
+	sut := MsgGetLease{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+	Equal(t, "{\"type\":\"crud/getlease\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgGetLease_GetSigners(t *testing.T) {
+	sut := MsgGetLease{
+		UUID:  "uuid",
+		Key:   "key",
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestMsgGetNShortestLeases_Route(t *testing.T) {
+	Equal(t, "crud", MsgGetNShortestLeases{}.Route())
+}
+
+func TestMsgGetNShortestLeases_Type(t *testing.T) {
+	Equal(t, "getnshortestleases", MsgGetNShortestLeases{}.Type())
+}
+
+func TestMsgGetNShortestLeases_ValidateBasic(t *testing.T) {
+	sut := MsgGetNShortestLeases{
+		UUID:  "uuid",
+		N:     10,
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.N = 0
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "N must be larger than 0").Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = nil
+	sut.N = 596740
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "").Error(), sut.ValidateBasic().Error())
+
+}
+
+func TestMsgGetNShortestLeases_GetSignBytes(t *testing.T) {
+	sut := MsgGetNShortestLeases{
+		UUID:  "uuid",
+		N:     10,
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+	Equal(t, "{\"type\":\"crud/getnshortestleases\",\"value\":{\"N\":\"10\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}", string(sut.GetSignBytes()))
+}
+
+func TestMsgGetNShortestLeases_GetSigners(t *testing.T) {
+	sut := MsgGetNShortestLeases{
+		UUID:  "uuid",
+		N:     10,
+		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
+	}
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestMsgRenewLease_Route(t *testing.T) {
+	Equal(t, "crud", MsgRenewLease{}.Route())
+}
+
+func TestMsgRenewLease_Type(t *testing.T) {
+	Equal(t, "renewlease", MsgRenewLease{}.Type())
+}
+
+func TestMsgRenewLease_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLease{UUID: "uuid", Key: "key", Lease: int64(100), Owner: owner}
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or Key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or Key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "key"
+	sut.Lease = -5
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgRenewLease_GetSignBytes(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLease{UUID: "uuid", Key: "key", Lease: int64(100), Owner: owner}
+	Equal(t,
+		"{\"type\":\"crud/renewlease\",\"value\":{\"Key\":\"key\",\"Lease\":\"100\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgRenewLease_GetSigners(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLease{UUID: "uuid", Key: "key", Lease: int64(100), Owner: owner}
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestMsgRenewLeaseAll_Route(t *testing.T) {
+	Equal(t, "crud", MsgRenewLease{}.Route())
+}
+
+func TestMsgRenewLeaseAll_Type(t *testing.T) {
+	Equal(t, "renewleaseall", MsgRenewLeaseAll{}.Type())
+}
+
+func TestMsgRenewLeaseAll_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLeaseAll{UUID: "uuid", Lease: int64(100), Owner: owner}
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Lease = -5
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgRenewLeaseAll_GetSignBytes(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLeaseAll{UUID: "uuid", Lease: int64(100), Owner: owner}
+	Equal(t,
+		"{\"type\":\"crud/renewleaseall\",\"value\":{\"Lease\":\"100\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgRenewLeaseAll_GetSigners(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := MsgRenewLeaseAll{UUID: "uuid", Lease: int64(100), Owner: owner}
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgCopy(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCopy("uuid", "key", "newuuid", "newkey", 100, owner)
+
+	True(t, reflect.DeepEqual(sut, MsgCopy{UUID: "uuid", Key: "key", NewUUID: "newuuid", NewKey: "newkey", Lease: 100, Owner: owner}))
+}
+
+func TestMsgCopy_Route(t *testing.T) {
+	Equal(t, "crud", MsgCopy{}.Route())
+}
+
+func TestMsgCopy_Type(t *testing.T) {
+	Equal(t, "copy", MsgCopy{}.Type())
+}
+
+func TestMsgCopy_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCopy("uuid", "key", "newuuid", "newkey", 0, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "key"
+	sut.NewUUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new UUID or new key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.NewUUID = string(make([]byte, MaxKeySize/2+2))
+	sut.NewKey = string(make([]byte, MaxKeySize/2))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "NewUUID+NewKey too large").Error(), sut.ValidateBasic().Error())
+
+	sut.NewUUID = "newuuid"
+	sut.NewKey = "newkey"
+	sut.Lease = -1
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgCopy_GetSignBytes(t *testing.T) {
+	sut := NewMsgCopy("uuid", "key", "newuuid", "newkey", 100, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/copy\",\"value\":{\"Key\":\"key\",\"Lease\":\"100\",\"NewKey\":\"newkey\",\"NewUUID\":\"newuuid\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgCopy_GetSigners(t *testing.T) {
+	sut := NewMsgCopy("uuid", "key", "newuuid", "newkey", 100, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgCopyUUID(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCopyUUID("uuid", "newuuid", 100, owner)
+
+	True(t, reflect.DeepEqual(sut, MsgCopyUUID{UUID: "uuid", NewUUID: "newuuid", Lease: 100, Owner: owner}))
+}
+
+func TestMsgCopyUUID_Route(t *testing.T) {
+	Equal(t, "crud", MsgCopyUUID{}.Route())
+}
+
+func TestMsgCopyUUID_Type(t *testing.T) {
+	Equal(t, "copyuuid", MsgCopyUUID{}.Type())
+}
+
+func TestMsgCopyUUID_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCopyUUID("uuid", "newuuid", 0, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.NewUUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or new UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.NewUUID = "uuid"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID and new UUID are the same").Error(), sut.ValidateBasic().Error())
+
+	sut.NewUUID = "newuuid"
+	sut.Lease = -1
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgCopyUUID_GetSignBytes(t *testing.T) {
+	sut := NewMsgCopyUUID("uuid", "newuuid", 100, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/copyuuid\",\"value\":{\"Lease\":\"100\",\"NewUUID\":\"newuuid\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgCopyUUID_GetSigners(t *testing.T) {
+	sut := NewMsgCopyUUID("uuid", "newuuid", 100, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+func TestNewMsgPatch(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgPatch("uuid", "key", `{"a":1}`, owner)
+
+	True(t, reflect.DeepEqual(sut, MsgPatch{UUID: "uuid", Key: "key", Patch: `{"a":1}`, Owner: owner}))
+}
+
+func TestMsgPatch_Route(t *testing.T) {
+	Equal(t, "crud", MsgPatch{}.Route())
+}
+
+func TestMsgPatch_Type(t *testing.T) {
+	Equal(t, "patch", MsgPatch{}.Type())
+}
+
+func TestMsgPatch_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgPatch("uuid", "key", `{"a":1}`, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Patch = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Patch = string(make([]byte, MaxValueSize+1))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch too large").Error(), sut.ValidateBasic().Error())
+
+	sut.Patch = `{"a":`
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Patch is not valid JSON").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgPatch_GetSignBytes(t *testing.T) {
+	sut := NewMsgPatch("uuid", "key", `{"a":1}`, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/patch\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"Patch\":\"{\\\"a\\\":1}\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgPatch_GetSigners(t *testing.T) {
+	sut := NewMsgPatch("uuid", "key", `{"a":1}`, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetBeneficiary_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetBeneficiary{}.Route())
+}
+
+func TestMsgSetBeneficiary_Type(t *testing.T) {
+	Equal(t, "setbeneficiary", MsgSetBeneficiary{}.Type())
+}
+
+func TestMsgSetBeneficiary_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetBeneficiary("uuid", "key", []byte("bluzelle1nnpyp9wr6la"), owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	// removing the beneficiary
+	sut.Beneficiary = nil
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = strings.Repeat("k", MaxKeySize)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetBeneficiary_GetSigners(t *testing.T) {
+	sut := NewMsgSetBeneficiary("uuid", "key", nil, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDepositEscrow_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDepositEscrow(sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), owner)
+	Equal(t, "crud", sut.Route())
+	Equal(t, "depositescrow", sut.Type())
+	Nil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.NewCoins()
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.Coins{sdk.Coin{Denom: "ubnt", Amount: sdk.NewInt(-1)}}
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+}
+
+func TestMsgDepositEscrow_GetSignBytes(t *testing.T) {
+	sut := NewMsgDepositEscrow(sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/depositescrow\",\"value\":{\"Amount\":[{\"amount\":\"100\",\"denom\":\"ubnt\"}],\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\"}}",
+		string(sut.GetSignBytes()))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgWithdrawEscrow_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgWithdrawEscrow(sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), owner)
+	Equal(t, "crud", sut.Route())
+	Equal(t, "withdrawescrow", sut.Type())
+	Nil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.NewCoins()
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.Coins{sdk.Coin{Denom: "ubnt", Amount: sdk.NewInt(-1)}}
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Amount = sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100))
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+}
+
+func TestMsgWithdrawEscrow_GetSignBytes(t *testing.T) {
+	sut := NewMsgWithdrawEscrow(sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/withdrawescrow\",\"value\":{\"Amount\":[{\"amount\":\"100\",\"denom\":\"ubnt\"}],\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\"}}",
+		string(sut.GetSignBytes()))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetAutoRenew_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetAutoRenew("uuid", "key", true, owner)
+	Equal(t, "crud", sut.Route())
+	Equal(t, "setautorenew", sut.Type())
+	Nil(t, sut.ValidateBasic())
+
+	sut.AutoRenew = false
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = strings.Repeat("k", MaxKeySize)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetAutoRenew_GetSigners(t *testing.T) {
+	sut := NewMsgSetAutoRenew("uuid", "key", true, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetIndex_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetIndex{}.Route())
+}
+
+func TestMsgSetIndex_Type(t *testing.T) {
+	Equal(t, "setindex", MsgSetIndex{}.Type())
+}
+
+func TestMsgSetIndex_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetIndex("uuid", "", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Field = "colour"
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Field = string(make([]byte, MaxKeySize+1))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Field too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetIndex_GetSignBytes(t *testing.T) {
+	sut := NewMsgSetIndex("uuid", "colour", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/setindex\",\"value\":{\"Field\":\"colour\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgSetIndex_GetSigners(t *testing.T) {
+	sut := NewMsgSetIndex("uuid", "", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDeleteIndex_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteIndex{}.Route())
+}
+
+func TestMsgDeleteIndex_Type(t *testing.T) {
+	Equal(t, "deleteindex", MsgDeleteIndex{}.Type())
+}
+
+func TestMsgDeleteIndex_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteIndex("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgDeleteIndex_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/deleteindex\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteIndex_GetSigners(t *testing.T) {
+	sut := NewMsgDeleteIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetHashIndex_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetHashIndex{}.Route())
+}
+
+func TestMsgSetHashIndex_Type(t *testing.T) {
+	Equal(t, "sethashindex", MsgSetHashIndex{}.Type())
+}
+
+func TestMsgSetHashIndex_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetHashIndex("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetHashIndex_GetSignBytes(t *testing.T) {
+	sut := NewMsgSetHashIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/sethashindex\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgSetHashIndex_GetSigners(t *testing.T) {
+	sut := NewMsgSetHashIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDeleteHashIndex_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteHashIndex{}.Route())
+}
+
+func TestMsgDeleteHashIndex_Type(t *testing.T) {
+	Equal(t, "deletehashindex", MsgDeleteHashIndex{}.Type())
+}
+
+func TestMsgDeleteHashIndex_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteHashIndex("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgDeleteHashIndex_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteHashIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/deletehashindex\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteHashIndex_GetSigners(t *testing.T) {
+	sut := NewMsgDeleteHashIndex("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetAudit_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetAudit{}.Route())
+}
+
+func TestMsgSetAudit_Type(t *testing.T) {
+	Equal(t, "setaudit", MsgSetAudit{}.Type())
+}
+
+func TestMsgSetAudit_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetAudit("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetAudit_GetSignBytes(t *testing.T) {
+	sut := NewMsgSetAudit("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/setaudit\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgSetAudit_GetSigners(t *testing.T) {
+	sut := NewMsgSetAudit("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDeleteAudit_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteAudit{}.Route())
+}
+
+func TestMsgDeleteAudit_Type(t *testing.T) {
+	Equal(t, "deleteaudit", MsgDeleteAudit{}.Type())
+}
+
+func TestMsgDeleteAudit_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteAudit("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgDeleteAudit_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteAudit("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/deleteaudit\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteAudit_GetSigners(t *testing.T) {
+	sut := NewMsgDeleteAudit("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetRetention_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetRetention{}.Route())
+}
+
+func TestMsgSetRetention_Type(t *testing.T) {
+	Equal(t, "setretention", MsgSetRetention{}.Type())
+}
+
+func TestMsgSetRetention_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetRetention("uuid", 10, RetentionOrderFIFO, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Order = RetentionOrderLRU
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.MaxKeys = 0
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "MaxKeys must be positive").Error(), sut.ValidateBasic().Error())
+
+	sut.MaxKeys = 10
+	sut.Order = "newest"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid order: newest").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetRetention_GetSignBytes(t *testing.T) {
+	sut := NewMsgSetRetention("uuid", 10, RetentionOrderFIFO, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/setretention\",\"value\":{\"MaxKeys\":\"10\",\"Order\":\"fifo\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgSetRetention_GetSigners(t *testing.T) {
+	sut := NewMsgSetRetention("uuid", 10, RetentionOrderFIFO, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDeleteRetention_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteRetention{}.Route())
+}
+
+func TestMsgDeleteRetention_Type(t *testing.T) {
+	Equal(t, "deleteretention", MsgDeleteRetention{}.Type())
+}
+
+func TestMsgDeleteRetention_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteRetention("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgDeleteRetention_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteRetention("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/deleteretention\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteRetention_GetSigners(t *testing.T) {
+	sut := NewMsgDeleteRetention("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgFreeze_Route(t *testing.T) {
+	Equal(t, "crud", MsgFreeze{}.Route())
+}
+
+func TestMsgFreeze_Type(t *testing.T) {
+	Equal(t, "freeze", MsgFreeze{}.Type())
+}
+
+func TestMsgFreeze_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgFreeze("uuid", "key", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Key = ""
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = strings.Repeat("k", MaxKeySize)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgFreeze_GetSignBytes(t *testing.T) {
+	sut := NewMsgFreeze("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/freeze\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgFreeze_GetSigners(t *testing.T) {
+	sut := NewMsgFreeze("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgUnfreeze_Route(t *testing.T) {
+	Equal(t, "crud", MsgUnfreeze{}.Route())
+}
+
+func TestMsgUnfreeze_Type(t *testing.T) {
+	Equal(t, "unfreeze", MsgUnfreeze{}.Type())
+}
+
+func TestMsgUnfreeze_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgUnfreeze("uuid", "key", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Key = ""
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Key = strings.Repeat("k", MaxKeySize)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgUnfreeze_GetSignBytes(t *testing.T) {
+	sut := NewMsgUnfreeze("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/unfreeze\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgUnfreeze_GetSigners(t *testing.T) {
+	sut := NewMsgUnfreeze("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgStartUpload_Route(t *testing.T) {
+	Equal(t, "crud", MsgStartUpload{}.Route())
+}
+
+func TestMsgStartUpload_Type(t *testing.T) {
+	Equal(t, "startupload", MsgStartUpload{}.Type())
+}
+
+func TestMsgStartUpload_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	hash := "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619"
+	sut := NewMsgStartUpload("uuid", "key", MaxUploadSize, hash, 0, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = string(make([]byte, MaxKeySize))
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID+Key too large").Error(), sut.ValidateBasic().Error())
+
+	sut.Key = "key"
+	sut.Size = 0
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid upload size").Error(), sut.ValidateBasic().Error())
+
+	sut.Size = MaxUploadSize + 1
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid upload size").Error(), sut.ValidateBasic().Error())
+
+	sut.Size = 5
+	sut.Hash = "not hex"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid hash").Error(), sut.ValidateBasic().Error())
+
+	sut.Hash = "cd42404d"
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Invalid hash").Error(), sut.ValidateBasic().Error())
+
+	sut.Hash = hash
+	sut.Lease = -1
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Lease negative").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgStartUpload_GetSignBytes(t *testing.T) {
+	sut := NewMsgStartUpload("uuid", "key", 5, "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619", 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/startupload\",\"value\":{\"Hash\":\"cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619\",\"Key\":\"key\",\"Lease\":\"0\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"Size\":\"5\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgStartUpload_GetSigners(t *testing.T) {
+	sut := NewMsgStartUpload("uuid", "key", 5, "", 0, []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgUploadChunk_Route(t *testing.T) {
+	Equal(t, "crud", MsgUploadChunk{}.Route())
+}
+
+func TestMsgUploadChunk_Type(t *testing.T) {
+	Equal(t, "uploadchunk", MsgUploadChunk{}.Type())
+}
+
+func TestMsgUploadChunk_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgUploadChunk("uuid", "key", 0, []byte("data"), owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.Data = []byte("")
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Chunk empty").Error(), sut.ValidateBasic().Error())
+
+	sut.Data = make([]byte, MaxValueSize+1)
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Chunk too large").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgUploadChunk_GetSignBytes(t *testing.T) {
+	sut := NewMsgUploadChunk("uuid", "key", 1, []byte("data"), []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/uploadchunk\",\"value\":{\"Data\":\"ZGF0YQ==\",\"Index\":\"1\",\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgUploadChunk_GetSigners(t *testing.T) {
+	sut := NewMsgUploadChunk("uuid", "key", 1, []byte("data"), []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgCommitUpload_Route(t *testing.T) {
+	Equal(t, "crud", MsgCommitUpload{}.Route())
+}
+
+func TestMsgCommitUpload_Type(t *testing.T) {
+	Equal(t, "commitupload", MsgCommitUpload{}.Type())
+}
+
+func TestMsgCommitUpload_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgCommitUpload("uuid", "key", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgCommitUpload_GetSignBytes(t *testing.T) {
+	sut := NewMsgCommitUpload("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/commitupload\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgCommitUpload_GetSigners(t *testing.T) {
+	sut := NewMsgCommitUpload("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgSetSchema_Route(t *testing.T) {
+	Equal(t, "crud", MsgSetSchema{}.Route())
+}
+
+func TestMsgSetSchema_Type(t *testing.T) {
+	Equal(t, "setschema", MsgSetSchema{}.Type())
+}
+
+func TestMsgSetSchema_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgSetSchema("uuid", 1024, "application/json", `{"type":"object"}`, owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+
+	sut.UUID = "uuid"
+	sut.JSONSchema = `{"type":"object","format":"date"}`
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "JSON schema /format: unsupported keyword").Error(), sut.ValidateBasic().Error())
+
+	sut.MaxValueSize, sut.ContentType, sut.JSONSchema = 0, "", ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "schema has no constraints").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgSetSchema_GetSignBytes(t *testing.T) {
+	sut := NewMsgSetSchema("uuid", 1024, "application/json", "", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/setschema\",\"value\":{\"ContentType\":\"application/json\",\"JSONSchema\":\"\",\"MaxValueSize\":\"1024\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgSetSchema_GetSigners(t *testing.T) {
+	sut := NewMsgSetSchema("uuid", 1024, "", "", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgDeleteSchema_Route(t *testing.T) {
+	Equal(t, "crud", MsgDeleteSchema{}.Route())
+}
+
+func TestMsgDeleteSchema_Type(t *testing.T) {
+	Equal(t, "deleteschema", MsgDeleteSchema{}.Type())
+}
+
+func TestMsgDeleteSchema_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgDeleteSchema("uuid", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	NotNil(t, sut.ValidateBasic())
+
+	sut.Owner = owner
+	sut.UUID = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgDeleteSchema_GetSignBytes(t *testing.T) {
+	sut := NewMsgDeleteSchema("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/deleteschema\",\"value\":{\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgDeleteSchema_GetSigners(t *testing.T) {
+	sut := NewMsgDeleteSchema("uuid", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
+
+func TestMsgExpireNow_Route(t *testing.T) {
+	Equal(t, "crud", MsgExpireNow{}.Route())
+}
+
+func TestMsgExpireNow_Type(t *testing.T) {
+	Equal(t, "expirenow", MsgExpireNow{}.Type())
+}
+
+func TestMsgExpireNow_ValidateBasic(t *testing.T) {
+	owner := []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23")
+	sut := NewMsgExpireNow("uuid", "key", owner)
+
+	Nil(t, sut.ValidateBasic())
+
+	sut.Owner = nil
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, sut.Owner.String()).Error(), sut.ValidateBasic().Error())
+
+	sut.Owner = owner
+	sut.Key = ""
+	Equal(t, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID or key Empty").Error(), sut.ValidateBasic().Error())
+}
+
+func TestMsgExpireNow_GetSignBytes(t *testing.T) {
+	sut := NewMsgExpireNow("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t,
+		"{\"type\":\"crud/expirenow\",\"value\":{\"Key\":\"key\",\"Owner\":\"cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3\",\"UUID\":\"uuid\"}}",
+		string(sut.GetSignBytes()))
+}
+
+func TestMsgExpireNow_GetSigners(t *testing.T) {
+	sut := NewMsgExpireNow("uuid", "key", []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"))
+	Equal(t, sut.GetSigners(), []sdk.AccAddress{sut.Owner})
+}
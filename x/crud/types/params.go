@@ -0,0 +1,446 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"strings"
+)
+
+const (
+	DefaultParamspace = ModuleName
+
+	// values are stored uncompressed unless a threshold is set
+	DefaultCompressionThreshold uint64 = 0
+
+	// key values a MsgMultiUpdate may carry, and the gas each of them is charged on
+	// top of the message's base gas
+	DefaultMaxBatchSize uint64 = 100
+	DefaultBatchItemGas uint64 = 500
+	DefaultBatchByteGas uint64 = 2
+)
+
+// DefaultBaseMsgGas prices each crud message at a few store reads or writes, whatever
+// it turns out to touch; message types that are not listed are charged nothing.
+var DefaultBaseMsgGas = []MsgGas{
+	{MsgType: "create", Gas: 2000},
+	{MsgType: "read", Gas: 1000},
+	{MsgType: "update", Gas: 2000},
+	{MsgType: "delete", Gas: 1000},
+	{MsgType: "keys", Gas: 2000},
+	{MsgType: "has", Gas: 1000},
+	{MsgType: "rename", Gas: 2000},
+	{MsgType: "keyvalues", Gas: 2000},
+	{MsgType: "count", Gas: 1000},
+	{MsgType: "deleteall", Gas: 5000},
+	{MsgType: "multiupdate", Gas: 2000},
+	{MsgType: "getlease", Gas: 1000},
+	{MsgType: "getnshortestleases", Gas: 2000},
+	{MsgType: "renewlease", Gas: 1000},
+	{MsgType: "renewleaseall", Gas: 2000},
+	{MsgType: "copy", Gas: 2000},
+	{MsgType: "copyuuid", Gas: 5000},
+	{MsgType: "patch", Gas: 2000},
+}
+
+var (
+	KeyCompressionThreshold = []byte("CompressionThreshold")
+	KeyMinMsgGas            = []byte("MinMsgGas")
+	KeyBaseMsgGas           = []byte("BaseMsgGas")
+	KeyLeasePrice           = []byte("LeasePrice")
+	KeyExpiryGraceBlocks    = []byte("ExpiryGraceBlocks")
+	KeyMaxWritesPerWindow   = []byte("MaxWritesPerWindow")
+	KeyRateLimitWindow      = []byte("RateLimitWindow")
+	KeyAuditRetentionBlocks = []byte("AuditRetentionBlocks")
+	KeyDefaultLeaseBlocks   = []byte("DefaultLeaseBlocks")
+	KeyUtilizationBands     = []byte("UtilizationBands")
+	KeyRentEpochBlocks      = []byte("RentEpochBlocks")
+	KeyMaxBatchSize         = []byte("MaxBatchSize")
+	KeyBatchItemGas         = []byte("BatchItemGas")
+	KeyBatchByteGas         = []byte("BatchByteGas")
+)
+
+var _ subspace.ParamSet = &Params{}
+
+type Params struct {
+	CompressionThreshold uint64   `json:"compression_threshold" yaml:"compression_threshold"`
+	MinMsgGas            []MsgGas `json:"min_msg_gas" yaml:"min_msg_gas"`
+	// price of keeping one byte for one block, leases are free when empty
+	LeasePrice sdk.DecCoins `json:"lease_price" yaml:"lease_price"`
+	// blocks an expired key is kept read-only, for its lease to be renewed, before it
+	// is deleted
+	ExpiryGraceBlocks uint64 `json:"expiry_grace_blocks" yaml:"expiry_grace_blocks"`
+	// crud write messages an address may send per RateLimitWindow blocks, unlimited
+	// when 0; windows are aligned to multiples of their length and 0 counts as 1
+	MaxWritesPerWindow uint64 `json:"max_writes_per_window" yaml:"max_writes_per_window"`
+	RateLimitWindow    uint64 `json:"rate_limit_window" yaml:"rate_limit_window"`
+	// gas consumed at the top of the handler of every crud message of a type, before
+	// the store access it does
+	BaseMsgGas []MsgGas `json:"base_msg_gas" yaml:"base_msg_gas"`
+	// blocks audit log entries are kept before they are pruned, forever when 0
+	AuditRetentionBlocks uint64 `json:"audit_retention_blocks" yaml:"audit_retention_blocks"`
+	// lease in blocks of keys created or renewed without one, the node's own default
+	// when 0
+	DefaultLeaseBlocks int64 `json:"default_lease_blocks" yaml:"default_lease_blocks"`
+	// multipliers of LeasePrice by the value bytes stored on chain, in increasing
+	// MinBytes; the price is unscaled below the first band
+	UtilizationBands []UtilizationBand `json:"utilization_bands" yaml:"utilization_bands"`
+	// blocks of the epochs the lease fees of each owner are added up over,
+	// DefaultRentEpochBlocks when 0
+	RentEpochBlocks uint64 `json:"rent_epoch_blocks" yaml:"rent_epoch_blocks"`
+	// key values a MsgMultiUpdate may carry, unlimited when 0
+	MaxBatchSize uint64 `json:"max_batch_size" yaml:"max_batch_size"`
+	// gas charged for every key value of a MsgMultiUpdate, plus BatchByteGas for each
+	// byte of its key and value, whether it is applied or not
+	BatchItemGas uint64 `json:"batch_item_gas" yaml:"batch_item_gas"`
+	BatchByteGas uint64 `json:"batch_byte_gas" yaml:"batch_byte_gas"`
+}
+
+// UtilizationBand scales the lease price by Multiplier while MinBytes or more value
+// bytes are stored on chain, up to the MinBytes of the next band.
+type UtilizationBand struct {
+	MinBytes   uint64  `json:"min_bytes" yaml:"min_bytes"`
+	Multiplier sdk.Dec `json:"multiplier" yaml:"multiplier"`
+}
+
+// RateLimitBlocks returns the length of the rate limit windows.
+func (p Params) RateLimitBlocks() uint64 {
+	if p.RateLimitWindow == 0 {
+		return 1
+	}
+	return p.RateLimitWindow
+}
+
+// RentEpoch returns the length of the rent accounting epochs.
+func (p Params) RentEpoch() uint64 {
+	if p.RentEpochBlocks == 0 {
+		return DefaultRentEpochBlocks
+	}
+	return p.RentEpochBlocks
+}
+
+// BatchGas returns the gas charged for a key value of key and value in a batch.
+func (p Params) BatchGas(key string, value []byte) uint64 {
+	return p.BatchItemGas + p.BatchByteGas*uint64(len(key)+len(value))
+}
+
+// RateLimitWindowAt returns the number of the rate limit window height falls in.
+func (p Params) RateLimitWindowAt(height int64) uint64 {
+	return uint64(height) / p.RateLimitBlocks()
+}
+
+// MsgGas is the flat gas charged for every crud message of MsgType, by the MinMsgGas
+// and BaseMsgGas tables.
+type MsgGas struct {
+	MsgType string `json:"msg_type" yaml:"msg_type"`
+	Gas     uint64 `json:"gas" yaml:"gas"`
+}
+
+// MinGas returns the flat gas charged for messages of msgType, 0 if there is none.
+func (p Params) MinGas(msgType string) uint64 {
+	return lookupMsgGas(p.MinMsgGas, msgType)
+}
+
+// BaseGas returns the gas the handler of messages of msgType consumes up front.
+func (p Params) BaseGas(msgType string) uint64 {
+	return lookupMsgGas(p.BaseMsgGas, msgType)
+}
+
+func lookupMsgGas(table []MsgGas, msgType string) uint64 {
+	for _, entry := range table {
+		if entry.MsgType == msgType {
+			return entry.Gas
+		}
+	}
+	return 0
+}
+
+func NewParams(compressionThreshold uint64) Params {
+	return Params{
+		CompressionThreshold: compressionThreshold,
+		BaseMsgGas:           append([]MsgGas{}, DefaultBaseMsgGas...),
+		MaxBatchSize:         DefaultMaxBatchSize,
+		BatchItemGas:         DefaultBatchItemGas,
+		BatchByteGas:         DefaultBatchByteGas,
+	}
+}
+
+func DefaultParams() Params {
+	return NewParams(DefaultCompressionThreshold)
+}
+
+// LeaseFee returns the price of usage byte-blocks, rounded up.
+func (p Params) LeaseFee(usage int64) sdk.Coins {
+	return p.LeaseFeeAt(usage, 0)
+}
+
+// LeaseFeeAt returns the price of usage byte-blocks while storedBytes value bytes are
+// stored on chain, rounded up.
+func (p Params) LeaseFeeAt(usage int64, storedBytes uint64) sdk.Coins {
+	fee := sdk.NewCoins()
+	for _, price := range p.LeasePriceAt(storedBytes) {
+		fee = fee.Add(sdk.NewCoin(price.Denom, price.Amount.MulInt64(usage).Ceil().TruncateInt()))
+	}
+	return fee
+}
+
+// LeasePriceAt returns the LeasePrice scaled by the utilization band storedBytes falls
+// in.
+func (p Params) LeasePriceAt(storedBytes uint64) sdk.DecCoins {
+	multiplier := p.UtilizationMultiplier(storedBytes)
+	if multiplier.Equal(sdk.OneDec()) {
+		return p.LeasePrice
+	}
+	return p.LeasePrice.MulDec(multiplier)
+}
+
+// UtilizationMultiplier returns the multiplier of the last band starting at or below
+// storedBytes, 1 if there is none.
+func (p Params) UtilizationMultiplier(storedBytes uint64) sdk.Dec {
+	multiplier := sdk.OneDec()
+	for _, band := range p.UtilizationBands {
+		if band.MinBytes > storedBytes {
+			break
+		}
+		multiplier = band.Multiplier
+	}
+	return multiplier
+}
+
+func ParamKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return subspace.ParamSetPairs{
+		subspace.NewParamSetPair(KeyCompressionThreshold, &p.CompressionThreshold, validateCompressionThreshold),
+		subspace.NewParamSetPair(KeyMinMsgGas, &p.MinMsgGas, validateMinMsgGas),
+		subspace.NewParamSetPair(KeyLeasePrice, &p.LeasePrice, validateLeasePrice),
+		subspace.NewParamSetPair(KeyExpiryGraceBlocks, &p.ExpiryGraceBlocks, validateExpiryGraceBlocks),
+		subspace.NewParamSetPair(KeyMaxWritesPerWindow, &p.MaxWritesPerWindow, validateMaxWritesPerWindow),
+		subspace.NewParamSetPair(KeyRateLimitWindow, &p.RateLimitWindow, validateRateLimitWindow),
+		subspace.NewParamSetPair(KeyBaseMsgGas, &p.BaseMsgGas, validateBaseMsgGas),
+		subspace.NewParamSetPair(KeyAuditRetentionBlocks, &p.AuditRetentionBlocks, validateAuditRetentionBlocks),
+		subspace.NewParamSetPair(KeyDefaultLeaseBlocks, &p.DefaultLeaseBlocks, validateDefaultLeaseBlocks),
+		subspace.NewParamSetPair(KeyUtilizationBands, &p.UtilizationBands, validateUtilizationBands),
+		subspace.NewParamSetPair(KeyRentEpochBlocks, &p.RentEpochBlocks, validateRentEpochBlocks),
+		subspace.NewParamSetPair(KeyMaxBatchSize, &p.MaxBatchSize, validateMaxBatchSize),
+		subspace.NewParamSetPair(KeyBatchItemGas, &p.BatchItemGas, validateBatchItemGas),
+		subspace.NewParamSetPair(KeyBatchByteGas, &p.BatchByteGas, validateBatchByteGas),
+	}
+}
+
+func (p Params) Validate() error {
+	if err := validateCompressionThreshold(p.CompressionThreshold); err != nil {
+		return err
+	}
+	if err := validateMinMsgGas(p.MinMsgGas); err != nil {
+		return err
+	}
+	if err := validateLeasePrice(p.LeasePrice); err != nil {
+		return err
+	}
+	if err := validateExpiryGraceBlocks(p.ExpiryGraceBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxWritesPerWindow(p.MaxWritesPerWindow); err != nil {
+		return err
+	}
+	if err := validateRateLimitWindow(p.RateLimitWindow); err != nil {
+		return err
+	}
+	if err := validateBaseMsgGas(p.BaseMsgGas); err != nil {
+		return err
+	}
+	if err := validateAuditRetentionBlocks(p.AuditRetentionBlocks); err != nil {
+		return err
+	}
+	if err := validateDefaultLeaseBlocks(p.DefaultLeaseBlocks); err != nil {
+		return err
+	}
+	if err := validateUtilizationBands(p.UtilizationBands); err != nil {
+		return err
+	}
+	if err := validateRentEpochBlocks(p.RentEpochBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxBatchSize(p.MaxBatchSize); err != nil {
+		return err
+	}
+	if err := validateBatchItemGas(p.BatchItemGas); err != nil {
+		return err
+	}
+	return validateBatchByteGas(p.BatchByteGas)
+}
+
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params:\n")
+	sb.WriteString(fmt.Sprintf("CompressionThreshold: %d\n", p.CompressionThreshold))
+	sb.WriteString("MinMsgGas:\n")
+	for _, entry := range p.MinMsgGas {
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", entry.MsgType, entry.Gas))
+	}
+	sb.WriteString(fmt.Sprintf("LeasePrice: %s\n", p.LeasePrice))
+	sb.WriteString(fmt.Sprintf("ExpiryGraceBlocks: %d\n", p.ExpiryGraceBlocks))
+	sb.WriteString(fmt.Sprintf("MaxWritesPerWindow: %d\n", p.MaxWritesPerWindow))
+	sb.WriteString(fmt.Sprintf("RateLimitWindow: %d\n", p.RateLimitWindow))
+	sb.WriteString("BaseMsgGas:\n")
+	for _, entry := range p.BaseMsgGas {
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", entry.MsgType, entry.Gas))
+	}
+	sb.WriteString(fmt.Sprintf("AuditRetentionBlocks: %d\n", p.AuditRetentionBlocks))
+	sb.WriteString(fmt.Sprintf("DefaultLeaseBlocks: %d\n", p.DefaultLeaseBlocks))
+	sb.WriteString("UtilizationBands:\n")
+	for _, band := range p.UtilizationBands {
+		sb.WriteString(fmt.Sprintf("  %d: %s\n", band.MinBytes, band.Multiplier))
+	}
+	sb.WriteString(fmt.Sprintf("RentEpochBlocks: %d\n", p.RentEpochBlocks))
+	sb.WriteString(fmt.Sprintf("MaxBatchSize: %d\n", p.MaxBatchSize))
+	sb.WriteString(fmt.Sprintf("BatchItemGas: %d\n", p.BatchItemGas))
+	sb.WriteString(fmt.Sprintf("BatchByteGas: %d\n", p.BatchByteGas))
+	return sb.String()
+}
+
+func validateCompressionThreshold(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateMinMsgGas(i interface{}) error {
+	return validateMsgGasTable("min msg gas", i)
+}
+
+func validateBaseMsgGas(i interface{}) error {
+	return validateMsgGasTable("base msg gas", i)
+}
+
+func validateMsgGasTable(name string, i interface{}) error {
+	table, ok := i.([]MsgGas)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range table {
+		if len(entry.MsgType) == 0 {
+			return fmt.Errorf("%s entry without a msg type", name)
+		}
+		if seen[entry.MsgType] {
+			return fmt.Errorf("duplicate %s entry for %s", name, entry.MsgType)
+		}
+		seen[entry.MsgType] = true
+	}
+	return nil
+}
+
+func validateLeasePrice(i interface{}) error {
+	price, ok := i.(sdk.DecCoins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if len(price) != 0 && !price.IsValid() {
+		return fmt.Errorf("invalid lease price: %s", price)
+	}
+	return nil
+}
+
+func validateExpiryGraceBlocks(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateMaxWritesPerWindow(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateRateLimitWindow(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateAuditRetentionBlocks(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateDefaultLeaseBlocks(i interface{}) error {
+	lease, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if lease < 0 {
+		return fmt.Errorf("negative default lease: %d", lease)
+	}
+	return nil
+}
+
+func validateUtilizationBands(i interface{}) error {
+	bands, ok := i.([]UtilizationBand)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for i, band := range bands {
+		if band.Multiplier.IsNil() || !band.Multiplier.IsPositive() {
+			return fmt.Errorf("utilization band at %d bytes without a positive multiplier", band.MinBytes)
+		}
+		if i > 0 && band.MinBytes <= bands[i-1].MinBytes {
+			return fmt.Errorf("utilization bands must be in increasing min bytes, %d follows %d", band.MinBytes, bands[i-1].MinBytes)
+		}
+	}
+	return nil
+}
+
+func validateRentEpochBlocks(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateMaxBatchSize(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBatchItemGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBatchByteGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
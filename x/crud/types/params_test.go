@@ -0,0 +1,153 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParams_Validate(t *testing.T) {
+	assert.Nil(t, DefaultParams().Validate())
+	assert.Nil(t, NewParams(1024).Validate())
+
+	assert.NotNil(t, validateCompressionThreshold(int64(1024)))
+	assert.NotNil(t, validateExpiryGraceBlocks(int64(10)))
+	assert.NotNil(t, validateMaxWritesPerWindow(int64(10)))
+	assert.NotNil(t, validateRateLimitWindow(int64(10)))
+	assert.NotNil(t, validateAuditRetentionBlocks(int64(10)))
+	assert.NotNil(t, validateDefaultLeaseBlocks(uint64(10)))
+	assert.NotNil(t, validateRentEpochBlocks(int64(10)))
+	assert.NotNil(t, validateMaxBatchSize(int64(10)))
+	assert.NotNil(t, validateBatchItemGas(int64(10)))
+	assert.NotNil(t, validateBatchByteGas(int64(10)))
+	assert.NotNil(t, validateDefaultLeaseBlocks(int64(-1)))
+	assert.Nil(t, validateDefaultLeaseBlocks(int64(100)))
+
+	params := DefaultParams()
+	params.MinMsgGas = []MsgGas{{MsgType: "create", Gas: 1000}, {MsgType: "update", Gas: 500}}
+	assert.Nil(t, params.Validate())
+
+	params.MinMsgGas = append(params.MinMsgGas, MsgGas{MsgType: "create", Gas: 10})
+	assert.NotNil(t, params.Validate())
+
+	params.MinMsgGas = []MsgGas{{Gas: 10}}
+	assert.NotNil(t, params.Validate())
+
+	params = DefaultParams()
+	params.BaseMsgGas = append(params.BaseMsgGas, MsgGas{MsgType: "read", Gas: 10})
+	assert.NotNil(t, params.Validate())
+}
+
+func TestParams_LeaseFee(t *testing.T) {
+	params := DefaultParams()
+	assert.True(t, params.LeaseFee(1000).IsZero())
+
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(25, 4)))
+	assert.Nil(t, params.Validate())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 25)), params.LeaseFee(10000))
+	// rounded up
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1)), params.LeaseFee(1))
+}
+
+func TestParams_MinGas(t *testing.T) {
+	params := DefaultParams()
+	assert.Equal(t, uint64(0), params.MinGas("create"))
+
+	params.MinMsgGas = []MsgGas{{MsgType: "create", Gas: 1000}}
+	assert.Equal(t, uint64(1000), params.MinGas("create"))
+	assert.Equal(t, uint64(0), params.MinGas("delete"))
+}
+
+func TestParams_BaseGas(t *testing.T) {
+	params := DefaultParams()
+	assert.Equal(t, uint64(1000), params.BaseGas("read"))
+	assert.Equal(t, uint64(5000), params.BaseGas("deleteall"))
+	assert.Equal(t, uint64(0), params.BaseGas("freeze"))
+
+	// the defaults are copied, not shared
+	params.BaseMsgGas[0].Gas = 1
+	assert.Equal(t, uint64(2000), DefaultParams().BaseGas("create"))
+}
+
+func TestParams_ParamSetPairs(t *testing.T) {
+	params := DefaultParams()
+	pairs := params.ParamSetPairs()
+
+	assert.Len(t, pairs, 14)
+	assert.Equal(t, KeyCompressionThreshold, pairs[0].Key)
+	assert.Equal(t, KeyMinMsgGas, pairs[1].Key)
+	assert.Equal(t, KeyLeasePrice, pairs[2].Key)
+	assert.Equal(t, KeyExpiryGraceBlocks, pairs[3].Key)
+	assert.Equal(t, KeyMaxWritesPerWindow, pairs[4].Key)
+	assert.Equal(t, KeyRateLimitWindow, pairs[5].Key)
+	assert.Equal(t, KeyBaseMsgGas, pairs[6].Key)
+	assert.Equal(t, KeyAuditRetentionBlocks, pairs[7].Key)
+	assert.Equal(t, KeyDefaultLeaseBlocks, pairs[8].Key)
+	assert.Equal(t, KeyUtilizationBands, pairs[9].Key)
+	assert.Equal(t, KeyRentEpochBlocks, pairs[10].Key)
+	assert.Equal(t, KeyMaxBatchSize, pairs[11].Key)
+	assert.Equal(t, KeyBatchItemGas, pairs[12].Key)
+	assert.Equal(t, KeyBatchByteGas, pairs[13].Key)
+}
+
+func TestParams_BatchGas(t *testing.T) {
+	params := DefaultParams()
+	assert.Equal(t, DefaultBatchItemGas+DefaultBatchByteGas*8, params.BatchGas("key", []byte("value")))
+
+	params.BatchItemGas, params.BatchByteGas = 0, 0
+	assert.Equal(t, uint64(0), params.BatchGas("key", []byte("value")))
+}
+
+func TestParams_RateLimitWindowAt(t *testing.T) {
+	params := DefaultParams()
+	assert.Equal(t, uint64(1), params.RateLimitBlocks())
+	assert.Equal(t, uint64(7), params.RateLimitWindowAt(7))
+
+	params.RateLimitWindow = 10
+	assert.Equal(t, uint64(0), params.RateLimitWindowAt(9))
+	assert.Equal(t, uint64(1), params.RateLimitWindowAt(10))
+	assert.Equal(t, uint64(1), params.RateLimitWindowAt(19))
+}
+
+func TestParams_Utilization(t *testing.T) {
+	params := DefaultParams()
+	params.LeasePrice = sdk.NewDecCoins(sdk.NewDecCoinFromDec("ubnt", sdk.NewDecWithPrec(1, 2)))
+	assert.True(t, params.UtilizationMultiplier(1<<40).Equal(sdk.OneDec()))
+	assert.Equal(t, params.LeaseFee(1000), params.LeaseFeeAt(1000, 1<<40))
+
+	params.UtilizationBands = []UtilizationBand{{MinBytes: 1000, Multiplier: sdk.NewDecWithPrec(15, 1)}, {MinBytes: 5000, Multiplier: sdk.NewDec(4)}}
+	assert.Nil(t, params.Validate())
+
+	assert.True(t, params.UtilizationMultiplier(999).Equal(sdk.OneDec()))
+	assert.True(t, params.UtilizationMultiplier(1000).Equal(sdk.NewDecWithPrec(15, 1)))
+	assert.True(t, params.UtilizationMultiplier(4999).Equal(sdk.NewDecWithPrec(15, 1)))
+	assert.True(t, params.UtilizationMultiplier(5000).Equal(sdk.NewDec(4)))
+
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), params.LeaseFeeAt(1000, 0))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 15)), params.LeaseFeeAt(1000, 2000))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 40)), params.LeaseFeeAt(1000, 5000))
+
+	// bands out of order, or without a positive multiplier
+	params.UtilizationBands = []UtilizationBand{{MinBytes: 5000, Multiplier: sdk.NewDec(4)}, {MinBytes: 1000, Multiplier: sdk.NewDec(2)}}
+	assert.NotNil(t, params.Validate())
+	params.UtilizationBands = []UtilizationBand{{MinBytes: 1000, Multiplier: sdk.NewDec(2)}, {MinBytes: 1000, Multiplier: sdk.NewDec(3)}}
+	assert.NotNil(t, params.Validate())
+	params.UtilizationBands = []UtilizationBand{{MinBytes: 1000}}
+	assert.NotNil(t, params.Validate())
+	params.UtilizationBands = []UtilizationBand{{MinBytes: 1000, Multiplier: sdk.ZeroDec()}}
+	assert.NotNil(t, params.Validate())
+}
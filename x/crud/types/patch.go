@@ -0,0 +1,74 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ApplyMergePatch applies an RFC 7386 JSON merge patch to a JSON document. Numbers
+// are carried through untouched and object keys are emitted in sorted order, so the
+// result is deterministic across nodes.
+func ApplyMergePatch(document []byte, patch string) ([]byte, error) {
+	var doc interface{}
+	if err := decodeJSON(document, &doc); err != nil {
+		return nil, errors.New("value is not valid JSON")
+	}
+
+	var p interface{}
+	if err := decodeJSON([]byte(patch), &p); err != nil {
+		return nil, errors.New("patch is not valid JSON")
+	}
+
+	result, err := json.Marshal(mergePatch(doc, p))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatch(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+func decodeJSON(bz []byte, v *interface{}) error {
+	if !json.Valid(bz) {
+		return errors.New("invalid JSON")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,420 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/base64"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"time"
+)
+
+type QueryResultRead struct {
+	UUID        string `json:"uuid"`
+	Key         string `json:"key"`
+	Value       []byte `json:"value"`
+	Version     uint64 `json:"version,string"`
+	ContentType string `json:"content_type,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+}
+
+// for fmt.Stringer
+func (r QueryResultRead) String() string {
+	return string(r.Value)
+}
+
+// MarshalYAML shows the value as base64 in text output, which yaml would otherwise
+// print one byte per line.
+func (r QueryResultRead) MarshalYAML() (interface{}, error) {
+	return struct {
+		UUID        string
+		Key         string
+		Value       string
+		Version     uint64
+		ContentType string `yaml:",omitempty"`
+		Encoding    string `yaml:",omitempty"`
+	}{r.UUID, r.Key, base64.StdEncoding.EncodeToString(r.Value), r.Version, r.ContentType, r.Encoding}, nil
+}
+
+// QueryResultReadMeta is a read that also returns what dashboards show next to the
+// value. Lease is the number of blocks left.
+type QueryResultReadMeta struct {
+	UUID           string         `json:"uuid"`
+	Key            string         `json:"key"`
+	Value          []byte         `json:"value"`
+	Owner          sdk.AccAddress `json:"owner"`
+	Lease          int64          `json:"lease,string"`
+	CreatedHeight  int64          `json:"created_height,string"`
+	ModifiedHeight int64          `json:"modified_height,string"`
+	Version        uint64         `json:"version,string"`
+	ContentType    string         `json:"content_type,omitempty"`
+	Encoding       string         `json:"encoding,omitempty"`
+}
+
+func (r QueryResultReadMeta) MarshalYAML() (interface{}, error) {
+	return struct {
+		UUID           string
+		Key            string
+		Value          string
+		Owner          sdk.AccAddress
+		Lease          int64
+		CreatedHeight  int64
+		ModifiedHeight int64
+		Version        uint64
+		ContentType    string `yaml:",omitempty"`
+		Encoding       string `yaml:",omitempty"`
+	}{r.UUID, r.Key, base64.StdEncoding.EncodeToString(r.Value), r.Owner, r.Lease, r.CreatedHeight, r.ModifiedHeight, r.Version,
+		r.ContentType, r.Encoding}, nil
+}
+
+type QueryResultHas struct {
+	UUID string `json:"uuid"`
+	Key  string `json:"key"`
+	Has  bool   `json:"has"`
+}
+
+// for fmt.Stringer
+func (r QueryResultHas) String() string {
+	if r.Has {
+		return "true"
+	} else {
+		return "false"
+	}
+}
+
+type QueryResultOwner struct {
+	UUID  string         `json:"uuid"`
+	Key   string         `json:"key"`
+	Has   bool           `json:"has"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// QueryResultKeys is a page of the keys of a UUID in lexicographic byte order. While
+// Next is set there are more, and it is passed back as the start of the next page.
+type QueryResultKeys struct {
+	UUID string   `json:"uuid"`
+	Keys []string `json:"keys"`
+	Next string   `json:"next,omitempty"`
+}
+
+// QueryResultKeyValues is a page of the keys of a UUID and their values, ordered and
+// continued like QueryResultKeys.
+type QueryResultKeyValues struct {
+	UUID      string     `json:"uuid"`
+	KeyValues []KeyValue `json:"keyvalues"`
+	Next      string     `json:"next,omitempty"`
+}
+
+type UUIDCount struct {
+	UUID  string `json:"uuid"`
+	Count uint64 `json:"count,string"`
+}
+
+type QueryResultUUIDs struct {
+	Owner sdk.AccAddress `json:"owner"`
+	UUIDs []UUIDCount    `json:"uuids"`
+}
+
+// QueryResultUUIDStats describes a UUID: its keys, the bytes of their values, the
+// number of owners they have and the heights their leases expire at, soonest and last.
+// The expiry heights are 0 for an empty UUID.
+type QueryResultUUIDStats struct {
+	UUID           string `json:"uuid"`
+	Keys           uint64 `json:"keys,string"`
+	Bytes          uint64 `json:"bytes,string"`
+	Owners         uint64 `json:"owners,string"`
+	EarliestExpiry int64  `json:"earliest_expiry,string"`
+	LatestExpiry   int64  `json:"latest_expiry,string"`
+}
+
+// QueryResultCountAll is the number of keys owner has, over all of its UUIDs.
+type QueryResultCountAll struct {
+	Owner sdk.AccAddress `json:"owner"`
+	Count uint64         `json:"count,string"`
+	UUIDs uint64         `json:"uuids,string"`
+}
+
+type UUIDKey struct {
+	UUID string `json:"uuid"`
+	Key  string `json:"key"`
+}
+
+type QueryResultKeysAll struct {
+	Owner sdk.AccAddress `json:"owner"`
+	Keys  []UUIDKey      `json:"keys"`
+}
+
+// UUIDUsage is what an owner stores in a UUID. Bytes counts the UUID, key and value of
+// each key, as leases are charged, and RenewalCost is the lease fee of renewing every
+// key for its current lease at the current lease_price.
+type UUIDUsage struct {
+	UUID        string    `json:"uuid"`
+	Keys        uint64    `json:"keys,string"`
+	Bytes       uint64    `json:"bytes,string"`
+	RenewalCost sdk.Coins `json:"renewal_cost"`
+}
+
+type QueryResultAccountUsage struct {
+	Owner       sdk.AccAddress `json:"owner"`
+	Keys        uint64         `json:"keys,string"`
+	Bytes       uint64         `json:"bytes,string"`
+	RenewalCost sdk.Coins      `json:"renewal_cost"`
+	UUIDs       []UUIDUsage    `json:"uuids"`
+}
+
+type QueryResultKeyValuesPage struct {
+	UUID      string          `json:"uuid"`
+	KeyValues []KeyValueLease `json:"keyvalues"`
+	Next      string          `json:"next,omitempty"`
+}
+
+type QueryResultCount struct {
+	UUID  string `json:"uuid"`
+	Count uint64 `json:"count,string"`
+}
+
+// QueryResultDeleteAll reports the keys deleted by a MsgDeleteAll. While Remaining is
+// not zero the message can be sent again to continue from the key in Next.
+type QueryResultDeleteAll struct {
+	UUID      string `json:"uuid"`
+	Count     uint64 `json:"count,string"`
+	Remaining uint64 `json:"remaining,string"`
+	Next      string `json:"next,omitempty"`
+}
+
+// QueryResultRenewLeaseAll reports the keys renewed by a MsgRenewLeaseAll, their new
+// expiry heights and the gas the renewals consumed.
+type QueryResultRenewLeaseAll struct {
+	UUID    string      `json:"uuid"`
+	Count   uint64      `json:"count,string"`
+	GasUsed uint64      `json:"gas_used,string"`
+	Keys    []KeyExpiry `json:"keys"`
+}
+
+// BlockTimeEstimate is the block interval assumed when estimating the time a lease runs out
+const BlockTimeEstimate = 5 * time.Second
+
+// QueryResultLeaseAll is a page of an owner's keys soonest expiring first. While Next
+// is set there are more, and it is passed back as the start of the next page.
+type QueryResultLeaseAll struct {
+	UUID   string           `json:"uuid"`
+	Owner  sdk.AccAddress   `json:"owner"`
+	Leases []KeyLeaseExpiry `json:"leases"`
+	Next   string           `json:"next,omitempty"`
+}
+
+// operations QueryEstimateLeaseParams can estimate
+const (
+	EstimateCreate = "create"
+	EstimateRenew  = "renew"
+)
+
+// QueryEstimateLeaseParams is sent as the data of an estimatelease query. Size is only
+// used by create, renew estimates the renewal of the existing key.
+type QueryEstimateLeaseParams struct {
+	Operation string       `json:"operation"`
+	UUID      string       `json:"uuid"`
+	Key       string       `json:"key"`
+	Size      uint64       `json:"size,string"`
+	Lease     int64        `json:"lease,string"`
+	GasPrices sdk.DecCoins `json:"gas_prices"`
+}
+
+type QueryResultEstimateLease struct {
+	Gas  uint64    `json:"gas,string"`
+	Fees sdk.Coins `json:"fees"`
+	// paid from the owner's account on top of the fees
+	LeaseFee sdk.Coins `json:"lease_fee"`
+}
+
+// QuerySimulateParams is sent as the data of a simulate query. Msg is any crud
+// message, unsigned, as it would appear in a transaction.
+type QuerySimulateParams struct {
+	Msg       sdk.Msg      `json:"msg"`
+	GasPrices sdk.DecCoins `json:"gas_prices"`
+}
+
+type QueryResultSimulate struct {
+	Gas  uint64    `json:"gas,string"`
+	Fees sdk.Coins `json:"fees"`
+	// paid from the signer's account on top of the fees
+	LeaseFee sdk.Coins `json:"lease_fee"`
+}
+
+// QueryResultMultiUpdate is the result of a MsgMultiUpdate, with the outcome of each of
+// its key values in order.
+type QueryResultMultiUpdate struct {
+	UUID    string              `json:"uuid"`
+	Results []MultiUpdateResult `json:"results"`
+}
+
+type MultiUpdateResult struct {
+	Key     string `json:"key"`
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// QueryResultRename is the result of a MsgRename, with the expiry height the lease of
+// the renamed key kept.
+type QueryResultRename struct {
+	UUID   string `json:"uuid"`
+	Key    string `json:"key"`
+	NewKey string `json:"new_key"`
+	Expiry int64  `json:"expiry,string"`
+}
+
+// QueryResultExpireNow is the result of a MsgExpireNow, with the height the lease of the
+// key now runs out at and the unearned lease deposit refunded to the owner.
+type QueryResultExpireNow struct {
+	UUID   string    `json:"uuid"`
+	Key    string    `json:"key"`
+	Expiry int64     `json:"expiry,string"`
+	Refund sdk.Coins `json:"refund"`
+}
+
+// GCPurge is what the purge of expired keys removed in the block at Height. Bytes
+// counts the UUID, key and value of each key.
+type GCPurge struct {
+	Height int64  `json:"height,string"`
+	Keys   uint64 `json:"keys,string"`
+	Bytes  uint64 `json:"bytes,string"`
+}
+
+// GCBacklog is the number of keys whose lease ran out at or before Height that have
+// not been purged yet.
+type GCBacklog struct {
+	Height int64  `json:"height,string"`
+	Keys   uint64 `json:"keys,string"`
+}
+
+// QueryResultGCStatus is how far the purge of expired keys has got at Height. Keys
+// whose lease ran out at or before PurgedHeight are gone, Backlog is the number that
+// ran out since and are kept for the expiry grace period, and LastPurge is the last
+// block that removed any.
+type QueryResultGCStatus struct {
+	Height       int64   `json:"height,string"`
+	PurgedHeight int64   `json:"purged_height,string"`
+	Backlog      uint64  `json:"backlog,string"`
+	LastPurge    GCPurge `json:"last_purge"`
+}
+
+// QueryResultRentHistory is a page of an owner's rent records, oldest first, and what
+// they add up to. While Next is set there are more, starting with the epoch at that
+// height.
+type QueryResultRentHistory struct {
+	Owner    sdk.AccAddress `json:"owner"`
+	Records  []RentRecord   `json:"records"`
+	Paid     sdk.Coins      `json:"paid"`
+	Refunded sdk.Coins      `json:"refunded"`
+	Next     int64          `json:"next,string,omitempty"`
+}
+
+// QueryResultLeasePrice is the price of one byte-block of lease while StoredBytes value
+// bytes are stored on chain: the BasePrice param scaled by the Multiplier of the
+// utilization band they fall in.
+type QueryResultLeasePrice struct {
+	StoredBytes uint64       `json:"stored_bytes,string"`
+	BasePrice   sdk.DecCoins `json:"base_price"`
+	Multiplier  sdk.Dec      `json:"multiplier"`
+	LeasePrice  sdk.DecCoins `json:"lease_price"`
+}
+
+// QueryResultDefaultLease is the lease in blocks given to keys created or renewed
+// without one.
+type QueryResultDefaultLease struct {
+	LeaseBlocks int64 `json:"lease_blocks,string"`
+}
+
+type QueryResultEscrow struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	Balance sdk.Coins      `json:"balance"`
+}
+
+type QueryResultLease struct {
+	UUID  string `json:"uuid"`
+	Key   string `json:"key"`
+	Lease int64  `json:"lease,string"`
+}
+
+// QueryResultNShortestLeaseKeys holds the keys with the least lease left. While Next is
+// set there are more, and the query takes it back as the start of the next page.
+type QueryResultNShortestLeaseKeys struct {
+	UUID      string     `json:"uuid"`
+	KeyLeases []KeyLease `json:"keyleases"`
+	Next      string     `json:"next,omitempty"`
+}
+
+// QueryMatchParams is sent as the data of a match query. Start is the Next of the
+// previous page, if any.
+type QueryMatchParams struct {
+	Pattern string `json:"pattern"`
+	Start   string `json:"start"`
+}
+
+// QueryResultMatch is a page of the keys matching a pattern, in key order. While Next is
+// set there are more keys to look at, starting with that one.
+type QueryResultMatch struct {
+	UUID    string   `json:"uuid"`
+	Pattern string   `json:"pattern"`
+	Keys    []string `json:"keys"`
+	Next    string   `json:"next,omitempty"`
+}
+
+// QueryResultKeysByExpiry is a page of keys in order of increasing remaining lease.
+// While Next is set there are more, and it is passed back as the start of the next page.
+type QueryResultKeysByExpiry struct {
+	UUID      string         `json:"uuid"`
+	Owner     sdk.AccAddress `json:"owner"`
+	KeyLeases []KeyLease     `json:"keyleases"`
+	Next      string         `json:"next,omitempty"`
+}
+
+// QueryResultAuditLog is a page of the audit log of a UUID, oldest first. While Next is
+// set there are more entries, starting with that sequence number.
+type QueryResultAuditLog struct {
+	UUID    string       `json:"uuid"`
+	Entries []AuditEntry `json:"entries"`
+	Next    uint64       `json:"next,string,omitempty"`
+}
+
+// QueryResultSchema is the schema of a UUID, with no owner if it has none.
+type QueryResultSchema struct {
+	UUID   string `json:"uuid"`
+	Schema Schema `json:"schema"`
+}
+
+type QueryResultMetadata struct {
+	UUID           string `json:"uuid"`
+	Key            string `json:"key"`
+	CreatedHeight  int64  `json:"created_height,string"`
+	ModifiedHeight int64  `json:"modified_height,string"`
+	Size           int64  `json:"size,string"`
+	Version        uint64 `json:"version,string"`
+	ContentType    string `json:"content_type,omitempty"`
+	Encoding       string `json:"encoding,omitempty"`
+	Frozen         bool   `json:"frozen"`
+	// takes the key over when its lease runs out
+	Beneficiary sdk.AccAddress `json:"beneficiary,omitempty"`
+}
+
+type QueryResultHash struct {
+	UUID string `json:"uuid"`
+	Key  string `json:"key"`
+	Hash string `json:"hash"`
+}
+
+// for fmt.Stringer
+func (r QueryResultHash) String() string {
+	return r.Hash
+}
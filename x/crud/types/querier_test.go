@@ -15,12 +15,19 @@
 package types
 
 import (
+	"fmt"
 	"github.com/magiconair/properties/assert"
 	"testing"
 )
 
 func TestQueryResultRead_String(t *testing.T) {
-	assert.Equal(t, QueryResultRead{UUID: "uuid", Key: "key", Value: "value"}.String(), "value")
+	assert.Equal(t, QueryResultRead{UUID: "uuid", Key: "key", Value: []byte("value")}.String(), "value")
+}
+
+func TestQueryResultRead_MarshalYAML(t *testing.T) {
+	out, err := QueryResultRead{UUID: "uuid", Key: "key", Value: []byte{0xff, 0x00}}.MarshalYAML()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, fmt.Sprintf("%v", out), "{uuid key /wA=}")
 }
 
 func TestQueryResultHas_String(t *testing.T) {
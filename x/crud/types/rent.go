@@ -0,0 +1,32 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// DefaultRentEpochBlocks is the length of the rent accounting epochs while the
+// RentEpochBlocks param is 0, a day of 5 second blocks.
+const DefaultRentEpochBlocks uint64 = 86400 / 5
+
+// RentRecord adds up the lease fees an owner paid, and had refunded, in the epoch
+// starting at StartHeight. Charges counts the payments and ByteBlocks the lease they
+// paid for, in bytes times blocks.
+type RentRecord struct {
+	StartHeight int64     `json:"start_height,string"`
+	Charges     uint64    `json:"charges,string"`
+	ByteBlocks  int64     `json:"byte_blocks,string"`
+	Paid        sdk.Coins `json:"paid"`
+	Refunded    sdk.Coins `json:"refunded"`
+}
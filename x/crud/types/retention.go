@@ -0,0 +1,46 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// the orders in which a retention policy evicts keys: fifo evicts the keys created
+// first, lru the keys whose value was changed least recently
+const (
+	RetentionOrderFIFO = "fifo"
+	RetentionOrderLRU  = "lru"
+)
+
+// RetentionPolicy caps the number of keys its owner keeps in a UUID at MaxKeys. Keys
+// beyond the cap are evicted at the end of the block, oldest first by Order; frozen
+// keys are never evicted. Like an index it is owned by the account that set it.
+type RetentionPolicy struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	MaxKeys uint64         `json:"max_keys,string"`
+	Order   string         `json:"order"`
+}
+
+// IsValidRetentionOrder reports whether order is one of the retention orders.
+func IsValidRetentionOrder(order string) bool {
+	return order == RetentionOrderFIFO || order == RetentionOrderLRU
+}
+
+// Age returns the height value is ordered by for eviction.
+func (p RetentionPolicy) Age(value BLZValue) int64 {
+	if p.Order == RetentionOrderLRU {
+		return value.ModifiedHeight
+	}
+	return value.CreatedHeight
+}
@@ -0,0 +1,466 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSchemaSize bounds the JSON Schema of a UUID, which is compiled for every write
+const MaxSchemaSize = 16384
+
+// Schema constrains the values written to a UUID: MaxValueSize caps their size,
+// ContentType, if set, is the content type they must be written with, and JSONSchema,
+// if set, is a JSON Schema they must be JSON documents conforming to. Like a retention
+// policy it is owned by the account that set it. Values already in the UUID are not
+// checked when it is set.
+type Schema struct {
+	Owner        sdk.AccAddress `json:"owner"`
+	MaxValueSize uint64         `json:"max_value_size,string"`
+	ContentType  string         `json:"content_type"`
+	JSONSchema   string         `json:"json_schema"`
+}
+
+// ValidateBasic checks that s constrains something and that its JSON Schema compiles.
+func (s Schema) ValidateBasic() error {
+	if s.MaxValueSize == 0 && len(s.ContentType) == 0 && len(s.JSONSchema) == 0 {
+		return errors.New("schema has no constraints")
+	}
+
+	if err := ValidateContentType(s.ContentType, ""); err != nil {
+		return err
+	}
+
+	if len(s.JSONSchema) > MaxSchemaSize {
+		return fmt.Errorf("JSON schema longer than %d", MaxSchemaSize)
+	}
+
+	if len(s.JSONSchema) != 0 {
+		if _, err := compileJSONSchema(s.JSONSchema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns why value, written with contentType, does not conform to s, if it
+// does not. The zero Schema accepts every value.
+func (s Schema) Validate(value []byte, contentType string) error {
+	if s.MaxValueSize != 0 && uint64(len(value)) > s.MaxValueSize {
+		return fmt.Errorf("value is %d bytes, more than %d", len(value), s.MaxValueSize)
+	}
+
+	if len(s.ContentType) != 0 && contentType != s.ContentType {
+		return fmt.Errorf("content type is %q, not %q", contentType, s.ContentType)
+	}
+
+	if len(s.JSONSchema) == 0 {
+		return nil
+	}
+
+	schema, err := compileJSONSchema(s.JSONSchema)
+	if err != nil {
+		return err
+	}
+
+	var document interface{}
+	if err := decodeJSON(value, &document); err != nil {
+		return errors.New("value is not valid JSON")
+	}
+	return schema.validate(document, "")
+}
+
+// the JSON types a schema can require, with integer standing for numbers without a
+// fractional part
+var jsonTypes = map[string]bool{"array": true, "boolean": true, "integer": true, "null": true, "number": true, "object": true, "string": true}
+
+// keywords that describe a schema without constraining the values it accepts
+var jsonSchemaAnnotations = map[string]bool{"$comment": true, "$id": true, "$schema": true, "default": true, "description": true, "examples": true, "title": true}
+
+// jsonSchema is a compiled JSON Schema. Only the keywords compiled below are supported,
+// and a schema using any other is rejected rather than silently accepting values it
+// would not.
+type jsonSchema struct {
+	// reject is set by the schema false, which no value conforms to
+	reject bool
+
+	types []string
+	enum  []interface{}
+
+	properties map[string]*jsonSchema
+	required   []string
+	// additional constrains the properties not in properties, nil allowing any
+	additional *jsonSchema
+
+	items              *jsonSchema
+	minItems, maxItems *uint64
+
+	minLength, maxLength *uint64
+	pattern              *regexp.Regexp
+
+	bounds []numberBound
+}
+
+// numberBound is one of the minimum, maximum, exclusiveMinimum and exclusiveMaximum
+// keywords; text is the limit as written in the schema, for errors.
+type numberBound struct {
+	keyword string
+	limit   *big.Rat
+	text    string
+}
+
+// schemaError is an error in a JSON schema, at path in it.
+type schemaError struct {
+	path    string
+	message string
+}
+
+func (e schemaError) Error() string {
+	if len(e.path) == 0 {
+		return fmt.Sprintf("JSON schema: %s", e.message)
+	}
+	return fmt.Sprintf("JSON schema %s: %s", e.path, e.message)
+}
+
+func compileJSONSchema(source string) (*jsonSchema, error) {
+	var raw interface{}
+	if err := decodeJSON([]byte(source), &raw); err != nil {
+		return nil, errors.New("JSON schema is not valid JSON")
+	}
+	return compileSchemaValue(raw, "")
+}
+
+// compileSchemaValue compiles the decoded schema raw found at path in the JSON schema.
+// Keywords are compiled in sorted order, so that the error for a schema with several
+// bad keywords is the same on every node.
+func compileSchemaValue(raw interface{}, path string) (*jsonSchema, error) {
+	var object map[string]interface{}
+	switch raw := raw.(type) {
+	case bool:
+		return &jsonSchema{reject: !raw}, nil
+	case map[string]interface{}:
+		object = raw
+	default:
+		return nil, schemaError{path: path, message: "not a schema"}
+	}
+
+	keywords := make([]string, 0, len(object))
+	for keyword := range object {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	schema := &jsonSchema{}
+	for _, keyword := range keywords {
+		err := schema.compileKeyword(keyword, object[keyword], path)
+		if _, nested := err.(schemaError); err != nil && !nested {
+			err = schemaError{path: path + "/" + escapePointer(keyword), message: err.Error()}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func (s *jsonSchema) compileKeyword(keyword string, value interface{}, path string) error {
+	var err error
+	switch keyword {
+	case "type":
+		switch value := value.(type) {
+		case string:
+			s.types = []string{value}
+		case []interface{}:
+			for _, t := range value {
+				name, ok := t.(string)
+				if !ok {
+					return errors.New("not a type name")
+				}
+				s.types = append(s.types, name)
+			}
+		default:
+			return errors.New("not a type name or list of them")
+		}
+		for _, name := range s.types {
+			if !jsonTypes[name] {
+				return fmt.Errorf("unknown type %q", name)
+			}
+		}
+	case "enum":
+		values, ok := value.([]interface{})
+		if !ok {
+			return errors.New("not an array")
+		}
+		s.enum = values
+	case "const":
+		s.enum = []interface{}{value}
+	case "properties":
+		properties, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("not an object")
+		}
+		s.properties = make(map[string]*jsonSchema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = compileSchemaValue(property, path+"/properties/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+	case "required":
+		names, ok := value.([]interface{})
+		if !ok {
+			return errors.New("not an array")
+		}
+		for _, name := range names {
+			name, ok := name.(string)
+			if !ok {
+				return errors.New("not a property name")
+			}
+			s.required = append(s.required, name)
+		}
+	case "additionalProperties":
+		s.additional, err = compileSchemaValue(value, path+"/additionalProperties")
+	case "items":
+		s.items, err = compileSchemaValue(value, path+"/items")
+	case "minItems":
+		s.minItems, err = compileCount(value)
+	case "maxItems":
+		s.maxItems, err = compileCount(value)
+	case "minLength":
+		s.minLength, err = compileCount(value)
+	case "maxLength":
+		s.maxLength, err = compileCount(value)
+	case "pattern":
+		expr, ok := value.(string)
+		if !ok {
+			return errors.New("not a string")
+		}
+		if s.pattern, err = regexp.Compile(expr); err != nil {
+			return errors.New("not a valid regular expression")
+		}
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+		number, ok := value.(json.Number)
+		if !ok {
+			return errors.New("not a number")
+		}
+		limit, _ := new(big.Rat).SetString(string(number))
+		s.bounds = append(s.bounds, numberBound{keyword: keyword, limit: limit, text: string(number)})
+	default:
+		if !jsonSchemaAnnotations[keyword] {
+			return errors.New("unsupported keyword")
+		}
+	}
+	return err
+}
+
+func compileCount(value interface{}) (*uint64, error) {
+	if number, ok := value.(json.Number); ok {
+		if count, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+			return &count, nil
+		}
+	}
+	return nil, errors.New("not a non-negative integer")
+}
+
+// validate returns why value, found at path in the document, does not conform to s.
+func (s *jsonSchema) validate(value interface{}, path string) error {
+	if s.reject {
+		return fmt.Errorf("%s: not allowed", where(path))
+	}
+
+	if len(s.types) != 0 && !s.matchesType(value) {
+		return fmt.Errorf("%s: expected %s, got %s", where(path), strings.Join(s.types, " or "), jsonTypeOf(value))
+	}
+
+	if s.enum != nil && !s.inEnum(value) {
+		return fmt.Errorf("%s: not one of the allowed values", where(path))
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := uint64(utf8.RuneCountInString(value))
+		if s.minLength != nil && length < *s.minLength {
+			return fmt.Errorf("%s: shorter than %d characters", where(path), *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			return fmt.Errorf("%s: longer than %d characters", where(path), *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			return fmt.Errorf("%s: does not match %q", where(path), s.pattern.String())
+		}
+	case json.Number:
+		number, _ := new(big.Rat).SetString(string(value))
+		for _, bound := range s.bounds {
+			if !bound.allows(number) {
+				return fmt.Errorf("%s: %s violates %s %s", where(path), value, bound.keyword, bound.text)
+			}
+		}
+	case []interface{}:
+		if s.minItems != nil && uint64(len(value)) < *s.minItems {
+			return fmt.Errorf("%s: fewer than %d items", where(path), *s.minItems)
+		}
+		if s.maxItems != nil && uint64(len(value)) > *s.maxItems {
+			return fmt.Errorf("%s: more than %d items", where(path), *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range value {
+				if err := s.items.validate(item, path+"/"+strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := value[name]; !ok {
+				return fmt.Errorf("%s: missing property %q", where(path), name)
+			}
+		}
+
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := s.properties[name]
+			if !ok {
+				property = s.additional
+			}
+			if property == nil {
+				continue
+			}
+			if err := property.validate(value[name], path+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) matchesType(value interface{}) bool {
+	valueType := jsonTypeOf(value)
+	for _, t := range s.types {
+		if t == valueType || (t == "integer" && valueType == "number" && isInteger(value.(json.Number))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jsonSchema) inEnum(value interface{}) bool {
+	for _, allowed := range s.enum {
+		if jsonEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b numberBound) allows(number *big.Rat) bool {
+	cmp := number.Cmp(b.limit)
+	switch b.keyword {
+	case "minimum":
+		return cmp >= 0
+	case "maximum":
+		return cmp <= 0
+	case "exclusiveMinimum":
+		return cmp > 0
+	default:
+		return cmp < 0
+	}
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func isInteger(number json.Number) bool {
+	r, ok := new(big.Rat).SetString(string(number))
+	return ok && r.IsInt()
+}
+
+// jsonEqual compares decoded JSON values, numbers by their value rather than how they
+// are written.
+func jsonEqual(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, _ := new(big.Rat).SetString(string(a))
+		y, _ := new(big.Rat).SetString(string(b))
+		return x != nil && y != nil && x.Cmp(y) == 0
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// where names the JSON pointer path in errors, the empty path being the whole value.
+func where(path string) string {
+	if len(path) == 0 {
+		return "value"
+	}
+	return path
+}
+
+func escapePointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
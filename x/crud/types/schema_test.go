@@ -0,0 +1,112 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const personSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"title": "person",
+	"type": "object",
+	"required": ["name", "age"],
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 8, "pattern": "^[A-Z]"},
+		"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+		"role": {"enum": ["admin", "user", null]},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+		"score": {"type": ["number", "null"], "maximum": 1.5}
+	},
+	"additionalProperties": false
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	schema := Schema{JSONSchema: personSchema}
+	assert.Nil(t, schema.ValidateBasic())
+
+	cases := []struct {
+		value string
+		err   string
+	}{
+		{`{"name":"Ann","age":30}`, ""},
+		{`{"name":"Ann","age":30.0,"role":null,"tags":["a","b"],"score":1.50}`, ""},
+		{`{"name":"Ann","age":30,"score":null}`, ""},
+		{`["Ann",30]`, "value: expected object, got array"},
+		{`{"name":"Ann"}`, `value: missing property "age"`},
+		{`{"name":"ann","age":30}`, `/name: does not match "^[A-Z]"`},
+		{`{"name":"","age":30}`, "/name: shorter than 1 characters"},
+		{`{"name":"Annabelle","age":30}`, "/name: longer than 8 characters"},
+		{`{"name":"Ann","age":30.5}`, "/age: expected integer, got number"},
+		{`{"name":"Ann","age":-1}`, "/age: -1 violates minimum 0"},
+		{`{"name":"Ann","age":150}`, "/age: 150 violates exclusiveMaximum 150"},
+		{`{"name":"Ann","age":30,"role":"owner"}`, "/role: not one of the allowed values"},
+		{`{"name":"Ann","age":30,"tags":["a",1]}`, "/tags/1: expected string, got number"},
+		{`{"name":"Ann","age":30,"tags":["a","b","c"]}`, "/tags: more than 2 items"},
+		{`{"name":"Ann","age":30,"score":1.6}`, "/score: 1.6 violates maximum 1.5"},
+		{`{"name":"Ann","age":30,"a/b":1}`, "/a~1b: not allowed"},
+		{`{"name":`, "value is not valid JSON"},
+	}
+
+	for _, c := range cases {
+		err := schema.Validate([]byte(c.value), "")
+		if c.err == "" {
+			assert.Nil(t, err, c.value)
+		} else {
+			assert.EqualError(t, err, c.err, c.value)
+		}
+	}
+
+	// the size and content type constraints
+	schema = Schema{MaxValueSize: 4, ContentType: "text/plain"}
+	assert.Nil(t, schema.Validate([]byte("1234"), "text/plain"))
+	assert.EqualError(t, schema.Validate([]byte("12345"), "text/plain"), "value is 5 bytes, more than 4")
+	assert.EqualError(t, schema.Validate([]byte("1234"), ""), `content type is "", not "text/plain"`)
+
+	// the zero schema accepts anything
+	assert.Nil(t, Schema{}.Validate([]byte("not json"), ""))
+}
+
+func TestSchema_ValidateBasic(t *testing.T) {
+	cases := []struct {
+		schema string
+		err    string
+	}{
+		{`true`, ""},
+		{`{"const":{"a":[1,2]}}`, ""},
+		{`{"type":`, "JSON schema is not valid JSON"},
+		{`[]`, "JSON schema: not a schema"},
+		{`{"type":"text"}`, `JSON schema /type: unknown type "text"`},
+		{`{"properties":{"a":{"minLength":-1}}}`, "JSON schema /properties/a/minLength: not a non-negative integer"},
+		{`{"items":{"items":3}}`, "JSON schema /items/items: not a schema"},
+		{`{"pattern":"("}`, "JSON schema /pattern: not a valid regular expression"},
+		{`{"minimum":"1"}`, "JSON schema /minimum: not a number"},
+		{`{"oneOf":[true]}`, "JSON schema /oneOf: unsupported keyword"},
+	}
+
+	for _, c := range cases {
+		err := Schema{JSONSchema: c.schema}.ValidateBasic()
+		if c.err == "" {
+			assert.Nil(t, err, c.schema)
+		} else {
+			assert.EqualError(t, err, c.err, c.schema)
+		}
+	}
+
+	assert.EqualError(t, Schema{}.ValidateBasic(), "schema has no constraints")
+	assert.EqualError(t, Schema{ContentType: "text/\n"}.ValidateBasic(), "content type or encoding is not printable ASCII")
+	assert.Nil(t, Schema{MaxValueSize: 1}.ValidateBasic())
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	cc "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+	"time"
+)
+
+type BLZValue struct {
+	Value          []byte         `json:"value"`
+	Lease          int64          `json:"lease"`
+	Height         int64          `json:"height"`
+	Owner          sdk.AccAddress `json:"owner"`
+	CreatedHeight  int64          `json:"created_height"`
+	ModifiedHeight int64          `json:"modified_height"`
+	Size           int64          `json:"size"`
+	Hash           []byte         `json:"hash"`
+	Compressed     bool           `json:"compressed"`
+	// bumped by every write of the key, starting from 1; keys last written before
+	// versions were added are at 0
+	Version uint64 `json:"version"`
+	// how the value is to be interpreted by whoever serves it, see ValidateContentType
+	ContentType string `json:"content_type"`
+	Encoding    string `json:"encoding"`
+}
+
+// ValueHash is the SHA-256 digest of a value, stored with it so clients can verify
+// their copies without reading the value back.
+func ValueHash(value []byte) []byte {
+	hash := sha256.Sum256(value)
+	return hash[:]
+}
+
+// ValidateContentType checks the content type and encoding a value is written with.
+// Gateways return them as the Content-Type and Content-Encoding headers of the value,
+// so they are limited to MaxContentTypeSize printable ASCII characters.
+func ValidateContentType(contentType string, encoding string) error {
+	for _, s := range []string{contentType, encoding} {
+		if len(s) > MaxContentTypeSize {
+			return fmt.Errorf("content type or encoding longer than %d", MaxContentTypeSize)
+		}
+		for i := 0; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] > 0x7e {
+				return fmt.Errorf("content type or encoding is not printable ASCII")
+			}
+		}
+	}
+	return nil
+}
+
+func (kv BLZValue) Unmarshal(b []byte) BLZValue {
+	var cdc = cc.New()
+	value := BLZValue{}
+	_ = cdc.UnmarshalBinaryBare(b, &value)
+	return value
+}
+
+func (kv BLZValue) String() string {
+	if kv.Owner.Empty() {
+		return strings.TrimSpace(fmt.Sprintf("Value: %s Owner: <empty>", kv.Value))
+	}
+	return strings.TrimSpace(fmt.Sprintf("Value: %s Owner: %s", kv.Value, kv.Owner.String()))
+}
+
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+	// Lease is added to the key's lease by MsgMultiUpdate, 0 leaving it unchanged
+	Lease int64 `json:"lease,string,omitempty"`
+}
+
+// the operations of a MsgAtomic
+const (
+	AtomicOpCreate = "create"
+	AtomicOpUpdate = "update"
+	AtomicOpDelete = "delete"
+)
+
+// AtomicOp is one operation of a MsgAtomic, on a key of any UUID. Lease, Value,
+// ContentType and Encoding are used as by MsgCreate and MsgUpdate, Version as by
+// MsgUpdate and MsgDelete.
+type AtomicOp struct {
+	Op          string `json:"op"`
+	UUID        string `json:"uuid"`
+	Key         string `json:"key"`
+	Value       []byte `json:"value,omitempty"`
+	Lease       int64  `json:"lease,string,omitempty"`
+	Version     uint64 `json:"version,string,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+}
+
+// Msg returns the message op stands for, sent by owner, or nil for an unknown operation.
+func (op AtomicOp) Msg(owner sdk.AccAddress) sdk.Msg {
+	switch op.Op {
+	case AtomicOpCreate:
+		msg := NewMsgCreate(op.UUID, op.Key, op.Value, op.Lease, owner)
+		msg.ContentType, msg.Encoding = op.ContentType, op.Encoding
+		return msg
+	case AtomicOpUpdate:
+		return MsgUpdate{UUID: op.UUID, Key: op.Key, Value: op.Value, Lease: op.Lease, Owner: owner, Version: op.Version,
+			ContentType: op.ContentType, Encoding: op.Encoding}
+	case AtomicOpDelete:
+		return MsgDelete{UUID: op.UUID, Key: op.Key, Owner: owner, Version: op.Version}
+	}
+	return nil
+}
+
+type KeyValueLease struct {
+	Key   string         `json:"key"`
+	Value []byte         `json:"value"`
+	Lease int64          `json:"lease,string"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+type KeyLease struct {
+	Key   string `json:"key"`
+	Lease int64  `json:"lease,string"`
+}
+
+type KeyLeases []KeyLease
+
+type KeyLeaseExpiry struct {
+	Key        string    `json:"key"`
+	Lease      int64     `json:"lease,string"`
+	ExpiryTime time.Time `json:"expiry_time"`
+}
+
+type KeyExpiry struct {
+	Key    string `json:"key"`
+	Expiry int64  `json:"expiry,string"`
+}
+
+func (a KeyLeases) Len() int           { return len(a) }
+func (a KeyLeases) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a KeyLeases) Less(i, j int) bool { return a[i].Lease < a[j].Lease }
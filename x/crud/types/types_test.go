@@ -16,6 +16,7 @@ package types
 
 import (
 	cc "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"sort"
@@ -24,7 +25,7 @@ import (
 
 func TestBLZValue_Unmarshal(t *testing.T) {
 	value := BLZValue{
-		Value: "value",
+		Value: []byte("value"),
 		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
 	}
 
@@ -36,15 +37,26 @@ func TestBLZValue_Unmarshal(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(value, resultValue.Unmarshal(b)))
 }
 
+func TestValidateContentType(t *testing.T) {
+	assert.Nil(t, ValidateContentType("", ""))
+	assert.Nil(t, ValidateContentType("application/json; charset=utf-8", "gzip"))
+	assert.Nil(t, ValidateContentType("application/octet-stream", "aes-256-gcm"))
+
+	assert.NotNil(t, ValidateContentType("text/plain\n", ""))
+	assert.NotNil(t, ValidateContentType("", "gzip\x00"))
+	assert.NotNil(t, ValidateContentType("text/plain; charset=é", ""))
+	assert.NotNil(t, ValidateContentType(string(make([]byte, MaxContentTypeSize+1)), ""))
+}
+
 func TestBLZValue_String(t *testing.T) {
 	value := BLZValue{
-		Value: "value",
+		Value: []byte("value"),
 		Owner: []byte("bluzelle1t0ywtmrduldf6h4wqrnnpyp9wr6law2u5jwa23"),
 	}
 	assert.Equal(t, value.String(), "Value: value Owner: cosmos1vfk827n9d3kx2vt5xpuhwardwfj82mryvcmxsdrhw9exumns09crjamjxekxzaejw56k5ampxgeslhg4h3")
 
 	value = BLZValue{
-		Value: "value",
+		Value: []byte("value"),
 	}
 	assert.Equal(t, value.String(), "Value: value Owner: <empty>")
 }
@@ -76,3 +88,14 @@ func TestKeyLeases_Sort(t *testing.T) {
 	assert.Equal(t, int64(0), keyLeases[0].Lease)
 	assert.Equal(t, int64(3), keyLeases[len(keyLeases)-1].Lease)
 }
+
+func TestLeaseDeposit_Unearned(t *testing.T) {
+	deposit := LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100), sdk.NewInt64Coin("utest", 7)), From: 10, To: 20}
+
+	assert.Equal(t, deposit.Amount, deposit.Unearned(5))
+	assert.Equal(t, deposit.Amount, deposit.Unearned(10))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 70), sdk.NewInt64Coin("utest", 4)), deposit.Unearned(13))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 10)), deposit.Unearned(19))
+	assert.True(t, deposit.Unearned(20).IsZero())
+	assert.True(t, deposit.Unearned(25).IsZero())
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	MaxUploadSize = 64 * MaxValueSize
+
+	// an upload left uncommitted for this many blocks can be taken over by another account
+	UploadTimeoutBlocks = int64(86400 / 5)
+)
+
+// Upload is a value being assembled from chunks sent in separate transactions
+type Upload struct {
+	Owner    sdk.AccAddress `json:"owner"`
+	Size     int64          `json:"size"`
+	Hash     []byte         `json:"hash"`
+	Lease    int64          `json:"lease"`
+	Height   int64          `json:"height"`
+	Chunks   uint64         `json:"chunks"`
+	Received int64          `json:"received"`
+}
+
+func (u Upload) IsExpired(blockHeight int64) bool {
+	return blockHeight-u.Height >= UploadTimeoutBlocks
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package crud
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// UpgradeName is the name of the software upgrade plan governance schedules to move the
+// chain to this crud ConsensusVersion.
+var UpgradeName = fmt.Sprintf("crud-v%d", ConsensusVersion)
+
+// NewUpgradeHandler returns the handler run at the height of the UpgradeName plan. It
+// is the only place the crud store is migrated, so every node rewrites it at the same
+// height, and an upgrade needing more than the registered migrations (new indexes,
+// param changes) can add it here. It then repairs the leases, indexes and counters that
+// disagree with the stored values, which blzd crud verify reports on a stopped node.
+func NewUpgradeHandler(k Keeper) upgrade.UpgradeHandler {
+	return func(ctx sdk.Context, plan upgrade.Plan) {
+		if err := k.RunMigrations(ctx); err != nil {
+			panic(err)
+		}
+		for _, inconsistency := range k.Verify(ctx, true) {
+			ctx.Logger().Info(fmt.Sprintf("repaired crud %s", inconsistency))
+		}
+		ctx.Logger().Info(fmt.Sprintf("applied upgrade %s at height %d", plan.Name, plan.Height))
+	}
+}
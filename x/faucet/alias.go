@@ -0,0 +1,42 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package faucet
+
+import (
+	"github.com/bluzelle/curium/x/faucet/internal/keeper"
+	"github.com/bluzelle/curium/x/faucet/internal/types"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	RouterKey         = types.RouterKey
+	StoreKey          = types.StoreKey
+	DefaultParamspace = types.DefaultParamspace
+)
+
+var (
+	NewKeeper        = keeper.NewKeeper
+	NewMsgMintTokens = types.NewMsgMintTokens
+	ModuleCdc        = types.ModuleCdc
+	RegisterCodec    = types.RegisterCodec
+	DefaultParams    = types.DefaultParams
+)
+
+type (
+	Keeper        = keeper.Keeper
+	GenesisState  = types.GenesisState
+	Params        = types.Params
+	MsgMintTokens = types.MsgMintTokens
+)
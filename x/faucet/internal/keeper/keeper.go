@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/faucet/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+type Keeper struct {
+	supplyKeeper types.SupplyKeeper
+	storeKey     sdk.StoreKey
+	paramspace   params.Subspace
+	cdc          *codec.Codec
+}
+
+func NewKeeper(supplyKeeper types.SupplyKeeper, storeKey sdk.StoreKey, paramspace params.Subspace, cdc *codec.Codec) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		storeKey:     storeKey,
+		paramspace:   paramspace.WithKeyTable(types.ParamKeyTable()),
+		cdc:          cdc,
+	}
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+func makeMintsKey(recipient sdk.AccAddress) []byte {
+	return append(append([]byte{}, types.MintsPrefix...), recipient...)
+}
+
+func today(ctx sdk.Context) int64 {
+	return ctx.BlockTime().Unix() / types.SecondsPerDay
+}
+
+// GetMints returns the mints made to recipient today.
+func (k Keeper) GetMints(ctx sdk.Context, recipient sdk.AccAddress) types.Mints {
+	mints := types.Mints{Day: today(ctx)}
+	bz := ctx.KVStore(k.storeKey).Get(makeMintsKey(recipient))
+	if bz == nil {
+		return mints
+	}
+
+	var stored types.Mints
+	k.cdc.MustUnmarshalBinaryBare(bz, &stored)
+	if stored.Day == mints.Day {
+		mints.Count = stored.Count
+	}
+	return mints
+}
+
+// MintTokens mints the MintAmount param to recipient, at most MintsPerDay times a day.
+func (k Keeper) MintTokens(ctx sdk.Context, recipient sdk.AccAddress) (sdk.Coins, error) {
+	params := k.GetParams(ctx)
+	if !params.Enabled {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Faucet disabled")
+	}
+
+	mints := k.GetMints(ctx, recipient)
+	if mints.Count >= params.MintsPerDay {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s already minted %d times today", recipient, mints.Count)
+	}
+
+	if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, params.MintAmount); err != nil {
+		return nil, err
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, params.MintAmount); err != nil {
+		return nil, err
+	}
+
+	mints.Count++
+	ctx.KVStore(k.storeKey).Set(makeMintsKey(recipient), k.cdc.MustMarshalBinaryBare(mints))
+	return params.MintAmount, nil
+}
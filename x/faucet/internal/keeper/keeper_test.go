@@ -0,0 +1,106 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"errors"
+	"github.com/bluzelle/curium/x/faucet/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+	"time"
+)
+
+var (
+	testStoreKey   = sdk.NewKVStoreKey(types.StoreKey)
+	testParamsKey  = sdk.NewKVStoreKey(params.StoreKey)
+	testParamsTKey = sdk.NewTransientStoreKey(params.TStoreKey)
+)
+
+// testSupplyKeeper records what the faucet mints and sends
+type testSupplyKeeper struct {
+	minted sdk.Coins
+	sent   map[string]sdk.Coins
+}
+
+func (s *testSupplyKeeper) MintCoins(_ sdk.Context, moduleName string, amt sdk.Coins) error {
+	if moduleName != types.ModuleName {
+		return errors.New("unexpected module")
+	}
+	s.minted = s.minted.Add(amt...)
+	return nil
+}
+
+func (s *testSupplyKeeper) SendCoinsFromModuleToAccount(_ sdk.Context, _ string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	s.sent[recipientAddr.String()] = s.sent[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+func initKeeperTest(enabled bool) (sdk.Context, Keeper, *testSupplyKeeper) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(testStoreKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsTKey, sdk.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	ctx := sdk.NewContext(ms.CacheMultiStore(), abci.Header{Time: time.Unix(10*types.SecondsPerDay, 0)}, false, log.NewNopLogger())
+	supplyKeeper := &testSupplyKeeper{sent: make(map[string]sdk.Coins)}
+	subspace := params.NewKeeper(codec.New(), testParamsKey, testParamsTKey).Subspace(types.DefaultParamspace)
+	keeper := NewKeeper(supplyKeeper, testStoreKey, subspace, codec.New())
+	keeper.SetParams(ctx, types.NewParams(enabled, types.DefaultMintAmount, 2))
+	return ctx, keeper, supplyKeeper
+}
+
+func TestKeeper_MintTokens(t *testing.T) {
+	ctx, keeper, supplyKeeper := initKeeperTest(true)
+	recipient := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+
+	for i := 0; i < 2; i++ {
+		minted, err := keeper.MintTokens(ctx, recipient)
+		assert.Nil(t, err)
+		assert.Equal(t, types.DefaultMintAmount, minted)
+	}
+	assert.Equal(t, types.Mints{Day: 10, Count: 2}, keeper.GetMints(ctx, recipient))
+
+	_, err := keeper.MintTokens(ctx, recipient)
+	assert.NotNil(t, err)
+
+	doubled := types.DefaultMintAmount.Add(types.DefaultMintAmount...)
+	assert.Equal(t, doubled, supplyKeeper.minted)
+	assert.Equal(t, doubled, supplyKeeper.sent[recipient.String()])
+
+	// the limit starts over the next day
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(24 * time.Hour))
+	assert.Equal(t, types.Mints{Day: 11}, keeper.GetMints(ctx, recipient))
+	_, err = keeper.MintTokens(ctx, recipient)
+	assert.Nil(t, err)
+}
+
+func TestKeeper_MintTokens_Disabled(t *testing.T) {
+	ctx, keeper, supplyKeeper := initKeeperTest(false)
+
+	_, err := keeper.MintTokens(ctx, sdk.AccAddress("bluzelle1t0ywtmrdu12"))
+	assert.NotNil(t, err)
+	assert.True(t, supplyKeeper.minted.Empty())
+}
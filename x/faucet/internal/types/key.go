@@ -0,0 +1,31 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+const (
+	// module name, also the name of the module account the tokens are minted by
+	ModuleName = "faucet"
+
+	StoreKey  = ModuleName
+	RouterKey = ModuleName
+)
+
+// prefixes for the entries held in the store
+var (
+	MintsPrefix = []byte{0x00}
+)
+
+// SecondsPerDay is the length of the period the mints of an address are limited over.
+const SecondsPerDay = int64(86400)
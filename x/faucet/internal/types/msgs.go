@@ -0,0 +1,53 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgMintTokens mints the MintAmount param to Recipient, which need not exist yet so that
+// new testnet accounts can be funded by anyone holding enough to pay the gas.
+type MsgMintTokens struct {
+	Recipient sdk.AccAddress
+	Sender    sdk.AccAddress
+}
+
+func NewMsgMintTokens(recipient sdk.AccAddress, sender sdk.AccAddress) MsgMintTokens {
+	return MsgMintTokens{Recipient: recipient, Sender: sender}
+}
+
+func (msg MsgMintTokens) Route() string { return RouterKey }
+
+func (msg MsgMintTokens) Type() string { return "minttokens" }
+
+func (msg MsgMintTokens) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Recipient.String())
+	}
+	return nil
+}
+
+func (msg MsgMintTokens) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgMintTokens) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"strings"
+)
+
+const (
+	DefaultParamspace = ModuleName
+
+	DefaultMintsPerDay uint64 = 5
+)
+
+var (
+	KeyEnabled     = []byte("Enabled")
+	KeyMintAmount  = []byte("MintAmount")
+	KeyMintsPerDay = []byte("MintsPerDay")
+
+	DefaultMintAmount = sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000000000))
+)
+
+var _ subspace.ParamSet = &Params{}
+
+type Params struct {
+	Enabled     bool      `json:"enabled" yaml:"enabled"`
+	MintAmount  sdk.Coins `json:"mint_amount" yaml:"mint_amount"`
+	MintsPerDay uint64    `json:"mints_per_day" yaml:"mints_per_day"`
+}
+
+func NewParams(enabled bool, mintAmount sdk.Coins, mintsPerDay uint64) Params {
+	return Params{Enabled: enabled, MintAmount: mintAmount, MintsPerDay: mintsPerDay}
+}
+
+func DefaultParams() Params {
+	return NewParams(DefaultEnabled, DefaultMintAmount, DefaultMintsPerDay)
+}
+
+func ParamKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return subspace.ParamSetPairs{
+		subspace.NewParamSetPair(KeyEnabled, &p.Enabled, validateEnabled),
+		subspace.NewParamSetPair(KeyMintAmount, &p.MintAmount, validateMintAmount),
+		subspace.NewParamSetPair(KeyMintsPerDay, &p.MintsPerDay, validateMintsPerDay),
+	}
+}
+
+func (p Params) Validate() error {
+	if err := validateEnabled(p.Enabled); err != nil {
+		return err
+	}
+	if err := validateMintAmount(p.MintAmount); err != nil {
+		return err
+	}
+	return validateMintsPerDay(p.MintsPerDay)
+}
+
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params:\n")
+	sb.WriteString(fmt.Sprintf("Enabled: %t\n", p.Enabled))
+	sb.WriteString(fmt.Sprintf("MintAmount: %s\n", p.MintAmount))
+	sb.WriteString(fmt.Sprintf("MintsPerDay: %d\n", p.MintsPerDay))
+	return sb.String()
+}
+
+func validateEnabled(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateMintAmount(i interface{}) error {
+	amount, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if !amount.IsValid() {
+		return fmt.Errorf("invalid mint amount: %s", amount)
+	}
+	return nil
+}
+
+func validateMintsPerDay(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
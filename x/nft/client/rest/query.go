@@ -0,0 +1,61 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package rest
+
+import (
+	"fmt"
+	"github.com/bluzelle/curium/x/nft/internal/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func BlzQTokenHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryToken, vars["id"]))
+	}
+}
+
+func BlzQTokensHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryTokens, vars["owner"]))
+	}
+}
+
+func BlzQReferenceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query(w, r, cliCtx, fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryReference, vars["UUID"], vars["key"]))
+	}
+}
+
+func query(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, path string) {
+	cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+	if !ok {
+		return
+	}
+
+	res, height, err := cliCtx.QueryWithData(path, nil)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	cliCtx = cliCtx.WithHeight(height)
+	rest.PostProcessResponse(w, cliCtx, res)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package nft
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func ValidateGenesis(data GenesisState) error {
+	IDs := make(map[uint64]bool)
+	references := make(map[string]bool)
+	for _, token := range data.Tokens {
+		if token.ID == 0 || token.ID >= data.NextID {
+			return fmt.Errorf("token %d is not below the next ID %d", token.ID, data.NextID)
+		}
+		if IDs[token.ID] {
+			return fmt.Errorf("token %d is listed twice", token.ID)
+		}
+		if token.Owner.Empty() {
+			return fmt.Errorf("token %d has no owner", token.ID)
+		}
+
+		reference := fmt.Sprintf("%d:%s%s", len(token.UUID), token.UUID, token.Key)
+		if references[reference] {
+			return fmt.Errorf("token %d refers to key %s of %s, which already has a token", token.ID, token.Key, token.UUID)
+		}
+		IDs[token.ID], references[reference] = true, true
+	}
+	return nil
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{NextID: 1}
+}
+
+// InitGenesis imports the tokens as they are; the crud entries they refer to are
+// expected to be part of the same genesis.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	for _, token := range data.Tokens {
+		keeper.ImportToken(ctx, token)
+	}
+	keeper.SetNextID(ctx, data.NextID)
+	return []abci.ValidatorUpdate{}
+}
+
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return GenesisState{NextID: keeper.GetNextID(ctx), Tokens: keeper.GetAllTokens(ctx)}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package nft
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bluzelle/curium/x/nft/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strconv"
+)
+
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		switch msg := msg.(type) {
+		case types.MsgMint:
+			return handleMsgMint(ctx, keeper, msg)
+		case types.MsgTransfer:
+			return handleMsgTransfer(ctx, keeper, msg)
+		case types.MsgBurn:
+			return handleMsgBurn(ctx, keeper, msg)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized nft msg type: %v", msg.Type()))
+		}
+	}
+}
+
+// handleMsgMint returns the minted token, so that its ID can be read from the
+// transaction result.
+func handleMsgMint(ctx sdk.Context, keeper Keeper, msg types.MsgMint) (*sdk.Result, error) {
+	token, err := keeper.Mint(ctx, msg.Owner, msg.UUID, msg.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(token)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "could not marshal result to JSON")
+	}
+
+	emitEvent(ctx, msg.Owner, token.ID)
+	return &sdk.Result{Data: jsonData, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgTransfer(ctx sdk.Context, keeper Keeper, msg types.MsgTransfer) (*sdk.Result, error) {
+	token, err := keeper.Transfer(ctx, msg.Owner, msg.ID, msg.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	emitEvent(ctx, msg.Owner, token.ID)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgBurn(ctx sdk.Context, keeper Keeper, msg types.MsgBurn) (*sdk.Result, error) {
+	if err := keeper.Burn(ctx, msg.Owner, msg.ID); err != nil {
+		return nil, err
+	}
+
+	emitEvent(ctx, msg.Owner, msg.ID)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func emitEvent(ctx sdk.Context, sender sdk.AccAddress, ID uint64) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+		sdk.NewAttribute("token", strconv.FormatUint(ID, 10)),
+	))
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/crud"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks keep tokens in step with the crud entries they refer to.
+type Hooks struct {
+	k Keeper
+}
+
+var _ crud.CrudHooks = Hooks{}
+
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterValueSet gives the token to the entry's new owner, when it is handed over to
+// a beneficiary, and follows its value.
+func (h Hooks) AfterValueSet(ctx sdk.Context, UUID string, key string, _ *crud.BLZValue, value crud.BLZValue) {
+	token, ok := h.k.GetTokenByReference(ctx, UUID, key)
+	if !ok {
+		return
+	}
+
+	hash := hex.EncodeToString(value.Hash)
+	if token.Owner.Equals(value.Owner) && token.Hash == hash {
+		return
+	}
+	token.Owner, token.Hash = value.Owner, hash
+	h.k.setToken(ctx, token)
+}
+
+// AfterValueRemoved burns the token of an entry that is gone.
+func (h Hooks) AfterValueRemoved(ctx sdk.Context, UUID string, key string, _ crud.BLZValue) {
+	if token, ok := h.k.GetTokenByReference(ctx, UUID, key); ok {
+		h.k.deleteToken(ctx, token)
+	}
+}
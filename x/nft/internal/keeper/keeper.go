@@ -0,0 +1,216 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/nft/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+type Keeper struct {
+	crudKeeper types.CrudKeeper
+	storeKey   sdk.StoreKey
+	cdc        *codec.Codec
+}
+
+func NewKeeper(crudKeeper types.CrudKeeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+	return Keeper{
+		crudKeeper: crudKeeper,
+		storeKey:   storeKey,
+		cdc:        cdc,
+	}
+}
+
+func (k Keeper) GetCdc() *codec.Codec {
+	return k.cdc
+}
+
+func makeTokenKey(ID uint64) []byte {
+	return append(append([]byte{}, types.TokenPrefix...), sdk.Uint64ToBigEndian(ID)...)
+}
+
+// makeReferenceKey is the key of the ID of the token referring to key of UUID; the
+// UUID is length prefixed so that no UUID and key can be mistaken for another pair.
+func makeReferenceKey(UUID string, key string) []byte {
+	bz := append([]byte{}, types.ReferencePrefix...)
+	bz = append(bz, make([]byte, binary.MaxVarintLen64)...)
+	n := binary.PutUvarint(bz[len(types.ReferencePrefix):], uint64(len(UUID)))
+	return append(append(bz[:len(types.ReferencePrefix)+n], UUID...), key...)
+}
+
+func makeOwnerPrefix(owner sdk.AccAddress) []byte {
+	return append(append(append([]byte{}, types.OwnerPrefix...), byte(len(owner))), owner...)
+}
+
+func makeOwnerKey(owner sdk.AccAddress, ID uint64) []byte {
+	return append(makeOwnerPrefix(owner), sdk.Uint64ToBigEndian(ID)...)
+}
+
+// GetNextID returns the ID the next token is minted with, IDs starting at 1.
+func (k Keeper) GetNextID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.NextIDKey)
+	if bz == nil {
+		return 1
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) SetNextID(ctx sdk.Context, ID uint64) {
+	ctx.KVStore(k.storeKey).Set(types.NextIDKey, sdk.Uint64ToBigEndian(ID))
+}
+
+func (k Keeper) GetToken(ctx sdk.Context, ID uint64) (types.Token, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(makeTokenKey(ID))
+	if bz == nil {
+		return types.Token{}, false
+	}
+
+	var token types.Token
+	k.cdc.MustUnmarshalBinaryBare(bz, &token)
+	return token, true
+}
+
+// GetTokenByReference returns the token referring to key of UUID.
+func (k Keeper) GetTokenByReference(ctx sdk.Context, UUID string, key string) (types.Token, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(makeReferenceKey(UUID, key))
+	if bz == nil {
+		return types.Token{}, false
+	}
+	return k.GetToken(ctx, sdk.BigEndianToUint64(bz))
+}
+
+// GetTokens returns the tokens of owner in the order they were minted.
+func (k Keeper) GetTokens(ctx sdk.Context, owner sdk.AccAddress) types.QueryResultTokens {
+	prefix := makeOwnerPrefix(owner)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	result := types.QueryResultTokens{Owner: owner, Tokens: make([]types.Token, 0)}
+	for ; iterator.Valid(); iterator.Next() {
+		if token, ok := k.GetToken(ctx, sdk.BigEndianToUint64(iterator.Key()[len(prefix):])); ok {
+			result.Tokens = append(result.Tokens, token)
+		}
+	}
+	return result
+}
+
+// GetAllTokens returns every token, for genesis export.
+func (k Keeper) GetAllTokens(ctx sdk.Context) []types.Token {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.TokenPrefix)
+	defer iterator.Close()
+
+	var tokens []types.Token
+	for ; iterator.Valid(); iterator.Next() {
+		var token types.Token
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &token)
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// setToken writes token along with its reference and owner index entries.
+func (k Keeper) setToken(ctx sdk.Context, token types.Token) {
+	store := ctx.KVStore(k.storeKey)
+	if old, ok := k.GetToken(ctx, token.ID); ok && !old.Owner.Equals(token.Owner) {
+		store.Delete(makeOwnerKey(old.Owner, token.ID))
+	}
+
+	store.Set(makeTokenKey(token.ID), k.cdc.MustMarshalBinaryBare(token))
+	store.Set(makeReferenceKey(token.UUID, token.Key), sdk.Uint64ToBigEndian(token.ID))
+	store.Set(makeOwnerKey(token.Owner, token.ID), []byte{})
+}
+
+func (k Keeper) deleteToken(ctx sdk.Context, token types.Token) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(makeTokenKey(token.ID))
+	store.Delete(makeReferenceKey(token.UUID, token.Key))
+	store.Delete(makeOwnerKey(token.Owner, token.ID))
+}
+
+// ImportToken writes a token read from genesis.
+func (k Keeper) ImportToken(ctx sdk.Context, token types.Token) {
+	k.setToken(ctx, token)
+}
+
+// Mint mints a token referring to key of UUID, which owner must own.
+func (k Keeper) Mint(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) (types.Token, error) {
+	store := k.crudKeeper.GetKVStore(ctx)
+	value := k.crudKeeper.GetValue(ctx, store, UUID, key)
+	if value.Owner.Empty() {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+	if !value.Owner.Equals(owner) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+	if k.crudKeeper.IsExpired(ctx, store, UUID, key) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+	if _, ok := k.GetTokenByReference(ctx, UUID, key); ok {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key already has a token")
+	}
+
+	ID := k.GetNextID(ctx)
+	token := types.Token{ID: ID, Owner: owner, UUID: UUID, Key: key, Hash: hex.EncodeToString(value.Hash)}
+	k.setToken(ctx, token)
+	k.SetNextID(ctx, ID+1)
+	return token, nil
+}
+
+// Transfer gives token ID, and the entry it refers to, to recipient. The entry keeps
+// its lease, the deposit paid for it and its auto-renewal; frozen entries cannot be
+// transferred, as the freeze is held by owner.
+func (k Keeper) Transfer(ctx sdk.Context, owner sdk.AccAddress, ID uint64, recipient sdk.AccAddress) (types.Token, error) {
+	token, ok := k.GetToken(ctx, ID)
+	if !ok {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Token does not exist")
+	}
+	if !token.Owner.Equals(owner) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+
+	store := k.crudKeeper.GetKVStore(ctx)
+	if k.crudKeeper.IsFrozen(ctx, owner, token.UUID, token.Key) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key is frozen")
+	}
+	if k.crudKeeper.IsExpired(ctx, store, token.UUID, token.Key) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key has expired")
+	}
+
+	if !k.crudKeeper.TransferValue(ctx, store, token.UUID, token.Key, recipient) {
+		return types.Token{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Key does not exist")
+	}
+
+	token.Owner = recipient
+	k.setToken(ctx, token)
+	return token, nil
+}
+
+// Burn burns token ID, leaving the entry it refers to as it is.
+func (k Keeper) Burn(ctx sdk.Context, owner sdk.AccAddress, ID uint64) error {
+	token, ok := k.GetToken(ctx, ID)
+	if !ok {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "Token does not exist")
+	}
+	if !token.Owner.Equals(owner) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+
+	k.deleteToken(ctx, token)
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/bluzelle/curium/x/crud"
+	"github.com/bluzelle/curium/x/nft/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+)
+
+var testStoreKey = sdk.NewKVStoreKey(types.StoreKey)
+
+// testCrudKeeper holds entries in memory and calls the hooks the way the crud keeper
+// does when they are written or removed
+type testCrudKeeper struct {
+	values  map[string]crud.BLZValue
+	expired map[string]bool
+	frozen  map[string]bool
+	hooks   crud.CrudHooks
+}
+
+func (c *testCrudKeeper) GetKVStore(sdk.Context) sdk.KVStore {
+	return nil
+}
+
+func (c *testCrudKeeper) GetValue(_ sdk.Context, _ sdk.KVStore, UUID string, key string) crud.BLZValue {
+	return c.values[UUID+"/"+key]
+}
+
+func (c *testCrudKeeper) SetValue(ctx sdk.Context, _ sdk.KVStore, UUID string, key string, value crud.BLZValue) {
+	hash := sha256.Sum256(value.Value)
+	value.Hash = hash[:]
+	if c.hooks != nil {
+		c.hooks.AfterValueSet(ctx, UUID, key, nil, value)
+	}
+	c.values[UUID+"/"+key] = value
+}
+
+func (c *testCrudKeeper) TransferValue(ctx sdk.Context, _ sdk.KVStore, UUID string, key string, recipient sdk.AccAddress) bool {
+	value, ok := c.values[UUID+"/"+key]
+	if !ok {
+		return false
+	}
+	value.Owner = recipient
+	c.SetValue(ctx, nil, UUID, key, value)
+	return true
+}
+
+func (c *testCrudKeeper) removeValue(ctx sdk.Context, UUID string, key string) {
+	if c.hooks != nil {
+		c.hooks.AfterValueRemoved(ctx, UUID, key, c.values[UUID+"/"+key])
+	}
+	delete(c.values, UUID+"/"+key)
+}
+
+func (c *testCrudKeeper) IsExpired(_ sdk.Context, _ sdk.KVStore, UUID string, key string) bool {
+	return c.expired[UUID+"/"+key]
+}
+
+func (c *testCrudKeeper) IsFrozen(_ sdk.Context, _ sdk.AccAddress, UUID string, key string) bool {
+	return c.frozen[UUID+"/"+key]
+}
+
+func initKeeperTest() (sdk.Context, Keeper, *testCrudKeeper) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(testStoreKey, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	ctx := sdk.NewContext(ms.CacheMultiStore(), abci.Header{}, false, log.NewNopLogger())
+	crudKeeper := &testCrudKeeper{values: map[string]crud.BLZValue{}, expired: map[string]bool{}, frozen: map[string]bool{}}
+	keeper := NewKeeper(crudKeeper, testStoreKey, codec.New())
+	crudKeeper.hooks = keeper.Hooks()
+	return ctx, keeper, crudKeeper
+}
+
+func TestKeeper_Mint(t *testing.T) {
+	ctx, keeper, crudKeeper := initKeeperTest()
+	owner, other := sdk.AccAddress("owner"), sdk.AccAddress("other")
+	crudKeeper.SetValue(ctx, nil, "uuid", "key", crud.BLZValue{Value: []byte("value"), Owner: owner})
+
+	_, err := keeper.Mint(ctx, owner, "uuid", "missing")
+	assert.EqualError(t, err, "Key does not exist: invalid request")
+
+	_, err = keeper.Mint(ctx, other, "uuid", "key")
+	assert.EqualError(t, err, "Incorrect Owner: unauthorized")
+
+	token, err := keeper.Mint(ctx, owner, "uuid", "key")
+	assert.Nil(t, err)
+	hash := sha256.Sum256([]byte("value"))
+	assert.Equal(t, types.Token{ID: 1, Owner: owner, UUID: "uuid", Key: "key", Hash: hex.EncodeToString(hash[:])}, token)
+	assert.Equal(t, uint64(2), keeper.GetNextID(ctx))
+
+	_, err = keeper.Mint(ctx, owner, "uuid", "key")
+	assert.EqualError(t, err, "Key already has a token: invalid request")
+
+	// a UUID ending where a key starts refers to a different entry
+	crudKeeper.SetValue(ctx, nil, "uuidk", "ey", crud.BLZValue{Value: []byte("value"), Owner: owner})
+	_, ok := keeper.GetTokenByReference(ctx, "uuidk", "ey")
+	assert.False(t, ok)
+
+	crudKeeper.SetValue(ctx, nil, "uuid", "expired", crud.BLZValue{Value: []byte("value"), Owner: owner})
+	crudKeeper.expired["uuid/expired"] = true
+	_, err = keeper.Mint(ctx, owner, "uuid", "expired")
+	assert.EqualError(t, err, "Key has expired: invalid request")
+
+	stored, ok := keeper.GetTokenByReference(ctx, "uuid", "key")
+	assert.True(t, ok)
+	assert.Equal(t, token, stored)
+}
+
+func TestKeeper_Transfer(t *testing.T) {
+	ctx, keeper, crudKeeper := initKeeperTest()
+	owner, recipient := sdk.AccAddress("owner"), sdk.AccAddress("recipient")
+	crudKeeper.SetValue(ctx, nil, "uuid", "key", crud.BLZValue{Value: []byte("value"), Owner: owner})
+	token, _ := keeper.Mint(ctx, owner, "uuid", "key")
+
+	_, err := keeper.Transfer(ctx, owner, 2, recipient)
+	assert.EqualError(t, err, "Token does not exist: invalid request")
+
+	_, err = keeper.Transfer(ctx, recipient, token.ID, recipient)
+	assert.EqualError(t, err, "Incorrect Owner: unauthorized")
+
+	crudKeeper.frozen["uuid/key"] = true
+	_, err = keeper.Transfer(ctx, owner, token.ID, recipient)
+	assert.EqualError(t, err, "Key is frozen: invalid request")
+	delete(crudKeeper.frozen, "uuid/key")
+
+	token, err = keeper.Transfer(ctx, owner, token.ID, recipient)
+	assert.Nil(t, err)
+	assert.Equal(t, recipient, token.Owner)
+	assert.Equal(t, recipient, crudKeeper.values["uuid/key"].Owner)
+
+	assert.Empty(t, keeper.GetTokens(ctx, owner).Tokens)
+	assert.Equal(t, []types.Token{token}, keeper.GetTokens(ctx, recipient).Tokens)
+}
+
+// a transfer against the crud keeper itself, which holds the lease deposit and the
+// auto-renewal of the entry
+func TestKeeper_Transfer_crudKeeper(t *testing.T) {
+	crudStoreKey, crudLeaseKey, crudIndexKey := sdk.NewKVStoreKey(crud.StoreKey), sdk.NewKVStoreKey(crud.LeaseKey), sdk.NewKVStoreKey(crud.IndexKey)
+	paramsKey, paramsTKey := sdk.NewKVStoreKey(params.StoreKey), sdk.NewTransientStoreKey(params.TStoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []sdk.StoreKey{testStoreKey, crudStoreKey, crudLeaseKey, crudIndexKey, paramsKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	ms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	// without a supply keeper a refund of the deposit would panic
+	ctx := sdk.NewContext(ms.CacheMultiStore(), abci.Header{}, false, log.NewNopLogger())
+	subspace := params.NewKeeper(codec.New(), paramsKey, paramsTKey).Subspace(crud.DefaultParamspace)
+	crudKeeper := crud.NewKeeper(nil, crudStoreKey, crudLeaseKey, crudIndexKey, subspace, codec.New(), crud.MaxKeeperSizes{})
+	crudKeeper.SetParams(ctx, crud.DefaultParams())
+	keeper := NewKeeper(&crudKeeper, testStoreKey, codec.New())
+	crudKeeper.SetHooks(keeper.Hooks())
+
+	owner, recipient := sdk.AccAddress("owner"), sdk.AccAddress("recipient")
+	crudStore := crudKeeper.GetKVStore(ctx)
+	crudKeeper.SetValue(ctx, crudStore, "uuid", "key", crud.BLZValue{Value: []byte("value"), Lease: 10, Owner: owner})
+	crudKeeper.SetLease(ctx, crudKeeper.GetLeaseStore(ctx), "uuid", "key", 0, 10)
+	deposit := crud.LeaseDeposit{Amount: sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), From: 0, To: 10}
+	crudKeeper.ImportLeaseDeposit(ctx, "uuid", "key", deposit)
+	crudKeeper.SetAutoRenew(ctx, "uuid", "key", true)
+	token, _ := keeper.Mint(ctx, owner, "uuid", "key")
+
+	ctx = ctx.WithBlockHeight(4)
+	token, err := keeper.Transfer(ctx, owner, token.ID, recipient)
+	assert.Nil(t, err)
+	assert.Equal(t, recipient, token.Owner)
+	assert.Equal(t, recipient, crudKeeper.GetOwner(ctx, crudStore, "uuid", "key"))
+	assert.Equal(t, deposit, crudKeeper.GetLeaseDeposit(ctx, "uuid", "key"))
+	assert.True(t, crudKeeper.IsAutoRenew(ctx, "uuid", "key"))
+	assert.Equal(t, []types.Token{token}, keeper.GetTokens(ctx, recipient).Tokens)
+}
+
+func TestKeeper_Burn(t *testing.T) {
+	ctx, keeper, crudKeeper := initKeeperTest()
+	owner := sdk.AccAddress("owner")
+	crudKeeper.SetValue(ctx, nil, "uuid", "key", crud.BLZValue{Value: []byte("value"), Owner: owner})
+	token, _ := keeper.Mint(ctx, owner, "uuid", "key")
+
+	assert.EqualError(t, keeper.Burn(ctx, sdk.AccAddress("other"), token.ID), "Incorrect Owner: unauthorized")
+	assert.Nil(t, keeper.Burn(ctx, owner, token.ID))
+
+	_, ok := keeper.GetToken(ctx, token.ID)
+	assert.False(t, ok)
+	assert.Empty(t, keeper.GetTokens(ctx, owner).Tokens)
+	assert.Equal(t, owner, crudKeeper.values["uuid/key"].Owner)
+
+	// the entry can be minted again, with a new ID
+	token, err := keeper.Mint(ctx, owner, "uuid", "key")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), token.ID)
+}
+
+func TestKeeper_Hooks(t *testing.T) {
+	ctx, keeper, crudKeeper := initKeeperTest()
+	owner, beneficiary := sdk.AccAddress("owner"), sdk.AccAddress("beneficiary")
+	crudKeeper.SetValue(ctx, nil, "uuid", "key", crud.BLZValue{Value: []byte("value"), Owner: owner})
+	token, _ := keeper.Mint(ctx, owner, "uuid", "key")
+
+	// updates and hand-overs of the entry carry over to the token
+	crudKeeper.SetValue(ctx, nil, "uuid", "key", crud.BLZValue{Value: []byte("new value"), Owner: beneficiary})
+	token, _ = keeper.GetToken(ctx, token.ID)
+	hash := sha256.Sum256([]byte("new value"))
+	assert.Equal(t, beneficiary, token.Owner)
+	assert.Equal(t, hex.EncodeToString(hash[:]), token.Hash)
+	assert.Empty(t, keeper.GetTokens(ctx, owner).Tokens)
+	assert.Equal(t, []types.Token{token}, keeper.GetTokens(ctx, beneficiary).Tokens)
+
+	// removing the entry burns the token
+	crudKeeper.removeValue(ctx, "uuid", "key")
+	_, ok := keeper.GetToken(ctx, token.ID)
+	assert.False(t, ok)
+	_, ok = keeper.GetTokenByReference(ctx, "uuid", "key")
+	assert.False(t, ok)
+	assert.Empty(t, keeper.GetTokens(ctx, beneficiary).Tokens)
+}
+
+func TestKeeper_GetAllTokens(t *testing.T) {
+	ctx, keeper, crudKeeper := initKeeperTest()
+	owner := sdk.AccAddress("owner")
+	for _, key := range []string{"a", "b", "c"} {
+		crudKeeper.SetValue(ctx, nil, "uuid", key, crud.BLZValue{Value: []byte(key), Owner: owner})
+		_, _ = keeper.Mint(ctx, owner, "uuid", key)
+	}
+	assert.Nil(t, keeper.Burn(ctx, owner, 2))
+
+	tokens := keeper.GetAllTokens(ctx)
+	assert.Equal(t, 2, len(tokens))
+	assert.Equal(t, uint64(1), tokens[0].ID)
+	assert.Equal(t, uint64(3), tokens[1].ID)
+	assert.Equal(t, tokens, keeper.GetTokens(ctx, owner).Tokens)
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/nft/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case types.QueryToken:
+			return queryToken(ctx, path[1:], keeper, keeper.GetCdc())
+		case types.QueryTokens:
+			return queryTokens(ctx, path[1:], keeper, keeper.GetCdc())
+		case types.QueryReference:
+			return queryReference(ctx, path[1:], keeper, keeper.GetCdc())
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown nft query endpoint")
+		}
+	}
+}
+
+func queryToken(ctx sdk.Context, path []string, keeper Keeper, cdc *codec.Codec) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "expected a token ID")
+	}
+
+	ID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid token ID")
+	}
+
+	token, ok := keeper.GetToken(ctx, ID)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "token not found")
+	}
+	return marshalResult(cdc, token)
+}
+
+func queryTokens(ctx sdk.Context, path []string, keeper Keeper, cdc *codec.Codec) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "expected an owner")
+	}
+
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, path[0])
+	}
+	return marshalResult(cdc, keeper.GetTokens(ctx, owner))
+}
+
+func queryReference(ctx sdk.Context, path []string, keeper Keeper, cdc *codec.Codec) ([]byte, error) {
+	if len(path) != 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "expected a UUID and key")
+	}
+
+	token, ok := keeper.GetTokenByReference(ctx, path[0], path[1])
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "token not found")
+	}
+	return marshalResult(cdc, token)
+}
+
+func marshalResult(cdc *codec.Codec, result interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/bluzelle/curium/x/crud"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CrudKeeper reads and writes the entries tokens refer to.
+type CrudKeeper interface {
+	GetKVStore(ctx sdk.Context) sdk.KVStore
+	GetValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string) crud.BLZValue
+	TransferValue(ctx sdk.Context, store sdk.KVStore, UUID string, key string, recipient sdk.AccAddress) bool
+	IsExpired(ctx sdk.Context, store sdk.KVStore, UUID string, key string) bool
+	IsFrozen(ctx sdk.Context, owner sdk.AccAddress, UUID string, key string) bool
+}
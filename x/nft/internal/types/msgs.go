@@ -0,0 +1,116 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgMint mints a token referring to the entry Key of UUID, which Owner must own.
+// An entry is referred to by one token at most.
+type MsgMint struct {
+	UUID  string
+	Key   string
+	Owner sdk.AccAddress
+}
+
+func NewMsgMint(UUID string, key string, owner sdk.AccAddress) MsgMint {
+	return MsgMint{UUID: UUID, Key: key, Owner: owner}
+}
+
+func (msg MsgMint) Route() string { return RouterKey }
+
+func (msg MsgMint) Type() string { return "mint" }
+
+func (msg MsgMint) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.UUID) == 0 || len(msg.Key) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "UUID and key are required")
+	}
+	return nil
+}
+
+func (msg MsgMint) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgMint) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTransfer gives token ID, and the entry it refers to, to Recipient.
+type MsgTransfer struct {
+	ID        uint64
+	Owner     sdk.AccAddress
+	Recipient sdk.AccAddress
+}
+
+func NewMsgTransfer(ID uint64, owner sdk.AccAddress, recipient sdk.AccAddress) MsgTransfer {
+	return MsgTransfer{ID: ID, Owner: owner, Recipient: recipient}
+}
+
+func (msg MsgTransfer) Route() string { return RouterKey }
+
+func (msg MsgTransfer) Type() string { return "transfer" }
+
+func (msg MsgTransfer) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Recipient.String())
+	}
+	return nil
+}
+
+func (msg MsgTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgBurn burns token ID. The entry it refers to is left as it is.
+type MsgBurn struct {
+	ID    uint64
+	Owner sdk.AccAddress
+}
+
+func NewMsgBurn(ID uint64, owner sdk.AccAddress) MsgBurn {
+	return MsgBurn{ID: ID, Owner: owner}
+}
+
+func (msg MsgBurn) Route() string { return RouterKey }
+
+func (msg MsgBurn) Type() string { return "burn" }
+
+func (msg MsgBurn) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	return nil
+}
+
+func (msg MsgBurn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBurn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
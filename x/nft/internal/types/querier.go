@@ -0,0 +1,34 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+type QueryResultTokens struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Tokens []Token        `json:"tokens"`
+}
+
+func (r QueryResultTokens) String() string {
+	tokens := make([]string, len(r.Tokens))
+	for i, token := range r.Tokens {
+		tokens[i] = token.String()
+	}
+	return fmt.Sprintf("%s:\n%s", r.Owner, strings.Join(tokens, "\n"))
+}
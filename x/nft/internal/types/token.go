@@ -0,0 +1,35 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Token is a transferable reference to the crud entry Key of UUID. Its Owner also owns
+// the entry and Hash is the hex SHA-256 digest of the entry's current value; both are
+// kept in step with the entry by the crud hooks, and the token is burned with it.
+type Token struct {
+	ID    uint64         `json:"id,string"`
+	Owner sdk.AccAddress `json:"owner"`
+	UUID  string         `json:"uuid"`
+	Key   string         `json:"key"`
+	Hash  string         `json:"hash"`
+}
+
+func (t Token) String() string {
+	return fmt.Sprintf("%d: %s/%s %s owned by %s", t.ID, t.UUID, t.Key, t.Hash, t.Owner)
+}
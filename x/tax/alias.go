@@ -0,0 +1,43 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package tax
+
+import (
+	"github.com/bluzelle/curium/x/tax/internal/keeper"
+	"github.com/bluzelle/curium/x/tax/internal/types"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	StoreKey          = types.StoreKey
+	QuerierRoute      = types.QuerierRoute
+	DefaultParamspace = types.DefaultParamspace
+)
+
+var (
+	NewKeeper     = keeper.NewKeeper
+	NewQuerier    = keeper.NewQuerier
+	NewParams     = types.NewParams
+	ModuleCdc     = types.ModuleCdc
+	RegisterCodec = types.RegisterCodec
+	DefaultParams = types.DefaultParams
+)
+
+type (
+	Keeper               = keeper.Keeper
+	GenesisState         = types.GenesisState
+	Params               = types.Params
+	QueryResultCollected = types.QueryResultCollected
+)
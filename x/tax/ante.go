@@ -0,0 +1,60 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package tax
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+)
+
+// CrudTaxDecorator diverts the CrudFeeShare of the fees of transactions holding
+// messages for one of routes. It has to come after the DeductFeeDecorator, the tax
+// being taken from the fees the fee collector has received.
+type CrudTaxDecorator struct {
+	keeper Keeper
+	routes map[string]bool
+}
+
+func NewCrudTaxDecorator(keeper Keeper, routes ...string) CrudTaxDecorator {
+	taxed := make(map[string]bool)
+	for _, route := range routes {
+		taxed[route] = true
+	}
+	return CrudTaxDecorator{keeper: keeper, routes: taxed}
+}
+
+func (d CrudTaxDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(ante.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	if d.taxed(tx) && !feeTx.GetFee().IsZero() {
+		if err := d.keeper.CollectCrudTax(ctx, feeTx.GetFee()); err != nil {
+			return ctx, err
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+func (d CrudTaxDecorator) taxed(tx sdk.Tx) bool {
+	for _, msg := range tx.GetMsgs() {
+		if d.routes[msg.Route()] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/tax/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+type Keeper struct {
+	supplyKeeper types.SupplyKeeper
+	storeKey     sdk.StoreKey
+	paramspace   params.Subspace
+	cdc          *codec.Codec
+}
+
+func NewKeeper(supplyKeeper types.SupplyKeeper, storeKey sdk.StoreKey, paramspace params.Subspace, cdc *codec.Codec) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		storeKey:     storeKey,
+		paramspace:   paramspace.WithKeyTable(types.ParamKeyTable()),
+		cdc:          cdc,
+	}
+}
+
+func (k Keeper) GetCdc() *codec.Codec {
+	return k.cdc
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// GetCollected returns the total of the fees diverted so far.
+func (k Keeper) GetCollected(ctx sdk.Context) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(types.CollectedKey)
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var collected sdk.Coins
+	k.cdc.MustUnmarshalBinaryBare(bz, &collected)
+	return collected
+}
+
+func (k Keeper) SetCollected(ctx sdk.Context, collected sdk.Coins) {
+	ctx.KVStore(k.storeKey).Set(types.CollectedKey, k.cdc.MustMarshalBinaryBare(collected))
+}
+
+// CrudTax returns the part of fees owed under the CrudFeeShare param, rounded down.
+func (k Keeper) CrudTax(ctx sdk.Context, fees sdk.Coins) sdk.Coins {
+	tax, _ := sdk.NewDecCoinsFromCoins(fees...).MulDecTruncate(k.GetParams(ctx).CrudFeeShare).TruncateDecimal()
+	return tax
+}
+
+// CollectCrudTax moves the tax on fees out of the fee collector, which the fees must
+// already have been paid to, and on to the treasury if one is set.
+func (k Keeper) CollectCrudTax(ctx sdk.Context, fees sdk.Coins) error {
+	tax := k.CrudTax(ctx, fees)
+	if tax.IsZero() {
+		return nil
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, auth.FeeCollectorName, types.ModuleName, tax); err != nil {
+		return err
+	}
+	if treasury := k.GetParams(ctx).Treasury; !treasury.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, treasury, tax); err != nil {
+			return err
+		}
+	}
+
+	k.SetCollected(ctx, k.GetCollected(ctx).Add(tax...))
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/tax/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+)
+
+var (
+	testStoreKey   = sdk.NewKVStoreKey(types.StoreKey)
+	testParamsKey  = sdk.NewKVStoreKey(params.StoreKey)
+	testParamsTKey = sdk.NewTransientStoreKey(params.TStoreKey)
+)
+
+// testSupplyKeeper records the balances of the module accounts and accounts
+type testSupplyKeeper struct {
+	balances map[string]sdk.Coins
+}
+
+func (s *testSupplyKeeper) SendCoinsFromModuleToModule(_ sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	s.balances[senderModule] = s.balances[senderModule].Sub(amt)
+	s.balances[recipientModule] = s.balances[recipientModule].Add(amt...)
+	return nil
+}
+
+func (s *testSupplyKeeper) SendCoinsFromModuleToAccount(_ sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	s.balances[senderModule] = s.balances[senderModule].Sub(amt)
+	s.balances[recipientAddr.String()] = s.balances[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+func initKeeperTest(params types.Params, fees sdk.Coins) (sdk.Context, Keeper, *testSupplyKeeper) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(testStoreKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(testParamsTKey, sdk.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	ctx := sdk.NewContext(ms.CacheMultiStore(), abci.Header{}, false, log.NewNopLogger())
+	supplyKeeper := &testSupplyKeeper{balances: map[string]sdk.Coins{auth.FeeCollectorName: fees}}
+	keeper := NewKeeper(supplyKeeper, testStoreKey, newTestSubspace(), codec.New())
+	keeper.SetParams(ctx, params)
+	return ctx, keeper, supplyKeeper
+}
+
+func newTestSubspace() params.Subspace {
+	return params.NewKeeper(codec.New(), testParamsKey, testParamsTKey).Subspace(types.DefaultParamspace)
+}
+
+func TestKeeper_CrudTax(t *testing.T) {
+	ctx, keeper, _ := initKeeperTest(types.NewParams(sdk.NewDecWithPrec(25, 2), nil), nil)
+
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 250)), keeper.CrudTax(ctx, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))))
+	// rounded down
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 2)), keeper.CrudTax(ctx, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 11))))
+	assert.True(t, keeper.CrudTax(ctx, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 3))).IsZero())
+}
+
+func TestKeeper_CollectCrudTax(t *testing.T) {
+	fees := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 2000))
+	ctx, keeper, supplyKeeper := initKeeperTest(types.NewParams(sdk.NewDecWithPrec(1, 1), nil), fees)
+
+	assert.Nil(t, keeper.CollectCrudTax(ctx, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))))
+	assert.Nil(t, keeper.CollectCrudTax(ctx, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))))
+
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 200)), keeper.GetCollected(ctx))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 200)), supplyKeeper.balances[types.ModuleName])
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1800)), supplyKeeper.balances[auth.FeeCollectorName])
+}
+
+func TestKeeper_CollectCrudTax_Treasury(t *testing.T) {
+	treasury := sdk.AccAddress("bluzelle1t0ywtmrdu12")
+	fees := sdk.NewCoins(sdk.NewInt64Coin("ubnt", 1000))
+	ctx, keeper, supplyKeeper := initKeeperTest(types.NewParams(sdk.NewDecWithPrec(1, 1), treasury), fees)
+
+	assert.Nil(t, keeper.CollectCrudTax(ctx, fees))
+
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), keeper.GetCollected(ctx))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("ubnt", 100)), supplyKeeper.balances[treasury.String()])
+	assert.True(t, supplyKeeper.balances[types.ModuleName].IsZero())
+}
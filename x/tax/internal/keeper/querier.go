@@ -0,0 +1,54 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package keeper
+
+import (
+	"github.com/bluzelle/curium/x/tax/internal/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case types.QueryCollected:
+			return queryCollected(ctx, keeper, keeper.GetCdc())
+		case types.QueryParams:
+			return queryParams(ctx, keeper, keeper.GetCdc())
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown tax query endpoint")
+		}
+	}
+}
+
+func queryCollected(ctx sdk.Context, keeper Keeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, types.QueryResultCollected{Collected: keeper.GetCollected(ctx)})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper, cdc *codec.Codec) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, keeper.GetParams(ctx))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
@@ -14,11 +14,11 @@
 
 package types
 
-const (
-	// module name
-	ModuleName = "crud"
-
-	// StoreKey to be used when creating the KVStore
-	StoreKey = ModuleName
-	LeaseKey = "crudLease"
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
+
+type GenesisState struct {
+	Params    Params
+	Collected sdk.Coins
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+const (
+	// module name, also the name of the module account holding the collected fees
+	ModuleName = "tax"
+
+	StoreKey     = ModuleName
+	QuerierRoute = ModuleName
+
+	QueryCollected = "collected"
+	QueryParams    = "params"
+)
+
+// keys of the entries held in the store
+var (
+	CollectedKey = []byte{0x00}
+)
@@ -0,0 +1,96 @@
+// Copyright (C) 2020 Bluzelle
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"strings"
+)
+
+const (
+	DefaultParamspace = ModuleName
+)
+
+var (
+	KeyCrudFeeShare = []byte("CrudFeeShare")
+	KeyTreasury     = []byte("Treasury")
+
+	// nothing is taxed until governance sets a share
+	DefaultCrudFeeShare = sdk.ZeroDec()
+)
+
+var _ subspace.ParamSet = &Params{}
+
+// Params are changed through parameter change proposals. CrudFeeShare is the fraction
+// of the fees of transactions holding crud messages that is diverted, to Treasury if
+// set and otherwise kept in the tax module account.
+type Params struct {
+	CrudFeeShare sdk.Dec        `json:"crud_fee_share" yaml:"crud_fee_share"`
+	Treasury     sdk.AccAddress `json:"treasury" yaml:"treasury"`
+}
+
+func NewParams(crudFeeShare sdk.Dec, treasury sdk.AccAddress) Params {
+	return Params{CrudFeeShare: crudFeeShare, Treasury: treasury}
+}
+
+func DefaultParams() Params {
+	return NewParams(DefaultCrudFeeShare, nil)
+}
+
+func ParamKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return subspace.ParamSetPairs{
+		subspace.NewParamSetPair(KeyCrudFeeShare, &p.CrudFeeShare, validateCrudFeeShare),
+		subspace.NewParamSetPair(KeyTreasury, &p.Treasury, validateTreasury),
+	}
+}
+
+func (p Params) Validate() error {
+	if err := validateCrudFeeShare(p.CrudFeeShare); err != nil {
+		return err
+	}
+	return validateTreasury(p.Treasury)
+}
+
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params:\n")
+	sb.WriteString(fmt.Sprintf("CrudFeeShare: %s\n", p.CrudFeeShare))
+	sb.WriteString(fmt.Sprintf("Treasury: %s\n", p.Treasury))
+	return sb.String()
+}
+
+func validateCrudFeeShare(i interface{}) error {
+	share, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if share.IsNil() || share.IsNegative() || share.GT(sdk.OneDec()) {
+		return fmt.Errorf("crud fee share must be between 0 and 1: %s", share)
+	}
+	return nil
+}
+
+func validateTreasury(i interface{}) error {
+	if _, ok := i.(sdk.AccAddress); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}